@@ -0,0 +1,311 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const asanaBaseURL = "https://app.asana.com/api/1.0"
+
+// asanaSource labels Asana cards in their metadata
+const asanaSource = "Asana"
+
+// AsanaClient reads projects and tasks from Asana with a personal access token
+type AsanaClient struct {
+	Token   string
+	BaseURL string
+}
+
+// AsanaRef is the compact form Asana uses for linked objects
+type AsanaRef struct {
+	GID  string `json:"gid"`
+	Name string `json:"name"`
+}
+
+// AsanaUser is the authenticated user
+type AsanaUser struct {
+	GID        string     `json:"gid"`
+	Name       string     `json:"name"`
+	Workspaces []AsanaRef `json:"workspaces"`
+}
+
+// AsanaTask is an incomplete task in a project
+type AsanaTask struct {
+	GID          string    `json:"gid"`
+	Name         string    `json:"name"`
+	Notes        string    `json:"notes"`
+	DueOn        string    `json:"due_on"` // YYYY-MM-DD
+	DueAt        string    `json:"due_at"` // RFC 3339, set when the task has a time
+	PermalinkURL string    `json:"permalink_url"`
+	Assignee     *AsanaRef `json:"assignee"`
+	Memberships  []struct {
+		Project AsanaRef `json:"project"`
+		Section AsanaRef `json:"section"`
+	} `json:"memberships"`
+}
+
+// NewAsanaClient creates an Asana client
+func NewAsanaClient(token string) *AsanaClient {
+	return &AsanaClient{Token: token, BaseURL: asanaBaseURL}
+}
+
+// asanaClientFromEnv builds an Asana client from ASANA_TOKEN
+func asanaClientFromEnv() (*AsanaClient, error) {
+	token := os.Getenv("ASANA_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("ASANA_TOKEN must be set")
+	}
+	return NewAsanaClient(token), nil
+}
+
+// get fetches every page of an Asana collection (or a single object) and
+// returns the raw data items
+func (a *AsanaClient) get(endpoint string, query url.Values) ([]json.RawMessage, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	var items []json.RawMessage
+	for {
+		req, err := http.NewRequest("GET", a.BaseURL+endpoint+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+a.Token)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Asana API request failed with status %d", resp.StatusCode)
+		}
+
+		var page struct {
+			Data     json.RawMessage `json:"data"`
+			NextPage *struct {
+				Offset string `json:"offset"`
+			} `json:"next_page"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Asana response: %w", err)
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(string(page.Data)), "[") {
+			var pageItems []json.RawMessage
+			if err := json.Unmarshal(page.Data, &pageItems); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal Asana data: %w", err)
+			}
+			items = append(items, pageItems...)
+		} else {
+			items = append(items, page.Data)
+		}
+
+		if page.NextPage == nil || page.NextPage.Offset == "" {
+			return items, nil
+		}
+		query.Set("offset", page.NextPage.Offset)
+	}
+}
+
+// GetMe returns the user the token belongs to
+func (a *AsanaClient) GetMe() (*AsanaUser, error) {
+	data, err := a.get("/users/me", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Asana user: %w", err)
+	}
+
+	var user AsanaUser
+	if len(data) == 0 {
+		return nil, fmt.Errorf("failed to get Asana user: empty response")
+	}
+	if err := json.Unmarshal(data[0], &user); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Asana user: %w", err)
+	}
+	return &user, nil
+}
+
+// GetProjects lists the unarchived projects in a workspace
+func (a *AsanaClient) GetProjects(workspaceGID string) ([]AsanaRef, error) {
+	query := url.Values{}
+	query.Set("workspace", workspaceGID)
+	query.Set("archived", "false")
+	query.Set("limit", "100")
+
+	data, err := a.get("/projects", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Asana projects: %w", err)
+	}
+
+	var projects []AsanaRef
+	for _, item := range data {
+		var project AsanaRef
+		if err := json.Unmarshal(item, &project); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Asana project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+// GetOpenProjectTasks lists a project's incomplete tasks
+func (a *AsanaClient) GetOpenProjectTasks(projectGID string) ([]AsanaTask, error) {
+	query := url.Values{}
+	query.Set("completed_since", "now") // Asana's way of asking for incomplete tasks only
+	query.Set("opt_fields", "name,notes,due_on,due_at,permalink_url,assignee,memberships.project.name,memberships.section.name")
+	query.Set("limit", "100")
+
+	data, err := a.get(fmt.Sprintf("/projects/%s/tasks", url.PathEscape(projectGID)), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Asana tasks: %w", err)
+	}
+
+	var tasks []AsanaTask
+	for _, item := range data {
+		var task AsanaTask
+		if err := json.Unmarshal(item, &task); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Asana task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// ResolveProjects turns project GIDs or names into projects, looking names
+// up across the user's workspaces
+func (a *AsanaClient) ResolveProjects(user *AsanaUser, wanted []string) ([]AsanaRef, error) {
+	var all []AsanaRef
+	for _, workspace := range user.Workspaces {
+		projects, err := a.GetProjects(workspace.GID)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, projects...)
+	}
+
+	var resolved []AsanaRef
+	for _, want := range wanted {
+		found := false
+		for _, project := range all {
+			if project.GID == want || strings.EqualFold(project.Name, want) {
+				resolved = append(resolved, project)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Asana project '%s' not found", want)
+		}
+	}
+	return resolved, nil
+}
+
+// dueTime returns when the task is due; date-only tasks are due at 11:59 PM local
+func (t AsanaTask) dueTime() *time.Time {
+	if due, err := time.Parse(time.RFC3339, t.DueAt); err == nil {
+		return &due
+	}
+	if day, err := time.ParseInLocation("2006-01-02", t.DueOn, time.Local); err == nil {
+		due := day.Add(23*time.Hour + 59*time.Minute)
+		return &due
+	}
+	return nil
+}
+
+// section returns the task's section name in a project
+func (t AsanaTask) section(projectGID string) string {
+	for _, membership := range t.Memberships {
+		if membership.Project.GID == projectGID {
+			return membership.Section.Name
+		}
+	}
+	return ""
+}
+
+// parseSectionMap reads ASANA_SECTION_MAP entries like "Doing=In Progress"
+func parseSectionMap(value string) map[string]string {
+	mapping := make(map[string]string)
+	for _, entry := range splitList(value) {
+		section, list, ok := strings.Cut(entry, "=")
+		if ok {
+			mapping[normalizeString(strings.TrimSpace(section))] = strings.TrimSpace(list)
+		}
+	}
+	return mapping
+}
+
+// sectionListName returns the Trello list name for an Asana section
+func sectionListName(section string, mapping map[string]string) string {
+	if mapped, ok := mapping[normalizeString(section)]; ok {
+		return mapped
+	}
+	return section
+}
+
+// SyncAsanaTasks mirrors Asana tasks assigned to the user in the given
+// projects onto a Trello board (ASANA_BOARD, default Mac). Each task goes to
+// the list matching its section, falling back to the board's first list.
+// Cards for tasks that were completed or reassigned move to Done.
+func (c *TrelloClient) SyncAsanaTasks(asana *AsanaClient, projectNames []string) error {
+	if len(projectNames) == 0 {
+		return fmt.Errorf("no Asana projects given (set ASANA_PROJECTS)")
+	}
+
+	boardName := os.Getenv("ASANA_BOARD")
+	if boardName == "" {
+		boardName = "Mac"
+	}
+	boardName = c.Target.board(boardName)
+	mapping := parseSectionMap(os.Getenv("ASANA_SECTION_MAP"))
+
+	user, err := asana.GetMe()
+	if err != nil {
+		return err
+	}
+	projects, err := asana.ResolveProjects(user, projectNames)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Syncing Asana tasks for %s to %s board\n", user.Name, boardName)
+
+	var items []WorkItem
+	seen := make(map[string]bool)
+	for _, project := range projects {
+		tasks, err := asana.GetOpenProjectTasks(project.GID)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			if task.Assignee == nil || task.Assignee.GID != user.GID || seen[task.GID] {
+				continue
+			}
+			seen[task.GID] = true
+
+			items = append(items, WorkItem{
+				Source: asanaSource,
+				ID:     task.GID,
+				Title:  task.Name,
+				Due:    task.dueTime(),
+				URL:    task.PermalinkURL,
+				Notes:  task.Notes,
+				List:   sectionListName(task.section(project.GID), mapping),
+			})
+		}
+	}
+	fmt.Printf("Found %d open task(s) assigned to you\n", len(items))
+
+	return c.mirrorWorkItems(boardName, c.Target.list(""), items, []string{asanaSource})
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAsanaGetFollowsPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("completed_since") != "now" {
+			t.Errorf("expected incomplete tasks only, got %s", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"gid":"1","name":"Write spec","memberships":[{"project":{"gid":"p1"},"section":{"name":"Doing"}}]}],"next_page":{"offset":"abc"}}`)
+		case "abc":
+			fmt.Fprint(w, `{"data":[{"gid":"2","name":"Review PR","due_on":"2025-10-10"}],"next_page":null}`)
+		}
+	}))
+	defer server.Close()
+
+	asana := &AsanaClient{Token: "token", BaseURL: server.URL}
+	tasks, err := asana.GetOpenProjectTasks("p1")
+	if err != nil {
+		t.Fatalf("GetOpenProjectTasks() error = %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks across pages, got %d", len(tasks))
+	}
+	if section := tasks[0].section("p1"); section != "Doing" {
+		t.Errorf("section() = %q, want Doing", section)
+	}
+}
+
+func TestAsanaTaskDueTime(t *testing.T) {
+	if due := (AsanaTask{DueAt: "2025-10-10T17:00:00Z", DueOn: "2025-10-10"}).dueTime(); due == nil || due.Hour() != 17 {
+		t.Errorf("due_at should win over due_on, got %v", due)
+	}
+	due := (AsanaTask{DueOn: "2025-10-10"}).dueTime()
+	if due == nil || due.Format("2006-01-02 15:04") != "2025-10-10 23:59" || due.Location() != time.Local {
+		t.Errorf("date-only tasks should be due at 11:59 PM local, got %v", due)
+	}
+	if (AsanaTask{}).dueTime() != nil {
+		t.Error("tasks without dates should have no due time")
+	}
+}
+
+func TestSectionListName(t *testing.T) {
+	mapping := parseSectionMap("Doing=In Progress, Backlog = To Do")
+
+	tests := []struct {
+		section  string
+		expected string
+	}{
+		{"In Review", "In Review"},
+		{"doing", "In Progress"},
+		{"Backlog", "To Do"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.section, func(t *testing.T) {
+			if got := sectionListName(tt.section, mapping); got != tt.expected {
+				t.Errorf("sectionListName(%q) = %q, want %q", tt.section, got, tt.expected)
+			}
+		})
+	}
+}
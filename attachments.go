@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Attachment is a file or link attached to a card
+type Attachment struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	IsUpload bool   `json:"isUpload"`
+}
+
+// ListAttachments returns all attachments on a card
+func (c *TrelloClient) ListAttachments(cardID string) ([]Attachment, error) {
+	endpoint := fmt.Sprintf("/cards/%s/attachments", cardID)
+	body, err := c.makeRequest(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []Attachment
+	if err := json.Unmarshal(body, &attachments); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// CreateAttachment attaches a link to a card
+func (c *TrelloClient) CreateAttachment(cardID, name, link string) (*Attachment, error) {
+	fields := url.Values{}
+	fields.Set("url", link)
+	if name != "" {
+		fields.Set("name", name)
+	}
+
+	body, err := c.sendForm("POST", fmt.Sprintf("/cards/%s/attachments", cardID), fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	var attachment Attachment
+	if err := json.Unmarshal(body, &attachment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+// DeleteAttachment removes an attachment from a card
+func (c *TrelloClient) DeleteAttachment(cardID, attachmentID string) error {
+	if _, err := c.sendForm("DELETE", fmt.Sprintf("/cards/%s/attachments/%s", cardID, attachmentID), nil); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return nil
+}
+
+// EnsureLinkAttachment attaches link to a card unless it's already there
+func (c *TrelloClient) EnsureLinkAttachment(cardID, name, link string) error {
+	if link == "" {
+		return nil
+	}
+
+	attachments, err := c.ListAttachments(cardID)
+	if err != nil {
+		return fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		if attachment.URL == link {
+			return nil
+		}
+	}
+
+	_, err = c.CreateAttachment(cardID, name, link)
+	return err
+}
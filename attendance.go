@@ -0,0 +1,353 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// attendanceLogFile records absences and tardies found by --check-attendance
+const attendanceLogFile = "attendance_log.json"
+
+// rollCallAssignmentName is the gradebook assignment Canvas Roll Call keeps
+// its attendance score in
+const rollCallAssignmentName = "Roll Call Attendance"
+
+// Attendance problems worth flagging
+const (
+	attendanceAbsent = "absent"
+	attendanceTardy  = "tardy"
+	attendanceDrop   = "score-drop" // Roll Call only reports a running score
+)
+
+// AttendanceMark is one absence or tardy
+type AttendanceMark struct {
+	Date           time.Time `json:"date"`
+	Course         string    `json:"course"`
+	Status         string    `json:"status"`
+	Source         string    `json:"source"`
+	Note           string    `json:"note,omitempty"`
+	FollowUpCardID string    `json:"followUpCardId,omitempty"`
+}
+
+// Describe renders the mark for the week in review, e.g. "Absent from Biology (Moodle)"
+func (m AttendanceMark) Describe() string {
+	var text string
+	switch m.Status {
+	case attendanceAbsent:
+		text = "Absent from " + m.Course
+	case attendanceTardy:
+		text = "Tardy to " + m.Course
+	default:
+		text = "Attendance dropped in " + m.Course
+	}
+	if m.Note != "" {
+		text += ": " + m.Note
+	}
+	return fmt.Sprintf("%s (%s)", text, m.Source)
+}
+
+// AttendanceLog is the local record of attendance problems, plus the last
+// Roll Call score per course to compare against
+type AttendanceLog struct {
+	Marks    []AttendanceMark   `json:"marks"`
+	RollCall map[string]float64 `json:"rollCall,omitempty"`
+}
+
+// LoadAttendanceLog reads the attendance log, returning an empty one if there isn't one yet
+func LoadAttendanceLog(path string) (*AttendanceLog, error) {
+	log := &AttendanceLog{RollCall: make(map[string]float64)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return log, nil
+		}
+		return nil, fmt.Errorf("failed to read attendance log: %w", err)
+	}
+
+	if err := json.Unmarshal(data, log); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attendance log: %w", err)
+	}
+	if log.RollCall == nil {
+		log.RollCall = make(map[string]float64)
+	}
+	return log, nil
+}
+
+// Save writes the attendance log
+func (l *AttendanceLog) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal attendance log: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write attendance log: %w", err)
+	}
+	return nil
+}
+
+// Add records a mark unless the same course already has one from the same
+// source that day, returning a pointer to the stored mark or nil
+func (l *AttendanceLog) Add(mark AttendanceMark) *AttendanceMark {
+	day := mark.Date.Format("2006-01-02")
+	for _, existing := range l.Marks {
+		if existing.Course == mark.Course && existing.Source == mark.Source && existing.Date.Format("2006-01-02") == day {
+			return nil
+		}
+	}
+	l.Marks = append(l.Marks, mark)
+	return &l.Marks[len(l.Marks)-1]
+}
+
+// Between returns the marks dated in [start, end), oldest first
+func (l *AttendanceLog) Between(start, end time.Time) []AttendanceMark {
+	var marks []AttendanceMark
+	for _, mark := range l.Marks {
+		if !mark.Date.Before(start) && mark.Date.Before(end) {
+			marks = append(marks, mark)
+		}
+	}
+	sort.Slice(marks, func(i, j int) bool { return marks[i].Date.Before(marks[j].Date) })
+	return marks
+}
+
+// moodleAttendanceStatus maps a Moodle attendance status to an attendance
+// problem, or "" for present and excused. Sites can rename statuses, so the
+// description is checked as well as the standard P/L/E/A acronyms.
+func moodleAttendanceStatus(acronym, description string) string {
+	description = strings.ToLower(description)
+	switch {
+	case strings.Contains(description, "excused") || strings.EqualFold(acronym, "E"):
+		return ""
+	case strings.Contains(description, "absent") || strings.EqualFold(acronym, "A"):
+		return attendanceAbsent
+	case strings.Contains(description, "late") || strings.Contains(description, "tardy") || strings.EqualFold(acronym, "L"):
+		return attendanceTardy
+	}
+	return ""
+}
+
+type moodleTodaySessions []struct {
+	FullName            string `json:"fullname"`
+	AttendanceInstances []struct {
+		TodaySessions []struct {
+			ID int `json:"id"`
+		} `json:"today_sessions"`
+	} `json:"attendance_instances"`
+}
+
+type moodleAttendanceSession struct {
+	SessDate      int64 `json:"sessdate"`
+	AttendanceLog []struct {
+		StudentID int `json:"studentid"`
+		StatusID  int `json:"statusid"`
+	} `json:"attendance_log"`
+	Statuses []struct {
+		ID          int    `json:"id"`
+		Acronym     string `json:"acronym"`
+		Description string `json:"description"`
+	} `json:"statuses"`
+}
+
+// GetTodayAttendance returns today's absences and tardies from the Moodle
+// attendance module. Sessions only show up on the day they happen, so this
+// has to run daily.
+func (m *MoodleClient) GetTodayAttendance(userID int) ([]AttendanceMark, error) {
+	params := url.Values{}
+	params.Set("userid", fmt.Sprintf("%d", userID))
+	body, err := m.makeRequest("mod_attendance_get_courses_with_today_sessions", params)
+	if err != nil {
+		return nil, err
+	}
+	var courses moodleTodaySessions
+	if err := json.Unmarshal(body, &courses); err != nil {
+		return nil, fmt.Errorf("decode attendance courses: %w", err)
+	}
+
+	var marks []AttendanceMark
+	for _, course := range courses {
+		for _, instance := range course.AttendanceInstances {
+			for _, today := range instance.TodaySessions {
+				params := url.Values{}
+				params.Set("sessionid", fmt.Sprintf("%d", today.ID))
+				body, err := m.makeRequest("mod_attendance_get_session", params)
+				if err != nil {
+					return nil, err
+				}
+				var session moodleAttendanceSession
+				if err := json.Unmarshal(body, &session); err != nil {
+					return nil, fmt.Errorf("decode attendance session: %w", err)
+				}
+				if mark, ok := session.markFor(userID, course.FullName); ok {
+					marks = append(marks, mark)
+				}
+			}
+		}
+	}
+	return marks, nil
+}
+
+// markFor returns the student's absence or tardy in a session, if any
+func (s moodleAttendanceSession) markFor(userID int, course string) (AttendanceMark, bool) {
+	for _, entry := range s.AttendanceLog {
+		if entry.StudentID != userID {
+			continue
+		}
+		for _, status := range s.Statuses {
+			if status.ID != entry.StatusID {
+				continue
+			}
+			if problem := moodleAttendanceStatus(status.Acronym, status.Description); problem != "" {
+				return AttendanceMark{Date: time.Unix(s.SessDate, 0), Course: course, Status: problem, Source: "Moodle"}, true
+			}
+		}
+	}
+	return AttendanceMark{}, false
+}
+
+// GetRollCallScores returns the Roll Call attendance percentage for each
+// course that uses it
+func (c *CanvasClient) GetRollCallScores(userID int) (map[string]float64, error) {
+	courses, err := c.GetCourses()
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[string]float64)
+	for _, course := range courses {
+		body, err := c.makeRequest(fmt.Sprintf("/courses/%d/assignments?search_term=%s", course.ID, url.QueryEscape(rollCallAssignmentName)))
+		if err != nil {
+			fmt.Printf("Warning: failed to check Roll Call in %s: %v\n", course.Name, err)
+			continue
+		}
+		var assignments []CanvasAssignment
+		if err := json.Unmarshal(body, &assignments); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal assignments: %w", err)
+		}
+
+		for _, assignment := range assignments {
+			if assignment.Name != rollCallAssignmentName || assignment.PointsPossible <= 0 {
+				continue
+			}
+			submission, err := c.GetSubmission(course.ID, assignment.ID, userID)
+			if err != nil || submission.Score == nil {
+				continue
+			}
+			scores[institutionCourseName(course.Name, c.Institution)] = *submission.Score / assignment.PointsPossible * 100
+		}
+	}
+	return scores, nil
+}
+
+// rollCallDrops compares Roll Call scores with the last check. Roll Call
+// doesn't say which day was missed, so a lower score is flagged on the day
+// it's noticed.
+func rollCallDrops(previous, current map[string]float64, now time.Time) []AttendanceMark {
+	var marks []AttendanceMark
+	for course, score := range current {
+		last, seen := previous[course]
+		if !seen || score >= last-0.05 {
+			continue
+		}
+		marks = append(marks, AttendanceMark{
+			Date:   now,
+			Course: course,
+			Status: attendanceDrop,
+			Source: "Canvas Roll Call",
+			Note:   fmt.Sprintf("%.1f%% → %.1f%%", last, score),
+		})
+	}
+	sort.Slice(marks, func(i, j int) bool { return marks[i].Course < marks[j].Course })
+	return marks
+}
+
+// attendanceFollowUpTitle names the card for catching up on a missed class
+func attendanceFollowUpTitle(mark AttendanceMark, loc *time.Location) string {
+	return fmt.Sprintf("Catch up on missed class - %s (%s)", mark.Course, mark.Date.In(loc).Format("Mon Jan 2"))
+}
+
+// CheckAttendance records new absences and tardies from Moodle's attendance
+// module and Canvas Roll Call, whichever are configured. With followUp, each
+// absence gets a card in Weekly, due the next day, to catch up on the class.
+func (c *TrelloClient) CheckAttendance(boardName string, followUp bool) error {
+	attendance, err := LoadAttendanceLog(attendanceLogFile)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	var found []AttendanceMark
+	checked := false
+	if moodleClient, err := moodleClientFromEnv(); err == nil {
+		checked = true
+		userID, err := moodleClient.GetSiteInfo()
+		if err == nil {
+			var marks []AttendanceMark
+			marks, err = moodleClient.GetTodayAttendance(userID)
+			found = append(found, marks...)
+		}
+		if err != nil {
+			fmt.Printf("Warning: failed to check Moodle attendance: %v\n", err)
+		}
+	}
+	if canvasClient, err := canvasClientFromEnv(); err == nil {
+		checked = true
+		user, err := canvasClient.GetCurrentUser()
+		if err == nil {
+			var scores map[string]float64
+			if scores, err = canvasClient.GetRollCallScores(user.ID); err == nil {
+				found = append(found, rollCallDrops(attendance.RollCall, scores, now)...)
+				for course, score := range scores {
+					attendance.RollCall[course] = score
+				}
+			}
+		}
+		if err != nil {
+			fmt.Printf("Warning: failed to check Canvas Roll Call: %v\n", err)
+		}
+	}
+	if !checked {
+		return fmt.Errorf("attendance needs Moodle (MOODLE_WSTOKEN and MOODLE_BASE_URL) or Canvas (CANVAS_API_TOKEN and CANVAS_BASE_URL)")
+	}
+
+	var listID string
+	if followUp {
+		if listID, err = c.FindListByName(boardName, "Weekly"); err != nil {
+			return fmt.Errorf("failed to find Weekly list: %w", err)
+		}
+	}
+
+	added := 0
+	loc := displayLocation()
+	for _, mark := range found {
+		stored := attendance.Add(mark)
+		if stored == nil {
+			continue
+		}
+		added++
+		fmt.Println(consoleText("🏫 " + stored.Describe()))
+
+		if !followUp || stored.Status == attendanceTardy {
+			continue
+		}
+		day := stored.Date.In(loc)
+		due := time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 0, 0, loc).AddDate(0, 0, 1)
+		desc := fmt.Sprintf("%s. Get the notes and any handouts, and check for missed work.", stored.Describe())
+		card, err := c.CreateCard(listID, attendanceFollowUpTitle(*stored, loc), desc, due.UTC().Format(trelloDueLayout))
+		if err != nil {
+			fmt.Printf("Warning: failed to create follow-up card: %v\n", err)
+			continue
+		}
+		stored.FollowUpCardID = card.ID
+	}
+
+	if err := attendance.Save(attendanceLogFile); err != nil {
+		return err
+	}
+	fmt.Printf("%s Attendance checked: %s\n", iconSuccess, plural(added, "new problem"))
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMoodleAttendanceStatus(t *testing.T) {
+	tests := []struct {
+		acronym, description, want string
+	}{
+		{"P", "Present", ""},
+		{"L", "Late", attendanceTardy},
+		{"E", "Excused", ""},
+		{"A", "Absent", attendanceAbsent},
+		{"T", "Tardy", attendanceTardy},
+		{"AE", "Absent - excused", ""},
+		{"X", "Field trip", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if got := moodleAttendanceStatus(tt.acronym, tt.description); got != tt.want {
+				t.Errorf("moodleAttendanceStatus(%q, %q) = %q, want %q", tt.acronym, tt.description, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRollCallDrops(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	previous := map[string]float64{"Biology": 100, "History": 95, "Math": 90}
+	current := map[string]float64{"Biology": 96.5, "History": 95, "Math": 92, "Art": 80}
+
+	marks := rollCallDrops(previous, current, now)
+	if len(marks) != 1 {
+		t.Fatalf("rollCallDrops() = %+v, want only Biology", marks)
+	}
+	if got := marks[0].Describe(); got != "Attendance dropped in Biology: 100.0% → 96.5% (Canvas Roll Call)" {
+		t.Errorf("Describe() = %q", got)
+	}
+}
+
+func TestAttendanceLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), attendanceLogFile)
+	monday := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	attendance, err := LoadAttendanceLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attendance.Add(AttendanceMark{Date: monday, Course: "Biology", Status: attendanceAbsent, Source: "Moodle"}) == nil {
+		t.Fatal("first mark wasn't added")
+	}
+	if attendance.Add(AttendanceMark{Date: monday.Add(3 * time.Hour), Course: "Biology", Status: attendanceAbsent, Source: "Moodle"}) != nil {
+		t.Error("same course and day was added twice")
+	}
+	attendance.Add(AttendanceMark{Date: monday.AddDate(0, 0, 8), Course: "Math", Status: attendanceTardy, Source: "Moodle"})
+	attendance.RollCall["History"] = 95
+
+	if err := attendance.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadAttendanceLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	week := loaded.Between(monday.AddDate(0, 0, -1), monday.AddDate(0, 0, 6))
+	if len(week) != 1 || week[0].Describe() != "Absent from Biology (Moodle)" {
+		t.Errorf("Between() = %+v", week)
+	}
+	if loaded.RollCall["History"] != 95 {
+		t.Errorf("RollCall = %v", loaded.RollCall)
+	}
+}
+
+func TestMoodleSessionMarkFor(t *testing.T) {
+	var session moodleAttendanceSession
+	data := `{"sessdate": 1760450400,
+		"attendance_log": [{"studentid": 7, "statusid": 2}, {"studentid": 8, "statusid": 1}],
+		"statuses": [{"id": 1, "acronym": "P", "description": "Present"}, {"id": 2, "acronym": "A", "description": "Absent"}]}`
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		t.Fatal(err)
+	}
+
+	if mark, ok := session.markFor(7, "Biology"); !ok || mark.Status != attendanceAbsent {
+		t.Errorf("markFor(7) = %+v, %v, want absent", mark, ok)
+	}
+	if _, ok := session.markFor(8, "Biology"); ok {
+		t.Error("present student was flagged")
+	}
+}
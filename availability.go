@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// How syncs treat assignments the student can't open yet
+const (
+	unavailableSkip  = "skip"  // no card until the assignment opens
+	unavailableLabel = "label" // a card now, labeled until it opens
+)
+
+// notYetAvailableLabel marks cards for assignments that haven't opened
+var notYetAvailableLabel = LabelSpec{Name: "Not yet available", Color: "black", CreateIfMissing: true}
+
+// unavailableMode reads UNAVAILABLE_ITEMS: skip (the default) or label
+func unavailableMode() string {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv("UNAVAILABLE_ITEMS")))
+	switch value {
+	case "":
+		return unavailableSkip
+	case unavailableSkip, unavailableLabel:
+		return value
+	}
+	fmt.Printf("Warning: invalid UNAVAILABLE_ITEMS '%s' (want skip or label), using skip\n", value)
+	return unavailableSkip
+}
+
+// canvasAvailable reports whether the student can see an assignment yet:
+// it's published and, if Canvas locks it for them, only because its lock
+// date has passed rather than because it hasn't unlocked
+func canvasAvailable(assignment CanvasAssignment, now time.Time) bool {
+	if assignment.Published != nil && !*assignment.Published {
+		return false
+	}
+	if assignment.LockedForUser {
+		if unlock, err := time.Parse(time.RFC3339, assignment.UnlockAt); err == nil && unlock.After(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// moodleAvailable reports whether a Moodle activity's module is visible.
+// Moodle only says so to some accounts; without it the activity counts as
+// visible.
+func moodleAvailable(a MoodleAssignment) bool {
+	return a.Visible == nil || *a.Visible != 0
+}
+
+// holdUntilOpen reads HOLD_UNTIL_OPEN. When it's true, syncs make no card
+// for work that opens on a later date, and the first scheduled sync after
+// it opens creates one.
+func holdUntilOpen() bool {
+	return os.Getenv("HOLD_UNTIL_OPEN") == "true"
+}
+
+// opensLater reports whether an RFC 3339 opening date is still ahead
+func opensLater(opens string, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, opens)
+	return err == nil && t.After(now)
+}
+
+// opens is when a Moodle activity accepts submissions, in RFC 3339, or ""
+// when it always has
+func (a MoodleAssignment) opens() string {
+	if a.OpensUnix <= 0 {
+		return ""
+	}
+	return time.Unix(a.OpensUnix, 0).Format(time.RFC3339)
+}
+
+// markAvailability labels a card whose assignment hasn't opened, and takes
+// the label off once it has
+func (c *TrelloClient) markAvailability(card Card, available bool) {
+	label := findLabel(card.Labels, LabelSpec{Name: notYetAvailableLabel.Name})
+	switch {
+	case !available && label == nil:
+		if err := c.AddLabelToCardWithOptions(card.ID, notYetAvailableLabel); err != nil {
+			fmt.Printf("Warning: failed to label %s as not yet available: %v\n", card.Name, err)
+		}
+	case available && label != nil:
+		if err := c.RemoveLabelFromCard(card.ID, label.ID); err != nil {
+			fmt.Printf("Warning: failed to remove the not yet available label from %s: %v\n", card.Name, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCanvasAvailable(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		assignment CanvasAssignment
+		want       bool
+	}{
+		{"plain", CanvasAssignment{}, true},
+		{"published", CanvasAssignment{Published: boolPtr(true)}, true},
+		{"unpublished", CanvasAssignment{Published: boolPtr(false)}, false},
+		{"not unlocked yet", CanvasAssignment{LockedForUser: true, UnlockAt: "2025-10-03T06:00:00Z"}, false},
+		{"locked after closing", CanvasAssignment{LockedForUser: true, UnlockAt: "2025-09-01T06:00:00Z", LockAt: "2025-09-30T06:00:00Z"}, true},
+		{"future unlock but open", CanvasAssignment{UnlockAt: "2025-10-03T06:00:00Z"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canvasAvailable(tt.assignment, now); got != tt.want {
+				t.Errorf("canvasAvailable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoodleAvailable(t *testing.T) {
+	hidden, shown := 0, 1
+	tests := []struct {
+		name    string
+		visible *int
+		want    bool
+	}{
+		{"not reported", nil, true},
+		{"visible", &shown, true},
+		{"hidden", &hidden, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moodleAvailable(MoodleAssignment{Visible: tt.visible}); got != tt.want {
+				t.Errorf("moodleAvailable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnavailableMode(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", unavailableSkip},
+		{"Label", unavailableLabel},
+		{"skip", unavailableSkip},
+		{"hide", unavailableSkip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("UNAVAILABLE_ITEMS", tt.value)
+			if got := unavailableMode(); got != tt.want {
+				t.Errorf("unavailableMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpensLater(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		opens string
+		want  bool
+	}{
+		{"no date", "", false},
+		{"opened", "2025-09-30T06:00:00Z", false},
+		{"opens later", "2025-10-02T06:00:00Z", true},
+		{"unparseable", "soon", false},
+		{"moodle", MoodleAssignment{OpensUnix: now.Add(time.Hour).Unix()}.opens(), true},
+		{"moodle always open", MoodleAssignment{}.opens(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := opensLater(tt.opens, now); got != tt.want {
+				t.Errorf("opensLater(%q) = %v, want %v", tt.opens, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// idSegmentRegex matches path segments that are record IDs: Trello's 24-char
+// hex IDs and Canvas's numeric ones
+var idSegmentRegex = regexp.MustCompile(`^([0-9a-f]{24}|\d+)$`)
+
+// endpointKey groups a request with others to the same endpoint, e.g.
+// "GET api.trello.com/1/boards/:id/cards". The first segment is Trello's API
+// version, not an ID. Moodle sends everything to one path, so its web service
+// function is kept.
+func endpointKey(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		if i > 1 && idSegmentRegex.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+	key := req.Method + " " + req.URL.Host + strings.Join(segments, "/")
+	if fn := req.URL.Query().Get("wsfunction"); fn != "" {
+		key += "?wsfunction=" + fn
+	}
+	return key
+}
+
+// EndpointStats is the latency of every request to one endpoint
+type EndpointStats struct {
+	Endpoint    string
+	Calls       int
+	Total       time.Duration
+	Max         time.Duration
+	Errors      int // transport errors and non-2xx responses
+	RateLimited int // 429 responses
+}
+
+// Average is the mean latency per call
+func (s EndpointStats) Average() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Calls)
+}
+
+// latencyRecorder is an http.RoundTripper that times each request by endpoint
+type latencyRecorder struct {
+	next  http.RoundTripper
+	mu    sync.Mutex
+	stats map[string]*EndpointStats
+}
+
+func newLatencyRecorder(next http.RoundTripper) *latencyRecorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &latencyRecorder{next: next, stats: make(map[string]*EndpointStats)}
+}
+
+func (r *latencyRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := r.next.RoundTrip(req)
+	elapsed := time.Since(started)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := endpointKey(req)
+	stats, ok := r.stats[key]
+	if !ok {
+		stats = &EndpointStats{Endpoint: key}
+		r.stats[key] = stats
+	}
+	stats.Calls++
+	stats.Total += elapsed
+	if elapsed > stats.Max {
+		stats.Max = elapsed
+	}
+	switch {
+	case err != nil:
+		stats.Errors++
+	case resp.StatusCode == http.StatusTooManyRequests:
+		stats.RateLimited++
+		stats.Errors++
+	case resp.StatusCode >= 300:
+		stats.Errors++
+	}
+	return resp, err
+}
+
+// Stats returns the endpoints by total time spent, slowest first
+func (r *latencyRecorder) Stats() []EndpointStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var all []EndpointStats
+	for _, stats := range r.stats {
+		all = append(all, *stats)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Total != all[j].Total {
+			return all[i].Total > all[j].Total
+		}
+		return all[i].Endpoint < all[j].Endpoint
+	})
+	return all
+}
+
+// benchStep is one timed stage of --bench
+type benchStep struct {
+	Name    string
+	Elapsed time.Duration
+	Err     error
+}
+
+// Bench times the board fetch, a full cache warm, the board's card fetch, and
+// whichever sync dry-runs are configured against the live APIs, then prints
+// per-endpoint latencies. Nothing is written to Trello or the cache.
+func (c *TrelloClient) Bench(boardName string) error {
+	recorder := newLatencyRecorder(http.DefaultClient.Transport)
+	previous := http.DefaultClient.Transport
+	http.DefaultClient.Transport = recorder
+	defer func() { http.DefaultClient.Transport = previous }()
+
+	var steps []benchStep
+	run := func(name string, fn func() error) {
+		fmt.Printf("⏱️  %s...\n", name)
+		started := time.Now()
+		err := fn()
+		steps = append(steps, benchStep{Name: name, Elapsed: time.Since(started), Err: err})
+	}
+
+	run("Board fetch", func() error {
+		_, err := c.GetBoards()
+		return err
+	})
+	run("Cache warm", func() error {
+		_, err := c.fetchCacheData(&CachedData{})
+		return err
+	})
+	run("Card fetch ("+boardName+")", func() error {
+		_, err := c.GetAllBoardCards(boardName)
+		return err
+	})
+
+	now := time.Now()
+	end := now.AddDate(0, 0, syncHorizonDays())
+	if moodleClient, err := moodleClientFromEnv(); err == nil {
+		run("Moodle sync dry-run", func() error {
+			return c.SyncMoodleAssignments(moodleClient, pastDueSince(now, includePastDueDays()), end, true, "")
+		})
+	}
+	if clients, err := canvasClientsFromEnv(); err == nil {
+		for _, canvasClient := range clients {
+			name := "Canvas assignment fetch"
+			if canvasClient.Institution != "" {
+				name += " (" + canvasClient.Institution + ")"
+			}
+			run(name, func() error {
+				user, err := canvasClient.GetCurrentUser()
+				if err != nil {
+					return err
+				}
+				_, err = canvasClient.GetUpcomingAssignments(user.ID, now, end)
+				return err
+			})
+		}
+	}
+
+	printBenchReport(steps, recorder.Stats())
+	for _, step := range steps {
+		if step.Err != nil {
+			return fmt.Errorf("%s failed: %w", step.Name, step.Err)
+		}
+	}
+	return nil
+}
+
+// printBenchReport prints the step timings and the per-endpoint breakdown
+func printBenchReport(steps []benchStep, endpoints []EndpointStats) {
+	fmt.Println("\nSteps:")
+	for _, step := range steps {
+		status := iconSuccess
+		if step.Err != nil {
+			status = "❌"
+		}
+		fmt.Printf("  %s %-40s %8s\n", status, step.Name, step.Elapsed.Round(time.Millisecond))
+	}
+
+	fmt.Println("\nEndpoints:")
+	fmt.Printf("  %-60s %5s %8s %8s %8s %6s %4s\n", "ENDPOINT", "CALLS", "AVG", "MAX", "TOTAL", "ERRORS", "429")
+	rateLimited := 0
+	for _, stats := range endpoints {
+		fmt.Printf("  %-60s %5d %8s %8s %8s %6d %4d\n", stats.Endpoint, stats.Calls,
+			stats.Average().Round(time.Millisecond), stats.Max.Round(time.Millisecond),
+			stats.Total.Round(time.Millisecond), stats.Errors, stats.RateLimited)
+		rateLimited += stats.RateLimited
+	}
+	if rateLimited > 0 {
+		fmt.Printf("\nWarning: %s rate limited (HTTP 429); lower concurrency or add delays\n", plural(rateLimited, "request"))
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEndpointKey(t *testing.T) {
+	tests := []struct {
+		method, url, want string
+	}{
+		{"GET", "https://api.trello.com/1/boards/5f1a2b3c4d5e6f7a8b9c0d1e/cards?key=k&token=t", "GET api.trello.com/1/boards/:id/cards"},
+		{"PUT", "https://api.trello.com/1/cards/5f1a2b3c4d5e6f7a8b9c0d1e", "PUT api.trello.com/1/cards/:id"},
+		{"GET", "https://school.instructure.com/api/v1/courses/123/assignments", "GET school.instructure.com/api/v1/courses/:id/assignments"},
+		{"GET", "https://moodle.example.com/webservice/rest/server.php?wstoken=x&wsfunction=core_webservice_get_site_info", "GET moodle.example.com/webservice/rest/server.php?wsfunction=core_webservice_get_site_info"},
+		{"GET", "https://api.trello.com/1/members/me/boards", "GET api.trello.com/1/members/me/boards"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			if got := endpointKey(req); got != tt.want {
+				t.Errorf("endpointKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatencyRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	recorder := newLatencyRecorder(nil)
+	client := &http.Client{Transport: recorder}
+	for _, path := range []string{"/cards/1", "/cards/2", "/limited"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	byEndpoint := make(map[string]EndpointStats)
+	for _, stats := range recorder.Stats() {
+		byEndpoint[stats.Endpoint] = stats
+	}
+	host := server.Listener.Addr().String()
+
+	cards := byEndpoint["GET "+host+"/cards/:id"]
+	if cards.Calls != 2 || cards.Errors != 0 || cards.Max < cards.Average() {
+		t.Errorf("cards stats = %+v", cards)
+	}
+	limited := byEndpoint["GET "+host+"/limited"]
+	if limited.Calls != 1 || limited.RateLimited != 1 || limited.Errors != 1 {
+		t.Errorf("limited stats = %+v", limited)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// personalWorkspace matches boards that don't belong to any workspace
+const personalWorkspace = "personal"
+
+// Organization is a Trello workspace
+type Organization struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// BoardFilter limits which boards GetBoards returns
+type BoardFilter struct {
+	Workspaces    []string // workspace IDs, short names, or display names; "personal" for boards outside any workspace
+	IncludeClosed bool
+}
+
+// boardFilterFromEnv reads TRELLO_WORKSPACES (comma-separated) and
+// TRELLO_INCLUDE_CLOSED_BOARDS
+func boardFilterFromEnv() BoardFilter {
+	return BoardFilter{
+		Workspaces:    splitList(os.Getenv("TRELLO_WORKSPACES")),
+		IncludeClosed: os.Getenv("TRELLO_INCLUDE_CLOSED_BOARDS") == "true",
+	}
+}
+
+// splitList splits a comma-separated setting, dropping blanks
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// workspaceIDs resolves the filter's workspace names to IDs. The personal
+// workspace resolves to the empty ID.
+func (f BoardFilter) workspaceIDs(orgs []Organization) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	for _, want := range f.Workspaces {
+		if normalizeString(want) == personalWorkspace {
+			ids[""] = true
+			continue
+		}
+
+		found := false
+		for _, org := range orgs {
+			if org.ID == want || normalizeString(org.Name) == normalizeString(want) || normalizeString(org.DisplayName) == normalizeString(want) {
+				ids[org.ID] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("workspace '%s' not found", want)
+		}
+	}
+	return ids, nil
+}
+
+// filterBoards applies the filter, given the member's workspaces
+func filterBoards(boards []Board, filter BoardFilter, orgs []Organization) ([]Board, error) {
+	var workspaceIDs map[string]bool
+	if len(filter.Workspaces) > 0 {
+		var err error
+		if workspaceIDs, err = filter.workspaceIDs(orgs); err != nil {
+			return nil, err
+		}
+	}
+
+	var filtered []Board
+	for _, board := range boards {
+		if board.Closed && !filter.IncludeClosed {
+			continue
+		}
+		if workspaceIDs != nil && !workspaceIDs[board.IDOrganization] {
+			continue
+		}
+		filtered = append(filtered, board)
+	}
+	return filtered, nil
+}
+
+// GetOrganizations lists the workspaces the member belongs to
+func (c *TrelloClient) GetOrganizations() ([]Organization, error) {
+	body, err := c.makeRequest("/members/me/organizations")
+	if err != nil {
+		return nil, err
+	}
+
+	var orgs []Organization
+	if err := json.Unmarshal(body, &orgs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal organizations: %w", err)
+	}
+
+	return orgs, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterBoards(t *testing.T) {
+	boards := []Board{
+		{ID: "b1", Name: "Makai School"},
+		{ID: "b2", Name: "Mac", IDOrganization: "o1"},
+		{ID: "b3", Name: "Old Sprint", IDOrganization: "o1", Closed: true},
+		{ID: "b4", Name: "Side Project", IDOrganization: "o2"},
+	}
+	orgs := []Organization{
+		{ID: "o1", Name: "alkira", DisplayName: "Alkira"},
+		{ID: "o2", Name: "sideproj", DisplayName: "Side Projects"},
+	}
+
+	tests := []struct {
+		name     string
+		filter   BoardFilter
+		expected string
+		wantErr  bool
+	}{
+		{"default hides closed", BoardFilter{}, "b1,b2,b4", false},
+		{"include closed", BoardFilter{IncludeClosed: true}, "b1,b2,b3,b4", false},
+		{"by short name", BoardFilter{Workspaces: []string{"alkira"}}, "b2", false},
+		{"by display name", BoardFilter{Workspaces: []string{"Side Projects"}}, "b4", false},
+		{"personal plus workspace id", BoardFilter{Workspaces: []string{"personal", "o1"}, IncludeClosed: true}, "b1,b2,b3", false},
+		{"unknown workspace", BoardFilter{Workspaces: []string{"nope"}}, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filtered, err := filterBoards(boards, test.filter, orgs)
+			if test.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("filterBoards() error = %v", err)
+			}
+
+			var ids []string
+			for _, board := range filtered {
+				ids = append(ids, board.ID)
+			}
+			if got := strings.Join(ids, ","); got != test.expected {
+				t.Errorf("filterBoards() = %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Board image layout, in pixels. Text is drawn with the built-in font at
+// boardTextScale, so every character is the same width in PNG and SVG.
+const (
+	boardTextScale   = 2
+	boardColumnWidth = 320
+	boardGap         = 16
+	boardPadding     = 10
+	boardCardLines   = 3 // lines of card name before it's cut off
+)
+
+// trelloLabelHex is each Trello label color as shown on the board
+var trelloLabelHex = map[string]string{
+	"green":  "#61bd4f",
+	"yellow": "#f2d600",
+	"orange": "#ff9f1a",
+	"red":    "#eb5a46",
+	"purple": "#c377e0",
+	"blue":   "#0079bf",
+	"sky":    "#00c2e0",
+	"lime":   "#51e898",
+	"pink":   "#ff78cb",
+	"black":  "#344563",
+}
+
+// layoutBox is a filled rectangle; Stroke is empty for no border
+type layoutBox struct {
+	X, Y, W, H   int
+	Fill, Stroke string
+}
+
+// layoutText is a line of text with its top-left corner at X, Y
+type layoutText struct {
+	X, Y  int
+	Scale int
+	Text  string
+	Color string
+}
+
+// BoardLayout is a board drawn as boxes and text, ready to render as PNG or SVG
+type BoardLayout struct {
+	Width, Height int
+	Boxes         []layoutBox
+	Texts         []layoutText
+}
+
+func (l *BoardLayout) box(x, y, w, h int, fill, stroke string) {
+	l.Boxes = append(l.Boxes, layoutBox{X: x, Y: y, W: w, H: h, Fill: fill, Stroke: stroke})
+}
+
+func (l *BoardLayout) text(x, y, scale int, text, color string) {
+	l.Texts = append(l.Texts, layoutText{X: x, Y: y, Scale: scale, Text: text, Color: color})
+}
+
+// wrapWords breaks text into lines of at most width characters, cutting the
+// last line with "..." when it needs more than maxLines
+func wrapWords(text string, width, maxLines int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		for len(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			lines = append(lines, word[:width])
+			word = word[width:]
+		}
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := lines[maxLines-1]
+		if len(last) > width-3 {
+			last = last[:width-3]
+		}
+		lines[maxLines-1] = last + "..."
+	}
+	return lines
+}
+
+// layoutBoard lays out one column per list with its open cards in board
+// order. Cards get a strip in their first label's color, and a due line that
+// is red when overdue and green once complete.
+func layoutBoard(board Board, lists []List, cards []Card, now time.Time) *BoardLayout {
+	charW := glyphCellW * boardTextScale
+	lineH := glyphCellH * boardTextScale
+	charsPerLine := (boardColumnWidth - 2*boardPadding - 8) / charW
+
+	layout := &BoardLayout{}
+	doneLists := doneListIDs(lists)
+	title := fmt.Sprintf("%s - %s", fontText(board.Name), now.Format("Mon, Jan 2, 2006 3:04 PM"))
+	layout.text(boardGap, boardGap, 3, title, "#172b4d")
+	top := boardGap + glyphCellH*3 + boardGap
+
+	height := top
+	for i, list := range lists {
+		x := boardGap + i*(boardColumnWidth+boardGap)
+		var listCards []Card
+		for _, card := range cards {
+			if card.IDList == list.ID && !card.Closed {
+				listCards = append(listCards, card)
+			}
+		}
+
+		columnStart := len(layout.Boxes)
+		layout.box(x, top, boardColumnWidth, 0, "#ebecf0", "")
+		header := fmt.Sprintf("%s (%d)", fontText(list.Name), len(listCards))
+		layout.text(x+boardPadding, top+boardPadding, boardTextScale, wrapWords(header, charsPerLine+1, 1)[0], "#172b4d")
+		y := top + boardPadding + lineH + boardPadding
+
+		for _, card := range listCards {
+			lines := wrapWords(fontText(card.Name), charsPerLine, boardCardLines)
+			dueLine, dueColor := "", "#5e6c84"
+			if card.Due != nil {
+				dueLine = "Due " + card.Due.In(now.Location()).Format("Mon 1/2 3:04 PM")
+				switch {
+				case isCardDone(card, doneLists):
+					dueLine, dueColor = "+ "+dueLine, "#3f8a2f"
+				case card.Due.Before(now):
+					dueColor = "#c9372c"
+				}
+			}
+
+			cardH := boardPadding*2 + len(lines)*lineH
+			if dueLine != "" {
+				cardH += lineH
+			}
+			layout.box(x+boardPadding, y, boardColumnWidth-2*boardPadding, cardH, "#ffffff", "#c1c7d0")
+			for _, label := range card.Labels {
+				if hex, ok := trelloLabelHex[label.Color]; ok {
+					layout.box(x+boardPadding, y, 6, cardH, hex, "")
+					break
+				}
+			}
+
+			textY := y + boardPadding
+			for _, line := range lines {
+				layout.text(x+boardPadding+12, textY, boardTextScale, line, "#172b4d")
+				textY += lineH
+			}
+			if dueLine != "" {
+				layout.text(x+boardPadding+12, textY, boardTextScale, dueLine, dueColor)
+			}
+			y += cardH + boardPadding/2
+		}
+
+		layout.Boxes[columnStart].H = y + boardPadding/2 - top
+		if y+boardGap > height {
+			height = y + boardGap
+		}
+	}
+
+	layout.Width = boardGap + len(lists)*(boardColumnWidth+boardGap)
+	if titleWidth := 2*boardGap + len(title)*glyphCellW*3; titleWidth > layout.Width {
+		layout.Width = titleWidth
+	}
+	layout.Height = height
+	return layout
+}
+
+// parseHexColor reads a "#rrggbb" color
+func parseHexColor(hex string) color.RGBA {
+	value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil {
+		return color.RGBA{0, 0, 0, 0xff}
+	}
+	return color.RGBA{uint8(value >> 16), uint8(value >> 8), uint8(value), 0xff}
+}
+
+// PNG renders the layout with the built-in bitmap font
+func (l *BoardLayout) PNG() ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, l.Width, l.Height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	for _, b := range l.Boxes {
+		fill := parseHexColor(b.Fill)
+		for y := b.Y; y < b.Y+b.H; y++ {
+			for x := b.X; x < b.X+b.W; x++ {
+				img.SetRGBA(x, y, fill)
+			}
+		}
+		if b.Stroke != "" {
+			stroke := parseHexColor(b.Stroke)
+			for x := b.X; x < b.X+b.W; x++ {
+				img.SetRGBA(x, b.Y, stroke)
+				img.SetRGBA(x, b.Y+b.H-1, stroke)
+			}
+			for y := b.Y; y < b.Y+b.H; y++ {
+				img.SetRGBA(b.X, y, stroke)
+				img.SetRGBA(b.X+b.W-1, y, stroke)
+			}
+		}
+	}
+	for _, t := range l.Texts {
+		drawText(img, t.X, t.Y, t.Scale, t.Text, parseHexColor(t.Color))
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, img); err != nil {
+		return nil, fmt.Errorf("failed to encode board image: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// SVG renders the layout as scalable vector graphics for printing
+func (l *BoardLayout) SVG() []byte {
+	var out bytes.Buffer
+	out.WriteString(fmt.Sprintf("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", l.Width, l.Height, l.Width, l.Height))
+	out.WriteString(fmt.Sprintf("<rect width=\"%d\" height=\"%d\" fill=\"#ffffff\"/>\n", l.Width, l.Height))
+	for _, b := range l.Boxes {
+		stroke := ""
+		if b.Stroke != "" {
+			stroke = fmt.Sprintf(" stroke=\"%s\"", b.Stroke)
+		}
+		out.WriteString(fmt.Sprintf("<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"3\" fill=\"%s\"%s/>\n", b.X, b.Y, b.W, b.H, b.Fill, stroke))
+	}
+	for _, t := range l.Texts {
+		// Monospace at the bitmap font's cell size keeps the same line widths
+		size := glyphCellH * t.Scale * 8 / 9
+		out.WriteString(fmt.Sprintf("<text x=\"%d\" y=\"%d\" font-family=\"monospace\" font-size=\"%d\" textLength=\"%d\" fill=\"%s\">%s</text>\n",
+			t.X, t.Y+glyphHeight*t.Scale, size, len(t.Text)*glyphCellW*t.Scale-t.Scale, t.Color, html.EscapeString(t.Text)))
+	}
+	out.WriteString("</svg>\n")
+	return out.Bytes()
+}
+
+// boardLayout loads a board's lists and open cards and lays them out
+func (c *TrelloClient) boardLayout(boardName string) (*BoardLayout, error) {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cache: %w", err)
+	}
+
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return nil, err
+	}
+
+	var lists []List
+	for _, list := range cache.Lists {
+		if list.BoardID == board.ID {
+			lists = append(lists, list)
+		}
+	}
+
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board cards: %w", err)
+	}
+
+	return layoutBoard(*board, lists, cards, time.Now().In(displayLocation())), nil
+}
+
+// SaveBoardImage renders a board to path, as SVG when it ends in .svg and
+// PNG otherwise
+func (c *TrelloClient) SaveBoardImage(boardName, path string) error {
+	layout, err := c.boardLayout(boardName)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		data = layout.SVG()
+	} else if data, err = layout.PNG(); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	fmt.Printf("%s Saved %s (%dx%d) to %s\n", iconSuccess, boardName, layout.Width, layout.Height, path)
+	return nil
+}
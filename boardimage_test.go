@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrapWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		maxLines int
+		want     []string
+	}{
+		{"fits", "Math worksheet", 20, 3, []string{"Math worksheet"}},
+		{"wraps", "Read chapter four of the novel", 12, 3, []string{"Read chapter", "four of the", "novel"}},
+		{"cut off", "one two three four five six", 8, 2, []string{"one two", "three..."}},
+		{"long word", "Supercalifragilistic", 8, 3, []string{"Supercal", "ifragili", "stic"}},
+		{"empty", "", 10, 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapWords(tt.text, tt.width, tt.maxLines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrapWords() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFontText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"REDO - Essay", "REDO - Essay"},
+		{"📅 Quiz — ch. 3…", "Quiz - ch. 3..."},
+		{"Café", "Caf?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := fontText(tt.in); got != tt.want {
+				t.Errorf("fontText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutBoard(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	board := Board{ID: "b", Name: "Makai School"}
+	lists := []List{{ID: "weekly", Name: "Weekly"}, {ID: "done", Name: "Done"}}
+	cards := []Card{
+		{ID: "1", Name: "Late <lab>", IDList: "weekly", Due: &past, Labels: []Label{{Color: "red"}}},
+		{ID: "2", Name: "Finished", IDList: "done", Due: &past, DueComplete: true},
+		{ID: "3", Name: "Archived", IDList: "weekly", Closed: true},
+	}
+
+	layout := layoutBoard(board, lists, cards, now)
+	if layout.Width < boardGap+2*(boardColumnWidth+boardGap) {
+		t.Errorf("Width = %d, too narrow for two columns", layout.Width)
+	}
+
+	var texts []string
+	colors := make(map[string]string)
+	for _, text := range layout.Texts {
+		texts = append(texts, text.Text)
+		colors[text.Text] = text.Color
+	}
+	joined := strings.Join(texts, "|")
+	for _, want := range []string{"Weekly (1)", "Done (1)", "Late <lab>", "Finished"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("layout text %q missing %q", joined, want)
+		}
+	}
+	if strings.Contains(joined, "Archived") {
+		t.Errorf("layout includes a closed card: %q", joined)
+	}
+	if got := colors["Due Fri 10/16 11:00 AM"]; got != "#c9372c" {
+		t.Errorf("overdue due line color = %q, want red", got)
+	}
+	if got := colors["+ Due Fri 10/16 11:00 AM"]; got != "#3f8a2f" {
+		t.Errorf("complete due line color = %q, want green", got)
+	}
+
+	t.Run("png", func(t *testing.T) {
+		data, err := layout.PNG()
+		if err != nil {
+			t.Fatalf("PNG() error = %v", err)
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("not a PNG: %v", err)
+		}
+		if bounds := img.Bounds(); bounds.Dx() != layout.Width || bounds.Dy() != layout.Height {
+			t.Errorf("size = %v, want %dx%d", bounds, layout.Width, layout.Height)
+		}
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		svg := string(layout.SVG())
+		if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, "Late &lt;lab&gt;") || !strings.Contains(svg, trelloLabelHex["red"]) {
+			t.Errorf("SVG() = %s", svg)
+		}
+	})
+}
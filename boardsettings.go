@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// boardSettingsFile holds local per-board settings, keyed by board name
+const boardSettingsFile = "board_settings.json"
+
+// settingsCardName is the card a board's own settings live on. Archiving it
+// hides it from the board; the syncs still find it.
+const settingsCardName = "Automation Settings"
+
+// BoardSettings are automation settings that can differ from board to board
+type BoardSettings struct {
+	RedoThreshold *float64          `json:"redo_threshold,omitempty"` // percent below which work is a REDO
+	QuietLists    []string          `json:"quiet_lists,omitempty"`    // lists syncs never move cards out of
+	Routes        map[string]string `json:"routes,omitempty"`         // e.g. {"Done": "Finished"}; "" turns a move off
+}
+
+// passing is the board's REDO threshold, or passingGrade by default
+func (s *BoardSettings) passing() float64 {
+	if s == nil || s.RedoThreshold == nil {
+		return passingGrade
+	}
+	return *s.RedoThreshold
+}
+
+// routeList maps one of the routing lists (Weekly, Done, Submitted) to the
+// board's own list for it. ok is false when the board turns that move off.
+func (s *BoardSettings) routeList(listName string) (string, bool) {
+	if s == nil {
+		return listName, true
+	}
+	for from, to := range s.Routes {
+		if strings.EqualFold(from, listName) {
+			return to, to != ""
+		}
+	}
+	return listName, true
+}
+
+// isQuiet reports whether a list is one of the board's quiet lists
+func (s *BoardSettings) isQuiet(listName string) bool {
+	if s == nil {
+		return false
+	}
+	for _, quiet := range s.QuietLists {
+		if normalizeString(quiet) == normalizeString(listName) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeBoardSettings lays the settings from the board's card over the local
+// ones, field by field
+func mergeBoardSettings(local, board BoardSettings) BoardSettings {
+	merged := local
+	if board.RedoThreshold != nil {
+		merged.RedoThreshold = board.RedoThreshold
+	}
+	if board.QuietLists != nil {
+		merged.QuietLists = board.QuietLists
+	}
+	if len(board.Routes) > 0 {
+		merged.Routes = make(map[string]string)
+		for from, to := range local.Routes {
+			merged.Routes[from] = to
+		}
+		for from, to := range board.Routes {
+			merged.Routes[from] = to
+		}
+	}
+	return merged
+}
+
+// parseSettingsCard reads the JSON in a settings card's description, which
+// may sit in a ``` code block so Trello shows it as typed
+func parseSettingsCard(desc string) (BoardSettings, error) {
+	desc = strings.TrimSpace(desc)
+	if strings.HasPrefix(desc, "```") {
+		desc = strings.TrimPrefix(strings.TrimPrefix(desc, "```json"), "```")
+		desc = strings.TrimSuffix(strings.TrimSpace(desc), "```")
+	}
+	var settings BoardSettings
+	if strings.TrimSpace(desc) == "" {
+		return settings, nil
+	}
+	if err := json.Unmarshal([]byte(desc), &settings); err != nil {
+		return settings, fmt.Errorf("failed to unmarshal %s card: %w", settingsCardName, err)
+	}
+	return settings, validateBoardSettings(settings)
+}
+
+// validateBoardSettings rejects thresholds that aren't percentages
+func validateBoardSettings(settings BoardSettings) error {
+	if settings.RedoThreshold != nil && (*settings.RedoThreshold < 0 || *settings.RedoThreshold > 100) {
+		return fmt.Errorf("redo_threshold %g is not between 0 and 100", *settings.RedoThreshold)
+	}
+	return nil
+}
+
+// loadLocalBoardSettings reads a board's entry in board_settings.json at
+// path. A missing file or board means no local settings.
+func loadLocalBoardSettings(path, boardName string) (BoardSettings, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return BoardSettings{}, nil
+	}
+	if err != nil {
+		return BoardSettings{}, fmt.Errorf("failed to read %s: %w", boardSettingsFile, err)
+	}
+	var boards map[string]BoardSettings
+	if err := json.Unmarshal(data, &boards); err != nil {
+		return BoardSettings{}, fmt.Errorf("failed to unmarshal %s: %w", boardSettingsFile, err)
+	}
+	for name, settings := range boards {
+		if normalizeString(name) == normalizeString(boardName) {
+			return settings, validateBoardSettings(settings)
+		}
+	}
+	return BoardSettings{}, nil
+}
+
+// findSettingsCard returns the board's settings card, open or archived, or
+// nil when it has none
+func (c *TrelloClient) findSettingsCard(boardID string) (*Card, error) {
+	params := url.Values{}
+	params.Set("fields", "name,desc,closed")
+	body, err := c.makeRequest(fmt.Sprintf("/boards/%s/cards/all?%s", boardID, params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	var cards []Card
+	if err := json.Unmarshal(body, &cards); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cards: %w", err)
+	}
+	for i := range cards {
+		if normalizeString(cards[i].Name) == normalizeString(settingsCardName) {
+			return &cards[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// boardSettings returns a board's settings: board_settings.json with the
+// board's settings card laid over it. They're read once per run. Problems
+// are warnings, and the defaults fill in.
+func (c *TrelloClient) boardSettings(boardName string) *BoardSettings {
+	key := normalizeString(boardName)
+	if settings, ok := c.settings[key]; ok {
+		return settings
+	}
+
+	local, err := loadLocalBoardSettings(findConfigFile(boardSettingsFile), boardName)
+	if err != nil {
+		fmt.Printf("Warning: ignoring %s: %v\n", boardSettingsFile, err)
+	}
+	settings := local
+	if cache, err := c.LoadCache(); err == nil {
+		if board, err := findBoardByName(cache.Boards, boardName); err == nil {
+			card, err := c.findSettingsCard(board.ID)
+			if err != nil {
+				fmt.Printf("Warning: failed to read the %s card on %s: %v\n", settingsCardName, boardName, err)
+			} else if card != nil {
+				fromBoard, err := parseSettingsCard(card.Description)
+				if err != nil {
+					fmt.Printf("Warning: ignoring the %s card on %s: %v\n", settingsCardName, boardName, err)
+				} else {
+					settings = mergeBoardSettings(local, fromBoard)
+				}
+			}
+		}
+	}
+
+	if c.settings == nil {
+		c.settings = make(map[string]*BoardSettings)
+	}
+	c.settings[key] = &settings
+	return &settings
+}
+
+// inQuietList reports whether a card sits in one of its board's quiet lists
+func (c *TrelloClient) inQuietList(boardName string, card Card) bool {
+	settings := c.boardSettings(boardName)
+	if len(settings.QuietLists) == 0 {
+		return false
+	}
+	cache, err := c.LoadCache()
+	if err != nil {
+		return false
+	}
+	for _, list := range cache.Lists {
+		if list.ID == card.IDList {
+			return settings.isQuiet(list.Name)
+		}
+	}
+	return false
+}
+
+// ShowBoardSettings prints a board's settings after merging
+func (c *TrelloClient) ShowBoardSettings(boardName string) error {
+	settings := c.boardSettings(boardName)
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	fmt.Printf("Settings for %s (REDO below %g%%):\n%s\n", boardName, settings.passing(), data)
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSettingsCard(t *testing.T) {
+	threshold := 85.0
+	tests := []struct {
+		name    string
+		desc    string
+		want    BoardSettings
+		wantErr bool
+	}{
+		{"empty", "", BoardSettings{}, false},
+		{"plain json", `{"redo_threshold": 85, "quiet_lists": ["Parking Lot"]}`, BoardSettings{RedoThreshold: &threshold, QuietLists: []string{"Parking Lot"}}, false},
+		{"code block", "```json\n{\"routes\": {\"Done\": \"Finished\"}}\n```", BoardSettings{Routes: map[string]string{"Done": "Finished"}}, false},
+		{"not json", "Settings go here", BoardSettings{}, true},
+		{"bad threshold", `{"redo_threshold": 190}`, BoardSettings{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSettingsCard(tt.desc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSettingsCard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSettingsCard() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeBoardSettings(t *testing.T) {
+	local, board := 80.0, 85.0
+	merged := mergeBoardSettings(
+		BoardSettings{RedoThreshold: &local, QuietLists: []string{"Someday"}, Routes: map[string]string{"Submitted": "Turned In", "Done": "Done"}},
+		BoardSettings{RedoThreshold: &board, Routes: map[string]string{"Done": "Finished"}},
+	)
+	if merged.passing() != 85 {
+		t.Errorf("passing() = %g, want the board's 85", merged.passing())
+	}
+	if !merged.isQuiet("someday") {
+		t.Error("local quiet list was lost")
+	}
+	if want := map[string]string{"Submitted": "Turned In", "Done": "Finished"}; !reflect.DeepEqual(merged.Routes, want) {
+		t.Errorf("Routes = %v, want %v", merged.Routes, want)
+	}
+}
+
+func TestRouteList(t *testing.T) {
+	settings := &BoardSettings{Routes: map[string]string{"done": "Finished", "Submitted": ""}}
+	tests := []struct {
+		list   string
+		want   string
+		wantOK bool
+	}{
+		{"Done", "Finished", true},
+		{"Submitted", "", false},
+		{"Weekly", "Weekly", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.list, func(t *testing.T) {
+			got, ok := settings.routeList(tt.list)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("routeList(%s) = %q, %v, want %q, %v", tt.list, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	var none *BoardSettings
+	if got, ok := none.routeList("Done"); got != "Done" || !ok || none.passing() != passingGrade {
+		t.Error("missing settings didn't fall back to the defaults")
+	}
+}
+
+func TestLoadLocalBoardSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), boardSettingsFile)
+	if settings, err := loadLocalBoardSettings(path, "Makai School"); err != nil || settings.RedoThreshold != nil {
+		t.Fatalf("no file: %+v, %v", settings, err)
+	}
+
+	data := `{"makai school": {"redo_threshold": 80}, "Other": {"redo_threshold": 70}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := loadLocalBoardSettings(path, "Makai School")
+	if err != nil || settings.passing() != 80 {
+		t.Errorf("loadLocalBoardSettings() = %+v, %v", settings, err)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// briefingMaxTests caps how many tests the briefing reads out
+const briefingMaxTests = 2
+
+// plural spells out a count with the right form of noun, e.g. "1 task" or "3 tasks"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// spokenDay says when a time falls relative to now: "today", "tomorrow", or a weekday
+func spokenDay(t, now time.Time) string {
+	t = t.In(now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location()); {
+	case day.Equal(today):
+		return "today"
+	case day.Equal(today.AddDate(0, 0, 1)):
+		return "tomorrow"
+	}
+	return t.Weekday().String()
+}
+
+// spokenName makes a card name easier to read aloud
+func spokenName(name string) string {
+	name = strings.TrimPrefix(name, "REDO - ")
+	return strings.Join(strings.Fields(stripEmoji(name)), " ")
+}
+
+// buildBriefing turns the agenda into a few spoken sentences, e.g. "You have
+// 3 tasks today. Math test Friday. Sundown is at 7:42 PM." Snoozed cards
+// aren't counted. sundown may be empty.
+func buildBriefing(agenda TodayAgenda, sundown string) string {
+	now := agenda.Date
+	endOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+
+	tasks := len(agenda.Dailies)
+	for _, card := range agenda.DueSoon {
+		if !agenda.Snoozed[card.ID] && card.Due.Before(endOfToday) {
+			tasks++
+		}
+	}
+
+	var sentences []string
+	if tasks == 0 {
+		sentences = append(sentences, "Nothing is due today")
+	} else {
+		sentences = append(sentences, fmt.Sprintf("You have %s today", plural(tasks, "task")))
+	}
+	if len(agenda.Redos) > 0 {
+		sentences = append(sentences, fmt.Sprintf("%s to redo", plural(len(agenda.Redos), "assignment")))
+	}
+	for i, card := range agenda.TestsThisWeek {
+		if i == briefingMaxTests {
+			sentences = append(sentences, plural(len(agenda.TestsThisWeek)-i, "more test")+" this week")
+			break
+		}
+		sentences = append(sentences, fmt.Sprintf("%s %s", spokenName(card.Name), spokenDay(*card.Due, now)))
+	}
+	if sundown != "" {
+		// Drop the time zone; nobody wants to hear "M D T"
+		if fields := strings.Fields(sundown); len(fields) == 3 {
+			sundown = fields[0] + " " + fields[1]
+		}
+		sentences = append(sentences, "Sundown is at "+sundown)
+	}
+	return strings.Join(sentences, ". ") + "."
+}
+
+// briefingAuthorized checks the request against BRIEFING_TOKEN, given as
+// ?token= or a bearer token. Without BRIEFING_TOKEN every request is
+// allowed, which Serve only permits on a loopback address.
+func briefingAuthorized(r *http.Request, token string) bool {
+	if token == "" {
+		return true
+	}
+	given := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		given = bearer
+	}
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+// briefingHandler serves the spoken briefing for a board as plain text, or
+// as {"text": ...} with ?format=json
+func (c *TrelloClient) briefingHandler(boardName, dailyListName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !briefingAuthorized(r, os.Getenv("BRIEFING_TOKEN")) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		agenda, err := c.GetTodayAgenda(boardName, dailyListName)
+		if err != nil {
+			fmt.Printf("Warning: briefing failed: %v\n", err)
+			http.Error(w, "Sorry, I couldn't load the board.", http.StatusInternalServerError)
+			return
+		}
+		sundown, err := GetTodaySundownTime(currentLogicalDay())
+		if err != nil {
+			fmt.Printf("Warning: briefing without sundown: %v\n", err)
+			sundown = ""
+		}
+		text := buildBriefing(*agenda, sundown)
+
+		if r.URL.Query().Get("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]string{"text": text})
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, text)
+	}
+}
+
+// isLoopbackAddr reports whether a listen address only accepts connections
+// from this machine. An empty host (":8080") listens on every interface.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Serve runs the HTTP server for voice assistants and shortcuts. Answers
+// come from the cache, so keep --refresh scheduled while it runs. The
+// briefing names the student's schedule, so listening beyond this machine
+// needs BRIEFING_TOKEN.
+func (c *TrelloClient) Serve(addr string) error {
+	if os.Getenv("BRIEFING_TOKEN") == "" && !isLoopbackAddr(addr) {
+		return fmt.Errorf("serving on %s reaches the whole network; set BRIEFING_TOKEN, or listen on 127.0.0.1 (e.g. 127.0.0.1:8080)", addr)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/briefing", c.briefingHandler("Makai School", "Daily"))
+
+	fmt.Printf("Serving the briefing at http://%s/briefing\n", addr)
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	if err := server.ListenAndServe(); err != nil {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
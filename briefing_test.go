@@ -0,0 +1,97 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBuildBriefing(t *testing.T) {
+	now := time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC) // Wednesday
+	at := func(days, hour int) *time.Time {
+		t := time.Date(2026, 10, 14+days, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		name    string
+		agenda  TodayAgenda
+		sundown string
+		want    string
+	}{
+		{
+			name:    "example",
+			agenda:  TodayAgenda{Date: now, Dailies: []Card{{ID: "d1"}, {ID: "d2"}}, DueSoon: []Card{{ID: "1", Due: at(0, 23)}}, TestsThisWeek: []Card{{ID: "t", Name: "Math test", Due: at(2, 9)}}},
+			sundown: "7:42 PM MDT",
+			want:    "You have 3 tasks today. Math test Friday. Sundown is at 7:42 PM.",
+		},
+		{
+			name:   "tomorrow and snoozed don't count",
+			agenda: TodayAgenda{Date: now, DueSoon: []Card{{ID: "1", Due: at(1, 9)}, {ID: "2", Due: at(0, 9)}}, Snoozed: map[string]bool{"2": true}},
+			want:   "Nothing is due today.",
+		},
+		{
+			name:   "one task, redos, many tests",
+			agenda: TodayAgenda{Date: now, Dailies: []Card{{ID: "d"}}, Redos: []Card{{ID: "r"}}, TestsThisWeek: []Card{{Name: "📝 Quiz", Due: at(0, 10)}, {Name: "Spelling test", Due: at(1, 10)}, {Name: "Final exam", Due: at(3, 10)}}},
+			want:   "You have 1 task today. 1 assignment to redo. Quiz today. Spelling test tomorrow. 1 more test this week.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildBriefing(tt.agenda, tt.sundown); got != tt.want {
+				t.Errorf("buildBriefing() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBriefingAuthorized(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		header string
+		token  string
+		want   bool
+	}{
+		{"no token set", "/briefing", "", "", true},
+		{"query token", "/briefing?token=secret", "", "secret", true},
+		{"bearer token", "/briefing", "Bearer secret", "secret", true},
+		{"wrong token", "/briefing?token=guess", "", "secret", false},
+		{"missing token", "/briefing", "", "secret", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.url, nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			if got := briefingAuthorized(r, tt.token); got != tt.want {
+				t.Errorf("briefingAuthorized() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1:8080", true},
+		{"localhost:8080", true},
+		{"[::1]:8080", true},
+		{":8080", false},
+		{"0.0.0.0:8080", false},
+		{"192.168.1.20:8080", false},
+		{"8080", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := isLoopbackAddr(tt.addr); got != tt.want {
+				t.Errorf("isLoopbackAddr(%q) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
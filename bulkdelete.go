@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// trelloBatchLimit is the most URLs Trello's /batch endpoint takes at once
+	trelloBatchLimit = 10
+
+	// bulkDeleteDelay keeps deletes under Trello's limit of 100 requests per
+	// 10 seconds per token
+	bulkDeleteDelay = 110 * time.Millisecond
+
+	// defaultBackupDir is where card backups are written before bulk deletes
+	defaultBackupDir = "backups"
+)
+
+// CardBackup is everything needed to recreate a deleted card by hand
+type CardBackup struct {
+	Card     json.RawMessage `json:"card"`     // includes checklists and attachments
+	Comments json.RawMessage `json:"comments"` // newest first
+}
+
+// ListBackup is the file written before a list's cards are deleted
+type ListBackup struct {
+	ListID  string       `json:"listId"`
+	TakenAt time.Time    `json:"takenAt"`
+	Cards   []CardBackup `json:"cards"`
+}
+
+// GetBatch fetches up to trelloBatchLimit GET endpoints in one request and
+// returns each response body in order. Trello's batch endpoint only supports GETs.
+func (c *TrelloClient) GetBatch(endpoints []string) ([]json.RawMessage, error) {
+	if len(endpoints) > trelloBatchLimit {
+		return nil, fmt.Errorf("batch of %d requests is over Trello's limit of %d", len(endpoints), trelloBatchLimit)
+	}
+
+	body, err := c.makeRequest("/batch?urls=" + url.QueryEscape(strings.Join(endpoints, ",")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to make batch request: %w", err)
+	}
+
+	// Each entry is {"200": body} on success, or a status and message on failure
+	var results []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal batch response: %w", err)
+	}
+	if len(results) != len(endpoints) {
+		return nil, fmt.Errorf("batch returned %d results for %d requests", len(results), len(endpoints))
+	}
+
+	responses := make([]json.RawMessage, len(results))
+	for i, result := range results {
+		ok, found := result["200"]
+		if !found {
+			return nil, fmt.Errorf("batch request %s failed: %s", endpoints[i], batchError(result))
+		}
+		responses[i] = ok
+	}
+	return responses, nil
+}
+
+// batchError summarizes a failed batch entry
+func batchError(result map[string]json.RawMessage) string {
+	var parts []string
+	for key, value := range result {
+		parts = append(parts, fmt.Sprintf("%s: %s", key, value))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BackupCards fetches each card with its checklists, attachments, and
+// comments (through the batch endpoint) and writes them to a timestamped
+// file in dir. It returns the file's path.
+func (c *TrelloClient) BackupCards(listID string, cards []Card, dir string) (string, error) {
+	backup := ListBackup{ListID: listID, TakenAt: time.Now()}
+
+	// Two requests per card, so each batch covers half the limit in cards
+	perBatch := trelloBatchLimit / 2
+	for start := 0; start < len(cards); start += perBatch {
+		end := start + perBatch
+		if end > len(cards) {
+			end = len(cards)
+		}
+
+		var endpoints []string
+		for _, card := range cards[start:end] {
+			endpoints = append(endpoints,
+				fmt.Sprintf("/cards/%s?checklists=all&attachments=true", card.ID),
+				fmt.Sprintf("/cards/%s/actions?filter=commentCard", card.ID))
+		}
+
+		responses, err := c.GetBatch(endpoints)
+		if err != nil {
+			return "", fmt.Errorf("failed to back up cards: %w", err)
+		}
+		for i := 0; i < len(responses); i += 2 {
+			backup.Cards = append(backup.Cards, CardBackup{Card: responses[i], Comments: responses[i+1]})
+		}
+	}
+
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal backup: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("list_%s_%s.json", listID, time.Now().Format("2006-01-02_15-04-05")))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+	return path, nil
+}
+
+// confirmBulkDelete asks for "yes" before deleting count cards
+func confirmBulkDelete(in io.Reader, out io.Writer, listName string, count int) bool {
+	fmt.Fprintf(out, "Delete %d card(s) from '%s'? This can't be undone (a backup is saved first). Type 'yes' to continue: ", count, listName)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	return strings.EqualFold(strings.TrimSpace(answer), "yes")
+}
+
+// DeleteAllCardsFromList backs up and then deletes every card in a list.
+// Unless yes is set, it asks for confirmation on stdin first. Deletes are
+// paced to stay under Trello's rate limit, since the batch endpoint can't
+// delete.
+func (c *TrelloClient) DeleteAllCardsFromList(listID, listName string, yes bool, backupDir string) error {
+	cards, err := c.GetCardsInList(listID)
+	if err != nil {
+		return fmt.Errorf("failed to get cards in list: %w", err)
+	}
+	if len(cards) == 0 {
+		fmt.Printf("No cards in '%s'\n", listName)
+		return nil
+	}
+
+	if !yes && !confirmBulkDelete(os.Stdin, os.Stdout, listName, len(cards)) {
+		return fmt.Errorf("cancelled; no cards were deleted")
+	}
+
+	if backupDir == "" {
+		backupDir = defaultBackupDir
+	}
+	fmt.Printf("Backing up %d card(s)...\n", len(cards))
+	path, err := c.BackupCards(listID, cards, backupDir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Backup saved to %s\n", iconSuccess, path)
+
+	for i, card := range cards {
+		if i > 0 {
+			time.Sleep(bulkDeleteDelay)
+		}
+		fmt.Printf("[%d/%d] Deleting card: %s\n", i+1, len(cards), strings.Join(strings.Fields(card.Name), " "))
+		if err := c.DeleteCard(card.ID); err != nil {
+			return fmt.Errorf("failed to delete card %s after deleting %d of %d (backup: %s): %w", card.Name, i, len(cards), path, err)
+		}
+	}
+
+	fmt.Printf("Successfully deleted %d cards!\n", len(cards))
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfirmBulkDelete(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"yes\n", true},
+		{"YES\n", true},
+		{"y\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var out bytes.Buffer
+			if got := confirmBulkDelete(strings.NewReader(tt.input), &out, "Old Homework", 3); got != tt.want {
+				t.Errorf("confirmBulkDelete(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if !strings.Contains(out.String(), "Delete 3 card(s) from 'Old Homework'") {
+				t.Errorf("prompt = %q", out.String())
+			}
+		})
+	}
+}
+
+func TestDeleteAllCardsFromListBacksUpFirst(t *testing.T) {
+	var deleted []string
+	var batches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/lists/list1/cards":
+			w.Write([]byte(`[{"id": "a", "name": "Essay"}, {"id": "b", "name": "Lab\nreport"}]`))
+		case r.Method == "GET" && r.URL.Path == "/batch":
+			batches++
+			if len(deleted) > 0 {
+				t.Errorf("backup fetched after deletes started")
+			}
+			urls := strings.Split(r.URL.Query().Get("urls"), ",")
+			var results []map[string]json.RawMessage
+			for _, u := range urls {
+				results = append(results, map[string]json.RawMessage{"200": json.RawMessage(`{"url": "` + u + `"}`)})
+			}
+			json.NewEncoder(w).Encode(results)
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/cards/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/cards/"))
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := &TrelloClient{BaseURL: server.URL}
+	if err := client.DeleteAllCardsFromList("list1", "Old Homework", true, dir); err != nil {
+		t.Fatalf("DeleteAllCardsFromList() error = %v", err)
+	}
+
+	if strings.Join(deleted, ",") != "a,b" {
+		t.Errorf("deleted = %v, want a,b", deleted)
+	}
+	if batches != 1 {
+		t.Errorf("batch requests = %d, want 1", batches)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "list_list1_*.json"))
+	if len(files) != 1 {
+		t.Fatalf("backup files = %v, want one", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var backup ListBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		t.Fatal(err)
+	}
+	if len(backup.Cards) != 2 || !strings.Contains(string(backup.Cards[1].Comments), "/cards/b/actions") {
+		t.Errorf("backup = %s", data)
+	}
+}
+
+func TestGetBatchReportsFailedEntry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"200": {}}, {"statusCode": 404, "message": "not found"}]`))
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	if _, err := client.GetBatch([]string{"/cards/a", "/cards/b"}); err == nil || !strings.Contains(err.Error(), "/cards/b") {
+		t.Errorf("GetBatch() error = %v, want failure naming /cards/b", err)
+	}
+}
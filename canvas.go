@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,11 @@ import (
 type CanvasClient struct {
 	APIToken string
 	BaseURL  string
+	Refresh  *CanvasRefresh // renews APIToken when it expires, if configured
+
+	// Institution tags courses and cards from an extra Canvas account, e.g.
+	// a concurrent-enrollment college; empty for CANVAS_BASE_URL
+	Institution string
 }
 
 type CanvasUser struct {
@@ -29,41 +35,95 @@ type CanvasCourse struct {
 }
 
 type CanvasAssignment struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	DueAt       string `json:"due_at"`
-	CourseID    int    `json:"course_id"`
-	HTMLURL     string `json:"html_url"`
+	ID             int     `json:"id"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+	DueAt          string  `json:"due_at"`
+	LockAt         string  `json:"lock_at"`
+	CourseID       int     `json:"course_id"`
+	HTMLURL        string  `json:"html_url"`
+	PointsPossible float64 `json:"points_possible"`
+
+	// Set on group assignments, which are submitted once for the whole group
+	GroupCategoryID *int `json:"group_category_id,omitempty"`
+	// Section, group, and per-student dates; only visible to some accounts
+	Overrides []CanvasAssignmentOverride `json:"overrides,omitempty"`
+	// The override DueAt and LockAt came from, e.g. "Section 2"
+	OverrideTitle string `json:"override_title,omitempty"`
+
+	// Unpublished or not-yet-unlocked work the student can't open
+	Published     *bool  `json:"published,omitempty"`
+	UnlockAt      string `json:"unlock_at,omitempty"`
+	LockedForUser bool   `json:"locked_for_user,omitempty"`
 }
 
 type CanvasSubmission struct {
 	Score      *float64 `json:"score"`
 	Grade      string   `json:"grade"`
 	WorkflowState string `json:"workflow_state"`
+	Late       bool     `json:"late"`
+	Missing    bool     `json:"missing"`
+	Excused    bool     `json:"excused"`
+}
+
+// CanvasLatePolicy mirrors the course late policy returned by Canvas
+type CanvasLatePolicy struct {
+	MissingSubmissionDeductionEnabled bool    `json:"missing_submission_deduction_enabled"`
+	MissingSubmissionDeduction        float64 `json:"missing_submission_deduction"`
+	LateSubmissionDeductionEnabled    bool    `json:"late_submission_deduction_enabled"`
+	LateSubmissionDeduction           float64 `json:"late_submission_deduction"`
+	LateSubmissionInterval            string  `json:"late_submission_interval"`
+	LateSubmissionMinimumPercentEnabled bool  `json:"late_submission_minimum_percent_enabled"`
+	LateSubmissionMinimumPercent      float64 `json:"late_submission_minimum_percent"`
 }
 
 func NewCanvasClient(apiToken, baseURL string) *CanvasClient {
 	return &CanvasClient{
 		APIToken: apiToken,
 		BaseURL:  baseURL,
+		Refresh:  canvasRefreshFromEnv(),
 	}
 }
 
 func (c *CanvasClient) makeRequest(endpoint string) ([]byte, error) {
+	return c.sendForm("GET", endpoint, nil)
+}
+
+// sendForm makes a Canvas API request with form fields (nil for none)
+func (c *CanvasClient) sendForm(method, endpoint string, form url.Values) ([]byte, error) {
+	body, err := c.doRequest(method, endpoint, form)
+	var authErr *CanvasAuthError
+	if errors.As(err, &authErr) && authErr.Status == http.StatusUnauthorized && c.Refresh != nil {
+		// Access tokens from a developer key last an hour; get a new one and retry once
+		if refreshErr := c.refreshAccessToken(); refreshErr != nil {
+			return nil, fmt.Errorf("%v (refreshing it also failed: %v)", err, refreshErr)
+		}
+		return c.doRequest(method, endpoint, form)
+	}
+	return body, err
+}
+
+func (c *CanvasClient) doRequest(method, endpoint string, form url.Values) ([]byte, error) {
 	u, err := url.Parse(c.BaseURL + "/api/v1" + endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
 
-	req, err := http.NewRequest("GET", u.String(), nil)
+	var reqBody io.Reader
+	contentType := "application/json"
+	if form != nil {
+		reqBody = strings.NewReader(form.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	req, err := http.NewRequest(method, u.String(), reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Canvas uses Authorization header with Bearer token
 	req.Header.Set("Authorization", "Bearer "+c.APIToken)
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -71,15 +131,18 @@ func (c *CanvasClient) makeRequest(endpoint string) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Canvas API request failed with status %d", resp.StatusCode)
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if authErr := classifyCanvasAuthError(resp.StatusCode, body); authErr != nil {
+		return nil, authErr
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Canvas API request failed with status %d", resp.StatusCode)
+	}
+
 	return body, nil
 }
 
@@ -103,7 +166,7 @@ func (c *CanvasClient) TestConnection() error {
 		return fmt.Errorf("failed to connect to Canvas: %w", err)
 	}
 
-	fmt.Printf("✅ Canvas connection successful!\n")
+	fmt.Printf("%s Canvas connection successful!\n", iconSuccess)
 	fmt.Printf("User: %s (%s)\n", user.Name, user.Email)
 	fmt.Printf("Login ID: %s\n", user.LoginID)
 	fmt.Printf("Canvas User ID: %d\n", user.ID)
@@ -126,7 +189,7 @@ func (c *CanvasClient) GetCourses() ([]CanvasCourse, error) {
 }
 
 func (c *CanvasClient) GetAssignments(courseID int) ([]CanvasAssignment, error) {
-	endpoint := fmt.Sprintf("/courses/%d/assignments?per_page=100", courseID)
+	endpoint := fmt.Sprintf("/courses/%d/assignments?include[]=overrides&per_page=100", courseID)
 	body, err := c.makeRequest(endpoint)
 	if err != nil {
 		return nil, err
@@ -155,14 +218,38 @@ func (c *CanvasClient) GetSubmission(courseID, assignmentID, userID int) (*Canva
 	return &submission, nil
 }
 
-func (c *CanvasClient) GetUpcomingAssignments(userID int) ([]CanvasAssignment, error) {
+// GetLatePolicy returns the late policy for a course
+func (c *CanvasClient) GetLatePolicy(courseID int) (*CanvasLatePolicy, error) {
+	endpoint := fmt.Sprintf("/courses/%d/late_policy", courseID)
+	body, err := c.makeRequest(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		LatePolicy CanvasLatePolicy `json:"late_policy"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal late policy: %w", err)
+	}
+
+	return &response.LatePolicy, nil
+}
+
+// GetUpcomingAssignments returns assignments due between since and end
+func (c *CanvasClient) GetUpcomingAssignments(userID int, since, end time.Time) ([]CanvasAssignment, error) {
 	courses, err := c.GetCourses()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get courses: %w", err)
 	}
 
+	// Overrides are matched against the student's sections and groups
+	sections, groups, err := c.GetStudentSectionsAndGroups()
+	if err != nil {
+		fmt.Printf("Warning: using base due dates without section and group overrides: %v\n", err)
+	}
+
 	var allAssignments []CanvasAssignment
-	threeMonthsFromNow := time.Now().AddDate(0, 3, 0)
 
 	for _, course := range courses {
 		assignments, err := c.GetAssignments(course.ID)
@@ -171,8 +258,9 @@ func (c *CanvasClient) GetUpcomingAssignments(userID int) ([]CanvasAssignment, e
 			continue
 		}
 
-		// Filter assignments due within 3 months
+		// Filter assignments due within the sync window
 		for _, assignment := range assignments {
+			applyAssignmentOverrides(&assignment, userID, sections, groups)
 			if assignment.DueAt == "" {
 				continue // Skip assignments with no due date
 			}
@@ -183,8 +271,7 @@ func (c *CanvasClient) GetUpcomingAssignments(userID int) ([]CanvasAssignment, e
 				continue
 			}
 
-			// Only include assignments due within the next 3 months
-			if dueDate.Before(threeMonthsFromNow) && dueDate.After(time.Now().AddDate(0, 0, -1)) {
+			if dueDate.Before(end) && dueDate.After(since) {
 				allAssignments = append(allAssignments, assignment)
 			}
 		}
@@ -210,21 +297,80 @@ func (c *CanvasClient) GetCourseNameByID(courseID int) (string, error) {
 
 func formatCanvasMetadata(assignment CanvasAssignment, courseName string, submission *CanvasSubmission) string {
 	var grade string
-	if submission != nil && submission.Score != nil {
-		grade = fmt.Sprintf("%.1f%%", *submission.Score)
-		if *submission.Score < 90 {
+	if percent, graded := canvasPercent(assignment, submission, courseName, activeGradeScale()); graded {
+		grade = fmt.Sprintf("%.1f%%", percent)
+		if percent < passingGrade {
 			grade += " (REDO NEEDED)"
 		}
 	} else {
 		grade = "Not graded"
 	}
 
-	return fmt.Sprintf("\n\n---\nCanvas Assignment ID: %d\nCourse: %s\nOriginal Due Date: %s\nGrade: %s\nCanvas URL: %s",
+	metadata := friendlyDateLines(assignment.UnlockAt, assignment.DueAt, assignment.LockAt, displayLocation())
+	metadata += fmt.Sprintf("\n\n---\nCanvas Assignment ID: %d\nCourse: %s\nOriginal Due Date: %s\nGrade: %s\n%s",
 		assignment.ID,
 		courseName,
 		assignment.DueAt,
 		grade,
-		assignment.HTMLURL)
+		sourceURLLine("Canvas", assignment.HTMLURL))
+
+	if assignment.LockAt != "" {
+		metadata += fmt.Sprintf("\nLock Date: %s", assignment.LockAt)
+		if isPastLockDate(assignment, time.Now()) {
+			metadata += " (LOCKED - can no longer be submitted)"
+		}
+	}
+	if assignment.UnlockAt != "" {
+		metadata += fmt.Sprintf("\nOpens: %s", assignment.UnlockAt)
+	}
+	if assignment.OverrideTitle != "" {
+		metadata += fmt.Sprintf("\nDue Date Override: %s", assignment.OverrideTitle)
+	}
+	if assignment.GroupCategoryID != nil {
+		metadata += "\nGroup Assignment: Yes"
+	}
+
+	return metadata
+}
+
+// isPastLockDate reports whether Canvas will no longer accept submissions
+func isPastLockDate(assignment CanvasAssignment, now time.Time) bool {
+	if assignment.LockAt == "" {
+		return false
+	}
+	lockAt, err := time.Parse(time.RFC3339, assignment.LockAt)
+	if err != nil {
+		return false
+	}
+	return now.After(lockAt)
+}
+
+// describeLatePolicy summarizes a course late policy for card metadata
+func describeLatePolicy(policy *CanvasLatePolicy) string {
+	if policy == nil {
+		return ""
+	}
+
+	var parts []string
+	if policy.LateSubmissionDeductionEnabled {
+		interval := policy.LateSubmissionInterval
+		if interval == "" {
+			interval = "day"
+		}
+		part := fmt.Sprintf("-%.0f%% per %s late", policy.LateSubmissionDeduction, interval)
+		if policy.LateSubmissionMinimumPercentEnabled {
+			part += fmt.Sprintf(" (minimum %.0f%%)", policy.LateSubmissionMinimumPercent)
+		}
+		parts = append(parts, part)
+	}
+	if policy.MissingSubmissionDeductionEnabled {
+		parts = append(parts, fmt.Sprintf("missing work scored at %.0f%%", 100-policy.MissingSubmissionDeduction))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return "\nLate Policy: " + strings.Join(parts, ", ")
 }
 
 func stripCanvasMetadata(description string) string {
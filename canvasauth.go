@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Why Canvas turned a token away
+const (
+	canvasTokenExpired   = "expired"
+	canvasTokenRevoked   = "revoked"
+	canvasTokenForbidden = "forbidden"
+)
+
+// CanvasAuthError is a Canvas request rejected because of the API token
+type CanvasAuthError struct {
+	Status  int
+	Reason  string // canvasTokenExpired, canvasTokenRevoked, or canvasTokenForbidden
+	Message string // Canvas's own explanation, if it gave one
+}
+
+func (e *CanvasAuthError) Error() string {
+	switch e.Reason {
+	case canvasTokenExpired:
+		return "the Canvas API token has expired; run --renew-canvas to make a new one"
+	case canvasTokenRevoked:
+		return "Canvas no longer accepts the API token (it was deleted, revoked, or mistyped); run --renew-canvas to make a new one"
+	}
+	return fmt.Sprintf("Canvas refused the request with status %d (%s); the token may lack access to it", e.Status, e.Message)
+}
+
+// CanvasRefresh holds a Canvas developer key and refresh token, used to get
+// a new access token when the current one expires
+type CanvasRefresh struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+}
+
+// canvasRefreshFromEnv reads CANVAS_CLIENT_ID, CANVAS_CLIENT_SECRET, and
+// CANVAS_REFRESH_TOKEN; it returns nil unless all three are set
+func canvasRefreshFromEnv() *CanvasRefresh {
+	refresh := &CanvasRefresh{
+		ClientID:     os.Getenv("CANVAS_CLIENT_ID"),
+		ClientSecret: os.Getenv("CANVAS_CLIENT_SECRET"),
+		RefreshToken: os.Getenv("CANVAS_REFRESH_TOKEN"),
+	}
+	if refresh.ClientID == "" || refresh.ClientSecret == "" || refresh.RefreshToken == "" {
+		return nil
+	}
+	return refresh
+}
+
+// classifyCanvasAuthError explains a 401 or 403 from Canvas. Canvas says
+// "Invalid access token." for tokens that were deleted or never existed, and
+// mentions expiry for tokens past their expiration date.
+func classifyCanvasAuthError(status int, body []byte) *CanvasAuthError {
+	if status != http.StatusUnauthorized && status != http.StatusForbidden {
+		return nil
+	}
+
+	var reply struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &reply) == nil && len(reply.Errors) > 0 {
+		message = reply.Errors[0].Message
+	}
+
+	reason := canvasTokenForbidden
+	switch lower := strings.ToLower(message); {
+	case strings.Contains(lower, "expired"):
+		reason = canvasTokenExpired
+	case strings.Contains(lower, "invalid access token"), strings.Contains(lower, "revoked"):
+		reason = canvasTokenRevoked
+	}
+	return &CanvasAuthError{Status: status, Reason: reason, Message: message}
+}
+
+// refreshAccessToken trades the refresh token for a new access token
+func (c *CanvasClient) refreshAccessToken() error {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {c.Refresh.ClientID},
+		"client_secret": {c.Refresh.ClientSecret},
+		"refresh_token": {c.Refresh.RefreshToken},
+	}
+	resp, err := http.PostForm(c.BaseURL+"/login/oauth2/token", form)
+	if err != nil {
+		return fmt.Errorf("failed to refresh Canvas token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Canvas token refresh failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return fmt.Errorf("failed to unmarshal Canvas token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return fmt.Errorf("Canvas token refresh returned no access token")
+	}
+
+	c.APIToken = token.AccessToken
+	fmt.Printf("Refreshed the Canvas access token (valid for %s)\n", time.Duration(token.ExpiresIn)*time.Second)
+	return nil
+}
+
+// setEnvValue sets KEY="value" in an env file, replacing an existing line
+// for the key or appending one
+func setEnvValue(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	line := fmt.Sprintf("%s=%q", key, value)
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(data) == 0 {
+		lines = nil
+	}
+	replaced := false
+	for i, existing := range lines {
+		trimmed := strings.TrimPrefix(strings.TrimSpace(existing), "export ")
+		if strings.HasPrefix(trimmed, key+"=") {
+			lines[i] = line
+			replaced = true
+		}
+	}
+	if !replaced {
+		lines = append(lines, line)
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// RenewCanvasToken walks through making a new Canvas access token: it opens
+// the Canvas settings page, reads the pasted token, checks it works, and
+// saves it to the env file
+func RenewCanvasToken(baseURL, envFile string, in io.Reader) error {
+	settingsURL := strings.TrimRight(baseURL, "/") + "/profile/settings"
+	fmt.Println("To make a new Canvas API token:")
+	fmt.Printf("  1. Open %s (opening it now)\n", settingsURL)
+	fmt.Println("  2. Under \"Approved Integrations\", click \"+ New Access Token\"")
+	fmt.Println("  3. Enter a purpose like \"Trello Daily Reset\"; leave the expiration blank for a token that doesn't expire")
+	fmt.Println("  4. Click \"Generate Token\" and copy the token (Canvas shows it only once)")
+	if err := openBrowser(settingsURL); err != nil {
+		fmt.Printf("Warning: failed to open browser: %v\n", err)
+	}
+
+	fmt.Print("\nPaste the new token: ")
+	token, _ := bufio.NewReader(in).ReadString('\n')
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return fmt.Errorf("no token entered")
+	}
+
+	// Check the token itself, without falling back to a refresh
+	checker := NewCanvasClient(token, baseURL)
+	checker.Refresh = nil
+	user, err := checker.GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("the new token doesn't work: %w", err)
+	}
+	fmt.Printf("%s Token works for %s\n", iconSuccess, user.Name)
+
+	if err := setEnvValue(envFile, "CANVAS_API_TOKEN", token); err != nil {
+		return err
+	}
+	fmt.Printf("%s Saved CANVAS_API_TOKEN to %s\n", iconSuccess, envFile)
+	return nil
+}
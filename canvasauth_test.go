@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyCanvasAuthError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"expired", 401, `{"errors":[{"message":"Access token expired"}]}`, canvasTokenExpired},
+		{"revoked", 401, `{"errors":[{"message":"Invalid access token."}]}`, canvasTokenRevoked},
+		{"forbidden", 403, `{"status":"unauthorized","errors":[{"message":"user not authorized to perform that action"}]}`, canvasTokenForbidden},
+		{"plain text", 401, "Invalid access token.", canvasTokenRevoked},
+		{"ok", 200, `{"id":1}`, ""},
+		{"not found", 404, `{"errors":[{"message":"The specified resource does not exist."}]}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyCanvasAuthError(tt.status, []byte(tt.body))
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("classifyCanvasAuthError() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Reason != tt.want {
+				t.Errorf("classifyCanvasAuthError() = %+v, want reason %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetEnvValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{"replaces", "TRELLO_API_KEY=\"k\"\nCANVAS_API_TOKEN=\"old\"\nCANVAS_BASE_URL=\"https://x\"\n", "TRELLO_API_KEY=\"k\"\nCANVAS_API_TOKEN=\"new\"\nCANVAS_BASE_URL=\"https://x\"\n"},
+		{"replaces export", "export CANVAS_API_TOKEN=old\n", "CANVAS_API_TOKEN=\"new\"\n"},
+		{"appends", "TRELLO_API_KEY=\"k\"\n", "TRELLO_API_KEY=\"k\"\nCANVAS_API_TOKEN=\"new\"\n"},
+		{"new file", "", "CANVAS_API_TOKEN=\"new\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".env")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := setEnvValue(path, "CANVAS_API_TOKEN", "new"); err != nil {
+				t.Fatalf("setEnvValue() error = %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("file = %q, want %q", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanvasRefreshOnExpiredToken(t *testing.T) {
+	refreshes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login/oauth2/token":
+			refreshes++
+			if r.FormValue("refresh_token") != "refresh" || r.FormValue("grant_type") != "refresh_token" {
+				http.Error(w, "bad refresh", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"fresh","expires_in":3600}`)
+		case "/api/v1/users/self":
+			if r.Header.Get("Authorization") != "Bearer fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"errors":[{"message":"Access token expired"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"id":7,"name":"Student"}`)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("refreshes and retries", func(t *testing.T) {
+		client := &CanvasClient{APIToken: "stale", BaseURL: server.URL, Refresh: &CanvasRefresh{ClientID: "id", ClientSecret: "secret", RefreshToken: "refresh"}}
+		user, err := client.GetCurrentUser()
+		if err != nil {
+			t.Fatalf("GetCurrentUser() error = %v", err)
+		}
+		if user.Name != "Student" || client.APIToken != "fresh" || refreshes != 1 {
+			t.Errorf("user = %q, token = %q, refreshes = %d", user.Name, client.APIToken, refreshes)
+		}
+	})
+
+	t.Run("without refresh token", func(t *testing.T) {
+		client := &CanvasClient{APIToken: "stale", BaseURL: server.URL}
+		_, err := client.GetCurrentUser()
+		var authErr *CanvasAuthError
+		if !errors.As(err, &authErr) || authErr.Reason != canvasTokenExpired {
+			t.Errorf("GetCurrentUser() error = %v, want expired token error", err)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// canvasInstitutionPrefix tags the metadata of cards synced from an extra
+// Canvas institution. Cards from the main CANVAS_BASE_URL have no tag.
+const canvasInstitutionPrefix = "Canvas Institution: "
+
+// canvasEnvName turns an institution name into its env var infix, e.g.
+// "UVU" -> "UVU" and "Salt Lake CC" -> "SALT_LAKE_CC"
+func canvasEnvName(institution string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(institution))
+}
+
+// canvasClientsFromEnv returns a client for the main Canvas account plus one
+// for each name in CANVAS_INSTITUTIONS, which reads CANVAS_<NAME>_BASE_URL
+// and CANVAS_<NAME>_API_TOKEN
+func canvasClientsFromEnv() ([]*CanvasClient, error) {
+	var clients []*CanvasClient
+	if client, err := canvasClientFromEnv(); err == nil {
+		clients = append(clients, client)
+	}
+
+	for _, institution := range splitList(os.Getenv("CANVAS_INSTITUTIONS")) {
+		prefix := "CANVAS_" + canvasEnvName(institution)
+		baseURL, token := os.Getenv(prefix+"_BASE_URL"), os.Getenv(prefix+"_API_TOKEN")
+		if baseURL == "" || token == "" {
+			return nil, fmt.Errorf("%s_BASE_URL and %s_API_TOKEN must be set for Canvas institution '%s'", prefix, prefix, institution)
+		}
+		clients = append(clients, &CanvasClient{APIToken: token, BaseURL: baseURL, Institution: institution})
+	}
+
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("CANVAS_API_TOKEN and CANVAS_BASE_URL (or CANVAS_INSTITUTIONS) must be set")
+	}
+	return clients, nil
+}
+
+// institutionCourseName tags a course with its institution so courses from
+// different schools stay apart on the board, e.g. "ENGL 1010 (UVU)"
+func institutionCourseName(courseName, institution string) string {
+	if institution == "" {
+		return courseName
+	}
+	return fmt.Sprintf("%s (%s)", courseName, institution)
+}
+
+// institutionMetadata is the metadata line tagging a card with its institution
+func institutionMetadata(institution string) string {
+	if institution == "" {
+		return ""
+	}
+	return "\n" + canvasInstitutionPrefix + institution
+}
+
+// cardCanvasInstitution returns the institution a card was synced from, or
+// "" for the main Canvas account
+func cardCanvasInstitution(desc string) string {
+	for _, line := range strings.Split(desc, "\n") {
+		if institution, ok := strings.CutPrefix(strings.TrimSpace(line), canvasInstitutionPrefix); ok {
+			return strings.TrimSpace(institution)
+		}
+	}
+	return ""
+}
+
+// findCanvasAssignmentCard finds the card for an assignment from one
+// institution. Assignment IDs are only unique within a Canvas instance.
+func (c *TrelloClient) findCanvasAssignmentCard(cards []Card, assignmentID int, institution string) *Card {
+	searchLine := fmt.Sprintf("Canvas Assignment ID: %d", assignmentID)
+	for i, card := range cards {
+		if !strings.EqualFold(cardCanvasInstitution(card.Description), institution) {
+			continue
+		}
+		for _, line := range strings.Split(card.Description, "\n") {
+			if strings.TrimSpace(line) == searchLine {
+				return &cards[i]
+			}
+		}
+	}
+	return nil
+}
+
+// SyncAllCanvas syncs every configured Canvas institution in turn, carrying
+// on past one that fails
+func (c *TrelloClient) SyncAllCanvas(clients []*CanvasClient, since, end time.Time) error {
+	var errs []error
+	for _, canvasClient := range clients {
+		name := canvasClient.Institution
+		if name == "" {
+			name = canvasClient.BaseURL
+		}
+
+		user, err := canvasClient.GetCurrentUser()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get Canvas user for %s: %w", name, err))
+			continue
+		}
+		fmt.Printf("Syncing Canvas assignments from %s for user: %s (ID: %d)\n", name, user.Name, user.ID)
+
+		if err := c.SyncCanvasAssignments(canvasClient, user.ID, since, end); errors.Is(err, errSyncInterrupted) {
+			return errors.Join(append(errs, err)...)
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
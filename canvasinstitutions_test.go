@@ -0,0 +1,127 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanvasClientsFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    []string // institution@base URL
+		wantErr string
+	}{
+		{
+			name: "main account only",
+			env:  map[string]string{"CANVAS_API_TOKEN": "t", "CANVAS_BASE_URL": "https://alpine.instructure.com"},
+			want: []string{"@https://alpine.instructure.com"},
+		},
+		{
+			name: "main account and college",
+			env: map[string]string{
+				"CANVAS_API_TOKEN": "t", "CANVAS_BASE_URL": "https://alpine.instructure.com",
+				"CANVAS_INSTITUTIONS": "UVU, Salt Lake CC",
+				"CANVAS_UVU_BASE_URL": "https://uvu.instructure.com", "CANVAS_UVU_API_TOKEN": "u",
+				"CANVAS_SALT_LAKE_CC_BASE_URL": "https://slcc.instructure.com", "CANVAS_SALT_LAKE_CC_API_TOKEN": "s",
+			},
+			want: []string{"@https://alpine.instructure.com", "UVU@https://uvu.instructure.com", "Salt Lake CC@https://slcc.instructure.com"},
+		},
+		{
+			name: "college only",
+			env:  map[string]string{"CANVAS_INSTITUTIONS": "uvu", "CANVAS_UVU_BASE_URL": "https://uvu.instructure.com", "CANVAS_UVU_API_TOKEN": "u"},
+			want: []string{"uvu@https://uvu.instructure.com"},
+		},
+		{
+			name:    "institution missing its token",
+			env:     map[string]string{"CANVAS_INSTITUTIONS": "UVU", "CANVAS_UVU_BASE_URL": "https://uvu.instructure.com"},
+			wantErr: "CANVAS_UVU_API_TOKEN",
+		},
+		{
+			name:    "nothing set",
+			env:     map[string]string{},
+			wantErr: "must be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"CANVAS_API_TOKEN", "CANVAS_BASE_URL", "CANVAS_INSTITUTIONS", "CANVAS_CLIENT_ID", "CANVAS_CLIENT_SECRET", "CANVAS_REFRESH_TOKEN"} {
+				t.Setenv(key, "")
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			clients, err := canvasClientsFromEnv()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("canvasClientsFromEnv() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("canvasClientsFromEnv() error = %v", err)
+			}
+
+			var got []string
+			for _, client := range clients {
+				got = append(got, client.Institution+"@"+client.BaseURL)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("canvasClientsFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindCanvasAssignmentCard(t *testing.T) {
+	cards := []Card{
+		{ID: "main", Description: "Essay\n\n---\nCanvas Assignment ID: 42\nCourse: English 10"},
+		{ID: "uvu", Description: "Essay\n\n---\nCanvas Assignment ID: 42\nCourse: ENGL 1010 (UVU)\nCanvas Institution: UVU"},
+		{ID: "other", Description: "Canvas Assignment ID: 420"},
+	}
+
+	tests := []struct {
+		name        string
+		id          int
+		institution string
+		want        string
+	}{
+		{"main account", 42, "", "main"},
+		{"college", 42, "UVU", "uvu"},
+		{"institution case doesn't matter", 42, "uvu", "uvu"},
+		{"unknown institution", 42, "SLCC", ""},
+		{"no partial ID match", 4, "", ""},
+	}
+
+	c := &TrelloClient{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if card := c.findCanvasAssignmentCard(cards, tt.id, tt.institution); card != nil {
+				got = card.ID
+			}
+			if got != tt.want {
+				t.Errorf("findCanvasAssignmentCard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstitutionCourseName(t *testing.T) {
+	tests := []struct {
+		course, institution, want string
+	}{
+		{"English 10", "", "English 10"},
+		{"ENGL 1010", "UVU", "ENGL 1010 (UVU)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := institutionCourseName(tt.course, tt.institution); got != tt.want {
+				t.Errorf("institutionCourseName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// messagesCardName is the card that collects teacher messages from the Canvas inbox
+const messagesCardName = "📬 Messages"
+
+// CanvasConversation is a thread in the Canvas inbox
+type CanvasConversation struct {
+	ID            int                 `json:"id"`
+	Subject       string              `json:"subject"`
+	WorkflowState string              `json:"workflow_state"`
+	ContextName   string              `json:"context_name"`
+	Participants  []CanvasParticipant `json:"participants"`
+	Messages      []CanvasMessage     `json:"messages"` // newest first; only when fetched by ID
+}
+
+// CanvasParticipant is someone on a conversation
+type CanvasParticipant struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// CanvasMessage is one message in a conversation
+type CanvasMessage struct {
+	ID        int    `json:"id"`
+	Body      string `json:"body"`
+	AuthorID  int    `json:"author_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// GetUnreadConversations lists the unread threads in the Canvas inbox
+func (c *CanvasClient) GetUnreadConversations() ([]CanvasConversation, error) {
+	body, err := c.makeRequest("/conversations?scope=unread&per_page=50")
+	if err != nil {
+		return nil, err
+	}
+
+	var conversations []CanvasConversation
+	if err := json.Unmarshal(body, &conversations); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal conversations: %w", err)
+	}
+	return conversations, nil
+}
+
+// GetConversation fetches a thread with its messages. Canvas marks threads
+// read when they're fetched unless told not to, so that's left to
+// MarkConversationRead.
+func (c *CanvasClient) GetConversation(id int) (*CanvasConversation, error) {
+	body, err := c.makeRequest(fmt.Sprintf("/conversations/%d?auto_mark_as_read=false", id))
+	if err != nil {
+		return nil, err
+	}
+
+	var conversation CanvasConversation
+	if err := json.Unmarshal(body, &conversation); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal conversation: %w", err)
+	}
+	return &conversation, nil
+}
+
+// MarkConversationRead marks a thread read in the Canvas inbox
+func (c *CanvasClient) MarkConversationRead(id int) error {
+	form := url.Values{}
+	form.Set("conversation[workflow_state]", "read")
+	if _, err := c.sendForm("PUT", fmt.Sprintf("/conversations/%d", id), form); err != nil {
+		return fmt.Errorf("failed to mark conversation read: %w", err)
+	}
+	return nil
+}
+
+// teacherMessages returns the messages in a thread not written by the
+// student, oldest first
+func teacherMessages(conversation CanvasConversation, studentID int) []CanvasMessage {
+	var messages []CanvasMessage
+	for i := len(conversation.Messages) - 1; i >= 0; i-- {
+		if conversation.Messages[i].AuthorID != studentID {
+			messages = append(messages, conversation.Messages[i])
+		}
+	}
+	return messages
+}
+
+// formatMessageComment renders a teacher message as a comment on the Messages card
+func formatMessageComment(conversation CanvasConversation, message CanvasMessage, loc *time.Location) string {
+	author := "Unknown sender"
+	for _, participant := range conversation.Participants {
+		if participant.ID == message.AuthorID {
+			author = participant.Name
+		}
+	}
+	if conversation.ContextName != "" {
+		author += " (" + conversation.ContextName + ")"
+	}
+
+	subject := conversation.Subject
+	if subject == "" {
+		subject = "(no subject)"
+	}
+
+	header := fmt.Sprintf("✉️ **%s** from %s", subject, author)
+	if sent, err := time.Parse(time.RFC3339, message.CreatedAt); err == nil {
+		header += " · " + friendlyTime(sent, loc)
+	}
+
+	var quoted []string
+	for _, line := range strings.Split(strings.TrimSpace(message.Body), "\n") {
+		quoted = append(quoted, "> "+line)
+	}
+	return header + "\n\n" + strings.Join(quoted, "\n")
+}
+
+// messagesCard returns the Messages card in a list, creating it if needed
+func (c *TrelloClient) messagesCard(boardName, listName string) (*Card, error) {
+	listID, err := c.FindListByName(boardName, listName)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := c.GetCardsInList(listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards in %s list: %w", listName, err)
+	}
+	for i := range cards {
+		if cards[i].Name == messagesCardName {
+			return &cards[i], nil
+		}
+	}
+
+	card, err := c.CreateCard(listID, messagesCardName, "Unread teacher messages from the Canvas inbox, one comment each.", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create messages card: %w", err)
+	}
+	return card, nil
+}
+
+// SyncCanvasMessages posts each unread teacher message in the Canvas inbox
+// as a comment on the Messages card. With markRead, the threads are then
+// marked read in Canvas.
+func (c *TrelloClient) SyncCanvasMessages(canvasClient *CanvasClient, boardName, listName string, markRead bool) error {
+	boardName, listName = c.Target.board(boardName), c.Target.list(listName)
+	user, err := canvasClient.GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("failed to get Canvas user: %w", err)
+	}
+
+	conversations, err := canvasClient.GetUnreadConversations()
+	if err != nil {
+		return fmt.Errorf("failed to get Canvas conversations: %w", err)
+	}
+	if len(conversations) == 0 {
+		fmt.Printf("%s No unread Canvas messages\n", iconSuccess)
+		return nil
+	}
+
+	card, err := c.messagesCard(boardName, listName)
+	if err != nil {
+		return err
+	}
+	if skipNoAuto(card) {
+		return nil
+	}
+
+	posted := 0
+	for _, summary := range conversations {
+		conversation, err := canvasClient.GetConversation(summary.ID)
+		if err != nil {
+			fmt.Printf("Warning: failed to get conversation '%s': %v\n", summary.Subject, err)
+			continue
+		}
+
+		failed := false
+		for _, message := range teacherMessages(*conversation, user.ID) {
+			comment := formatMessageComment(*conversation, message, displayLocation())
+			if err := c.UpsertComment(card.ID, fmt.Sprintf("canvas-message-%d", message.ID), comment); err != nil {
+				fmt.Printf("Warning: failed to post message '%s': %v\n", conversation.Subject, err)
+				failed = true
+				continue
+			}
+			posted++
+		}
+
+		// Only mark threads read once they're safely on the card
+		if markRead && !failed {
+			if err := canvasClient.MarkConversationRead(conversation.ID); err != nil {
+				fmt.Printf("Warning: %v\n", err)
+			}
+		}
+	}
+
+	fmt.Printf("%s Posted %s from %s to '%s'\n", iconSuccess, plural(posted, "message"), plural(len(conversations), "unread thread"), messagesCardName)
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTeacherMessages(t *testing.T) {
+	conversation := CanvasConversation{Messages: []CanvasMessage{
+		{ID: 3, AuthorID: 20, Body: "newest, from the teacher"},
+		{ID: 2, AuthorID: 7, Body: "the student's reply"},
+		{ID: 1, AuthorID: 20, Body: "oldest, from the teacher"},
+	}}
+
+	var got []int
+	for _, message := range teacherMessages(conversation, 7) {
+		got = append(got, message.ID)
+	}
+	if fmt.Sprint(got) != "[1 3]" {
+		t.Errorf("teacherMessages() IDs = %v, want [1 3]", got)
+	}
+}
+
+func TestFormatMessageComment(t *testing.T) {
+	conversation := CanvasConversation{Subject: "Lab report", ContextName: "Biology", Participants: []CanvasParticipant{{ID: 20, Name: "Ms. Lee"}}}
+
+	tests := []struct {
+		name    string
+		conv    CanvasConversation
+		message CanvasMessage
+		want    string
+	}{
+		{
+			name:    "known sender",
+			conv:    conversation,
+			message: CanvasMessage{AuthorID: 20, Body: "Please resubmit.\nThanks!", CreatedAt: "2026-10-14T21:30:00Z"},
+			want:    "✉️ **Lab report** from Ms. Lee (Biology) · Wed, Oct 14 at 9:30 PM UTC\n\n> Please resubmit.\n> Thanks!",
+		},
+		{
+			name:    "unknown sender, no subject",
+			conv:    CanvasConversation{},
+			message: CanvasMessage{AuthorID: 5, Body: "Hi"},
+			want:    "✉️ **(no subject)** from Unknown sender\n\n> Hi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatMessageComment(tt.conv, tt.message, time.UTC); got != tt.want {
+				t.Errorf("formatMessageComment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConversationRequests(t *testing.T) {
+	var marked string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/conversations/9":
+			if r.URL.Query().Get("auto_mark_as_read") != "false" {
+				t.Error("fetching a conversation would mark it read")
+			}
+			fmt.Fprint(w, `{"id":9,"subject":"Lab report","messages":[{"id":1,"author_id":20,"body":"Hi"}]}`)
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/conversations/9":
+			marked = r.FormValue("conversation[workflow_state]")
+			fmt.Fprint(w, `{"id":9}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &CanvasClient{APIToken: "token", BaseURL: server.URL}
+	conversation, err := client.GetConversation(9)
+	if err != nil {
+		t.Fatalf("GetConversation() error = %v", err)
+	}
+	if len(conversation.Messages) != 1 || !strings.Contains(conversation.Messages[0].Body, "Hi") {
+		t.Errorf("GetConversation() messages = %+v", conversation.Messages)
+	}
+
+	if err := client.MarkConversationRead(9); err != nil {
+		t.Fatalf("MarkConversationRead() error = %v", err)
+	}
+	if marked != "read" {
+		t.Errorf("workflow_state sent = %q, want read", marked)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CanvasAssignmentOverride gives some students different dates for an
+// assignment: named students, a course section, or a group
+type CanvasAssignmentOverride struct {
+	ID              int    `json:"id"`
+	Title           string `json:"title"`
+	StudentIDs      []int  `json:"student_ids,omitempty"`
+	CourseSectionID *int   `json:"course_section_id,omitempty"`
+	GroupID         *int   `json:"group_id,omitempty"`
+	DueAt           string `json:"due_at"`
+	LockAt          string `json:"lock_at"`
+}
+
+// appliesTo reports whether the override covers the student
+func (o CanvasAssignmentOverride) appliesTo(userID int, sections, groups map[int]bool) bool {
+	for _, id := range o.StudentIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return (o.CourseSectionID != nil && sections[*o.CourseSectionID]) || (o.GroupID != nil && groups[*o.GroupID])
+}
+
+// applyAssignmentOverrides swaps the base dates for the student's own. When
+// several overrides apply, Canvas gives the student the latest due date, so
+// that one wins. The overrides are dropped once applied; they list other
+// students' IDs.
+func applyAssignmentOverrides(assignment *CanvasAssignment, userID int, sections, groups map[int]bool) {
+	var chosen *CanvasAssignmentOverride
+	var chosenDue time.Time
+	for i, override := range assignment.Overrides {
+		if !override.appliesTo(userID, sections, groups) {
+			continue
+		}
+		due, err := time.Parse(time.RFC3339, override.DueAt)
+		if err != nil {
+			continue
+		}
+		if chosen == nil || due.After(chosenDue) {
+			chosen, chosenDue = &assignment.Overrides[i], due
+		}
+	}
+	if chosen != nil {
+		assignment.DueAt, assignment.LockAt = chosen.DueAt, chosen.LockAt
+		assignment.OverrideTitle = chosen.Title
+	}
+	assignment.Overrides = nil
+}
+
+// GetStudentSectionsAndGroups returns the IDs of the current user's course
+// sections and groups, for matching assignment overrides
+func (c *CanvasClient) GetStudentSectionsAndGroups() (sections, groups map[int]bool, err error) {
+	body, err := c.makeRequest("/users/self/enrollments?state[]=active&per_page=100")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get enrollments: %w", err)
+	}
+	var enrollments []struct {
+		CourseSectionID int `json:"course_section_id"`
+	}
+	if err := json.Unmarshal(body, &enrollments); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal enrollments: %w", err)
+	}
+	sections = make(map[int]bool)
+	for _, enrollment := range enrollments {
+		sections[enrollment.CourseSectionID] = true
+	}
+
+	body, err = c.makeRequest("/users/self/groups?per_page=100")
+	if err != nil {
+		return sections, nil, fmt.Errorf("failed to get groups: %w", err)
+	}
+	var userGroups []struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(body, &userGroups); err != nil {
+		return sections, nil, fmt.Errorf("failed to unmarshal groups: %w", err)
+	}
+	groups = make(map[int]bool)
+	for _, group := range userGroups {
+		groups[group.ID] = true
+	}
+	return sections, groups, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyAssignmentOverrides(t *testing.T) {
+	section, group := 20, 30
+	overrides := []CanvasAssignmentOverride{
+		{Title: "Section 2", CourseSectionID: &section, DueAt: "2025-10-10T23:59:00Z"},
+		{Title: "Extension", StudentIDs: []int{5, 7}, DueAt: "2025-10-12T23:59:00Z", LockAt: "2025-10-13T23:59:00Z"},
+		{Title: "Group B", GroupID: &group, DueAt: "2025-10-09T23:59:00Z"},
+	}
+
+	tests := []struct {
+		name      string
+		userID    int
+		sections  map[int]bool
+		groups    map[int]bool
+		wantDue   string
+		wantTitle string
+	}{
+		{"no override applies", 1, nil, nil, "2025-10-08T23:59:00Z", ""},
+		{"section", 1, map[int]bool{20: true}, nil, "2025-10-10T23:59:00Z", "Section 2"},
+		{"group", 1, nil, map[int]bool{30: true}, "2025-10-09T23:59:00Z", "Group B"},
+		{"latest of several", 7, map[int]bool{20: true}, map[int]bool{30: true}, "2025-10-12T23:59:00Z", "Extension"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignment := CanvasAssignment{DueAt: "2025-10-08T23:59:00Z", Overrides: overrides}
+			applyAssignmentOverrides(&assignment, tt.userID, tt.sections, tt.groups)
+			if assignment.DueAt != tt.wantDue || assignment.OverrideTitle != tt.wantTitle {
+				t.Errorf("due %s from %q, want %s from %q", assignment.DueAt, assignment.OverrideTitle, tt.wantDue, tt.wantTitle)
+			}
+			if assignment.Overrides != nil {
+				t.Error("overrides kept after applying")
+			}
+		})
+	}
+}
+
+func TestFormatCanvasMetadataOverridesAndGroups(t *testing.T) {
+	category := 4
+	assignment := CanvasAssignment{ID: 1, DueAt: "2025-10-10T23:59:00Z", OverrideTitle: "Section 2", GroupCategoryID: &category}
+	metadata := formatCanvasMetadata(assignment, "Biology", nil)
+	for _, want := range []string{"Original Due Date: 2025-10-10T23:59:00Z", "Due Date Override: Section 2", "Group Assignment: Yes"} {
+		if !strings.Contains(metadata, want) {
+			t.Errorf("metadata missing %q:\n%s", want, metadata)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Checklist is a card checklist with its items
+type Checklist struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	CheckItems []CheckItem `json:"checkItems"`
+}
+
+// CheckItem is one checklist item; State is "complete" or "incomplete"
+type CheckItem struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// GetCardChecklists returns a card's checklists
+func (c *TrelloClient) GetCardChecklists(cardID string) ([]Checklist, error) {
+	body, err := c.makeRequest(fmt.Sprintf("/cards/%s/checklists", cardID))
+	if err != nil {
+		return nil, err
+	}
+
+	var checklists []Checklist
+	if err := json.Unmarshal(body, &checklists); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checklists: %w", err)
+	}
+	return checklists, nil
+}
+
+// CreateChecklist adds an empty checklist to a card
+func (c *TrelloClient) CreateChecklist(cardID, name string) (*Checklist, error) {
+	fields := url.Values{}
+	fields.Set("idCard", cardID)
+	fields.Set("name", name)
+
+	body, err := c.sendForm("POST", "/checklists", fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checklist: %w", err)
+	}
+
+	var checklist Checklist
+	if err := json.Unmarshal(body, &checklist); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checklist: %w", err)
+	}
+	return &checklist, nil
+}
+
+// AddCheckItem appends an item to a checklist
+func (c *TrelloClient) AddCheckItem(checklistID, name string) error {
+	fields := url.Values{}
+	fields.Set("name", name)
+
+	if _, err := c.sendForm("POST", fmt.Sprintf("/checklists/%s/checkItems", checklistID), fields); err != nil {
+		return fmt.Errorf("failed to add checklist item: %w", err)
+	}
+	return nil
+}
+
+// carryOverMode reads WEEKLY_CARRY_OVER: "copy" (the default) links last
+// week's unfinished card and copies its open checklist items and comments,
+// "link" only links the cards, and "off" does nothing
+func carryOverMode() string {
+	switch mode := strings.ToLower(os.Getenv("WEEKLY_CARRY_OVER")); mode {
+	case "link", "off":
+		return mode
+	case "", "copy":
+		return "copy"
+	default:
+		fmt.Printf("Warning: unknown WEEKLY_CARRY_OVER '%s' (want copy, link, or off), using copy\n", mode)
+		return "copy"
+	}
+}
+
+// unfinishedCards maps each name in names to its card in cards, when that
+// card hasn't been marked complete
+func unfinishedCards(cards []Card, names []string) map[string]Card {
+	unfinished := make(map[string]Card)
+	for _, name := range names {
+		for _, card := range cards {
+			if card.Name == name && !card.DueComplete && !card.Closed {
+				unfinished[name] = card
+				break
+			}
+		}
+	}
+	return unfinished
+}
+
+// openCheckItems returns the names of a checklist's unchecked items, in order
+func openCheckItems(checklist Checklist) []string {
+	var names []string
+	for _, item := range checklist.CheckItems {
+		if item.State != "complete" {
+			names = append(names, item.Name)
+		}
+	}
+	return names
+}
+
+// carriedCommentText gathers last week's comments, oldest first, into one
+// comment. Comments posted by this tool are skipped. Returns "" when there
+// is nothing to carry.
+func carriedCommentText(weekNumber int, comments []CardComment) string {
+	var notes []string
+	// Trello returns comments newest first
+	for i := len(comments) - 1; i >= 0; i-- {
+		text := strings.TrimSpace(comments[i].Data.Text)
+		if text == "" || strings.Contains(text, "[trello-sync:") {
+			continue
+		}
+		notes = append(notes, "> "+strings.ReplaceAll(text, "\n", "\n> "))
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("💬 Comments carried over from Week %d:\n\n%s", weekNumber, strings.Join(notes, "\n\n"))
+}
+
+// continuedFromLine links a new weekly card back to last week's card
+func continuedFromLine(weekNumber int, old Card) string {
+	link := old.ShortURL
+	if link == "" {
+		link = old.URL
+	}
+	return fmt.Sprintf("\n\n↩️ Continued from Week %d: %s", weekNumber, link)
+}
+
+// carryOverWeeklyCard copies last week's unfinished checklist items and
+// comments to the new card (in "copy" mode) and points the old card at the
+// new one. Failures are warnings; the new card already exists.
+func (c *TrelloClient) carryOverWeeklyCard(old Card, newCard *Card, fromWeek, toWeek int, mode string) {
+	if mode == "copy" {
+		checklists, err := c.GetCardChecklists(old.ID)
+		if err != nil {
+			fmt.Printf("Warning: failed to get checklists from %s: %v\n", old.Name, err)
+		}
+		for _, checklist := range checklists {
+			items := openCheckItems(checklist)
+			if len(items) == 0 {
+				continue
+			}
+			copied, err := c.CreateChecklist(newCard.ID, checklist.Name)
+			if err != nil {
+				fmt.Printf("Warning: failed to copy checklist %s: %v\n", checklist.Name, err)
+				continue
+			}
+			for _, item := range items {
+				if err := c.AddCheckItem(copied.ID, item); err != nil {
+					fmt.Printf("Warning: failed to copy checklist item %s: %v\n", item, err)
+				}
+			}
+			fmt.Printf("  Carried over %d open item(s) from checklist %s\n", len(items), checklist.Name)
+		}
+
+		comments, err := c.GetCardComments(old.ID)
+		if err != nil {
+			fmt.Printf("Warning: failed to get comments from %s: %v\n", old.Name, err)
+		} else if text := carriedCommentText(fromWeek, comments); text != "" {
+			if err := c.AddCommentToCard(newCard.ID, text); err != nil {
+				fmt.Printf("Warning: failed to carry over comments from %s: %v\n", old.Name, err)
+			}
+		}
+	}
+
+	link := newCard.ShortURL
+	if link == "" {
+		link = newCard.URL
+	}
+	if err := c.UpsertComment(old.ID, "carry-over", fmt.Sprintf("➡️ Continued in Week %d: %s", toWeek, link)); err != nil {
+		fmt.Printf("Warning: failed to link %s to next week's card: %v\n", old.Name, err)
+	}
+}
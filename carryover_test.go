@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUnfinishedCards(t *testing.T) {
+	cards := []Card{
+		{ID: "1", Name: "Math - Week 3"},
+		{ID: "2", Name: "Science - Week 3", DueComplete: true},
+		{ID: "3", Name: "Math - Week 2"},
+	}
+
+	got := unfinishedCards(cards, []string{"Math - Week 3", "Science - Week 3", "History - Week 3"})
+	if len(got) != 1 || got["Math - Week 3"].ID != "1" {
+		t.Errorf("unfinishedCards() = %+v, want only the incomplete Math card", got)
+	}
+}
+
+func TestCarriedCommentText(t *testing.T) {
+	comment := func(text string) CardComment {
+		var c CardComment
+		c.Data.Text = text
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		comments []CardComment // newest first, as Trello returns them
+		want     string
+	}{
+		{"none", nil, ""},
+		{"only tool comments", []CardComment{comment("➡️ Continued\n\n[trello-sync:carry-over]")}, ""},
+		{
+			"oldest first and quoted",
+			[]CardComment{comment("Finish problems 5-9"), comment("Ask about lab\nbring notes")},
+			"💬 Comments carried over from Week 3:\n\n> Ask about lab\n> bring notes\n\n> Finish problems 5-9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := carriedCommentText(3, tt.comments); got != tt.want {
+				t.Errorf("carriedCommentText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCarryOverWeeklyCardCopiesOpenItems(t *testing.T) {
+	var added []string
+	var comments []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/cards/old/checklists":
+			w.Write([]byte(`[
+				{"id": "cl1", "name": "Homework", "checkItems": [
+					{"id": "i1", "name": "Problems 1-4", "state": "complete"},
+					{"id": "i2", "name": "Problems 5-9", "state": "incomplete"}
+				]},
+				{"id": "cl2", "name": "Done list", "checkItems": [{"id": "i3", "name": "Read", "state": "complete"}]}
+			]`))
+		case r.Method == "POST" && r.URL.Path == "/checklists":
+			if r.FormValue("idCard") != "new" || r.FormValue("name") != "Homework" {
+				t.Errorf("unexpected checklist %v", r.Form)
+			}
+			w.Write([]byte(`{"id": "copy1", "name": "Homework"}`))
+		case r.Method == "POST" && r.URL.Path == "/checklists/copy1/checkItems":
+			added = append(added, r.FormValue("name"))
+			w.Write([]byte(`{}`))
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/actions"):
+			w.Write([]byte(`[]`))
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/actions/comments"):
+			comments = append(comments, r.URL.Path+" "+r.FormValue("text"))
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	old := Card{ID: "old", Name: "Math - Week 3"}
+	newCard := &Card{ID: "new", ShortURL: "https://trello.com/c/new"}
+	client.carryOverWeeklyCard(old, newCard, 3, 4, "copy")
+
+	if len(added) != 1 || added[0] != "Problems 5-9" {
+		t.Errorf("copied items = %v, want only the open one", added)
+	}
+	if len(comments) != 1 || !strings.Contains(comments[0], "/cards/old/") || !strings.Contains(comments[0], "Continued in Week 4: https://trello.com/c/new") {
+		t.Errorf("comments = %v, want a link on the old card", comments)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// What --catch-up does with a missed job, set per job in schedule.json
+const (
+	onMissedRun    = "run"
+	onMissedPrompt = "prompt"
+	onMissedSkip   = "skip"
+)
+
+// Describe says how often and since when a job was missed
+func (m MissedRun) Describe(loc *time.Location) string {
+	if m.Count == 1 {
+		return "missed " + friendlyTime(m.Since, loc)
+	}
+	return fmt.Sprintf("missed %d times since %s", m.Count, friendlyTime(m.Since, loc))
+}
+
+// chooseCatchUpJobs decides which missed jobs to run now. "prompt" jobs ask
+// on in; without a terminal to ask on they are left for the next run.
+func chooseCatchUpJobs(missed []MissedRun, in io.Reader, out io.Writer, interactive bool, loc *time.Location) []string {
+	reader := bufio.NewReader(in)
+	var jobs []string
+	for _, miss := range missed {
+		switch miss.Run.OnMissed {
+		case onMissedSkip:
+			fmt.Fprintf(out, "Skipping %s (%s; onMissed is skip)\n", miss.Run.Job, miss.Describe(loc))
+			continue
+		case onMissedPrompt:
+			if !interactive {
+				fmt.Fprintf(out, "Not running %s (%s): it asks first, and there's no terminal to ask on\n", miss.Run.Job, miss.Describe(loc))
+				continue
+			}
+			fmt.Fprintf(out, "%s was %s. Run it now? [y/N]: ", miss.Run.Job, miss.Describe(loc))
+			answer, _ := reader.ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				continue
+			}
+		default:
+			fmt.Fprintf(out, "Catching up %s (%s)\n", miss.Run.Job, miss.Describe(loc))
+		}
+		jobs = append(jobs, miss.Run.Job)
+	}
+	return jobs
+}
+
+// stdinIsTerminal reports whether someone is at the keyboard to answer prompts
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// CatchUp runs the scheduled jobs missed since their last successful run,
+// e.g. while the computer was off, following each job's onMissed setting.
+// The summary has no jobs when there was nothing to run.
+func (c *TrelloClient) CatchUp(jiraTasksDir string, now time.Time) (RunSummary, error) {
+	schedule, err := LoadSchedule()
+	if err != nil {
+		return RunSummary{}, err
+	}
+	history, err := LoadRunHistory(runHistoryFile)
+	if err != nil {
+		return RunSummary{}, err
+	}
+
+	jobs := chooseCatchUpJobs(missedRuns(schedule, history, now), os.Stdin, os.Stdout, stdinIsTerminal(), now.Location())
+	if len(jobs) == 0 {
+		return RunSummary{Started: now, Finished: now}, nil
+	}
+	return c.RunScheduledJobs(jobs, jiraTasksDir)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChooseCatchUpJobs(t *testing.T) {
+	since := time.Date(2025, 10, 8, 20, 0, 0, 0, time.UTC)
+	missed := []MissedRun{
+		{Run: ScheduledRun{Job: "daily-reset"}, Since: since, Count: 1},
+		{Run: ScheduledRun{Job: "create-weekly", OnMissed: onMissedPrompt}, Since: since, Count: 1},
+		{Run: ScheduledRun{Job: "sundown:Family", OnMissed: onMissedSkip}, Since: since, Count: 2},
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		interactive bool
+		want        string
+	}{
+		{"prompt accepted", "y\n", true, "daily-reset,create-weekly"},
+		{"prompt declined", "n\n", true, "daily-reset"},
+		{"no terminal", "y\n", false, "daily-reset"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got := chooseCatchUpJobs(missed, strings.NewReader(tt.input), &out, tt.interactive, time.UTC)
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("chooseCatchUpJobs() = %v, want %s", got, tt.want)
+			}
+			if !strings.Contains(out.String(), "Skipping sundown:Family (missed 2 times since") {
+				t.Errorf("skipped job should be reported, got:\n%s", out.String())
+			}
+		})
+	}
+}
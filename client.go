@@ -10,7 +10,6 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
-	"sort"
 	"strings"
 	"time"
 )
@@ -19,6 +18,14 @@ type TrelloClient struct {
 	APIKey   string
 	APIToken string
 	BaseURL  string
+	Filter   BoardFilter // which boards GetBoards returns
+	Target   SyncTarget  // where syncs write, from --target-board and --target-list
+
+	writes    map[string]int            // successful writes by HTTP method, for run summaries
+	members   map[string][]Member       // board members by board ID, for mention checks
+	rateLimit trelloRateLimit           // from the last response, for pacing bulk writes
+	settings  map[string]*BoardSettings // per-board automation settings by normalized name
+	noted     map[string]bool           // notes already printed this run, so each shows once
 }
 
 type Card struct {
@@ -29,32 +36,51 @@ type Card struct {
 	ShortURL    string    `json:"shortUrl"`
 	Closed      bool      `json:"closed"`
 	IDList      string    `json:"idList"`
+	IDBoard     string    `json:"idBoard"`
 	Due         *time.Time `json:"due"`
 	DueComplete bool      `json:"dueComplete"`
+	Labels      []Label   `json:"labels"`
 }
 
 type Board struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-	URL  string `json:"url"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	URL            string `json:"url"`
+	Closed         bool   `json:"closed"`
+	IDOrganization string `json:"idOrganization"`
 }
 
 type List struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	BoardID string `json:"idBoard"`
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	BoardID string  `json:"idBoard"`
+	Pos     float64 `json:"pos,omitempty"`
 }
 
 type CachedData struct {
-	Boards []Board `json:"boards"`
-	Lists  []List  `json:"lists"`
+	Boards      []Board           `json:"boards"`
+	Lists       []List            `json:"lists"`
+	Cards       []Card            `json:"cards,omitempty"`
+	UpdatedAt   time.Time         `json:"updatedAt,omitempty"`
+	BoardErrors []BoardCacheError `json:"boardErrors,omitempty"`
 }
 
+// BoardCacheError records a board whose lists or cards failed to refresh.
+// The board's previously cached lists and cards are kept when available.
+type BoardCacheError struct {
+	BoardID   string `json:"boardId"`
+	BoardName string `json:"boardName"`
+	Error     string `json:"error"`
+}
+
+const cacheFile = "trello_cache.json"
+
 func NewTrelloClient(apiKey, apiToken string) *TrelloClient {
 	return &TrelloClient{
 		APIKey:   apiKey,
 		APIToken: apiToken,
 		BaseURL:  "https://api.trello.com/1",
+		Filter:   boardFilterFromEnv(),
 	}
 }
 
@@ -74,7 +100,11 @@ func (c *TrelloClient) makeRequest(endpoint string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
+	c.recordRateLimit(resp)
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("API request failed with status %d: %w", resp.StatusCode, errTrelloRateLimited)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
 	}
@@ -87,6 +117,60 @@ func (c *TrelloClient) makeRequest(endpoint string) ([]byte, error) {
 	return body, nil
 }
 
+// sendForm makes a mutating request with fields form-encoded in the body,
+// so long or unicode-heavy names and descriptions never touch the URL.
+// Only the credentials go in the query string.
+func (c *TrelloClient) sendForm(method, endpoint string, fields url.Values) ([]byte, error) {
+	u, err := url.Parse(c.BaseURL + endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("key", c.APIKey)
+	q.Set("token", c.APIToken)
+	u.RawQuery = q.Encode()
+
+	var body io.Reader
+	if len(fields) > 0 {
+		body = strings.NewReader(fields.Encode())
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	c.recordRateLimit(resp)
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("API request failed with status %s: %w", resp.Status, errTrelloRateLimited)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %s: %s", resp.Status, string(respBody))
+	}
+
+	if c.writes == nil {
+		c.writes = make(map[string]int)
+	}
+	c.writes[method]++
+
+	return respBody, nil
+}
+
 func (c *TrelloClient) GetBoards() ([]Board, error) {
 	endpoint := "/members/me/boards"
 
@@ -100,7 +184,14 @@ func (c *TrelloClient) GetBoards() ([]Board, error) {
 		return nil, fmt.Errorf("failed to unmarshal boards: %w", err)
 	}
 
-	return boards, nil
+	var orgs []Organization
+	if len(c.Filter.Workspaces) > 0 {
+		if orgs, err = c.GetOrganizations(); err != nil {
+			return nil, fmt.Errorf("failed to get workspaces: %w", err)
+		}
+	}
+
+	return filterBoards(boards, c.Filter, orgs)
 }
 
 func (c *TrelloClient) GetListsInBoard(boardID string) ([]List, error) {
@@ -141,35 +232,180 @@ func (c *TrelloClient) GetCardsInList(listID string) ([]Card, error) {
 }
 
 func (c *TrelloClient) CacheData() error {
+	// Previous data stands in for any board that fails this time
+	previous, err := c.LoadCache()
+	if err != nil {
+		previous = &CachedData{}
+	}
+
+	cache, err := c.fetchCacheData(previous)
+	if err != nil {
+		return err
+	}
+
+	if err := writeCache(cache); err != nil {
+		return err
+	}
+
+	if len(cache.BoardErrors) > 0 {
+		fmt.Printf("Warning: %d of %d board(s) failed to refresh:\n", len(cache.BoardErrors), len(cache.Boards))
+		for _, boardErr := range cache.BoardErrors {
+			fmt.Printf("  - %s: %s\n", boardErr.BoardName, boardErr.Error)
+		}
+	}
+
+	return nil
+}
+
+// fetchCacheData fetches every board's lists and cards, carrying over the
+// previous data for boards that fail. Only fails when no board succeeds.
+func (c *TrelloClient) fetchCacheData(previous *CachedData) (*CachedData, error) {
 	boards, err := c.GetBoards()
 	if err != nil {
-		return fmt.Errorf("failed to get boards: %w", err)
+		return nil, fmt.Errorf("failed to get boards: %w", err)
 	}
 
-	var allLists []List
+	cache := &CachedData{Boards: boards, UpdatedAt: time.Now()}
 	for _, board := range boards {
-		lists, err := c.GetListsInBoard(board.ID)
-		if err != nil {
-			return fmt.Errorf("failed to get lists for board %s: %w", board.Name, err)
+		lists, cards, err := c.fetchBoardData(board.ID)
+		if err == nil {
+			cache.Lists = append(cache.Lists, lists...)
+			cache.Cards = append(cache.Cards, cards...)
+			continue
 		}
-		allLists = append(allLists, lists...)
+
+		cache.BoardErrors = append(cache.BoardErrors, BoardCacheError{
+			BoardID:   board.ID,
+			BoardName: board.Name,
+			Error:     err.Error(),
+		})
+		for _, list := range previous.Lists {
+			if list.BoardID == board.ID {
+				cache.Lists = append(cache.Lists, list)
+			}
+		}
+		for _, card := range previous.Cards {
+			if card.IDBoard == board.ID {
+				cache.Cards = append(cache.Cards, card)
+			}
+		}
+	}
+
+	if len(boards) > 0 && len(cache.BoardErrors) == len(boards) {
+		return nil, fmt.Errorf("failed to refresh all %d board(s); first error on %s: %s",
+			len(boards), cache.BoardErrors[0].BoardName, cache.BoardErrors[0].Error)
+	}
+
+	return cache, nil
+}
+
+// RefreshBoardCache refreshes one board's lists (and optionally its cards)
+// in the existing cache, leaving every other board untouched
+func (c *TrelloClient) RefreshBoardCache(boardName string, includeCards bool) error {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("%w (run a full --refresh first)", err)
+	}
+
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		// The board may be new since the last full refresh
+		boards, fetchErr := c.GetBoards()
+		if fetchErr != nil {
+			return fmt.Errorf("failed to get boards: %w", fetchErr)
+		}
+		if board, err = findBoardByName(boards, boardName); err != nil {
+			return err
+		}
+		cache.Boards = boards
 	}
 
-	cache := CachedData{
-		Boards: boards,
-		Lists:  allLists,
+	lists, err := c.GetListsInBoard(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get lists for board %s: %w", board.Name, err)
+	}
+
+	var cards []Card
+	if includeCards {
+		if cards, err = c.GetBoardCardsByID(board.ID); err != nil {
+			return fmt.Errorf("failed to get cards for board %s: %w", board.Name, err)
+		}
+	}
+
+	replaceBoardData(cache, board.ID, lists, cards, includeCards)
+	if err := writeCache(cache); err != nil {
+		return err
+	}
+
+	fmt.Printf("Refreshed %d list(s)", len(lists))
+	if includeCards {
+		fmt.Printf(" and %d card(s)", len(cards))
+	}
+	fmt.Printf(" for %s\n", board.Name)
+	return nil
+}
+
+// replaceBoardData swaps one board's lists (and cards, when replaceCards is
+// set) in the cache and clears any recorded refresh error for it
+func replaceBoardData(cache *CachedData, boardID string, lists []List, cards []Card, replaceCards bool) {
+	var keptLists []List
+	for _, list := range cache.Lists {
+		if list.BoardID != boardID {
+			keptLists = append(keptLists, list)
+		}
 	}
+	cache.Lists = append(keptLists, lists...)
 
+	if replaceCards {
+		var keptCards []Card
+		for _, card := range cache.Cards {
+			if card.IDBoard != boardID {
+				keptCards = append(keptCards, card)
+			}
+		}
+		cache.Cards = append(keptCards, cards...)
+	}
+
+	var keptErrors []BoardCacheError
+	for _, boardErr := range cache.BoardErrors {
+		if boardErr.BoardID != boardID {
+			keptErrors = append(keptErrors, boardErr)
+		}
+	}
+	cache.BoardErrors = keptErrors
+}
+
+// writeCache saves the cache to disk
+func writeCache(cache *CachedData) error {
 	data, err := json.MarshalIndent(cache, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal cache data: %w", err)
 	}
 
-	return os.WriteFile("trello_cache.json", data, 0644)
+	if err := os.WriteFile(cacheFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	return nil
+}
+
+// fetchBoardData gets one board's lists and cards
+func (c *TrelloClient) fetchBoardData(boardID string) ([]List, []Card, error) {
+	lists, err := c.GetListsInBoard(boardID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+
+	cards, err := c.GetBoardCardsByID(boardID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+
+	return lists, cards, nil
 }
 
 func (c *TrelloClient) LoadCache() (*CachedData, error) {
-	data, err := os.ReadFile("trello_cache.json")
+	data, err := os.ReadFile(cacheFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read cache file: %w", err)
 	}
@@ -182,52 +418,89 @@ func (c *TrelloClient) LoadCache() (*CachedData, error) {
 	return &cache, nil
 }
 
-func (c *TrelloClient) UpdateCard(cardID, due string, dueComplete bool) error {
-	endpoint := fmt.Sprintf("/cards/%s", cardID)
+// CardPatch lists card fields to change; nil fields are left untouched
+type CardPatch struct {
+	Name        *string
+	Desc        *string
+	Due         *string // empty string clears the due date
+	DueComplete *bool
+	Pos         *string // "top", "bottom", or a numeric position
+	IDList      *string
+	Closed      *bool
+}
 
-	u, err := url.Parse(c.BaseURL + endpoint)
-	if err != nil {
-		return fmt.Errorf("failed to parse URL: %w", err)
+// values returns the query parameters for the fields that are set
+func (p CardPatch) values() url.Values {
+	v := url.Values{}
+	if p.Name != nil {
+		v.Set("name", *p.Name)
 	}
+	if p.Desc != nil {
+		v.Set("desc", *p.Desc)
+	}
+	if p.Due != nil {
+		v.Set("due", *p.Due)
+	}
+	if p.DueComplete != nil {
+		v.Set("dueComplete", fmt.Sprintf("%t", *p.DueComplete))
+	}
+	if p.Pos != nil {
+		v.Set("pos", *p.Pos)
+	}
+	if p.IDList != nil {
+		v.Set("idList", *p.IDList)
+	}
+	if p.Closed != nil {
+		v.Set("closed", fmt.Sprintf("%t", *p.Closed))
+	}
+	return v
+}
 
-	q := u.Query()
-	q.Set("key", c.APIKey)
-	q.Set("token", c.APIToken)
-	q.Set("due", due)
-	q.Set("dueComplete", fmt.Sprintf("%t", dueComplete))
-	u.RawQuery = q.Encode()
+func stringPtr(s string) *string { return &s }
 
-	req, err := http.NewRequest("PUT", u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+func boolPtr(b bool) *bool { return &b }
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+// UpdateCardFields changes only the fields set in patch, in a single request
+func (c *TrelloClient) UpdateCardFields(cardID string, patch CardPatch) error {
+	fields := patch.values()
+	if len(fields) == 0 {
+		return nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	_, err := c.sendForm("PUT", fmt.Sprintf("/cards/%s", cardID), fields)
+	if err != nil {
+		return fmt.Errorf("failed to update card: %w", err)
 	}
 
 	return nil
 }
 
+func (c *TrelloClient) UpdateCard(cardID, due string, dueComplete bool) error {
+	return c.UpdateCardFields(cardID, CardPatch{Due: &due, DueComplete: &dueComplete})
+}
+
 func (c *TrelloClient) ResetDailyTasks(boardName, listName string) error {
 	listID, err := c.FindListByName(boardName, listName)
 	if err != nil {
 		return err
 	}
 
+	// Dailies finished by dragging them to Done come back for tomorrow
+	if reclaimed, err := c.reclaimDoneDailies(boardName, listID); err != nil {
+		fmt.Printf("Warning: skipping dailies moved to a done list: %v\n", err)
+	} else if reclaimed > 0 {
+		fmt.Printf("Brought back %s from the done list\n", plural(reclaimed, "daily task"))
+	}
+
 	cards, err := c.GetCardsInList(listID)
 	if err != nil {
 		return fmt.Errorf("failed to get cards: %w", err)
 	}
+	cards = withoutNoAuto(cards)
 
-	// Calculate next day due date (end of tomorrow)
-	tomorrow := time.Now().AddDate(0, 0, 1)
+	// Calculate next day due date (end of tomorrow), where a run delayed past
+	// midnight still counts as the previous day
+	tomorrow := currentLogicalDay().AddDate(0, 0, 1)
 	endOfTomorrow := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 23, 59, 59, 0, tomorrow.Location())
 	dueDate := endOfTomorrow.Format("2006-01-02T15:04:05.000Z")
 
@@ -244,43 +517,29 @@ func (c *TrelloClient) ResetDailyTasks(boardName, listName string) error {
 	return nil
 }
 
-func (c *TrelloClient) CreateCard(listID, name, desc, due string) error {
-	endpoint := "/cards"
-
-	u, err := url.Parse(c.BaseURL + endpoint)
-	if err != nil {
-		return fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("key", c.APIKey)
-	q.Set("token", c.APIToken)
-	q.Set("idList", listID)
-	q.Set("name", name)
+// CreateCard creates a card and returns it as Trello saved it
+func (c *TrelloClient) CreateCard(listID, name, desc, due string) (*Card, error) {
+	fields := url.Values{}
+	fields.Set("idList", listID)
+	fields.Set("name", name)
 	if desc != "" {
-		q.Set("desc", desc)
+		fields.Set("desc", desc)
 	}
 	if due != "" {
-		q.Set("due", due)
+		fields.Set("due", due)
 	}
-	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequest("POST", u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
+	body, err := c.sendForm("POST", "/cards", fields)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	var card Card
+	if err := json.Unmarshal(body, &card); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal created card: %w", err)
 	}
 
-	return nil
+	return &card, nil
 }
 
 func (c *TrelloClient) CreateWeeklyCards() error {
@@ -321,20 +580,69 @@ func (c *TrelloClient) CreateWeeklyCards() error {
 	dueTime := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 18, 0, 0, 0, endDate.Location())
 	dueDate := dueTime.Format("2006-01-02T15:04:05.000Z")
 
+	templates, err := LoadCardTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to load card templates: %w", err)
+	}
+
 	// Format week range
 	weekRange := quarter.FormatWeekRange(nextWeek)
 
 	fmt.Printf("Creating cards for Week %d: %s\n", nextWeek.Number, weekRange)
 	fmt.Printf("Due date: %s\n", dueTime.Format("January 2, 2006 at 3:04 PM"))
 
+	// Find this week's subject cards that were never marked complete, so
+	// their unfinished work follows them into next week
+	mode := carryOverMode()
+	unfinished := make(map[string]Card)
+	if mode != "off" {
+		currentRange := quarter.FormatWeekRange(currentWeek)
+		var currentNames []string
+		for _, subject := range quarter.Subjects {
+			currentNames = append(currentNames, renderTemplate(templates.WeeklyCardName, map[string]string{
+				"subject": subject,
+				"week":    fmt.Sprintf("%d", currentWeek.Number),
+				"range":   currentRange,
+			}))
+		}
+		weeklyCards, err := c.GetCardsInList(listID)
+		if err != nil {
+			fmt.Printf("Warning: skipping carry-over, failed to get Weekly cards: %v\n", err)
+		} else {
+			byName := unfinishedCards(withoutNoAuto(weeklyCards), currentNames)
+			for i, subject := range quarter.Subjects {
+				if card, ok := byName[currentNames[i]]; ok {
+					unfinished[subject] = card
+				}
+			}
+		}
+	}
+
 	// Create cards for each subject
 	for _, subject := range quarter.Subjects {
-		cardName := fmt.Sprintf("%s Week %d: %s", subject, nextWeek.Number, weekRange)
+		values := map[string]string{
+			"subject": subject,
+			"week":    fmt.Sprintf("%d", nextWeek.Number),
+			"range":   weekRange,
+		}
+		cardName := renderTemplate(templates.WeeklyCardName, values)
+		cardDesc := renderTemplate(templates.WeeklyCardDescription, values)
+
+		old, carry := unfinished[subject]
+		if carry {
+			cardDesc += continuedFromLine(currentWeek.Number, old)
+		}
 
 		fmt.Printf("Creating: %s\n", cardName)
-		if err := c.CreateCard(listID, cardName, "", dueDate); err != nil {
+		newCard, err := c.CreateCard(listID, cardName, cardDesc, dueDate)
+		if err != nil {
 			return fmt.Errorf("failed to create card for %s: %w", subject, err)
 		}
+
+		if carry {
+			fmt.Printf("  Continuing unfinished %s\n", old.Name)
+			c.carryOverWeeklyCard(old, newCard, currentWeek.Number, nextWeek.Number, mode)
+		}
 	}
 
 	fmt.Printf("Successfully created %d weekly cards!\n", len(quarter.Subjects))
@@ -360,7 +668,11 @@ func (c *TrelloClient) GetAllBoardCards(boardName string) ([]Card, error) {
 		return nil, fmt.Errorf("board '%s' not found", boardName)
 	}
 
-	// Get all cards from the board
+	return c.GetBoardCardsByID(boardID)
+}
+
+// GetBoardCardsByID returns all open cards on a board
+func (c *TrelloClient) GetBoardCardsByID(boardID string) ([]Card, error) {
 	endpoint := fmt.Sprintf("/boards/%s/cards", boardID)
 	body, err := c.makeRequest(endpoint)
 	if err != nil {
@@ -375,6 +687,46 @@ func (c *TrelloClient) GetAllBoardCards(boardName string) ([]Card, error) {
 	return cards, nil
 }
 
+// BoardAction is a single entry from a board's activity feed
+type BoardAction struct {
+	ID   string    `json:"id"`
+	Type string    `json:"type"`
+	Date time.Time `json:"date"`
+	Data struct {
+		Card struct {
+			ID          string `json:"id"`
+			Name        string `json:"name"`
+			Desc        string `json:"desc"`
+			IDList      string `json:"idList"`
+			DueComplete bool   `json:"dueComplete"`
+		} `json:"card"`
+		Old struct {
+			Desc        *string `json:"desc"`
+			DueComplete *bool   `json:"dueComplete"`
+			IDList      *string `json:"idList"`
+		} `json:"old"`
+		Text string `json:"text"`
+	} `json:"data"`
+}
+
+// GetBoardActions returns board activity of the given type(s) since a point in time
+func (c *TrelloClient) GetBoardActions(boardID, filter string, since time.Time) ([]BoardAction, error) {
+	endpoint := fmt.Sprintf("/boards/%s/actions?filter=%s&since=%s&limit=1000",
+		boardID, url.QueryEscape(filter), url.QueryEscape(since.UTC().Format(time.RFC3339)))
+
+	body, err := c.makeRequest(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []BoardAction
+	if err := json.Unmarshal(body, &actions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal board actions: %w", err)
+	}
+
+	return actions, nil
+}
+
 func (c *TrelloClient) FindCardByCanvasID(cards []Card, canvasID int, canvasType string) *Card {
     searchPattern := fmt.Sprintf("Canvas %s ID: %d", canvasType, canvasID)
 
@@ -399,152 +751,123 @@ func (c *TrelloClient) FindCardByMoodleAssignmentID(cards []Card, moodleID int)
 }
 
 
-func (c *TrelloClient) SortCardsByDueDate(listID string) error {
+// SortListCards reorders a list by priority, or by due date with CARD_SORT=due
+func (c *TrelloClient) SortListCards(listID string) error {
 	// Get all cards in the list
 	cards, err := c.GetCardsInList(listID)
 	if err != nil {
 		return fmt.Errorf("failed to get cards: %w", err)
 	}
 
+	// Cards marked [no-auto] keep their place; the rest sort around them
+	cards = withoutNoAuto(cards)
 	if len(cards) <= 1 {
 		return nil // No need to sort
 	}
 
-	// Sort cards by due date (cards without due dates go to the end)
-	sort.Slice(cards, func(i, j int) bool {
-		cardI, cardJ := cards[i], cards[j]
-
-		// Cards without due dates go to the end
-		if cardI.Due == nil && cardJ.Due == nil {
-			return false // Preserve existing order for cards without due dates
-		}
-		if cardI.Due == nil {
-			return false // cardI goes after cardJ
-		}
-		if cardJ.Due == nil {
-			return true // cardI goes before cardJ
-		}
-
-		// Both have due dates - sort by earliest first
-		return cardI.Due.Before(*cardJ.Due)
-	})
+	sortCards(cards, time.Now())
 
 	// Update card positions in Trello - move cards in reverse order
-	// so the first card (earliest due date) ends up at the top
+	// so the first card ends up at the top. Writes are paced by Trello's
+	// rate-limit headers, so small lists go straight through.
 	for i := len(cards) - 1; i >= 0; i-- {
 		card := cards[i]
-		err := c.UpdateCardPosition(card.ID, "top")
+		err := c.pacedWrite(func() error { return c.UpdateCardPosition(card.ID, "top") })
 		if err != nil {
 			fmt.Printf("Warning: failed to update position for card %s: %v\n", card.Name, err)
 		}
-		// Small delay to avoid rate limiting
-		if i > 0 {
-			time.Sleep(100 * time.Millisecond)
-		}
 	}
 
-	fmt.Printf("✅ Sorted %d cards by due date in list\n", len(cards))
+	fmt.Printf("%s Sorted %d cards by %s in list\n", iconSuccess, len(cards), cardSortMode())
 	return nil
 }
 
 func (c *TrelloClient) UpdateCardPosition(cardID, position string) error {
-	endpoint := fmt.Sprintf("/cards/%s", cardID)
-
-	u, err := url.Parse(c.BaseURL + endpoint)
-	if err != nil {
-		return fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("key", c.APIKey)
-	q.Set("token", c.APIToken)
-	q.Set("pos", position)
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequest("PUT", u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to update card position: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status: %s", resp.Status)
-	}
-
-	return nil
+	return c.UpdateCardFields(cardID, CardPatch{Pos: &position})
 }
 
 func (c *TrelloClient) UpdateCardDescription(cardID, description string) error {
-	endpoint := fmt.Sprintf("/cards/%s", cardID)
-
-	u, err := url.Parse(c.BaseURL + endpoint)
-	if err != nil {
-		return fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("key", c.APIKey)
-	q.Set("token", c.APIToken)
-	q.Set("desc", description)
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequest("PUT", u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to update card: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status: %s", resp.Status)
-	}
-
-	return nil
+	return c.UpdateCardFields(cardID, CardPatch{Desc: &description})
 }
 
-func (c *TrelloClient) SyncCanvasAssignments(canvasClient *CanvasClient, canvasUserID int) error {
-	fmt.Println("Starting Canvas sync...")
+func (c *TrelloClient) SyncCanvasAssignments(canvasClient *CanvasClient, canvasUserID int, since, end time.Time) error {
+	fmt.Printf("Starting Canvas sync (due %s to %s)...\n", since.Format("2006-01-02"), end.Format("2006-01-02"))
 
 	// Get upcoming assignments from Canvas
-	assignments, err := canvasClient.GetUpcomingAssignments(canvasUserID)
+	assignments, err := canvasClient.GetUpcomingAssignments(canvasUserID, since, end)
 	if err != nil {
 		return fmt.Errorf("failed to get Canvas assignments: %w", err)
 	}
 
-	fmt.Printf("Found %d assignments due within 3 months\n", len(assignments))
+	fmt.Printf("Found %d assignments in the sync window\n", len(assignments))
 
-	// Get all cards from the Makai School board
-	allCards, err := c.GetAllBoardCards("Makai School")
+	// Get all cards from the Makai School board, or --target-board
+	boardName := c.Target.board("Makai School")
+	allCards, err := c.GetAllBoardCards(boardName)
 	if err != nil {
 		return fmt.Errorf("failed to get Trello cards: %w", err)
 	}
 
-	fmt.Printf("Found %d existing cards on Makai School board\n", len(allCards))
+	fmt.Printf("Found %d existing cards on %s board\n", len(allCards), boardName)
 
-	// Get the Weekly list ID for new cards
-	weeklyListID, err := c.FindListByName("Makai School", "Weekly")
+	// Get the Weekly list ID (or --target-list) for new cards
+	listName := c.Target.list("Weekly")
+	weeklyListID, err := c.FindListByName(boardName, listName)
 	if err != nil {
-		return fmt.Errorf("failed to find Weekly list: %w", err)
+		return fmt.Errorf("failed to find %s list: %w", listName, err)
+	}
+	placement := c.newCardPlacement(boardName, weeklyListID)
+
+	// Late policies are per course, so only fetch each one once
+	latePolicies := make(map[int]*CanvasLatePolicy)
+
+	// Current course scores feed the priority badge
+	courseScores := make(map[int]*float64)
+	if scores, err := canvasClient.GetCourseScores(); err != nil {
+		fmt.Printf("Warning: priority badges without course scores: %v\n", err)
+	} else {
+		for _, score := range scores {
+			courseScores[score.CourseID] = score.Score
+		}
+	}
+
+	// A shutdown stops between assignments, and the next run picks up there
+	source := "canvas"
+	if canvasClient.Institution != "" {
+		source += ":" + canvasClient.Institution
 	}
+	progress := resumeSync(syncCursorFile, source, time.Now())
+	titles := newTitleMatcher()
+	zeroPoints := zeroPointMode()
+	unavailable := unavailableMode()
+	hold := holdUntilOpen()
+	passing := c.boardSettings(boardName).passing()
+	stats := newSyncStats(source)
 
 	// Process each Canvas assignment
 	for _, assignment := range assignments {
+		if done, err := progress.Step(fmt.Sprint(assignment.ID)); err != nil {
+			return err
+		} else if done {
+			continue
+		}
+		stats.Evaluate()
+
 		courseName, err := canvasClient.GetCourseNameByID(assignment.CourseID)
 		if err != nil {
 			fmt.Printf("Warning: failed to get course name for %d: %v\n", assignment.CourseID, err)
 			courseName = fmt.Sprintf("Course %d", assignment.CourseID)
 		}
+		courseName = institutionCourseName(courseName, canvasClient.Institution)
+		zeroPoint := isZeroPoint(assignment)
+		if zeroPoint && zeroPoints == zeroPointSkip {
+			continue
+		}
+		available := canvasAvailable(assignment, time.Now())
+		if !available && unavailable == unavailableSkip {
+			fmt.Printf("Skipping %s - %s until it's available\n", courseName, assignment.Name)
+			continue
+		}
 
 		// Get grade/submission info
 		submission, err := canvasClient.GetSubmission(assignment.CourseID, assignment.ID, canvasUserID)
@@ -552,24 +875,62 @@ func (c *TrelloClient) SyncCanvasAssignments(canvasClient *CanvasClient, canvasU
 			fmt.Printf("Warning: failed to get submission for assignment %s: %v\n", assignment.Name, err)
 			submission = nil
 		}
+		// Scores are points, and letter grades need the course's scale
+		percent, graded := canvasPercent(assignment, submission, courseName, activeGradeScale())
+
+		// Older past-due work only gets a card while it's still missing or failing
+		if due, err := time.Parse(time.RFC3339, assignment.DueAt); err == nil && isPastDueExtra(due, time.Now()) && submission != nil {
+			var score *float64
+			if graded {
+				score = &percent
+			}
+			if !pastDueNeedsCard(submission.Submitted(), score) {
+				continue
+			}
+		}
 
 		// Check if card already exists
-		existingCard := c.FindCardByCanvasID(allCards, assignment.ID, "Assignment")
+		existingCard := c.findCanvasAssignmentCard(allCards, assignment.ID, canvasClient.Institution)
+		if existingCard == nil {
+			// Cards made before the sync have no ID to find them by
+			existingCard = titles.Find(allCards, fmt.Sprintf("%s - %s", courseName, assignment.Name), courseName)
+		}
+		if skipNoAuto(existingCard) {
+			continue
+		}
+
+		// Excused work doesn't count, so it gets no redo and its card goes away
+		if submission != nil && submission.Excused {
+			c.archiveExcused(existingCard)
+			continue
+		}
+
+		if _, fetched := latePolicies[assignment.CourseID]; !fetched {
+			policy, err := canvasClient.GetLatePolicy(assignment.CourseID)
+			if err != nil {
+				fmt.Printf("Warning: failed to get late policy for %s: %v\n", courseName, err)
+			}
+			latePolicies[assignment.CourseID] = policy
+		}
 
 		// Prepare card data
 		cardTitle := fmt.Sprintf("%s - %s", courseName, assignment.Name)
-		needsRedo := submission != nil && submission.Score != nil && *submission.Score < 90
+		needsRedo := graded && percent < passing && !zeroPoint
+		isMissing := submission != nil && submission.Missing
+
+		// A redo or missing assignment past its lock date can't be turned in anymore
+		locked := (needsRedo || isMissing) && isPastLockDate(assignment, time.Now())
+		if locked {
+			needsRedo = false
+			cardTitle = "LOCKED - " + cardTitle
+		}
+
 		if needsRedo && !strings.HasPrefix(cardTitle, "REDO - ") {
 			cardTitle = "REDO - " + cardTitle
 		} else if !needsRedo && strings.HasPrefix(cardTitle, "REDO - ") {
 			cardTitle = strings.TrimPrefix(cardTitle, "REDO - ")
 		}
 
-		// Prepare description with Canvas metadata
-		baseDescription := stripCanvasMetadata(assignment.Description)
-		canvasMetadata := formatCanvasMetadata(assignment, courseName, submission)
-		fullDescription := baseDescription + canvasMetadata
-
 		// Calculate due date (use Canvas due date, or 1 week from now for REDO)
 		var dueDate string
 		if needsRedo {
@@ -583,35 +944,130 @@ func (c *TrelloClient) SyncCanvasAssignments(canvasClient *CanvasClient, canvasU
 			}
 		}
 
+		// Prepare description with Canvas metadata and the priority badge
+		priority := PriorityInput{Points: assignment.PointsPossible, CourseScore: courseScores[assignment.CourseID], Redo: needsRedo}
+		if due, err := time.Parse(time.RFC3339, dueDate); err == nil {
+			priority.Due = &due
+		}
+		baseDescription := stripCanvasMetadata(assignment.Description)
+		canvasMetadata := formatCanvasMetadata(assignment, courseName, submission) + institutionMetadata(canvasClient.Institution) + priorityMetadata(priority, time.Now())
+		canvasMetadata += describeLatePolicy(latePolicies[assignment.CourseID]) + descriptionHashLine(baseDescription)
+		fullDescription, truncated := fitCardDescription(baseDescription, canvasMetadata, assignment.HTMLURL)
+		if truncated {
+			fmt.Printf("Note: truncated long description for %s\n", cardTitle)
+		}
+
 		if existingCard != nil {
+			if truncated {
+				fullDescription = c.linkDescriptionOverflow(existingCard, baseDescription, canvasMetadata, fullDescription)
+			}
 			// Update existing card
 			fmt.Printf("Updating existing card: %s\n", cardTitle)
-			if err := c.UpdateCard(existingCard.ID, dueDate, false); err != nil {
+			// Redo dates are ours, not the teacher's, so only real LMS moves count
+			if !needsRedo && !strings.HasPrefix(existingCard.Name, "REDO - ") {
+				dueDate = c.preserveSnooze(existingCard, dueDate)
+				c.noteDueDateMove(existingCard, dueDate, "Canvas")
+			}
+			_, _, changed := dueDateMoved(existingCard, dueDate)
+			failed := false
+			// Only a REDO reopens a card; otherwise the student's checkmark stays
+			patch := CardPatch{Due: &dueDate}
+			if needsRedo {
+				patch.DueComplete = boolPtr(false)
+			}
+			if err := c.UpdateCardFields(existingCard.ID, patch); err != nil {
 				fmt.Printf("Warning: failed to update due date for card %s: %v\n", cardTitle, err)
+				failed = true
 			}
-			// Note: We'd need a UpdateCardNameAndDescription function for full updates
-		} else {
+
+			// The description is only replaced when the teacher edited it (with a
+			// diff comment), once to record the hash on cards from older syncs, to
+			// refresh the priority badge, or to link a truncated one to the rest
+			if c.noteDescriptionChange(existingCard, baseDescription, "Canvas") || storedDescriptionHash(existingCard.Description) == "" ||
+				storedPriority(existingCard.Description) != storedPriority(fullDescription) || truncated && existingCard.Description != fullDescription {
+				changed = true
+				if err := c.UpdateCardFields(existingCard.ID, CardPatch{Desc: &fullDescription}); err != nil {
+					fmt.Printf("Warning: failed to update description for card %s: %v\n", cardTitle, err)
+					failed = true
+				}
+			}
+			stats.Existing(changed, failed)
+
+			if err := c.EnsureLinkAttachment(existingCard.ID, "Canvas", assignment.HTMLURL); err != nil {
+				fmt.Printf("Warning: failed to attach Canvas link to card %s: %v\n", cardTitle, err)
+			}
+			if zeroPoint && zeroPoints == zeroPointLabel {
+				c.labelZeroPoint(*existingCard)
+			}
+			c.markAvailability(*existingCard, available)
+
+			if !locked && submission != nil {
+				state := AssignmentState{
+					Submitted: submission.Submitted(),
+					Graded:    graded,
+					Percent:   percent,
+					NeedsRedo: needsRedo,
+					Passing:   passing,
+				}
+				if redoResolved(*existingCard, state.Graded, state.Percent, passing) {
+					c.resolveRedo(existingCard, boardName, "Canvas", courseName, state.Percent)
+				}
+				c.routeCard(existingCard, boardName, state)
+			}
+
+			// Warn once, when the card first flips to LOCKED
+			if locked && !strings.HasPrefix(existingCard.Name, "LOCKED - ") {
+				if err := c.UpdateCardTitle(existingCard.ID, cardTitle); err != nil {
+					fmt.Printf("Warning: failed to update title for card %s: %v\n", cardTitle, err)
+				}
+				comment := fmt.Sprintf("⚠️ This assignment locked on %s and can no longer be submitted in Canvas, so no redo was scheduled.", assignment.LockAt)
+				if err := c.UpsertComment(existingCard.ID, "canvas-locked", comment); err != nil {
+					fmt.Printf("Warning: failed to add lock warning to card %s: %v\n", cardTitle, err)
+				}
+			}
+		} else if hold && opensLater(assignment.UnlockAt, time.Now()) {
+			fmt.Printf("Holding %s until it opens\n", cardTitle)
+		} else if !placement.Rejected(cardTitle, fullDescription) {
 			// Create new card
 			fmt.Printf("Creating new card: %s\n", cardTitle)
-			if err := c.CreateCard(weeklyListID, cardTitle, fullDescription, dueDate); err != nil {
+			newCard, err := c.CreateCard(placement.ListFor(cardTitle, dueDate), cardTitle, fullDescription, dueDate)
+			if err != nil {
 				fmt.Printf("Warning: failed to create card %s: %v\n", cardTitle, err)
+				stats.Fail()
+				continue
+			}
+			stats.Create()
+			if truncated {
+				c.linkNewCardOverflow(newCard, baseDescription, canvasMetadata, fullDescription)
+			}
+			if err := c.EnsureLinkAttachment(newCard.ID, "Canvas", assignment.HTMLURL); err != nil {
+				fmt.Printf("Warning: failed to attach Canvas link to card %s: %v\n", cardTitle, err)
+			}
+			if zeroPoint && zeroPoints == zeroPointLabel {
+				c.labelZeroPoint(*newCard)
+			}
+			if !available {
+				c.markAvailability(*newCard, false)
 			}
 		}
 	}
 
+	progress.Finish()
+	titles.Report()
+	stats.Save(syncStatsFile)
 	fmt.Printf("Canvas sync completed successfully!\n")
 
-	// Sort cards by due date in the Weekly list
-	fmt.Println("Sorting cards by due date...")
-	if err := c.SortCardsByDueDate(weeklyListID); err != nil {
-		fmt.Printf("Warning: failed to sort cards by due date: %v\n", err)
+	// Sort cards in the Weekly list
+	fmt.Println("Sorting cards...")
+	if err := c.SortListCards(weeklyListID); err != nil {
+		fmt.Printf("Warning: failed to sort cards: %v\n", err)
 	}
 
 	return nil
 }
 
 
-func (c *TrelloClient) SyncMoodleAssignments(moodleClient *MoodleClient, toDate time.Time, dryRun bool, testFile string) error {
+func (c *TrelloClient) SyncMoodleAssignments(moodleClient *MoodleClient, since, toDate time.Time, dryRun bool, testFile string) error {
     fmt.Println("Starting Moodle/Open LMS sync...")
 
     var assignments []MoodleAssignment
@@ -631,35 +1087,62 @@ func (c *TrelloClient) SyncMoodleAssignments(moodleClient *MoodleClient, toDate
     } else {
         // Pull upcoming assignments from API
         var err error
-        assignments, courseNames, err = moodleClient.GetUpcomingAssignments(toDate)
+        assignments, courseNames, err = moodleClient.GetUpcomingAssignments(since, toDate)
         if err != nil {
             return fmt.Errorf("failed to get Moodle assignments: %w", err)
         }
         fmt.Printf("Found %d Moodle assignments due by %s\n", len(assignments), toDate.Format("2006-01-02"))
     }
 
-    // Get all cards from the Makai School board
-    allCards, err := c.GetAllBoardCards("Makai School")
+    // Get all cards from the Makai School board, or --target-board
+    boardName := c.Target.board("Makai School")
+    allCards, err := c.GetAllBoardCards(boardName)
     if err != nil {
         return fmt.Errorf("failed to get Trello cards: %w", err)
     }
-    fmt.Printf("Found %d existing cards on Makai School board\n", len(allCards))
+    fmt.Printf("Found %d existing cards on %s board\n", len(allCards), boardName)
 
     var weeklyListID string
     if !dryRun {
-        // Weekly list for new cards
+        // Weekly list (or --target-list) for new cards
         var err error
-        weeklyListID, err = c.FindListByName("Makai School", "Weekly")
+        listName := c.Target.list("Weekly")
+        weeklyListID, err = c.FindListByName(boardName, listName)
         if err != nil {
-            return fmt.Errorf("failed to find Weekly list: %w", err)
+            return fmt.Errorf("failed to find %s list: %w", listName, err)
         }
     }
+    placement := c.newCardPlacement(boardName, weeklyListID)
+
+    // Test-file and dry runs never save a cursor
+    var progress *syncProgress
+    var stats *SyncStats
+    if !dryRun && testFile == "" {
+        progress = resumeSync(syncCursorFile, "moodle", time.Now())
+        stats = newSyncStats("moodle")
+    }
+    titles := newTitleMatcher()
+    unavailable := unavailableMode()
+    hold := holdUntilOpen()
+    passing := c.boardSettings(boardName).passing()
 
     for _, a := range assignments {
+        if done, err := progress.Step(fmt.Sprint(a.ID)); err != nil {
+            return err
+        } else if done {
+            continue
+        }
+        stats.Evaluate()
+
         courseName := courseNames[a.CourseID]
         if courseName == "" {
             courseName = fmt.Sprintf("Course %d", a.CourseID)
         }
+        available := moodleAvailable(a)
+        if !available && unavailable == unavailableSkip {
+            fmt.Printf("Skipping %s - %s until it's available\n", courseName, a.Name)
+            continue
+        }
 
         // Get grade for this assignment/quiz
         var grade *MoodleGrade
@@ -682,19 +1165,27 @@ func (c *TrelloClient) SyncMoodleAssignments(moodleClient *MoodleClient, toDate
             }
         }
 
-        // Check if assignment has passing grade (>= 90%) and skip if so
+        // Check if assignment has a passing grade (90% unless the board
+        // sets its own) and skip if so, moving any existing card to Done
         if grade != nil && grade.GradeMax > 0 {
             percentage := (grade.Grade / grade.GradeMax) * 100
-            if percentage >= 90 {
+            if percentage >= passing {
                 fmt.Printf("Skipping assignment with passing grade: %s (%.1f%%)\n", a.Name, percentage)
+                if existing := c.FindCardByMoodleAssignmentID(allCards, a.ID); existing != nil && !dryRun && !skipNoAuto(existing) {
+                    if redoResolved(*existing, true, percentage, passing) {
+                        c.resolveRedo(existing, boardName, "Moodle", courseName, percentage)
+                    } else {
+                        c.routeCard(existing, boardName, AssignmentState{Submitted: true, Graded: true, Percent: percentage, Passing: passing})
+                    }
+                }
                 continue
             }
         }
 
         cardTitle := fmt.Sprintf("%s - %s", courseName, a.Name)
 
-        // Add REDO prefix if grade is below 90%
-        needsRedo := grade != nil && grade.GradeMax > 0 && (grade.Grade/grade.GradeMax)*100 < 90
+        // Add REDO prefix if grade is below passing
+        needsRedo := grade != nil && grade.GradeMax > 0 && (grade.Grade/grade.GradeMax)*100 < passing
         if needsRedo && !strings.HasPrefix(cardTitle, "REDO - ") {
             cardTitle = "REDO - " + cardTitle
         } else if !needsRedo && strings.HasPrefix(cardTitle, "REDO - ") {
@@ -703,8 +1194,11 @@ func (c *TrelloClient) SyncMoodleAssignments(moodleClient *MoodleClient, toDate
 
         baseDescription := a.Intro
         // Many Moodle sites return HTML in Intro; keep as-is to preserve formatting.
-        meta := formatMoodleMetadata(a, courseName, grade)
-        fullDescription := strings.TrimSpace(baseDescription) + meta
+        meta := formatMoodleMetadata(a, courseName, grade) + descriptionHashLine(baseDescription)
+        fullDescription, truncated := fitCardDescription(strings.TrimSpace(baseDescription), meta, a.URL)
+        if truncated {
+            fmt.Printf("Note: truncated long description for %s\n", cardTitle)
+        }
 
         // Due date
         var dueDate string
@@ -715,56 +1209,107 @@ func (c *TrelloClient) SyncMoodleAssignments(moodleClient *MoodleClient, toDate
 
         // Check for existing card
         existing := c.FindCardByMoodleAssignmentID(allCards, a.ID)
+        if existing == nil {
+            // Cards made before the sync have no ID to find them by
+            existing = titles.Find(allCards, fmt.Sprintf("%s - %s", courseName, a.Name), courseName)
+        }
+        if skipNoAuto(existing) {
+            continue
+        }
         if existing != nil {
             if dryRun {
                 fmt.Printf("[DRY RUN] Would update card: %s (due %s)\n", cardTitle, dueDate)
+                if oldDue, newDue, moved := dueDateMoved(existing, dueDate); moved {
+                    fmt.Printf("[DRY RUN] Would note due date move: %s -> %s\n", oldDue.Format(time.RFC3339), newDue.Format(time.RFC3339))
+                }
+                if stored := storedDescriptionHash(existing.Description); stored != "" && stored != descriptionHash(baseDescription) {
+                    fmt.Printf("[DRY RUN] Would comment on description change\n")
+                }
             } else {
                 fmt.Printf("Updating existing Moodle card: %s\n", cardTitle)
-
-                // Update due date
-                if err := c.UpdateCard(existing.ID, dueDate, false); err != nil {
-                    fmt.Printf("Warning: failed to update due date for %s: %v\n", cardTitle, err)
+                if truncated {
+                    fullDescription = c.linkDescriptionOverflow(existing, strings.TrimSpace(baseDescription), meta, fullDescription)
+                }
+                dueDate = c.preserveSnooze(existing, dueDate)
+                c.noteDueDateMove(existing, dueDate, "Moodle")
+                c.noteDescriptionChange(existing, baseDescription, "Moodle")
+
+                // Update due date, plus title (e.g., REDO prefix added/removed)
+                // and description if they have changed, in one request. Only a
+                // REDO reopens a card; otherwise the student's checkmark stays.
+                patch := CardPatch{Due: &dueDate}
+                if needsRedo {
+                    patch.DueComplete = boolPtr(false)
                 }
-
-                // Update title if it has changed (e.g., REDO prefix added/removed)
                 if existing.Name != cardTitle {
-                    if err := c.UpdateCardTitle(existing.ID, cardTitle); err != nil {
-                        fmt.Printf("Warning: failed to update title for %s: %v\n", cardTitle, err)
-                    }
+                    patch.Name = &cardTitle
                 }
-
-                // Update description if it has changed
                 if existing.Description != fullDescription {
-                    if err := c.UpdateCardDescription(existing.ID, fullDescription); err != nil {
-                        fmt.Printf("Warning: failed to update description for %s: %v\n", cardTitle, err)
-                    }
+                    patch.Desc = &fullDescription
+                }
+                _, _, moved := dueDateMoved(existing, dueDate)
+                err := c.UpdateCardFields(existing.ID, patch)
+                if err != nil {
+                    fmt.Printf("Warning: failed to update card %s: %v\n", cardTitle, err)
+                }
+                stats.Existing(moved || patch.Name != nil || patch.Desc != nil, err != nil)
+
+                if err := c.EnsureLinkAttachment(existing.ID, "Moodle", a.URL); err != nil {
+                    fmt.Printf("Warning: failed to attach Moodle link to %s: %v\n", cardTitle, err)
+                }
+                c.markAvailability(*existing, available)
+
+                if needsRedo {
+                    c.routeCard(existing, boardName, AssignmentState{Graded: true, NeedsRedo: true})
                 }
             }
-        } else {
+        } else if hold && opensLater(a.opens(), time.Now()) {
+            fmt.Printf("Holding %s until it opens\n", cardTitle)
+        } else if !placement.Rejected(cardTitle, fullDescription) {
             if dryRun {
                 fmt.Printf("[DRY RUN] Would create card: %s (due %s)\n", cardTitle, dueDate)
             } else {
                 fmt.Printf("Creating new Moodle card: %s\n", cardTitle)
-                if err := c.CreateCard(weeklyListID, cardTitle, fullDescription, dueDate); err != nil {
+                newCard, err := c.CreateCard(placement.ListFor(cardTitle, dueDate), cardTitle, fullDescription, dueDate)
+                if err != nil {
                     fmt.Printf("Warning: failed to create card %s: %v\n", cardTitle, err)
+                    stats.Fail()
+                } else {
+                    stats.Create()
+                    if truncated {
+                        c.linkNewCardOverflow(newCard, strings.TrimSpace(baseDescription), meta, fullDescription)
+                    }
+                    if err := c.EnsureLinkAttachment(newCard.ID, "Moodle", a.URL); err != nil {
+                        fmt.Printf("Warning: failed to attach Moodle link to %s: %v\n", cardTitle, err)
+                    }
+                    if !available {
+                        c.markAvailability(*newCard, false)
+                    }
                 }
             }
         }
     }
 
+    progress.Finish()
+    titles.Report()
+    moodleClient.ReportWarnings()
+    stats.Save(syncStatsFile)
     fmt.Printf("Moodle sync completed successfully!\n")
 
-    // Sort cards by due date in the Weekly list (if not dry run)
+    // Sort cards in the Weekly list (if not dry run)
     if !dryRun {
-        fmt.Println("Sorting cards by due date...")
-        if err := c.SortCardsByDueDate(weeklyListID); err != nil {
-            fmt.Printf("Warning: failed to sort cards by due date: %v\n", err)
+        fmt.Println("Sorting cards...")
+        if err := c.SortListCards(weeklyListID); err != nil {
+            fmt.Printf("Warning: failed to sort cards: %v\n", err)
         }
     }
 
     return nil
 }
 
+// bugLabel is applied to JIRA bug cards, created on the board if needed
+var bugLabel = LabelSpec{Name: "Bug", Color: "red", CreateIfMissing: true}
+
 // JiraTask represents a JIRA task parsed from local files
 type JiraTask struct {
 	ID          string
@@ -776,13 +1321,16 @@ type JiraTask struct {
 	Priority    string
 	IssueType   string
 	PRLink      string
+	Epic        string // the epic or parent issue's key
+	EpicName    string
 }
 
 // SyncJiraTasks syncs local JIRA tasks to Trello Mac board
 func (c *TrelloClient) SyncJiraTasks(tasksDir string) error {
 	fmt.Printf("Syncing JIRA tasks from %s\n", tasksDir)
 
-	// Get Mac board
+	// Get Mac board, or --target-board
+	boardName := c.Target.board("Mac")
 	boards, err := c.GetBoards()
 	if err != nil {
 		return fmt.Errorf("failed to get boards: %v", err)
@@ -790,44 +1338,43 @@ func (c *TrelloClient) SyncJiraTasks(tasksDir string) error {
 
 	var macBoardID string
 	for _, board := range boards {
-		if board.Name == "Mac" {
+		if board.Name == boardName {
 			macBoardID = board.ID
 			break
 		}
 	}
 
 	if macBoardID == "" {
-		return fmt.Errorf("Mac board not found")
+		return fmt.Errorf("%s board not found", boardName)
 	}
 
-	// Get board lists and cards
-	lists, err := c.GetBoardLists(macBoardID)
+	// Snapshot board lists and cards once for the whole run
+	snapshot, err := NewBoardSnapshot(c, macBoardID)
 	if err != nil {
-		return fmt.Errorf("failed to get board lists: %v", err)
-	}
-
-	cards, err := c.GetAllBoardCards("Mac")
-	if err != nil {
-		return fmt.Errorf("failed to get board cards: %v", err)
+		return err
 	}
+	lists := snapshot.Lists
 
 	// Create list ID to name mapping
-	listIDToName := make(map[string]string)
-	for _, list := range lists {
-		listIDToName[list.ID] = list.Name
-	}
+	listIDToName := snapshot.ListNames()
 
-	// Use first list as default for new cards
+	// Use --target-list, or the first list, as default for new cards
 	var defaultListID string
-	if len(lists) > 0 {
+	if c.Target.List != "" {
+		list, err := findListByName(lists, macBoardID, c.Target.List)
+		if err != nil {
+			return fmt.Errorf("%s in board '%s'", err.Error(), boardName)
+		}
+		defaultListID = list.ID
+	} else if len(lists) > 0 {
 		defaultListID = lists[0].ID
 		fmt.Printf("Using list '%s' for new cards\n", lists[0].Name)
 	} else {
-		return fmt.Errorf("no lists found on Mac board")
+		return fmt.Errorf("no lists found on %s board", boardName)
 	}
 
 	// Parse JIRA tasks from directory
-	tasks, err := c.parseJiraTasks(tasksDir)
+	tasks, skipped, err := c.parseJiraTasks(tasksDir)
 	if err != nil {
 		return fmt.Errorf("failed to parse JIRA tasks: %v", err)
 	}
@@ -837,12 +1384,21 @@ func (c *TrelloClient) SyncJiraTasks(tasksDir string) error {
 	// Process each task
 	updatedCards := 0
 	createdCards := 0
+	grouping := epicGrouping()
 
 	for _, task := range tasks {
 		fmt.Printf("Processing task: %s\n", task.ID)
 
+		cards, err := snapshot.Cards()
+		if err != nil {
+			return err
+		}
+
 		// Find matching card by task ID in title
 		existingCard := c.FindCardByTaskID(cards, task.ID)
+		if skipNoAuto(existingCard) {
+			continue
+		}
 
 		if existingCard != nil {
 			fmt.Printf("  Found existing card: %s\n", existingCard.Name)
@@ -855,7 +1411,7 @@ func (c *TrelloClient) SyncJiraTasks(tasksDir string) error {
 				if err := c.UpdateCardTitle(existingCard.ID, fixedTitle); err != nil {
 					fmt.Printf("  Warning: failed to fix card title: %v\n", err)
 				} else {
-					fmt.Printf("  ✓ Fixed duplicate title\n")
+					fmt.Printf("  %s Fixed duplicate title\n", iconCheck)
 				}
 			}
 
@@ -866,7 +1422,16 @@ func (c *TrelloClient) SyncJiraTasks(tasksDir string) error {
 				if err := c.updateLocalTaskStatus(tasksDir, task.ID, newStatus); err != nil {
 					fmt.Printf("  Warning: failed to update local status: %v\n", err)
 				} else {
-					fmt.Printf("  ✓ Updated local status to: %s (from %s list)\n", newStatus, listName)
+					fmt.Printf("  %s Updated local status to: %s (from %s list)\n", iconCheck, newStatus, listName)
+				}
+
+				// Keep a local record of finished work before the card is archived
+				if newStatus == statusCompleted {
+					if exported, err := c.exportCompletedTask(tasksDir, task.ID, *existingCard, listName); err != nil {
+						fmt.Printf("  Warning: failed to export the finished card: %v\n", err)
+					} else if exported {
+						fmt.Printf("  %s Saved the card to %s\n", iconCheck, completedRecordFile)
+					}
 				}
 
 				// Update JIRA status
@@ -875,7 +1440,7 @@ func (c *TrelloClient) SyncJiraTasks(tasksDir string) error {
 					if err := c.updateJiraStatus(task.ID, jiraStatus); err != nil {
 						fmt.Printf("  Warning: failed to update JIRA status: %v\n", err)
 					} else {
-						fmt.Printf("  ✓ Updated JIRA status to: %s\n", jiraStatus)
+						fmt.Printf("  %s Updated JIRA status to: %s\n", iconCheck, jiraStatus)
 					}
 				}
 
@@ -886,19 +1451,21 @@ func (c *TrelloClient) SyncJiraTasks(tasksDir string) error {
 			if err := c.UpdateCardDescription(existingCard.ID, description); err != nil {
 				fmt.Printf("  Warning: failed to update card description: %v\n", err)
 			} else {
-				fmt.Printf("  ✓ Updated card description\n")
+				fmt.Printf("  %s Updated card description\n", iconCheck)
 				updatedCards++
 			}
 
 			// Add red label for bugs (check both IssueType and Priority fields)
 			isBug := strings.ToLower(task.IssueType) == "bug" || strings.ToLower(task.Priority) == "bug"
 			if isBug {
-				if err := c.AddLabelToCard(existingCard.ID, "red"); err != nil {
+				if err := c.AddLabelToCardWithOptions(existingCard.ID, bugLabel); err != nil {
 					fmt.Printf("  Warning: failed to add bug label: %v\n", err)
 				} else {
-					fmt.Printf("  ✓ Added bug label\n")
+					fmt.Printf("  %s Added bug label\n", iconCheck)
 				}
 			}
+
+			c.applyEpicGrouping(*existingCard, task, grouping)
 		} else {
 			fmt.Printf("  Creating new card for task\n")
 
@@ -909,36 +1476,49 @@ func (c *TrelloClient) SyncJiraTasks(tasksDir string) error {
 			} else {
 				cardTitle = fmt.Sprintf("%s: %s", task.ID, task.Title)
 			}
+			if grouping == "prefix" {
+				cardTitle = epicTitle(cardTitle, task)
+			}
 			description := c.buildJiraCardDescription(task)
 
-			if err := c.CreateCard(defaultListID, cardTitle, description, ""); err != nil {
+			newCard, err := c.CreateCard(defaultListID, cardTitle, description, "")
+			if err != nil {
 				fmt.Printf("  Warning: failed to create card: %v\n", err)
 			} else {
-				fmt.Printf("  ✓ Created new card\n")
+				fmt.Printf("  %s Created new card\n", iconCheck)
 				createdCards++
+				snapshot.Add(*newCard)
 
-				// Add red label for bugs (need to get the card ID first)
+				// Add red label for bugs
 				isBug := strings.ToLower(task.IssueType) == "bug" || strings.ToLower(task.Priority) == "bug"
 				if isBug {
-					// Find the newly created card to get its ID
-					newCards, err := c.GetAllBoardCards("Mac")
-					if err == nil {
-						if newCard := c.FindCardByTaskID(newCards, task.ID); newCard != nil {
-							if err := c.AddLabelToCard(newCard.ID, "red"); err != nil {
-								fmt.Printf("  Warning: failed to add bug label: %v\n", err)
-							} else {
-								fmt.Printf("  ✓ Added bug label\n")
-							}
-						}
+					if err := c.AddLabelToCardWithOptions(newCard.ID, bugLabel); err != nil {
+						fmt.Printf("  Warning: failed to add bug label: %v\n", err)
+					} else {
+						fmt.Printf("  %s Added bug label\n", iconCheck)
 					}
 				}
+				if grouping == "label" {
+					c.applyEpicGrouping(*newCard, task, grouping)
+				}
 			}
 		}
 	}
 
+	overviews, err := c.syncEpicOverviews(snapshot, tasks, defaultListID)
+	if err != nil {
+		fmt.Printf("Warning: failed to update the epic overviews: %v\n", err)
+	}
+
 	fmt.Printf("\nJIRA sync completed!\n")
 	fmt.Printf("Created: %d cards\n", createdCards)
 	fmt.Printf("Updated: %d cards\n", updatedCards)
+	if overviews > 0 {
+		fmt.Printf("Epic overviews written: %d\n", overviews)
+	}
+	if len(skipped) > 0 {
+		fmt.Print(formatSkippedTasks(skipped))
+	}
 
 	return nil
 }
@@ -946,20 +1526,22 @@ func (c *TrelloClient) SyncJiraTasks(tasksDir string) error {
 // FindCardByTaskID finds a card that contains the task ID in its title
 func (c *TrelloClient) FindCardByTaskID(cards []Card, taskID string) *Card {
 	for i := range cards {
-		if strings.Contains(cards[i].Name, taskID) {
+		if strings.Contains(cards[i].Name, taskID) && !isEpicOverview(cards[i]) {
 			return &cards[i]
 		}
 	}
 	return nil
 }
 
-// parseJiraTasks reads and parses JIRA tasks from the directory
-func (c *TrelloClient) parseJiraTasks(tasksDir string) ([]JiraTask, error) {
+// parseJiraTasks reads and parses JIRA tasks from the directory. Tasks that
+// opt out of syncing are returned separately.
+func (c *TrelloClient) parseJiraTasks(tasksDir string) ([]JiraTask, []SkippedTask, error) {
 	var tasks []JiraTask
+	var skipped []SkippedTask
 
 	entries, err := os.ReadDir(tasksDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read tasks directory: %v", err)
+		return nil, nil, fmt.Errorf("failed to read tasks directory: %v", err)
 	}
 
 	for _, entry := range entries {
@@ -968,6 +1550,10 @@ func (c *TrelloClient) parseJiraTasks(tasksDir string) ([]JiraTask, error) {
 		}
 
 		taskID := entry.Name()
+		if skip, reason := jiraTaskSkip(filepath.Join(tasksDir, taskID), taskID); skip {
+			skipped = append(skipped, SkippedTask{ID: taskID, Reason: reason})
+			continue
+		}
 		statusFile := filepath.Join(tasksDir, taskID, "STATUS.md")
 		taskFile := filepath.Join(tasksDir, taskID, taskID+".md")
 
@@ -980,7 +1566,7 @@ func (c *TrelloClient) parseJiraTasks(tasksDir string) ([]JiraTask, error) {
 		tasks = append(tasks, task)
 	}
 
-	return tasks, nil
+	return tasks, skipped, nil
 }
 
 // parseJiraTask parses a single JIRA task from its files
@@ -989,10 +1575,10 @@ func (c *TrelloClient) parseJiraTask(taskID, statusFile, taskFile string) (JiraT
 
 	// Read STATUS.md file
 	if statusData, err := os.ReadFile(statusFile); err == nil {
-		statusContent := string(statusData)
+		statusContent := cleanText(string(statusData))
 
 		// Extract current status
-		if match := regexp.MustCompile(`## Current Status:\s*(.+)`).FindStringSubmatch(statusContent); len(match) > 1 {
+		if match := regexp.MustCompile(`## Current Status:[ \t]*([^\r\n]+)`).FindStringSubmatch(statusContent); len(match) > 1 {
 			task.Status = strings.TrimSpace(match[1])
 		}
 
@@ -1016,6 +1602,7 @@ func (c *TrelloClient) parseJiraTask(taskID, statusFile, taskFile string) (JiraT
 		if match := regexp.MustCompile(`- \*\*Issue Type\*\*:\s*(.+)`).FindStringSubmatch(statusContent); len(match) > 1 {
 			task.IssueType = strings.TrimSpace(match[1])
 		}
+		task.Epic, task.EpicName = parseJiraEpic(statusContent)
 
 		// Extract PR link from Context Links section - try multiple patterns
 		prPatterns := []string{
@@ -1045,7 +1632,12 @@ func (c *TrelloClient) parseJiraTask(taskID, statusFile, taskFile string) (JiraT
 
 	// Read task.md file for title
 	if taskData, err := os.ReadFile(taskFile); err == nil {
-		taskContent := string(taskData)
+		taskContent := cleanText(string(taskData))
+
+		// The epic can be noted in either file; STATUS.md wins
+		if task.Epic == "" {
+			task.Epic, task.EpicName = parseJiraEpic(taskContent)
+		}
 
 		// Extract title from first heading
 		if match := regexp.MustCompile(`# (.+)`).FindStringSubmatch(taskContent); len(match) > 1 {
@@ -1071,7 +1663,7 @@ func (c *TrelloClient) buildJiraCardDescription(task JiraTask) string {
 		desc.WriteString(fmt.Sprintf("**Current Status**: %s\n\n", task.Status))
 	}
 
-	if task.JiraStatus != "" || task.Priority != "" || task.IssueType != "" {
+	if task.JiraStatus != "" || task.Priority != "" || task.IssueType != "" || task.Epic != "" {
 		desc.WriteString("**JIRA Info**:\n")
 		if task.JiraStatus != "" {
 			desc.WriteString(fmt.Sprintf("- Status: %s\n", task.JiraStatus))
@@ -1082,6 +1674,9 @@ func (c *TrelloClient) buildJiraCardDescription(task JiraTask) string {
 		if task.IssueType != "" {
 			desc.WriteString(fmt.Sprintf("- Type: %s\n", task.IssueType))
 		}
+		if task.Epic != "" {
+			desc.WriteString(fmt.Sprintf("- Epic: %s\n", strings.TrimSpace(task.Epic+" "+task.EpicName)))
+		}
 		desc.WriteString("\n")
 	}
 
@@ -1098,7 +1693,7 @@ func (c *TrelloClient) buildJiraCardDescription(task JiraTask) string {
 	}
 
 	desc.WriteString("**Links**:\n")
-	desc.WriteString(fmt.Sprintf("- [JIRA Ticket](https://alkiranet.atlassian.net/browse/%s)\n", task.ID))
+	desc.WriteString(fmt.Sprintf("- [JIRA Ticket](%s/browse/%s)\n", jiraSiteURL(), task.ID))
 	if task.PRLink != "" {
 		desc.WriteString(fmt.Sprintf("- [Related PR](%s)\n", task.PRLink))
 	}
@@ -1108,135 +1703,30 @@ func (c *TrelloClient) buildJiraCardDescription(task JiraTask) string {
 	return desc.String()
 }
 
-// AddLabelToCard adds a label to a Trello card
+// AddLabelToCard adds the board's first label of the given color to a card
 func (c *TrelloClient) AddLabelToCard(cardID, labelColor string) error {
-	// Get card info to find board
-	endpoint := fmt.Sprintf("/cards/%s", cardID)
-	body, err := c.makeRequest(endpoint)
-	if err != nil {
-		return fmt.Errorf("failed to get card: %v", err)
-	}
-
-	var card struct {
-		IDBoard string `json:"idBoard"`
-	}
-	if err := json.Unmarshal(body, &card); err != nil {
-		return fmt.Errorf("failed to unmarshal card: %w", err)
-	}
-
-	// Get board labels
-	labelsEndpoint := fmt.Sprintf("/boards/%s/labels", card.IDBoard)
-	labelsBody, err := c.makeRequest(labelsEndpoint)
-	if err != nil {
-		return fmt.Errorf("failed to get board labels: %v", err)
-	}
-
-	var labels []struct {
-		ID    string `json:"id"`
-		Name  string `json:"name"`
-		Color string `json:"color"`
-	}
-
-	if err := json.Unmarshal(labelsBody, &labels); err != nil {
-		return fmt.Errorf("failed to unmarshal labels: %w", err)
-	}
-
-	// Find existing label or use first red label
-	var labelID string
-	for _, label := range labels {
-		if label.Color == labelColor {
-			labelID = label.ID
-			break
-		}
-	}
-
-	if labelID == "" {
-		return fmt.Errorf("no %s label found on board", labelColor)
-	}
-
-	// Add label to card
-	addLabelEndpoint := fmt.Sprintf("/cards/%s/idLabels", cardID)
-
-	u, err := url.Parse(c.BaseURL + addLabelEndpoint)
-	if err != nil {
-		return fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("key", c.APIKey)
-	q.Set("token", c.APIToken)
-	q.Set("value", labelID)
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequest("POST", u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to add label: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		// Read response body for debugging
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %s: %s", resp.Status, string(respBody))
-	}
-
-	return nil
+	return c.AddLabelToCardWithOptions(cardID, LabelSpec{Color: labelColor})
 }
 
 
 // UpdateCardTitle updates the title of a Trello card
 func (c *TrelloClient) UpdateCardTitle(cardID, title string) error {
-	endpoint := fmt.Sprintf("/cards/%s", cardID)
-
-	u, err := url.Parse(c.BaseURL + endpoint)
-	if err != nil {
-		return fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("key", c.APIKey)
-	q.Set("token", c.APIToken)
-	q.Set("name", title)
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequest("PUT", u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to update card title: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status: %s", resp.Status)
-	}
-
-	return nil
+	return c.UpdateCardFields(cardID, CardPatch{Name: &title})
 }
 
 // mapListNameToStatus converts Trello list names to local status
 func (c *TrelloClient) mapListNameToStatus(listName string) string {
 	switch strings.ToLower(listName) {
 	case "sprint", "backlog", "to do", "todo":
-		return "🎯 PLANNED"
+		return statusPlanned
 	case "doing", "in progress":
-		return "🔄 IN PROGRESS"
+		return statusInProgress
 	case "in review", "code review", "review":
-		return "👀 IN REVIEW"
+		return statusInReview
 	case "done", "completed":
-		return "✅ COMPLETED"
+		return statusCompleted
 	default:
-		return "🔄 " + strings.ToUpper(listName)
+		return statusOtherPrefix + strings.ToUpper(listName)
 	}
 }
 
@@ -1252,15 +1742,21 @@ func (c *TrelloClient) updateLocalTaskStatus(tasksDir, taskID, newStatus string)
 
 	statusContent := string(content)
 
-	// Update the Current Status line
-	statusRegex := regexp.MustCompile(`## Current Status:\s*(.+)`)
+	// Keep Windows line endings intact when the file uses them
+	newline := "\n"
+	if strings.Contains(statusContent, "\r\n") {
+		newline = "\r\n"
+	}
+
+	// Update the Current Status line (stopping before any \r)
+	statusRegex := regexp.MustCompile(`## Current Status:[ \t]*([^\r\n]+)`)
 	if statusRegex.MatchString(statusContent) {
 		statusContent = statusRegex.ReplaceAllString(statusContent, fmt.Sprintf("## Current Status: %s", newStatus))
 	} else {
 		// If no Current Status line exists, add one after the title
 		titleRegex := regexp.MustCompile(`(# [^\n]+\n)`)
 		if titleRegex.MatchString(statusContent) {
-			statusContent = titleRegex.ReplaceAllString(statusContent, fmt.Sprintf("$1\n## Current Status: %s\n", newStatus))
+			statusContent = titleRegex.ReplaceAllString(statusContent, fmt.Sprintf("$1%s## Current Status: %s%s", newline, newStatus, newline))
 		}
 	}
 
@@ -1298,7 +1794,7 @@ func (c *TrelloClient) updateJiraStatus(taskID, targetStatus string) error {
 
 	output, err := cmd.CombinedOutput()
 	if err == nil {
-		fmt.Printf("    ✓ Updated JIRA %s to '%s'\n", taskID, targetStatus)
+		fmt.Printf("    %s Updated JIRA %s to '%s'\n", iconCheck, taskID, targetStatus)
 		return nil
 	}
 
@@ -1344,7 +1840,7 @@ func (c *TrelloClient) updateJiraStatus(taskID, targetStatus string) error {
 		return fmt.Errorf("failed to update JIRA status: %v, output: %s", err, string(output))
 	}
 
-	fmt.Printf("    ✓ Updated JIRA %s to '%s'\n", taskID, bestMatch)
+	fmt.Printf("    %s Updated JIRA %s to '%s'\n", iconCheck, taskID, bestMatch)
 	return nil
 }
 
@@ -1394,87 +1890,21 @@ func (c *TrelloClient) findBestJiraState(issueOutput string, candidates []string
 
 // DeleteCard deletes a Trello card
 func (c *TrelloClient) DeleteCard(cardID string) error {
-	endpoint := fmt.Sprintf("/cards/%s", cardID)
-
-	u, err := url.Parse(c.BaseURL + endpoint)
-	if err != nil {
-		return fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("key", c.APIKey)
-	q.Set("token", c.APIToken)
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequest("DELETE", u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
+	if _, err := c.sendForm("DELETE", fmt.Sprintf("/cards/%s", cardID), nil); err != nil {
 		return fmt.Errorf("failed to delete card: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status: %s", resp.Status)
-	}
 
 	return nil
 }
 
-// DeleteAllCardsFromList removes all cards from a specific list
-func (c *TrelloClient) DeleteAllCardsFromList(listID string) error {
-	cards, err := c.GetCardsInList(listID)
-	if err != nil {
-		return fmt.Errorf("failed to get cards in list: %w", err)
-	}
-
-	fmt.Printf("Deleting %d cards from list...\n", len(cards))
-
-	for _, card := range cards {
-		fmt.Printf("Deleting card: %s\n", card.Name)
-		if err := c.DeleteCard(card.ID); err != nil {
-			return fmt.Errorf("failed to delete card %s: %w", card.Name, err)
-		}
-	}
-
-	fmt.Printf("Successfully deleted %d cards!\n", len(cards))
-	return nil
-}
-
 // AddCommentToCard adds a comment to a Trello card
 func (c *TrelloClient) AddCommentToCard(cardID, text string) error {
-	endpoint := fmt.Sprintf("/cards/%s/actions/comments", cardID)
+	fields := url.Values{}
+	fields.Set("text", text)
 
-	u, err := url.Parse(c.BaseURL + endpoint)
-	if err != nil {
-		return fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("key", c.APIKey)
-	q.Set("token", c.APIToken)
-	q.Set("text", text)
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequest("POST", u.String(), nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
+	if _, err := c.sendForm("POST", fmt.Sprintf("/cards/%s/actions/comments", cardID), fields); err != nil {
 		return fmt.Errorf("failed to add comment: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status: %s", resp.Status)
-	}
 
 	return nil
 }
@@ -1490,58 +1920,72 @@ func (c *TrelloClient) CreateDailySundownNotification(boardName string) error {
 		return fmt.Errorf("failed to find Sundown Notification list: %w", err)
 	}
 
-	// Delete all existing cards from the list
-	if err := c.DeleteAllCardsFromList(listID); err != nil {
-		return fmt.Errorf("failed to clear existing cards: %w", err)
-	}
-
-	// Get todays sundown time
-	sundownTime, err := GetTodaySundownTime()
+	// Get todays sundown time; a run just after midnight still belongs to yesterday
+	today := currentLogicalDay()
+	sundownTime, err := GetTodaySundownTime(today)
 	if err != nil {
 		return fmt.Errorf("failed to get sundown time: %w", err)
 	}
 
-	// Create todays card
-	today := time.Now()
 	cardTitle := fmt.Sprintf("Sundown Notification - %s", today.Format("Monday, January 2, 2006"))
 
-	// Create the card
-	if err := c.CreateCard(listID, cardTitle, "", ""); err != nil {
-		return fmt.Errorf("failed to create sundown card: %w", err)
+	// Clear out previous days' cards, but keep today's if this is a rerun
+	existingCards, err := c.GetCardsInList(listID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing cards: %w", err)
 	}
 
-	// Find the card we just created to add a comment
-	cards, err := c.GetCardsInList(listID)
-	if err != nil {
-		return fmt.Errorf("failed to get cards to find new card: %w", err)
+	var todayCard *Card
+	for i, card := range existingCards {
+		if card.Name == cardTitle && todayCard == nil {
+			todayCard = &existingCards[i]
+			continue
+		}
+		if skipNoAuto(&existingCards[i]) {
+			continue
+		}
+		fmt.Printf("Deleting card: %s\n", card.Name)
+		if err := c.DeleteCard(card.ID); err != nil {
+			return fmt.Errorf("failed to clear existing card %s: %w", card.Name, err)
+		}
 	}
 
-	if len(cards) == 0 {
-		return fmt.Errorf("no cards found after creation")
+	if todayCard == nil {
+		// Create todays card
+		todayCard, err = c.CreateCard(listID, cardTitle, "", "")
+		if err != nil {
+			return fmt.Errorf("failed to create sundown card: %w", err)
+		}
+	} else {
+		fmt.Println("Today's sundown card already exists, updating it")
 	}
 
-	// Use the first (and should be only) card
-	newCard := cards[0]
+	templates, err := LoadCardTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to load card templates: %w", err)
+	}
 
-	// Add comment with mention and sundown information
-	comment := fmt.Sprintf("@nalani_farnsworth Sundown today (%s) is at %s 🌅",
-		today.Format("Monday, January 2, 2006"),
-		sundownTime)
+	// Add comment with mention and sundown information (updated in place on reruns)
+	comment := renderTemplate(templates.SundownComment, map[string]string{
+		"date": today.Format("Monday, January 2, 2006"),
+		"time": sundownTime,
+	})
+	comment = c.checkMentions(todayCard.IDBoard, comment)
 
-	if err := c.AddCommentToCard(newCard.ID, comment); err != nil {
+	if err := c.UpsertComment(todayCard.ID, "sundown", comment); err != nil {
 		return fmt.Errorf("failed to add comment to sundown card: %w", err)
 	}
 
-	fmt.Printf("✅ Created sundown notification card for %s\n", today.Format("January 2, 2006"))
+	fmt.Printf("%s Created sundown notification card for %s\n", iconSuccess, today.Format("January 2, 2006"))
 	fmt.Printf("   Sundown time: %s\n", sundownTime)
-	fmt.Printf("   Notified: @nalani_farnsworth\n")
+	fmt.Printf("   Comment: %s\n", comment)
 
 	return nil
 }
 
 // ExportMoodleAssignments exports all Moodle assignments to a JSON file
 func (c *TrelloClient) ExportMoodleAssignments(moodleClient *MoodleClient, endDate time.Time) error {
-	assignments, courseNames, err := moodleClient.GetUpcomingAssignments(endDate)
+	assignments, courseNames, err := moodleClient.GetUpcomingAssignments(pastDueSince(time.Now(), 0), endDate)
 	if err != nil {
 		return fmt.Errorf("failed to get Moodle assignments: %w", err)
 	}
@@ -1599,7 +2043,7 @@ func (c *TrelloClient) ExportMoodleAssignments(moodleClient *MoodleClient, endDa
 		return fmt.Errorf("failed to write JSON file: %w", err)
 	}
 
-	fmt.Printf("✅ Exported %d Moodle assignments to %s\n", len(assignments), filename)
+	fmt.Printf("%s Exported %d Moodle assignments to %s\n", iconSuccess, len(assignments), filename)
 	return nil
 }
 
@@ -1692,6 +2136,6 @@ func (c *TrelloClient) ExportCanvasAssignments(canvasClient *CanvasClient, userI
 		return fmt.Errorf("failed to write JSON file: %w", err)
 	}
 
-	fmt.Printf("✅ Exported %d Canvas assignments to %s\n", len(allAssignments), filename)
+	fmt.Printf("%s Exported %d Canvas assignments to %s\n", iconSuccess, len(allAssignments), filename)
 	return nil
 }
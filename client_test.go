@@ -0,0 +1,241 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCardPatchValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    CardPatch
+		expected map[string]string
+	}{
+		{
+			name:     "empty patch",
+			patch:    CardPatch{},
+			expected: map[string]string{},
+		},
+		{
+			name:     "due and complete",
+			patch:    CardPatch{Due: stringPtr("2025-09-20T18:00:00.000Z"), DueComplete: boolPtr(false)},
+			expected: map[string]string{"due": "2025-09-20T18:00:00.000Z", "dueComplete": "false"},
+		},
+		{
+			name:     "clear due date",
+			patch:    CardPatch{Due: stringPtr("")},
+			expected: map[string]string{"due": ""},
+		},
+		{
+			name:     "move and archive",
+			patch:    CardPatch{IDList: stringPtr("list1"), Pos: stringPtr("top"), Closed: boolPtr(true)},
+			expected: map[string]string{"idList": "list1", "pos": "top", "closed": "true"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values := test.patch.values()
+			if len(values) != len(test.expected) {
+				t.Fatalf("got %d fields (%v), want %d", len(values), values, len(test.expected))
+			}
+			for field, want := range test.expected {
+				if _, ok := values[field]; !ok {
+					t.Errorf("missing field %s", field)
+					continue
+				}
+				if got := values.Get(field); got != want {
+					t.Errorf("%s = %q, want %q", field, got, want)
+				}
+			}
+		})
+	}
+}
+
+// recordingServer captures the last request Trello would have received
+func recordingServer(t *testing.T, response string) (*httptest.Server, *http.Request, *url.Values) {
+	t.Helper()
+	var received http.Request
+	var form url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = *r
+		form, _ = url.ParseQuery(string(body))
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &received, &form
+}
+
+func TestCreateCardSendsFieldsInBody(t *testing.T) {
+	server, received, form := recordingServer(t, `{"id":"card1","name":"created","shortUrl":"https://trello.com/c/abc"}`)
+	client := NewTrelloClient("key", "token")
+	client.BaseURL = server.URL
+
+	name := "🧬 Biology – Unit 3 “Cells” & <Mitosis> ✅"
+	desc := strings.Repeat("Ünïcödé 🔬🧪🌅 text with & = ? # % characters\n", 200)
+
+	card, err := client.CreateCard("list1", name, desc, "2025-09-20T18:00:00.000Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if card.ID != "card1" || card.ShortURL != "https://trello.com/c/abc" {
+		t.Errorf("unexpected card returned: %+v", card)
+	}
+	if received.Method != "POST" {
+		t.Errorf("method = %s, want POST", received.Method)
+	}
+	if !strings.HasPrefix(received.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		t.Errorf("unexpected content type %q", received.Header.Get("Content-Type"))
+	}
+	if q := received.URL.Query(); q.Get("key") != "key" || q.Get("token") != "token" {
+		t.Errorf("credentials missing from query: %s", received.URL.RawQuery)
+	}
+	if received.URL.Query().Has("desc") || received.URL.Query().Has("name") {
+		t.Errorf("card fields leaked into the query string")
+	}
+	if form.Get("name") != name {
+		t.Errorf("name = %q, want %q", form.Get("name"), name)
+	}
+	if form.Get("desc") != desc {
+		t.Errorf("description did not round-trip (%d bytes sent)", len(form.Get("desc")))
+	}
+	if form.Get("idList") != "list1" {
+		t.Errorf("idList = %q, want list1", form.Get("idList"))
+	}
+}
+
+func TestUpdateCardFieldsSendsOnlySetFields(t *testing.T) {
+	server, received, form := recordingServer(t, `{}`)
+	client := NewTrelloClient("key", "token")
+	client.BaseURL = server.URL
+
+	desc := strings.Repeat("🌅", 5000)
+	if err := client.UpdateCardFields("card1", CardPatch{Desc: &desc}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received.Method != "PUT" || received.URL.Path != "/cards/card1" {
+		t.Errorf("unexpected request %s %s", received.Method, received.URL.Path)
+	}
+	if len(*form) != 1 || form.Get("desc") != desc {
+		t.Errorf("expected only desc in body, got %d fields", len(*form))
+	}
+}
+
+func TestSendFormReportsErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid value for desc", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewTrelloClient("key", "token")
+	client.BaseURL = server.URL
+
+	err := client.AddCommentToCard("card1", "hello")
+	if err == nil || !strings.Contains(err.Error(), "invalid value for desc") {
+		t.Errorf("expected error with response body, got %v", err)
+	}
+}
+
+func TestFetchCacheDataKeepsGoingPastBoardFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/members/me/boards":
+			w.Write([]byte(`[{"id": "b1", "name": "Makai School"}, {"id": "b2", "name": "Mac"}]`))
+		case "/boards/b1/lists":
+			w.Write([]byte(`[{"id": "l1", "name": "Daily", "idBoard": "b1"}]`))
+		case "/boards/b1/cards":
+			w.Write([]byte(`[{"id": "c1", "name": "Read", "idList": "l1", "idBoard": "b1"}]`))
+		case "/boards/b2/lists":
+			http.Error(w, "board unavailable", http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	previous := &CachedData{
+		Lists: []List{{ID: "old-l2", Name: "Sprint", BoardID: "b2"}, {ID: "old-l1", Name: "Daily", BoardID: "b1"}},
+		Cards: []Card{{ID: "old-c2", Name: "AK-1: Old task", IDBoard: "b2"}},
+	}
+
+	cache, err := client.fetchCacheData(previous)
+	if err != nil {
+		t.Fatalf("fetchCacheData() error = %v", err)
+	}
+
+	if len(cache.BoardErrors) != 1 || cache.BoardErrors[0].BoardName != "Mac" {
+		t.Fatalf("BoardErrors = %+v, want one error for Mac", cache.BoardErrors)
+	}
+
+	var listIDs []string
+	for _, list := range cache.Lists {
+		listIDs = append(listIDs, list.ID)
+	}
+	if strings.Join(listIDs, ",") != "l1,old-l2" {
+		t.Errorf("lists = %v, want fresh l1 plus carried-over old-l2", listIDs)
+	}
+	if len(cache.Cards) != 2 {
+		t.Errorf("expected fresh and carried-over cards, got %+v", cache.Cards)
+	}
+}
+
+func TestFetchCacheDataFailsWhenEveryBoardFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/members/me/boards" {
+			w.Write([]byte(`[{"id": "b1", "name": "Makai School"}]`))
+			return
+		}
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	if _, err := client.fetchCacheData(&CachedData{}); err == nil {
+		t.Error("expected an error when no board refreshes")
+	}
+}
+
+func TestReplaceBoardData(t *testing.T) {
+	newCache := func() *CachedData {
+		return &CachedData{
+			Lists: []List{{ID: "l1", BoardID: "b1"}, {ID: "l2", BoardID: "b2"}},
+			Cards: []Card{{ID: "c1", IDBoard: "b1"}, {ID: "c2", IDBoard: "b2"}},
+			BoardErrors: []BoardCacheError{
+				{BoardID: "b1", BoardName: "Makai School", Error: "timeout"},
+				{BoardID: "b2", BoardName: "Mac", Error: "timeout"},
+			},
+		}
+	}
+
+	cache := newCache()
+	replaceBoardData(cache, "b1", []List{{ID: "l1"}, {ID: "l3", BoardID: "b1"}}, nil, false)
+	if len(cache.Lists) != 3 || cache.Lists[0].ID != "l2" {
+		t.Errorf("lists = %+v, want b2's list kept plus two fresh b1 lists", cache.Lists)
+	}
+	if len(cache.Cards) != 2 {
+		t.Errorf("cards should be untouched without replaceCards, got %+v", cache.Cards)
+	}
+	if len(cache.BoardErrors) != 1 || cache.BoardErrors[0].BoardID != "b2" {
+		t.Errorf("BoardErrors = %+v, want only b2's error left", cache.BoardErrors)
+	}
+
+	cache = newCache()
+	replaceBoardData(cache, "b1", nil, []Card{{ID: "c3", IDBoard: "b1"}}, true)
+	var cardIDs []string
+	for _, card := range cache.Cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+	if strings.Join(cardIDs, ",") != "c2,c3" {
+		t.Errorf("cards = %v, want c2,c3", cardIDs)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// boardNameTaken reports whether a board already has exactly this name
+func boardNameTaken(boards []Board, name string) bool {
+	for _, board := range boards {
+		if normalizeString(board.Name) == normalizeString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneBoardFields asks Trello to copy a board with its lists, cards, and
+// labels into a new private board in the same workspace
+func cloneBoardFields(src Board, name string) url.Values {
+	fields := url.Values{}
+	fields.Set("name", name)
+	fields.Set("idBoardSource", src.ID)
+	fields.Set("keepFromSource", "cards")
+	fields.Set("prefs_permissionLevel", "private")
+	if src.IDOrganization != "" {
+		fields.Set("idOrganization", src.IDOrganization)
+	}
+	return fields
+}
+
+// copyBoard creates a copy of a board under a new name
+func (c *TrelloClient) copyBoard(src Board, name string) (*Board, error) {
+	body, err := c.sendForm("POST", "/boards", cloneBoardFields(src, name))
+	if err != nil {
+		return nil, err
+	}
+
+	var board Board
+	if err := json.Unmarshal(body, &board); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal copied board: %w", err)
+	}
+	return &board, nil
+}
+
+// CloneBoard copies a board's lists, cards, and labels to a new board, so
+// risky changes can be tried on the copy first. The copy keeps each card's
+// sync metadata, so syncs update its cards rather than adding duplicates.
+func (c *TrelloClient) CloneBoard(srcName, dstName string) error {
+	if dstName == "" {
+		return fmt.Errorf("no name given for the new board")
+	}
+
+	boards, err := c.GetBoards()
+	if err != nil {
+		return fmt.Errorf("failed to get boards: %w", err)
+	}
+	src, err := findBoardByName(boards, srcName)
+	if err != nil {
+		return err
+	}
+	if boardNameTaken(boards, dstName) {
+		return fmt.Errorf("a board named '%s' already exists; pick another name or delete it first", dstName)
+	}
+
+	fmt.Printf("Copying %s to %s...\n", src.Name, dstName)
+	board, err := c.copyBoard(*src, dstName)
+	if err != nil {
+		return fmt.Errorf("failed to copy board %s: %w", src.Name, err)
+	}
+
+	// Trello copies in one request; count what arrived to confirm it
+	lists, err := c.GetListsInBoard(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get lists for board %s: %w", board.Name, err)
+	}
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get cards for board %s: %w", board.Name, err)
+	}
+	labels, err := c.GetBoardLabels(board.ID)
+	if err != nil {
+		return err
+	}
+
+	// Cache the new board so --target-board finds it straight away
+	if err := c.RefreshBoardCache(board.Name, true); err != nil {
+		fmt.Printf("Warning: failed to cache %s (run --refresh): %v\n", board.Name, err)
+	}
+
+	fmt.Printf("%s Cloned %s to %s: %s, %s, %s\n", iconSuccess, src.Name, board.Name,
+		plural(len(lists), "list"), plural(len(cards), "card"), plural(len(labels), "label"))
+	if board.URL != "" {
+		fmt.Println(board.URL)
+	}
+	fmt.Printf("Point syncs at it with --target-board \"%s\", and other commands with --board \"%s\"\n", board.Name, board.Name)
+	return nil
+}
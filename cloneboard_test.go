@@ -0,0 +1,62 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestBoardNameTaken(t *testing.T) {
+	boards := []Board{{Name: "Makai School"}, {Name: "Mac"}}
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"makai school", true},
+		{"Makai School Sandbox", false},
+		{"Makai", false}, // a partial match is still free
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardNameTaken(boards, tt.name); got != tt.want {
+				t.Errorf("boardNameTaken(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyBoard(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/boards" {
+			t.Errorf("request = %s %s, want POST /boards", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		form, _ = url.ParseQuery(string(body))
+		w.Write([]byte(`{"id": "new", "name": "Makai School Sandbox"}`))
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	src := Board{ID: "src", Name: "Makai School", IDOrganization: "org"}
+	board, err := client.copyBoard(src, "Makai School Sandbox")
+	if err != nil || board.ID != "new" {
+		t.Fatalf("copyBoard() = %+v, %v", board, err)
+	}
+
+	want := map[string]string{
+		"name":                  "Makai School Sandbox",
+		"idBoardSource":         "src",
+		"keepFromSource":        "cards",
+		"idOrganization":        "org",
+		"prefs_permissionLevel": "private",
+	}
+	for field, value := range want {
+		if got := form.Get(field); got != value {
+			t.Errorf("%s = %q, want %q", field, got, value)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CardComment is a comment action on a card
+type CardComment struct {
+	ID   string `json:"id"`
+	Date string `json:"date"`
+	Data struct {
+		Text string `json:"text"`
+	} `json:"data"`
+	MemberCreator struct {
+		FullName string `json:"fullName"`
+	} `json:"memberCreator"`
+}
+
+// commentMarker tags comments posted by this tool so reruns can find them
+func commentMarker(key string) string {
+	return fmt.Sprintf("[trello-sync:%s]", key)
+}
+
+// withCommentMarker appends the marker for key on its own line
+func withCommentMarker(text, key string) string {
+	return text + "\n\n" + commentMarker(key)
+}
+
+// findCommentByMarker returns the newest comment carrying the marker for key
+func findCommentByMarker(comments []CardComment, key string) *CardComment {
+	marker := commentMarker(key)
+	// Trello returns actions newest first
+	for i := range comments {
+		if strings.Contains(comments[i].Data.Text, marker) {
+			return &comments[i]
+		}
+	}
+	return nil
+}
+
+// GetCardComments returns a card's comments, newest first
+func (c *TrelloClient) GetCardComments(cardID string) ([]CardComment, error) {
+	endpoint := fmt.Sprintf("/cards/%s/actions?filter=commentCard", cardID)
+	body, err := c.makeRequest(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []CardComment
+	if err := json.Unmarshal(body, &comments); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// UpdateComment replaces the text of an existing comment
+func (c *TrelloClient) UpdateComment(cardID, commentID, text string) error {
+	fields := url.Values{}
+	fields.Set("text", text)
+
+	if _, err := c.sendForm("PUT", fmt.Sprintf("/cards/%s/actions/%s/comments", cardID, commentID), fields); err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return nil
+}
+
+// PostComment adds a comment to a card and returns it
+func (c *TrelloClient) PostComment(cardID, text string) (*CardComment, error) {
+	fields := url.Values{}
+	fields.Set("text", text)
+
+	body, err := c.sendForm("POST", fmt.Sprintf("/cards/%s/actions/comments", cardID), fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	var comment CardComment
+	if err := json.Unmarshal(body, &comment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// DeleteComment removes a comment from a card
+func (c *TrelloClient) DeleteComment(cardID, commentID string) error {
+	if _, err := c.sendForm("DELETE", fmt.Sprintf("/cards/%s/actions/%s/comments", cardID, commentID), nil); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertComment posts a marked comment, or updates the tool's previous one
+// for the same key in place. Identical text is left alone.
+func (c *TrelloClient) UpsertComment(cardID, key, text string) error {
+	markedText := withCommentMarker(text, key)
+
+	comments, err := c.GetCardComments(cardID)
+	if err != nil {
+		return fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	existing := findCommentByMarker(comments, key)
+	if existing == nil {
+		return c.AddCommentToCard(cardID, markedText)
+	}
+
+	if existing.Data.Text == markedText {
+		return nil
+	}
+
+	return c.UpdateComment(cardID, existing.ID, markedText)
+}
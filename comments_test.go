@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestFindCommentByMarker(t *testing.T) {
+	comment := func(id, text string) CardComment {
+		var c CardComment
+		c.ID = id
+		c.Data.Text = text
+		return c
+	}
+
+	comments := []CardComment{
+		comment("3", "Just a human comment"),
+		comment("2", withCommentMarker("Sundown today is at 7:35 PM", "sundown")),
+		comment("1", withCommentMarker("Sundown today is at 7:36 PM", "sundown")),
+	}
+
+	if found := findCommentByMarker(comments, "sundown"); found == nil || found.ID != "2" {
+		t.Errorf("expected newest sundown comment (2), got %v", found)
+	}
+	if found := findCommentByMarker(comments, "canvas-locked"); found != nil {
+		t.Errorf("expected no canvas-locked comment, got %s", found.ID)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// friendlyLayout renders dates like "Fri, Oct 3 at 6:00 PM MDT"
+const friendlyLayout = "Mon, Jan 2 at 3:04 PM MST"
+
+// displayLocation is the timezone dates are shown in: DISPLAY_TIMEZONE when
+// set, otherwise the sunset cache's timezone (Mountain time by default).
+// Scheduled runs happen in UTC, so time.Local can't be trusted here.
+func displayLocation() *time.Location {
+	if name := os.Getenv("DISPLAY_TIMEZONE"); name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+		fmt.Printf("Warning: unknown DISPLAY_TIMEZONE '%s', using the default\n", name)
+	}
+	return cachedSunsetTimezone()
+}
+
+// friendlyTime formats a time for people, in loc
+func friendlyTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(friendlyLayout)
+}
+
+// friendlyDueLines renders RFC 3339 due and lock dates as a readable block to
+// place above the metadata, e.g. "\n\n📅 Due Fri, Oct 3 at 6:00 PM MDT".
+// Empty or unparseable dates are left out; the metadata keeps the raw values.
+func friendlyDueLines(due, lock string, loc *time.Location) string {
+	return friendlyDateLines("", due, lock, loc)
+}
+
+// friendlyDateLines is friendlyDueLines with an "🔓 Opens" line first for
+// work that becomes available on a set date
+func friendlyDateLines(opens, due, lock string, loc *time.Location) string {
+	var lines []string
+	if t, err := time.Parse(time.RFC3339, opens); err == nil {
+		lines = append(lines, "🔓 Opens "+friendlyTime(t, loc))
+	}
+	if t, err := time.Parse(time.RFC3339, due); err == nil {
+		lines = append(lines, "📅 Due "+friendlyTime(t, loc))
+	}
+	if t, err := time.Parse(time.RFC3339, lock); err == nil {
+		lines = append(lines, "🔒 Locks "+friendlyTime(t, loc))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n\n" + strings.Join(lines, "\n")
+}
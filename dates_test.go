@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFriendlyDueLines(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skip("no tz database")
+	}
+
+	tests := []struct {
+		name string
+		due  string
+		lock string
+		want string
+	}{
+		{"due only", "2025-10-04T00:00:00Z", "", "\n\n📅 Due Fri, Oct 3 at 6:00 PM MDT"},
+		{"due and lock", "2025-10-04T00:00:00Z", "2025-12-01T07:00:00Z", "\n\n📅 Due Fri, Oct 3 at 6:00 PM MDT\n🔒 Locks Mon, Dec 1 at 12:00 AM MST"},
+		{"no due date", "", "", ""},
+		{"unparseable", "next week", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := friendlyDueLines(tt.due, tt.lock, denver); got != tt.want {
+				t.Errorf("friendlyDueLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFriendlyDateLinesOpens(t *testing.T) {
+	got := friendlyDateLines("2025-10-01T14:00:00Z", "2025-10-04T00:00:00Z", "", time.UTC)
+	if want := "\n\n🔓 Opens Wed, Oct 1 at 2:00 PM UTC\n📅 Due Sat, Oct 4 at 12:00 AM UTC"; got != want {
+		t.Errorf("friendlyDateLines() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// defaultDayBoundaryHour makes runs before 4 AM count as the previous day,
+// so a delayed evening run doesn't skip a day
+const defaultDayBoundaryHour = 4
+
+// dayBoundaryHour reads DAY_BOUNDARY_HOUR (0-23); 0 turns the cutoff off
+func dayBoundaryHour() int {
+	value := os.Getenv("DAY_BOUNDARY_HOUR")
+	if value == "" {
+		return defaultDayBoundaryHour
+	}
+
+	hour, err := strconv.Atoi(value)
+	if err != nil || hour < 0 || hour > 23 {
+		fmt.Printf("Warning: invalid DAY_BOUNDARY_HOUR '%s' (want 0-23), using %d\n", value, defaultDayBoundaryHour)
+		return defaultDayBoundaryHour
+	}
+	return hour
+}
+
+// logicalDay returns midnight of the day now belongs to. Times before
+// boundaryHour belong to the previous day.
+func logicalDay(now time.Time, boundaryHour int) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if now.Hour() < boundaryHour {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// currentLogicalDay is today for the daily reset and sundown notification
+func currentLogicalDay() time.Time {
+	now := time.Now()
+	boundaryHour := dayBoundaryHour()
+	day := logicalDay(now, boundaryHour)
+	if day.Day() != now.Day() {
+		fmt.Printf("Running before %d AM, so treating today as %s\n", boundaryHour, day.Format("Monday, January 2"))
+	}
+	return day
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLogicalDay(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		boundary int
+		wantDay  int
+	}{
+		{"evening run", at(3, 20, 0), 4, 3},
+		{"delayed past midnight", at(4, 0, 30), 4, 3},
+		{"just before cutoff", at(4, 3, 59), 4, 3},
+		{"at cutoff", at(4, 4, 0), 4, 4},
+		{"cutoff disabled", at(4, 0, 30), 0, 4},
+		{"month boundary", time.Date(2025, 11, 1, 1, 0, 0, 0, time.UTC), 4, 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := logicalDay(tt.now, tt.boundary)
+			if got.Day() != tt.wantDay || got.Hour() != 0 || got.Minute() != 0 {
+				t.Errorf("logicalDay(%v, %d) = %v, want midnight on day %d", tt.now, tt.boundary, got, tt.wantDay)
+			}
+		})
+	}
+}
+
+func TestDayBoundaryHour(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"", defaultDayBoundaryHour},
+		{"0", 0},
+		{"5", 5},
+		{"24", defaultDayBoundaryHour},
+		{"late", defaultDayBoundaryHour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("DAY_BOUNDARY_HOUR", tt.value)
+			if got := dayBoundaryHour(); got != tt.want {
+				t.Errorf("dayBoundaryHour() with %q = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultFiles are the starter config files written by --init
+//
+//go:embed defaults
+var defaultFiles embed.FS
+
+// initFiles maps each embedded default to the name it is written as
+var initFiles = map[string]string{
+	"env.example":      ".env",
+	"subjects.json":    "subjects.json",
+	"cards.json":       "cards.json",
+	"grade_scale.json": "grade_scale.json",
+}
+
+// CardTemplates holds the text used for generated cards. Placeholders in
+// {braces} are filled in by renderTemplate.
+type CardTemplates struct {
+	WeeklyCardName        string `json:"weeklyCardName"`
+	WeeklyCardDescription string `json:"weeklyCardDescription"`
+	SundownComment        string `json:"sundownComment"`
+}
+
+// configDir returns TRELLO_CONFIG_DIR, or trello-daily-reset under the
+// OS config directory (~/.config, ~/Library/Application Support, %AppData%)
+func configDir() (string, error) {
+	if dir := os.Getenv("TRELLO_CONFIG_DIR"); dir != "" {
+		return expandHome(dir), nil
+	}
+
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+	return filepath.Join(base, "trello-daily-reset"), nil
+}
+
+// findConfigFile returns the path to a config file, preferring the working
+// directory and falling back to the config directory. The working directory
+// path is returned when neither exists so errors name the familiar location.
+func findConfigFile(name string) string {
+	if _, err := os.Stat(name); err == nil {
+		return name
+	}
+
+	if dir, err := configDir(); err == nil {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	return name
+}
+
+// InitConfig writes the embedded defaults into dir, leaving existing files alone
+func InitConfig(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	return fs.WalkDir(defaultFiles, "defaults", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		target, ok := initFiles[entry.Name()]
+		if !ok {
+			return nil
+		}
+		targetPath := filepath.Join(dir, target)
+
+		if _, err := os.Stat(targetPath); err == nil {
+			fmt.Printf("Skipping %s (already exists)\n", targetPath)
+			return nil
+		}
+
+		data, err := defaultFiles.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read embedded %s: %w", entry.Name(), err)
+		}
+
+		// .env holds credentials, so keep it private
+		perm := os.FileMode(0644)
+		if target == ".env" {
+			perm = 0600
+		}
+		if err := os.WriteFile(targetPath, data, perm); err != nil {
+			return fmt.Errorf("failed to write %s: %w", targetPath, err)
+		}
+
+		fmt.Printf("%s Wrote %s\n", iconSuccess, targetPath)
+		return nil
+	})
+}
+
+// LoadCardTemplates reads cards.json, filling any blank template from the
+// embedded defaults
+func LoadCardTemplates() (*CardTemplates, error) {
+	var templates CardTemplates
+	defaults, err := defaultFiles.ReadFile("defaults/cards.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded cards.json: %w", err)
+	}
+	if err := json.Unmarshal(defaults, &templates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal default card templates: %w", err)
+	}
+
+	data, err := os.ReadFile(findConfigFile("cards.json"))
+	if err != nil {
+		return &templates, nil // No override, use the defaults
+	}
+
+	var custom CardTemplates
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cards.json: %w", err)
+	}
+	if custom.WeeklyCardName != "" {
+		templates.WeeklyCardName = custom.WeeklyCardName
+	}
+	if custom.WeeklyCardDescription != "" {
+		templates.WeeklyCardDescription = custom.WeeklyCardDescription
+	}
+	if custom.SundownComment != "" {
+		templates.SundownComment = custom.SundownComment
+	}
+
+	return &templates, nil
+}
+
+// renderTemplate replaces {key} placeholders with their values
+func renderTemplate(template string, values map[string]string) string {
+	var pairs []string
+	for key, value := range values {
+		pairs = append(pairs, "{"+key+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
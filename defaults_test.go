@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInitConfig(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "config")
+
+	if err := InitConfig(dir); err != nil {
+		t.Fatalf("InitConfig() error = %v", err)
+	}
+
+	for _, name := range []string{".env", "subjects.json", "cards.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+		}
+	}
+
+	var subjects SubjectsConfig
+	data, err := os.ReadFile(filepath.Join(dir, "subjects.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &subjects); err != nil || len(subjects.Quarters) == 0 {
+		t.Errorf("default subjects.json did not parse into quarters: %v", err)
+	}
+
+	// A second run must not overwrite edited files
+	envPath := filepath.Join(dir, ".env")
+	if err := os.WriteFile(envPath, []byte("TRELLO_API_KEY=mine\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := InitConfig(dir); err != nil {
+		t.Fatalf("second InitConfig() error = %v", err)
+	}
+	data, err = os.ReadFile(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "TRELLO_API_KEY=mine\n" {
+		t.Errorf("InitConfig overwrote existing .env: %q", data)
+	}
+}
+
+func TestLoadCardTemplates(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TRELLO_CONFIG_DIR", dir)
+
+	templates, err := LoadCardTemplates()
+	if err != nil {
+		t.Fatalf("LoadCardTemplates() error = %v", err)
+	}
+	name := renderTemplate(templates.WeeklyCardName, map[string]string{"subject": "Math", "week": "3", "range": "September 8–12"})
+	if name != "Math Week 3: September 8–12" {
+		t.Errorf("default weekly card name = %q", name)
+	}
+
+	custom := `{"weeklyCardName": "{subject} - Week {week}"}`
+	if err := os.WriteFile(filepath.Join(dir, "cards.json"), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+	templates, err = LoadCardTemplates()
+	if err != nil {
+		t.Fatalf("LoadCardTemplates() error = %v", err)
+	}
+	if templates.WeeklyCardName != "{subject} - Week {week}" {
+		t.Errorf("custom weekly card name not used: %q", templates.WeeklyCardName)
+	}
+	if templates.SundownComment == "" {
+		t.Error("blank sundown comment should fall back to the default")
+	}
+}
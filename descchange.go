@@ -0,0 +1,151 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// maxDiffLines caps how many changed lines a description diff comment shows
+const maxDiffLines = 40
+
+var (
+	descriptionHashRegex = regexp.MustCompile(`(?m)^Description Hash: ([0-9a-f]+)$`)
+	blockTagRegex        = regexp.MustCompile(`(?i)<br\s*/?>|</?(p|div|li|ul|ol|h[1-6]|tr|table|blockquote)[^>]*>`)
+	anyTagRegex          = regexp.MustCompile(`<[^>]*>`)
+)
+
+// descriptionHash fingerprints an assignment's source description
+func descriptionHash(body string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(cleanText(body))))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// descriptionHashLine is the metadata line recording the source description's hash
+func descriptionHashLine(body string) string {
+	return "\nDescription Hash: " + descriptionHash(body)
+}
+
+// storedDescriptionHash reads the hash recorded on a card, or "" for cards
+// synced before hashes were recorded
+func storedDescriptionHash(desc string) string {
+	if match := descriptionHashRegex.FindStringSubmatch(desc); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// descriptionBody returns the assignment text of a synced card's description,
+// without the metadata block, due date lines, or truncation note
+func descriptionBody(desc string) string {
+	if i := strings.LastIndex(desc, "\n\n---\n"); i >= 0 {
+		desc = desc[:i]
+	}
+	for {
+		i := strings.LastIndex(desc, "\n\n")
+		if i < 0 {
+			break
+		}
+		last := desc[i+2:]
+		if !strings.HasPrefix(last, "🔓 Opens ") && !strings.HasPrefix(last, "📅 Due ") && !strings.HasPrefix(last, "🔒 Locks ") && !strings.HasPrefix(last, "… (description truncated") {
+			break
+		}
+		desc = desc[:i]
+	}
+	return desc
+}
+
+// readableLines turns an HTML or plain-text description into trimmed,
+// non-empty lines of text for diffing
+func readableLines(s string) []string {
+	s = blockTagRegex.ReplaceAllString(s, "\n")
+	s = html.UnescapeString(anyTagRegex.ReplaceAllString(s, ""))
+
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// lineDiff returns the removed ("- ") and added ("+ ") lines between two
+// texts, in order, using a longest-common-subsequence match
+func lineDiff(oldLines, newLines []string) []string {
+	// lcs[i][j] is the LCS length of oldLines[i:] and newLines[j:]
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			i++
+			j++
+		case i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, "- "+oldLines[i])
+			i++
+		default:
+			diff = append(diff, "+ "+newLines[j])
+			j++
+		}
+	}
+	return diff
+}
+
+// descriptionChangeComment shows what the teacher changed as a diff block.
+// It returns "" when the change doesn't affect the readable text (e.g. only
+// HTML markup changed).
+func descriptionChangeComment(source, oldBody, newBody string) string {
+	diff := lineDiff(readableLines(oldBody), readableLines(newBody))
+	if len(diff) == 0 {
+		return ""
+	}
+
+	more := ""
+	if len(diff) > maxDiffLines {
+		more = fmt.Sprintf("\n… and %d more changed line(s)", len(diff)-maxDiffLines)
+		diff = diff[:maxDiffLines]
+	}
+
+	return fmt.Sprintf("✏️ Teacher updated the assignment description in %s:\n\n```diff\n%s\n```%s",
+		source, strings.Join(diff, "\n"), more)
+}
+
+// noteDescriptionChange comments with a diff when the source description no
+// longer matches the hash recorded on the card. Cards without a recorded
+// hash just pick one up on this sync. Reports whether the source changed.
+func (c *TrelloClient) noteDescriptionChange(card *Card, newBody, source string) bool {
+	stored := storedDescriptionHash(card.Description)
+	if stored == "" || stored == descriptionHash(newBody) {
+		return false
+	}
+
+	fmt.Printf("  Assignment description changed for %s\n", card.Name)
+	comment := descriptionChangeComment(source, descriptionBody(card.Description), newBody)
+	if comment == "" {
+		return true
+	}
+	if err := c.AddCommentToCard(card.ID, comment); err != nil {
+		fmt.Printf("Warning: failed to comment on description change for %s: %v\n", card.Name, err)
+	}
+	return true
+}
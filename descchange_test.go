@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDescriptionBody(t *testing.T) {
+	metadata := "\n\n---\nCanvas Assignment ID: 1\nGrade: Not graded" + descriptionHashLine("Read chapter 4")
+
+	tests := []struct {
+		name string
+		desc string
+		want string
+	}{
+		{"plain", "Read chapter 4" + metadata, "Read chapter 4"},
+		{"due lines", "Read chapter 4" + friendlyDueLines("2025-10-04T00:00:00Z", "2025-10-05T00:00:00Z", time.UTC) + metadata, "Read chapter 4"},
+		{"opens line", "Read chapter 4" + friendlyDateLines("2025-10-01T00:00:00Z", "2025-10-04T00:00:00Z", "", time.UTC) + metadata, "Read chapter 4"},
+		{"truncated", "Read chap\n\n… (description truncated - [read the rest](https://x))" + metadata, "Read chap"},
+		{"body with a rule", "Part 1\n\n---\nPart 2" + metadata, "Part 1\n\n---\nPart 2"},
+		{"no metadata", "Just notes", "Just notes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := descriptionBody(tt.desc); got != tt.want {
+				t.Errorf("descriptionBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoredDescriptionHash(t *testing.T) {
+	desc := "Body\n\n---\nCanvas Assignment ID: 1" + descriptionHashLine("Body") + "\nLate Policy: -10% per day late"
+	if got := storedDescriptionHash(desc); got != descriptionHash("Body") {
+		t.Errorf("storedDescriptionHash() = %q, want %q", got, descriptionHash("Body"))
+	}
+	if got := storedDescriptionHash("Body\n\n---\nCanvas Assignment ID: 1"); got != "" {
+		t.Errorf("storedDescriptionHash() without a hash = %q, want empty", got)
+	}
+	if descriptionHash(" Body\n") != descriptionHash("Body") {
+		t.Errorf("descriptionHash() should ignore surrounding whitespace")
+	}
+}
+
+func TestReadableLines(t *testing.T) {
+	got := readableLines("<p>Answer   questions 1&ndash;5</p><ul><li>Show work</li><li><b>Due</b> Friday</li></ul>")
+	want := []string{"Answer questions 1–5", "Show work", "Due Friday"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("readableLines() = %q, want %q", got, want)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new []string
+		want     []string
+	}{
+		{"unchanged", []string{"a", "b"}, []string{"a", "b"}, nil},
+		{"changed line", []string{"a", "b", "c"}, []string{"a", "B", "c"}, []string{"- b", "+ B"}},
+		{"added at end", []string{"a"}, []string{"a", "b"}, []string{"+ b"}},
+		{"removed at start", []string{"a", "b"}, []string{"b"}, []string{"- a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lineDiff(tt.old, tt.new)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("lineDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescriptionChangeComment(t *testing.T) {
+	comment := descriptionChangeComment("Canvas", "<p>Answer questions 1-5</p>", "<p>Answer questions 1-8</p>")
+	want := "✏️ Teacher updated the assignment description in Canvas:\n\n```diff\n- Answer questions 1-5\n+ Answer questions 1-8\n```"
+	if comment != want {
+		t.Errorf("descriptionChangeComment() = %q, want %q", comment, want)
+	}
+
+	if got := descriptionChangeComment("Canvas", "<p>Same</p>", "<div>Same</div>"); got != "" {
+		t.Errorf("markup-only change should not comment, got %q", got)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// trelloMaxDescriptionLength is Trello's hard limit on card descriptions
+const trelloMaxDescriptionLength = 16384
+
+// trelloMaxCommentLength is Trello's hard limit on comments
+const trelloMaxCommentLength = 16384
+
+// descriptionNoteReserve is the room kept for the truncation note, so where
+// a description is cut doesn't depend on where the note links
+const descriptionNoteReserve = 200
+
+// descriptionOverflowKey marks the comments holding the text cut from a
+// card's description
+const descriptionOverflowKey = "description-overflow"
+
+// overflowCommentLength leaves room in each comment for its heading and
+// marker
+const overflowCommentLength = trelloMaxCommentLength - 200
+
+// splitCardDescription cleans an assignment body and cuts it so that it
+// fits in a card description with its metadata block, returning the part
+// that fits and the overflow, which is empty when nothing was cut
+func splitCardDescription(body, metadata string) (string, string) {
+	body = sanitizeDescription(cleanText(body))
+	if utf8.RuneCountInString(body+metadata) <= trelloMaxDescriptionLength {
+		return body, ""
+	}
+
+	budget := max(trelloMaxDescriptionLength-utf8.RuneCountInString(metadata)-descriptionNoteReserve, 0)
+	kept := truncateRunes(body, budget)
+	return strings.TrimRight(kept, " \n\t"), strings.TrimSpace(body[len(kept):])
+}
+
+// fitCardDescription joins an assignment body with its metadata block,
+// truncating the body (never the metadata) to stay under Trello's limit.
+// When truncated, the note left in its place links to the rest at link:
+// the overflow comment once it's posted, or the LMS page until then.
+func fitCardDescription(body, metadata, link string) (string, bool) {
+	kept, overflow := splitCardDescription(body, metadata)
+	if overflow == "" {
+		return kept + metadata, false
+	}
+
+	note := "\n\n… (description truncated)"
+	if link != "" {
+		linked := fmt.Sprintf("\n\n… (description truncated - [read the rest](%s))", link)
+		if utf8.RuneCountInString(linked) <= descriptionNoteReserve {
+			note = linked
+		}
+	}
+	return kept + note + metadata, true
+}
+
+// overflowComments splits the text cut from a description into comments
+// short enough for Trello, each headed so they read in order
+func overflowComments(overflow string) []string {
+	var parts []string
+	for overflow != "" {
+		part := truncateRunes(overflow, overflowCommentLength)
+		parts = append(parts, part)
+		overflow = overflow[len(part):]
+	}
+
+	comments := make([]string, len(parts))
+	for i, part := range parts {
+		heading := "📄 Description, continued:"
+		if len(parts) > 1 {
+			heading = fmt.Sprintf("📄 Description, continued (part %d of %d):", i+1, len(parts))
+		}
+		comments[i] = heading + "\n\n" + part
+	}
+	return comments
+}
+
+// overflowCommentKey is the marker key for one part of a description's
+// overflow; the first part, which the description links to, has the plain key
+func overflowCommentKey(part int) string {
+	if part == 0 {
+		return descriptionOverflowKey
+	}
+	return fmt.Sprintf("%s-%d", descriptionOverflowKey, part+1)
+}
+
+// overflowPartRegex finds the part number in the marker of a later part of
+// a description's overflow
+var overflowPartRegex = regexp.MustCompile(`\[trello-sync:` + descriptionOverflowKey + `-(\d+)\]`)
+
+// staleOverflowComments returns the overflow comments for parts past the
+// last of parts, left from when the description was longer
+func staleOverflowComments(comments []CardComment, parts int) []CardComment {
+	var stale []CardComment
+	for _, comment := range comments {
+		match := overflowPartRegex.FindStringSubmatch(comment.Data.Text)
+		if match == nil {
+			continue
+		}
+		if part, err := strconv.Atoi(match[1]); err == nil && part > parts {
+			stale = append(stale, comment)
+		}
+	}
+	return stale
+}
+
+// postDescriptionOverflow keeps the text cut from a card's description in
+// comments on the card, updating the ones from earlier syncs in place and
+// removing parts the text no longer needs, and returns a link to the first
+func (c *TrelloClient) postDescriptionOverflow(card *Card, overflow string) (string, error) {
+	cardURL := card.ShortURL
+	if cardURL == "" {
+		cardURL = card.URL
+	}
+	if cardURL == "" {
+		return "", fmt.Errorf("card %s has no URL to link to", card.Name)
+	}
+
+	comments, err := c.GetCardComments(card.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	parts := overflowComments(overflow)
+	firstID := ""
+	for i, text := range parts {
+		key := overflowCommentKey(i)
+		markedText := withCommentMarker(text, key)
+		existing := findCommentByMarker(comments, key)
+		switch {
+		case existing == nil:
+			posted, err := c.PostComment(card.ID, markedText)
+			if err != nil {
+				return "", err
+			}
+			existing = posted
+		case existing.Data.Text != markedText:
+			if err := c.UpdateComment(card.ID, existing.ID, markedText); err != nil {
+				return "", err
+			}
+		}
+		if i == 0 {
+			firstID = existing.ID
+		}
+	}
+
+	for _, stale := range staleOverflowComments(comments, len(parts)) {
+		if err := c.DeleteComment(card.ID, stale.ID); err != nil {
+			fmt.Printf("Warning: failed to remove an old part of the description of %s: %v\n", card.Name, err)
+		}
+	}
+
+	if firstID == "" {
+		return "", fmt.Errorf("Trello didn't return the overflow comment on %s", card.Name)
+	}
+	return cardURL + "#comment-" + firstID, nil
+}
+
+// linkDescriptionOverflow posts the text cut from a truncated description
+// as comments on the card and returns the description with its note
+// linking to them. If they can't be posted, desc is returned unchanged.
+func (c *TrelloClient) linkDescriptionOverflow(card *Card, body, metadata, desc string) string {
+	_, overflow := splitCardDescription(body, metadata)
+	link, err := c.postDescriptionOverflow(card, overflow)
+	if err != nil {
+		fmt.Printf("Warning: failed to keep the rest of the description of %s in a comment: %v\n", card.Name, err)
+		return desc
+	}
+	linked, _ := fitCardDescription(body, metadata, link)
+	return linked
+}
+
+// linkNewCardOverflow does the same for a card just created with desc,
+// which can only link to its comments once the card exists
+func (c *TrelloClient) linkNewCardOverflow(card *Card, body, metadata, desc string) {
+	linked := c.linkDescriptionOverflow(card, body, metadata, desc)
+	if linked == desc {
+		return
+	}
+	if err := c.UpdateCardFields(card.ID, CardPatch{Desc: &linked}); err != nil {
+		fmt.Printf("Warning: failed to link the rest of the description of %s: %v\n", card.Name, err)
+	}
+}
+
+// truncateRunes cuts s to at most n runes without splitting a character
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	count := 0
+	for i := range s {
+		if count == n {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
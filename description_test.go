@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFitCardDescription(t *testing.T) {
+	metadata := formatCanvasMetadata(CanvasAssignment{
+		ID:      12345,
+		DueAt:   "2025-09-20T18:00:00Z",
+		HTMLURL: "https://alpine.instructure.com/courses/123/assignments/12345",
+	}, "Biology", nil)
+
+	tests := []struct {
+		name          string
+		body          string
+		wantTruncated bool
+	}{
+		{"short body", "Read chapter 4", false},
+		{"huge html blob", strings.Repeat("<p>Lorem ipsum dolor sit amet</p>", 1000), true},
+		{"huge emoji body", strings.Repeat("🧬🔬 ", 10000), true},
+		{"exactly at limit", strings.Repeat("a", trelloMaxDescriptionLength-utf8.RuneCountInString(metadata)), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, truncated := fitCardDescription(test.body, metadata, "https://alpine.instructure.com/courses/123/assignments/12345")
+
+			if truncated != test.wantTruncated {
+				t.Errorf("truncated = %v, want %v", truncated, test.wantTruncated)
+			}
+			if n := utf8.RuneCountInString(result); n > trelloMaxDescriptionLength {
+				t.Errorf("description is %d characters, over the %d limit", n, trelloMaxDescriptionLength)
+			}
+			if !utf8.ValidString(result) {
+				t.Errorf("description is not valid UTF-8")
+			}
+			if !strings.HasSuffix(result, metadata) {
+				t.Errorf("metadata block did not survive")
+			}
+			if stripCanvasMetadata(result) == result {
+				t.Errorf("metadata separator missing")
+			}
+			if truncated && !strings.Contains(result, "[read the rest](https://alpine.instructure.com/courses/123/assignments/12345)") {
+				t.Errorf("expected link to the rest of the description")
+			}
+
+			kept, overflow := splitCardDescription(test.body, metadata)
+			if (overflow != "") != test.wantTruncated {
+				t.Errorf("overflow = %d characters, want truncated = %v", len(overflow), test.wantTruncated)
+			}
+			if full := sanitizeDescription(cleanText(test.body)); !strings.HasPrefix(full, kept) || !strings.HasSuffix(full, overflow) {
+				t.Errorf("kept and overflow don't add up to the body")
+			}
+		})
+	}
+}
+
+func TestOverflowComments(t *testing.T) {
+	if got := overflowComments("Part two of the lab"); len(got) != 1 || got[0] != "📄 Description, continued:\n\nPart two of the lab" {
+		t.Errorf("overflowComments() = %q", got)
+	}
+
+	long := strings.Repeat("🧬", overflowCommentLength*2+10)
+	got := overflowComments(long)
+	if len(got) != 3 {
+		t.Fatalf("overflowComments() made %d comments, want 3", len(got))
+	}
+	for i, comment := range got {
+		if n := utf8.RuneCountInString(withCommentMarker(comment, overflowCommentKey(i))); n > trelloMaxCommentLength {
+			t.Errorf("comment %d is %d characters, over the %d limit", i, n, trelloMaxCommentLength)
+		}
+	}
+	if !strings.HasPrefix(got[2], "📄 Description, continued (part 3 of 3):") {
+		t.Errorf("last comment = %q", got[2][:60])
+	}
+}
+
+func TestLinkDescriptionOverflow(t *testing.T) {
+	// The card's comments by ID, in the order they were posted
+	var ids []string
+	texts := make(map[string]string)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/cards/c1/actions/"), "/comments")
+		switch r.Method {
+		case "POST":
+			id = fmt.Sprintf("a%d", requests)
+			ids = append(ids, id)
+			texts[id] = r.FormValue("text")
+			fmt.Fprintf(w, `{"id": %q, "data": {"text": %q}}`, id, texts[id])
+		case "PUT":
+			texts[id] = r.FormValue("text")
+			w.Write([]byte(`{}`))
+		case "DELETE":
+			delete(texts, id)
+			w.Write([]byte(`{}`))
+		default:
+			var comments []string
+			for i := len(ids) - 1; i >= 0; i-- {
+				if text, ok := texts[ids[i]]; ok {
+					comments = append(comments, fmt.Sprintf(`{"id": %q, "data": {"text": %q}}`, ids[i], text))
+				}
+			}
+			w.Write([]byte("[" + strings.Join(comments, ",") + "]"))
+		}
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	card := &Card{ID: "c1", Name: "Lab Report", ShortURL: "https://trello.com/c/abc"}
+	metadata := "\n\n---\nPlugin: Khan\nItem ID: 7"
+	link := func(body string) string {
+		desc, _ := fitCardDescription(body, metadata, "https://example.com/7")
+		return client.linkDescriptionOverflow(card, body, metadata, desc)
+	}
+
+	// Long enough for the overflow to take two comments
+	body := strings.Repeat("Measure the plant every day. ", 1500)
+	linked := link(body)
+	if !strings.Contains(linked, "[read the rest](https://trello.com/c/abc#comment-a2)") {
+		t.Errorf("description doesn't link to the first overflow comment: %q", linked[len(linked)-200:])
+	}
+	if utf8.RuneCountInString(linked) > trelloMaxDescriptionLength {
+		t.Errorf("linked description is over the limit")
+	}
+	if len(texts) != 2 || !strings.Contains(texts["a2"], commentMarker(descriptionOverflowKey)) || !strings.Contains(texts["a3"], commentMarker(overflowCommentKey(1))) {
+		t.Errorf("comments = %d, want the overflow in two marked comments", len(texts))
+	}
+
+	// A rerun leaves the comments and the link as they are
+	requests = 0
+	if again := link(body); again != linked || requests != 1 {
+		t.Errorf("rerun made %d requests, changed link = %v", requests, again != linked)
+	}
+
+	// A shorter description updates the first part in place and drops the second
+	body = strings.Repeat("Measure the plant every day. ", 1000)
+	_, overflow := splitCardDescription(body, metadata)
+	if got := link(body); !strings.Contains(got, "#comment-a2)") {
+		t.Errorf("description doesn't link to the first overflow comment: %q", got[len(got)-200:])
+	}
+	if len(texts) != 1 || !strings.Contains(texts["a2"], overflow) {
+		t.Errorf("comments = %d, want the new overflow in the first", len(texts))
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// defaultDoneLists is used when DONE_LISTS isn't set
+const defaultDoneLists = "Done"
+
+// reclaimWindow is how far back the daily reset looks for dailies moved to
+// a done list, enough to cover a missed reset or two
+const reclaimWindow = 72 * time.Hour
+
+// doneListNames reads DONE_LISTS, the comma-separated lists where moving a
+// card counts as finishing it
+func doneListNames() []string {
+	value := os.Getenv("DONE_LISTS")
+	if value == "" {
+		value = defaultDoneLists
+	}
+	return splitList(value)
+}
+
+// doneListIDs returns the IDs of the done lists among lists
+func doneListIDs(lists []List) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range doneListNames() {
+		names[normalizeString(name)] = true
+	}
+	ids := make(map[string]bool)
+	for _, list := range lists {
+		if names[normalizeString(list.Name)] {
+			ids[list.ID] = true
+		}
+	}
+	return ids
+}
+
+// isCardDone reports whether a card is finished: its due date is checked
+// off or it was moved to a done list
+func isCardDone(card Card, doneLists map[string]bool) bool {
+	return card.DueComplete || doneLists[card.IDList]
+}
+
+// inferDoneCards marks the cards sitting in a done list as complete. Only
+// the copies in cards change; nothing is written back to Trello.
+func inferDoneCards(cards []Card, doneLists map[string]bool) []Card {
+	for i := range cards {
+		cards[i].DueComplete = isCardDone(cards[i], doneLists)
+	}
+	return cards
+}
+
+// movedToDone returns the IDs of cards the actions show moving from a list
+// straight into a done list
+func movedToDone(actions []BoardAction, fromListID string, doneLists map[string]bool) map[string]bool {
+	moved := make(map[string]bool)
+	for _, action := range actions {
+		old := action.Data.Old.IDList
+		if old != nil && *old == fromListID && doneLists[action.Data.Card.IDList] {
+			moved[action.Data.Card.ID] = true
+		}
+	}
+	return moved
+}
+
+// boardDoneLists looks up a board's done lists in the cache. When that
+// fails only checked-off due dates count as done.
+func (c *TrelloClient) boardDoneLists(boardName string) map[string]bool {
+	cache, err := c.LoadCache()
+	if err != nil {
+		fmt.Printf("Warning: can't find the done lists: %v\n", err)
+		return nil
+	}
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		fmt.Printf("Warning: can't find the done lists: %v\n", err)
+		return nil
+	}
+	var lists []List
+	for _, list := range cache.Lists {
+		if list.BoardID == board.ID {
+			lists = append(lists, list)
+		}
+	}
+	return doneListIDs(lists)
+}
+
+// reclaimDoneDailies moves daily cards that were finished by dragging them
+// to a done list back into the daily list, so the reset picks them up again
+func (c *TrelloClient) reclaimDoneDailies(boardName, dailyListID string) (int, error) {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load cache: %w", err)
+	}
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return 0, err
+	}
+	doneLists := doneListIDs(cache.Lists)
+	if len(doneLists) == 0 {
+		return 0, nil
+	}
+
+	actions, err := c.GetBoardActions(board.ID, "updateCard", time.Now().Add(-reclaimWindow))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get board activity: %w", err)
+	}
+	moved := movedToDone(actions, dailyListID, doneLists)
+	if len(moved) == 0 {
+		return 0, nil
+	}
+
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get board cards: %w", err)
+	}
+	reclaimed := 0
+	for _, card := range cards {
+		// Cards moved on again since (or archived) are left alone
+		if !moved[card.ID] || !doneLists[card.IDList] || card.Closed || isNoAuto(card) {
+			continue
+		}
+		fmt.Printf("Moving %s back from the done list\n", card.Name)
+		if err := c.MoveCardToList(card.ID, dailyListID); err != nil {
+			fmt.Printf("Warning: failed to move %s back to the daily list: %v\n", card.Name, err)
+			continue
+		}
+		reclaimed++
+	}
+	return reclaimed, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+func TestDoneListIDs(t *testing.T) {
+	lists := []List{{ID: "d", Name: "Daily"}, {ID: "done", Name: "Done"}, {ID: "fin", Name: " Finished "}}
+
+	tests := []struct {
+		name string
+		env  string
+		want []string
+	}{
+		{"default", "", []string{"done"}},
+		{"configured", "done, finished", []string{"done", "fin"}},
+		{"no match", "Complete", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DONE_LISTS", tt.env)
+			got := doneListIDs(lists)
+			if len(got) != len(tt.want) {
+				t.Fatalf("doneListIDs() = %v, want %v", got, tt.want)
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("doneListIDs() = %v, missing %s", got, id)
+				}
+			}
+		})
+	}
+}
+
+func TestInferDoneCards(t *testing.T) {
+	doneLists := map[string]bool{"done": true}
+	cards := inferDoneCards([]Card{
+		{ID: "1", IDList: "weekly"},
+		{ID: "2", IDList: "weekly", DueComplete: true},
+		{ID: "3", IDList: "done"},
+	}, doneLists)
+
+	want := []bool{false, true, true}
+	for i, card := range cards {
+		if card.DueComplete != want[i] {
+			t.Errorf("card %s DueComplete = %t, want %t", card.ID, card.DueComplete, want[i])
+		}
+	}
+}
+
+func TestMovedToDone(t *testing.T) {
+	daily, weekly := "daily", "weekly"
+	action := func(cardID, to string, from *string) BoardAction {
+		var a BoardAction
+		a.Data.Card.ID = cardID
+		a.Data.Card.IDList = to
+		a.Data.Old.IDList = from
+		return a
+	}
+	actions := []BoardAction{
+		action("1", "done", &daily),
+		action("2", "done", &weekly),
+		action("3", "weekly", &daily),
+		action("4", "done", nil),
+	}
+
+	got := movedToDone(actions, "daily", map[string]bool{"done": true})
+	if len(got) != 1 || !got["1"] {
+		t.Errorf("movedToDone() = %v, want only card 1", got)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// trelloDueLayout is the layout sync code uses for due dates sent to Trello
+const trelloDueLayout = "2006-01-02T15:04:05.000Z"
+
+// dueDateMoved reports whether an LMS due date differs from the card's
+// current one. Cards without a due date, or LMS dates that were cleared,
+// don't count as moves.
+func dueDateMoved(card *Card, newDue string) (time.Time, time.Time, bool) {
+	if card.Due == nil || newDue == "" {
+		return time.Time{}, time.Time{}, false
+	}
+
+	parsed, err := time.Parse(trelloDueLayout, newDue)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	// Trello stores milliseconds, so ignore sub-minute noise
+	diff := parsed.Sub(*card.Due)
+	if diff < time.Minute && diff > -time.Minute {
+		return time.Time{}, time.Time{}, false
+	}
+	return *card.Due, parsed, true
+}
+
+// dueChangeComment describes a due date move for a card comment
+func dueChangeComment(source string, oldDue, newDue time.Time, mentions []string, loc *time.Location) string {
+	text := fmt.Sprintf("📅 Teacher moved the due date in %s from %s to %s",
+		source, friendlyTime(oldDue, loc), friendlyTime(newDue, loc))
+	if len(mentions) > 0 {
+		text = strings.Join(mentions, " ") + " " + text
+	}
+	return text
+}
+
+// mentionsFromEnv parses a comma-separated list of Trello usernames,
+// adding the @ where it's missing
+func mentionsFromEnv(key string) []string {
+	var mentions []string
+	for _, name := range splitList(os.Getenv(key)) {
+		if !strings.HasPrefix(name, "@") {
+			name = "@" + name
+		}
+		mentions = append(mentions, name)
+	}
+	return mentions
+}
+
+// noteDueDateMove comments on a card when the LMS due date moved. Anyone in
+// DUE_CHANGE_MENTIONS who is on the board is @mentioned so Trello notifies them.
+func (c *TrelloClient) noteDueDateMove(card *Card, newDue, source string) {
+	oldDue, movedTo, moved := dueDateMoved(card, newDue)
+	if !moved {
+		return
+	}
+
+	comment := c.checkMentions(card.IDBoard, dueChangeComment(source, oldDue, movedTo, mentionsFromEnv("DUE_CHANGE_MENTIONS"), displayLocation()))
+	fmt.Printf("  Due date moved for %s\n", card.Name)
+	if err := c.AddCommentToCard(card.ID, comment); err != nil {
+		fmt.Printf("Warning: failed to comment on due date move for %s: %v\n", card.Name, err)
+	}
+}
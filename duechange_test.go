@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDueDateMoved(t *testing.T) {
+	due := time.Date(2025, 9, 22, 5, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		card   Card
+		newDue string
+		moved  bool
+	}{
+		{"same date", Card{Due: &due}, "2025-09-22T05:59:59.000Z", false},
+		{"millisecond noise", Card{Due: timePtr(due.Add(500 * time.Millisecond))}, "2025-09-22T05:59:59.000Z", false},
+		{"moved two days", Card{Due: &due}, "2025-09-24T05:59:59.000Z", true},
+		{"card had no due date", Card{}, "2025-09-24T05:59:59.000Z", false},
+		{"lms cleared due date", Card{Due: &due}, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, moved := dueDateMoved(&test.card, test.newDue); moved != test.moved {
+				t.Errorf("dueDateMoved() = %v, want %v", moved, test.moved)
+			}
+		})
+	}
+}
+
+func TestDueChangeComment(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skip("no tz database")
+	}
+	oldDue := time.Date(2025, 9, 22, 5, 59, 59, 0, time.UTC)
+	newDue := oldDue.AddDate(0, 0, 2)
+
+	comment := dueChangeComment("Canvas", oldDue, newDue, []string{"@makai"}, denver)
+	expected := "@makai 📅 Teacher moved the due date in Canvas from Sun, Sep 21 at 11:59 PM MDT to Tue, Sep 23 at 11:59 PM MDT"
+	if comment != expected {
+		t.Errorf("dueChangeComment() = %q, want %q", comment, expected)
+	}
+}
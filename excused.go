@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// How syncs treat assignments worth no points, like participation checks
+const (
+	zeroPointInclude = "include" // a normal card, but never a REDO
+	zeroPointLabel   = "label"   // the same, with the participation label
+	zeroPointSkip    = "skip"    // no card
+)
+
+// participationLabel marks zero-point cards with ZERO_POINT_ITEMS=label
+var participationLabel = LabelSpec{Name: "participation", Color: "sky", CreateIfMissing: true}
+
+// zeroPointMode reads ZERO_POINT_ITEMS: include (the default), label, or skip
+func zeroPointMode() string {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv("ZERO_POINT_ITEMS")))
+	switch value {
+	case "":
+		return zeroPointInclude
+	case zeroPointInclude, zeroPointLabel, zeroPointSkip:
+		return value
+	}
+	fmt.Printf("Warning: invalid ZERO_POINT_ITEMS '%s' (want include, label, or skip), using include\n", value)
+	return zeroPointInclude
+}
+
+// isZeroPoint reports whether an assignment is worth no points. Its score
+// says nothing about the work, so it never needs a redo.
+func isZeroPoint(assignment CanvasAssignment) bool {
+	return assignment.PointsPossible == 0
+}
+
+// labelZeroPoint adds the participation label to a card that lacks it
+func (c *TrelloClient) labelZeroPoint(card Card) {
+	if findLabel(card.Labels, LabelSpec{Name: participationLabel.Name}) != nil {
+		return
+	}
+	if err := c.AddLabelToCardWithOptions(card.ID, participationLabel); err != nil {
+		fmt.Printf("Warning: failed to label %s as participation: %v\n", card.Name, err)
+	}
+}
+
+// archiveExcused archives the card for an assignment the teacher excused
+func (c *TrelloClient) archiveExcused(card *Card) {
+	if card == nil || card.Closed {
+		return
+	}
+	fmt.Printf("Archiving excused assignment: %s\n", card.Name)
+	if err := c.UpdateCardFields(card.ID, CardPatch{Closed: boolPtr(true)}); err != nil {
+		fmt.Printf("Warning: failed to archive %s: %v\n", card.Name, err)
+		return
+	}
+	card.Closed = true
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestZeroPointMode(t *testing.T) {
+	tests := []struct {
+		env  string
+		want string
+	}{
+		{"", zeroPointInclude},
+		{"label", zeroPointLabel},
+		{" Skip ", zeroPointSkip},
+		{"hide", zeroPointInclude},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv("ZERO_POINT_ITEMS", tt.env)
+			if got := zeroPointMode(); got != tt.want {
+				t.Errorf("zeroPointMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArchiveExcused(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		requests = append(requests, r.Method+" "+r.URL.Path+" closed="+r.Form.Get("closed"))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	card := &Card{ID: "c1", Name: "Biology - Lab Report"}
+	client.archiveExcused(card)
+	client.archiveExcused(card) // already archived
+	client.archiveExcused(nil)  // no card yet
+
+	if len(requests) != 1 || requests[0] != "PUT /cards/c1 closed=true" || !card.Closed {
+		t.Errorf("requests = %v, card closed = %t", requests, card.Closed)
+	}
+}
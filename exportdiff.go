@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// exportedAssignment is an assignment from either LMS export, reduced to
+// the fields compared by --diff-exports
+type exportedAssignment struct {
+	ID     int
+	Name   string
+	Course string
+	Due    time.Time // zero when the assignment has no due date
+	Grade  string    // empty when ungraded
+}
+
+// AssignmentChange is an assignment whose due date or grade changed
+type AssignmentChange struct {
+	Assignment exportedAssignment
+	Old        string
+	New        string
+}
+
+// ExportDiff lists what changed between two exports of the same LMS
+type ExportDiff struct {
+	Added       []exportedAssignment
+	Removed     []exportedAssignment
+	Rescheduled []AssignmentChange
+	Regraded    []AssignmentChange
+}
+
+// rawExport covers both the Canvas and Moodle export file layouts
+type rawExport struct {
+	Assignments json.RawMessage           `json:"assignments"`
+	CourseNames map[int]string            `json:"course_names"`
+	Submissions map[int]*CanvasSubmission `json:"submissions"`
+	Grades      map[int]*MoodleGrade      `json:"grades"`
+}
+
+// loadExport reads a --export-canvas or --export-moodle file and reports
+// which LMS it came from
+func loadExport(path string) ([]exportedAssignment, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read export: %w", err)
+	}
+
+	var raw rawExport
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal export %s: %w", path, err)
+	}
+
+	var assignments []exportedAssignment
+	switch {
+	case raw.Submissions != nil:
+		var canvasAssignments []CanvasAssignment
+		if err := json.Unmarshal(raw.Assignments, &canvasAssignments); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal Canvas assignments: %w", err)
+		}
+		for _, a := range canvasAssignments {
+			exported := exportedAssignment{ID: a.ID, Name: a.Name, Course: raw.CourseNames[a.CourseID]}
+			if due, err := time.Parse(time.RFC3339, a.DueAt); err == nil {
+				exported.Due = due
+			}
+			if sub := raw.Submissions[a.ID]; sub != nil {
+				if sub.Score != nil {
+					exported.Grade = fmt.Sprintf("%g", *sub.Score)
+				} else {
+					exported.Grade = sub.Grade
+				}
+			}
+			assignments = append(assignments, exported)
+		}
+		return assignments, "Canvas", nil
+
+	case raw.Grades != nil:
+		var moodleAssignments []MoodleAssignment
+		if err := json.Unmarshal(raw.Assignments, &moodleAssignments); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal Moodle assignments: %w", err)
+		}
+		for _, a := range moodleAssignments {
+			exported := exportedAssignment{ID: a.ID, Name: a.Name, Course: raw.CourseNames[a.CourseID]}
+			if a.DueDateUnix > 0 {
+				exported.Due = time.Unix(a.DueDateUnix, 0)
+			}
+			if grade := raw.Grades[a.ID]; grade != nil {
+				exported.Grade = fmt.Sprintf("%g/%g", grade.Grade, grade.GradeMax)
+			}
+			assignments = append(assignments, exported)
+		}
+		return assignments, "Moodle", nil
+	}
+
+	return nil, "", fmt.Errorf("%s is not a Canvas or Moodle export", path)
+}
+
+// formatExportDue renders a due date for the diff report
+func formatExportDue(due time.Time) string {
+	if due.IsZero() {
+		return "no due date"
+	}
+	return due.Local().Format("Mon Jan 2 3:04 PM")
+}
+
+// diffExports compares two exports by assignment ID
+func diffExports(oldAssignments, newAssignments []exportedAssignment) ExportDiff {
+	var diff ExportDiff
+
+	oldByID := make(map[int]exportedAssignment)
+	for _, a := range oldAssignments {
+		oldByID[a.ID] = a
+	}
+	newByID := make(map[int]exportedAssignment)
+	for _, a := range newAssignments {
+		newByID[a.ID] = a
+	}
+
+	for _, a := range newAssignments {
+		old, ok := oldByID[a.ID]
+		if !ok {
+			diff.Added = append(diff.Added, a)
+			continue
+		}
+		if !old.Due.Equal(a.Due) {
+			diff.Rescheduled = append(diff.Rescheduled, AssignmentChange{Assignment: a, Old: formatExportDue(old.Due), New: formatExportDue(a.Due)})
+		}
+		if old.Grade != a.Grade && a.Grade != "" {
+			oldGrade := old.Grade
+			if oldGrade == "" {
+				oldGrade = "ungraded"
+			}
+			diff.Regraded = append(diff.Regraded, AssignmentChange{Assignment: a, Old: oldGrade, New: a.Grade})
+		}
+	}
+
+	for _, a := range oldAssignments {
+		if _, ok := newByID[a.ID]; !ok {
+			diff.Removed = append(diff.Removed, a)
+		}
+	}
+
+	sortByName := func(assignments []exportedAssignment) {
+		sort.SliceStable(assignments, func(i, j int) bool { return assignments[i].Name < assignments[j].Name })
+	}
+	sortByName(diff.Added)
+	sortByName(diff.Removed)
+
+	return diff
+}
+
+// Format renders the diff as a plain-text report
+func (d ExportDiff) Format() string {
+	var out strings.Builder
+
+	describe := func(a exportedAssignment) string {
+		if a.Course != "" {
+			return fmt.Sprintf("%s (%s)", a.Name, a.Course)
+		}
+		return a.Name
+	}
+
+	out.WriteString(fmt.Sprintf("➕ Added (%d)\n", len(d.Added)))
+	for _, a := range d.Added {
+		out.WriteString(fmt.Sprintf("- %s, due %s\n", describe(a), formatExportDue(a.Due)))
+	}
+
+	out.WriteString(fmt.Sprintf("\n➖ Removed (%d)\n", len(d.Removed)))
+	for _, a := range d.Removed {
+		out.WriteString(fmt.Sprintf("- %s\n", describe(a)))
+	}
+
+	out.WriteString(fmt.Sprintf("\n📅 Rescheduled (%d)\n", len(d.Rescheduled)))
+	for _, change := range d.Rescheduled {
+		out.WriteString(fmt.Sprintf("- %s: %s → %s\n", describe(change.Assignment), change.Old, change.New))
+	}
+
+	out.WriteString(fmt.Sprintf("\n📈 Regraded (%d)\n", len(d.Regraded)))
+	for _, change := range d.Regraded {
+		out.WriteString(fmt.Sprintf("- %s: %s → %s\n", describe(change.Assignment), change.Old, change.New))
+	}
+
+	return out.String()
+}
+
+// DiffExportFiles compares two export files from the same LMS
+func DiffExportFiles(oldPath, newPath string) (*ExportDiff, error) {
+	oldAssignments, oldSource, err := loadExport(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newAssignments, newSource, err := loadExport(newPath)
+	if err != nil {
+		return nil, err
+	}
+	if oldSource != newSource {
+		return nil, fmt.Errorf("can't compare a %s export with a %s export", oldSource, newSource)
+	}
+
+	diff := diffExports(oldAssignments, newAssignments)
+	return &diff, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffExports(t *testing.T) {
+	due := time.Date(2025, 10, 1, 5, 59, 59, 0, time.UTC)
+	oldExport := []exportedAssignment{
+		{ID: 1, Name: "Vocab Quiz", Due: due},
+		{ID: 2, Name: "Lab Report", Due: due},
+		{ID: 3, Name: "Essay Draft", Due: due},
+	}
+	newExport := []exportedAssignment{
+		{ID: 1, Name: "Vocab Quiz", Due: due, Grade: "95"},
+		{ID: 2, Name: "Lab Report", Due: due.AddDate(0, 0, 3)},
+		{ID: 4, Name: "Unit Test", Due: due.AddDate(0, 0, 7)},
+	}
+
+	diff := diffExports(oldExport, newExport)
+
+	if len(diff.Added) != 1 || diff.Added[0].ID != 4 {
+		t.Errorf("Added = %+v, want Unit Test", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != 3 {
+		t.Errorf("Removed = %+v, want Essay Draft", diff.Removed)
+	}
+	if len(diff.Rescheduled) != 1 || diff.Rescheduled[0].Assignment.ID != 2 {
+		t.Errorf("Rescheduled = %+v, want Lab Report", diff.Rescheduled)
+	}
+	if len(diff.Regraded) != 1 || diff.Regraded[0].Old != "ungraded" || diff.Regraded[0].New != "95" {
+		t.Errorf("Regraded = %+v, want Vocab Quiz ungraded → 95", diff.Regraded)
+	}
+}
+
+func TestLoadExport(t *testing.T) {
+	dir := t.TempDir()
+
+	canvasPath := filepath.Join(dir, "canvas.json")
+	canvas := `{"assignments": [{"id": 7, "name": "Scale Pass Off", "due_at": "2025-09-20T05:59:59Z", "course_id": 9}],
+		"course_names": {"9": "Orchestra"}, "submissions": {"7": {"score": 88.5, "workflow_state": "graded"}}}`
+	if err := os.WriteFile(canvasPath, []byte(canvas), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	moodlePath := filepath.Join(dir, "moodle.json")
+	moodle := `{"assignments": [{"id": 70305, "name": "Question of the Week", "course": 3592, "duedate": 1759363200}],
+		"course_names": {"3592": "MHA"}, "grades": {}}`
+	if err := os.WriteFile(moodlePath, []byte(moodle), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	assignments, source, err := loadExport(canvasPath)
+	if err != nil {
+		t.Fatalf("loadExport(canvas) error = %v", err)
+	}
+	if source != "Canvas" || len(assignments) != 1 || assignments[0].Grade != "88.5" || assignments[0].Course != "Orchestra" {
+		t.Errorf("loadExport(canvas) = %s %+v", source, assignments)
+	}
+
+	assignments, source, err = loadExport(moodlePath)
+	if err != nil {
+		t.Fatalf("loadExport(moodle) error = %v", err)
+	}
+	if source != "Moodle" || len(assignments) != 1 || assignments[0].Due.Unix() != 1759363200 {
+		t.Errorf("loadExport(moodle) = %s %+v", source, assignments)
+	}
+
+	if _, err := DiffExportFiles(canvasPath, moodlePath); err == nil || !strings.Contains(err.Error(), "can't compare") {
+		t.Errorf("expected mixed-LMS error, got %v", err)
+	}
+}
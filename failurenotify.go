@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// failureNoticesFile records when each failure was last sent, for deduplication
+const failureNoticesFile = "failure_notices.json"
+
+// defaultFailureWindow is how long the same failure stays quiet after it's sent
+const defaultFailureWindow = 6 * time.Hour
+
+// FailureNotice is what gets sent when an unattended run fails
+type FailureNotice struct {
+	Command    string
+	Stage      string // what the command was doing, from the outermost error
+	Details    string // the full error, secrets redacted
+	Suggestion string
+	At         time.Time
+}
+
+// secretValueRegex matches credentials in error text: query parameters,
+// key=value pairs, and bearer tokens
+var secretValueRegex = regexp.MustCompile(`(?i)\b(` + strings.Join(secretParams, "|") + `)=([^&\s"']+)|(bearer\s+)\S+`)
+
+// redactSecrets blanks credentials in error text
+func redactSecrets(text string) string {
+	return secretValueRegex.ReplaceAllStringFunc(text, func(match string) string {
+		if strings.HasPrefix(strings.ToLower(match), "bearer") {
+			return "Bearer REDACTED"
+		}
+		name, _, _ := strings.Cut(match, "=")
+		return name + "=REDACTED"
+	})
+}
+
+// failureSuggestion gives a next step for the common kinds of failure
+func failureSuggestion(err error) string {
+	var authErr *CanvasAuthError
+	if errors.As(err, &authErr) {
+		return "Run --renew-canvas to make a new Canvas token."
+	}
+
+	text := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(text, "status 401") || strings.Contains(text, "unauthorized"):
+		return "Check the API token in .env; for Trello, run --authorize-trello."
+	case strings.Contains(text, "status 429") || strings.Contains(text, "too many requests"):
+		return "The API is rate limiting; space out scheduled jobs or run --bench to check."
+	case strings.Contains(text, "invalidtoken") || strings.Contains(text, "moodle error"):
+		return "Check MOODLE_WSTOKEN and that the Moodle web service is still enabled."
+	case strings.Contains(text, "no such host") || strings.Contains(text, "timeout") ||
+		strings.Contains(text, "connection refused") || strings.Contains(text, "status 5"):
+		return "This looks like a network or server outage; it usually clears up by the next run."
+	case strings.Contains(text, "failed to find") || strings.Contains(text, "not found"):
+		return "Check the board and list names used by this command."
+	}
+	return "Run the command by hand with --trace for the full request log."
+}
+
+// newFailureNotice describes a failed run
+func newFailureNotice(result JobResult, now time.Time) FailureNotice {
+	details := redactSecrets(result.Err.Error())
+	stage, _, found := strings.Cut(details, ": ")
+	if !found {
+		stage = "run"
+	}
+	return FailureNotice{
+		Command:    result.Name,
+		Stage:      stage,
+		Details:    details,
+		Suggestion: failureSuggestion(result.Err),
+		At:         now,
+	}
+}
+
+// Subject is the one-line summary of the failure
+func (n FailureNotice) Subject() string {
+	return fmt.Sprintf("❌ trello-client %s failed", n.Command)
+}
+
+// Body is the full notice
+func (n FailureNotice) Body() string {
+	host, _ := os.Hostname()
+	var body strings.Builder
+	fmt.Fprintf(&body, "Command: %s\n", n.Command)
+	fmt.Fprintf(&body, "Stage: %s\n", n.Stage)
+	fmt.Fprintf(&body, "When: %s", friendlyTime(n.At, displayLocation()))
+	if host != "" {
+		fmt.Fprintf(&body, " on %s", host)
+	}
+	fmt.Fprintf(&body, "\nError: %s\n", n.Details)
+	fmt.Fprintf(&body, "Suggestion: %s\n", n.Suggestion)
+	return body.String()
+}
+
+// dedupKey identifies repeats of the same failure
+func (n FailureNotice) dedupKey() string {
+	return n.Command + "|" + n.Stage
+}
+
+// failureChannel sends a notice somewhere
+type failureChannel struct {
+	Name string
+	Send func(FailureNotice) error
+}
+
+// failureChannelsFromEnv returns the configured channels: FAILURE_WEBHOOK_URL
+// (Slack, Discord, or anything that takes a JSON post) and FAILURE_EMAIL
+// (using the SMTP settings)
+func failureChannelsFromEnv() []failureChannel {
+	var channels []failureChannel
+	if webhook := os.Getenv("FAILURE_WEBHOOK_URL"); webhook != "" {
+		channels = append(channels, failureChannel{Name: "webhook", Send: func(n FailureNotice) error {
+			return postFailureWebhook(webhook, n)
+		}})
+	}
+	if os.Getenv("FAILURE_EMAIL") != "" {
+		channels = append(channels, failureChannel{Name: "email", Send: sendFailureEmail})
+	}
+	return channels
+}
+
+// postFailureWebhook posts the notice as both "text" (Slack) and "content" (Discord)
+func postFailureWebhook(webhook string, n FailureNotice) error {
+	message := n.Subject() + "\n" + n.Body()
+	payload, err := json.Marshal(map[string]string{"text": message, "content": message})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	resp, err := http.Post(webhook, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func sendFailureEmail(n FailureNotice) error {
+	config, err := smtpConfigFor("FAILURE_EMAIL")
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		config.From, strings.Join(config.To, ", "), n.Subject(), strings.ReplaceAll(n.Body(), "\n", "\r\n"))
+	if err := smtp.SendMail(config.Host+":"+config.Port, auth, config.From, config.To, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send failure email: %w", err)
+	}
+	return nil
+}
+
+// failureWindow reads FAILURE_NOTIFY_WINDOW (e.g. 6h, 30m), defaulting to 6 hours
+func failureWindow() time.Duration {
+	value := os.Getenv("FAILURE_NOTIFY_WINDOW")
+	if value == "" {
+		return defaultFailureWindow
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil || window < 0 {
+		fmt.Printf("Warning: invalid FAILURE_NOTIFY_WINDOW '%s' (want e.g. 6h), using %s\n", value, defaultFailureWindow)
+		return defaultFailureWindow
+	}
+	return window
+}
+
+func loadFailureNotices(path string) (map[string]time.Time, error) {
+	sent := make(map[string]time.Time)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return sent, nil
+		}
+		return nil, fmt.Errorf("failed to read failure notices: %w", err)
+	}
+	if err := json.Unmarshal(data, &sent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal failure notices: %w", err)
+	}
+	return sent, nil
+}
+
+// sendFailureNotices sends each failure through every channel unless the
+// same failure was sent within the window, returning how many went out
+func sendFailureNotices(results []JobResult, channels []failureChannel, path string, window time.Duration, now time.Time) (int, error) {
+	sent, err := loadFailureNotices(path)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, result := range results {
+		// A shutdown isn't a failure; the sync resumes next time
+		if result.Err == nil || errors.Is(result.Err, errSyncInterrupted) {
+			continue
+		}
+		notice := newFailureNotice(result, now)
+		if last, ok := sent[notice.dedupKey()]; ok && now.Sub(last) < window {
+			continue
+		}
+		delivered := false
+		for _, channel := range channels {
+			if err := channel.Send(notice); err != nil {
+				fmt.Printf("Warning: failed to send %s failure notice by %s: %v\n", notice.Command, channel.Name, err)
+				continue
+			}
+			delivered = true
+		}
+		if delivered {
+			sent[notice.dedupKey()] = now
+			count++
+		}
+	}
+
+	data, err := json.MarshalIndent(sent, "", "  ")
+	if err != nil {
+		return count, fmt.Errorf("failed to marshal failure notices: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return count, fmt.Errorf("failed to write failure notices: %w", err)
+	}
+	return count, nil
+}
+
+// notifyRunFailures sends failure notices for unattended runs (no terminal
+// on stdin, as under cron or launchd). Notification problems are warnings.
+func notifyRunFailures(results ...JobResult) {
+	if stdinIsTerminal() {
+		return
+	}
+	failed := false
+	for _, result := range results {
+		failed = failed || result.Err != nil
+	}
+	if !failed {
+		return
+	}
+	channels := failureChannelsFromEnv()
+	if len(channels) == 0 {
+		return
+	}
+	if _, err := sendFailureNotices(results, channels, failureNoticesFile, failureWindow(), time.Now()); err != nil {
+		fmt.Printf("Warning: failure notices: %v\n", err)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`Get "https://api.trello.com/1/boards?key=abc&token=def": timeout`, `Get "https://api.trello.com/1/boards?key=REDACTED&token=REDACTED": timeout`},
+		{"moodle error: wstoken=s3cret is invalid", "moodle error: wstoken=REDACTED is invalid"},
+		{"Authorization: Bearer abc.def", "Authorization: Bearer REDACTED"},
+		{"monkey=banana stays", "monkey=banana stays"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := redactSecrets(tt.in); got != tt.want {
+				t.Errorf("redactSecrets() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailureSuggestion(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("failed to get user: %w", &CanvasAuthError{Status: 401, Reason: canvasTokenExpired}), "--renew-canvas"},
+		{errors.New("API request failed with status 401"), "--authorize-trello"},
+		{errors.New("API request failed with status 429"), "rate limiting"},
+		{errors.New(`dial tcp: lookup school.instructure.com: no such host`), "outage"},
+		{errors.New("failed to find Weekly list"), "list names"},
+		{errors.New("something odd"), "--trace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			if got := failureSuggestion(tt.err); !strings.Contains(got, tt.want) {
+				t.Errorf("failureSuggestion() = %q, want it to mention %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendFailureNotices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), failureNoticesFile)
+	now := time.Date(2026, 10, 14, 20, 0, 0, 0, time.UTC)
+
+	var sent []FailureNotice
+	channels := []failureChannel{{Name: "test", Send: func(n FailureNotice) error {
+		sent = append(sent, n)
+		return nil
+	}}}
+	results := []JobResult{
+		{Name: "refresh"},
+		{Name: "sync-canvas", Err: errors.New("failed to get Canvas assignments: status 503 for ?access_token=abc")},
+		{Name: "sync-moodle", Err: errSyncInterrupted},
+	}
+
+	count, err := sendFailureNotices(results, channels, path, time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || len(sent) != 1 {
+		t.Fatalf("sent %d notice(s), want only sync-canvas", len(sent))
+	}
+	notice := sent[0]
+	if notice.Stage != "failed to get Canvas assignments" || strings.Contains(notice.Body(), "abc") {
+		t.Errorf("notice = %+v", notice)
+	}
+
+	// The same failure inside the window is quiet, and sent again after it
+	if count, _ := sendFailureNotices(results, channels, path, time.Hour, now.Add(30*time.Minute)); count != 0 {
+		t.Errorf("repeat within the window sent %d notice(s)", count)
+	}
+	if count, _ := sendFailureNotices(results, channels, path, time.Hour, now.Add(2*time.Hour)); count != 1 {
+		t.Errorf("repeat after the window sent %d notice(s), want 1", count)
+	}
+}
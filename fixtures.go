@@ -0,0 +1,312 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Prefixes and keywords that drive sync and agenda logic survive anonymization
+var (
+	fixtureKeptPrefixes = []string{"REDO - ", "LOCKED - "}
+	fixtureIDRegex      = regexp.MustCompile(`(ID: )(\d+)`)
+	fixtureURLRegex     = regexp.MustCompile(`https?://[^\s)\]]+`)
+	fixtureTaskIDRegex  = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-\d+\b`)
+)
+
+// anonymizer replaces real IDs and names with stable placeholders, so the
+// same source value always maps to the same fixture value within a recording
+type anonymizer struct {
+	trelloIDs map[string]string
+	intIDs    map[int]int
+	names     map[string]string
+	counts    map[string]int
+}
+
+func newAnonymizer() *anonymizer {
+	return &anonymizer{
+		trelloIDs: make(map[string]string),
+		intIDs:    make(map[int]int),
+		names:     make(map[string]string),
+		counts:    make(map[string]int),
+	}
+}
+
+// trelloID maps a Trello ID to a fake one, keeping the creation timestamp
+// in the first 8 hex digits so card ages still work
+func (a *anonymizer) trelloID(id string) string {
+	if id == "" {
+		return ""
+	}
+	if fake, ok := a.trelloIDs[id]; ok {
+		return fake
+	}
+	timestamp := "00000000"
+	if len(id) >= 8 {
+		timestamp = id[:8]
+	}
+	fake := fmt.Sprintf("%s%016x", timestamp, len(a.trelloIDs)+1)
+	a.trelloIDs[id] = fake
+	return fake
+}
+
+// intID maps an LMS ID to a small fake one
+func (a *anonymizer) intID(id int) int {
+	if id == 0 {
+		return 0
+	}
+	if fake, ok := a.intIDs[id]; ok {
+		return fake
+	}
+	fake := 1000 + len(a.intIDs) + 1
+	a.intIDs[id] = fake
+	return fake
+}
+
+// name replaces a name with "<kind> N", keeping status prefixes and any
+// test/quiz/exam keyword
+func (a *anonymizer) name(kind, name string) string {
+	if name == "" {
+		return ""
+	}
+
+	prefix := ""
+	for _, kept := range fixtureKeptPrefixes {
+		if strings.HasPrefix(name, kept) {
+			prefix = kept
+			name = strings.TrimPrefix(name, kept)
+			break
+		}
+	}
+
+	key := kind + "\x00" + name
+	fake, ok := a.names[key]
+	if !ok {
+		a.counts[kind]++
+		fake = fmt.Sprintf("%s %d", kind, a.counts[kind])
+		if match := testCardRegex.FindStringSubmatch(name); match != nil {
+			keyword := strings.ToLower(match[1])
+			fake += " " + strings.ToUpper(keyword[:1]) + keyword[1:]
+		}
+		a.names[key] = fake
+	}
+	return prefix + fake
+}
+
+// text keeps only the metadata block of a description (the part after
+// "---"), with IDs, URLs, and task keys replaced
+func (a *anonymizer) text(desc string) string {
+	metadata := ""
+	if i := strings.Index(desc, "\n---\n"); i >= 0 {
+		metadata = desc[i:]
+	}
+
+	metadata = fixtureIDRegex.ReplaceAllStringFunc(metadata, func(match string) string {
+		parts := fixtureIDRegex.FindStringSubmatch(match)
+		var id int
+		fmt.Sscanf(parts[2], "%d", &id)
+		return fmt.Sprintf("%s%d", parts[1], a.intID(id))
+	})
+	metadata = fixtureURLRegex.ReplaceAllString(metadata, "https://example.com/redacted")
+	metadata = fixtureTaskIDRegex.ReplaceAllStringFunc(metadata, func(key string) string {
+		return a.name("TASK", key)
+	})
+
+	// Course names in metadata would leave the real name behind
+	lines := strings.Split(metadata, "\n")
+	for i, line := range lines {
+		if course, ok := strings.CutPrefix(line, "Course: "); ok {
+			lines[i] = "Course: " + a.name("Course", course)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// anonymizeTrello sanitizes boards, lists, and cards in cache form
+func (a *anonymizer) anonymizeTrello(cache *CachedData) *CachedData {
+	out := &CachedData{UpdatedAt: cache.UpdatedAt}
+	for _, board := range cache.Boards {
+		out.Boards = append(out.Boards, Board{
+			ID:             a.trelloID(board.ID),
+			Name:           board.Name, // Board names are looked up by the sync code
+			URL:            "https://trello.com/b/redacted",
+			Closed:         board.Closed,
+			IDOrganization: a.trelloID(board.IDOrganization),
+		})
+	}
+	for _, list := range cache.Lists {
+		out.Lists = append(out.Lists, List{
+			ID:      a.trelloID(list.ID),
+			Name:    list.Name, // List names drive routing, so keep them
+			BoardID: a.trelloID(list.BoardID),
+		})
+	}
+	for _, card := range cache.Cards {
+		out.Cards = append(out.Cards, Card{
+			ID:          a.trelloID(card.ID),
+			Name:        a.name("Card", card.Name),
+			Description: a.text(card.Description),
+			URL:         "https://trello.com/c/redacted",
+			ShortURL:    "https://trello.com/c/redacted",
+			Closed:      card.Closed,
+			IDList:      a.trelloID(card.IDList),
+			IDBoard:     a.trelloID(card.IDBoard),
+			Due:         card.Due,
+			DueComplete: card.DueComplete,
+		})
+	}
+	return out
+}
+
+// writeFixture saves one fixture file as indented JSON
+func writeFixture(dir, name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	fmt.Printf("%s Wrote %s\n", iconSuccess, path)
+	return nil
+}
+
+// RecordFixtures makes read-only calls against Trello and, when given, Canvas
+// and Moodle, then writes anonymized fixtures to dir:
+//   - trello_cache.json: usable as the cache for --today, --week-review, etc.
+//   - canvas_assignments.json: Canvas export format (works with --diff-exports)
+//   - moodle_test_data.json: usable with --moodle-test-file
+func (c *TrelloClient) RecordFixtures(dir string, canvasClient *CanvasClient, moodleClient *MoodleClient) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+
+	anon := newAnonymizer()
+
+	cache, err := c.fetchCacheData(&CachedData{})
+	if err != nil {
+		return fmt.Errorf("failed to record Trello data: %w", err)
+	}
+	if err := writeFixture(dir, "trello_cache.json", anon.anonymizeTrello(cache)); err != nil {
+		return err
+	}
+
+	if canvasClient != nil {
+		if err := recordCanvasFixture(dir, canvasClient, anon); err != nil {
+			fmt.Printf("Warning: skipping Canvas fixture: %v\n", err)
+		}
+	}
+
+	if moodleClient != nil {
+		if err := recordMoodleFixture(dir, moodleClient, anon); err != nil {
+			fmt.Printf("Warning: skipping Moodle fixture: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// recordCanvasFixture writes upcoming Canvas assignments and submissions
+func recordCanvasFixture(dir string, canvasClient *CanvasClient, anon *anonymizer) error {
+	user, err := canvasClient.GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("failed to get Canvas user: %w", err)
+	}
+
+	assignments, err := canvasClient.GetUpcomingAssignments(user.ID, pastDueSince(time.Now(), 0), time.Now().AddDate(0, 0, syncHorizonDays()))
+	if err != nil {
+		return fmt.Errorf("failed to get Canvas assignments: %w", err)
+	}
+
+	courses, err := canvasClient.GetCourses()
+	if err != nil {
+		return fmt.Errorf("failed to get Canvas courses: %w", err)
+	}
+
+	fixture := struct {
+		ExportDate  string                    `json:"export_date"`
+		Assignments []CanvasAssignment        `json:"assignments"`
+		CourseNames map[int]string            `json:"course_names"`
+		Submissions map[int]*CanvasSubmission `json:"submissions"`
+	}{
+		ExportDate:  time.Now().Format(time.RFC3339),
+		CourseNames: make(map[int]string),
+		Submissions: make(map[int]*CanvasSubmission),
+	}
+
+	for _, course := range courses {
+		fixture.CourseNames[anon.intID(course.ID)] = anon.name("Course", course.Name)
+	}
+
+	for _, assignment := range assignments {
+		submission, err := canvasClient.GetSubmission(assignment.CourseID, assignment.ID, user.ID)
+		if err != nil {
+			fmt.Printf("Warning: failed to get submission for an assignment: %v\n", err)
+		}
+
+		fakeID := anon.intID(assignment.ID)
+		assignment.ID = fakeID
+		assignment.CourseID = anon.intID(assignment.CourseID)
+		assignment.Name = anon.name("Assignment", assignment.Name)
+		assignment.Description = ""
+		assignment.HTMLURL = "https://example.com/redacted"
+		fixture.Assignments = append(fixture.Assignments, assignment)
+
+		if submission != nil {
+			fixture.Submissions[fakeID] = submission
+		}
+	}
+
+	return writeFixture(dir, "canvas_assignments.json", fixture)
+}
+
+// recordMoodleFixture writes upcoming Moodle assignments and grades
+func recordMoodleFixture(dir string, moodleClient *MoodleClient, anon *anonymizer) error {
+	assignments, courseNames, err := moodleClient.GetUpcomingAssignments(pastDueSince(time.Now(), 0), time.Now().AddDate(0, 0, syncHorizonDays()))
+	if err != nil {
+		return fmt.Errorf("failed to get Moodle assignments: %w", err)
+	}
+
+	userID, err := moodleClient.GetSiteInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get Moodle site info: %w", err)
+	}
+
+	fixture := MoodleTestData{
+		CourseNames: make(map[int]string),
+		Grades:      make(map[int]*MoodleGrade),
+	}
+	for id, name := range courseNames {
+		fixture.CourseNames[anon.intID(id)] = anon.name("Course", name)
+	}
+
+	for _, a := range assignments {
+		grade, err := moodleClient.GetAssignmentGrade(a.ID, a.CourseID, userID, a.Type)
+		if err != nil {
+			fmt.Printf("Warning: failed to get grade for an assignment: %v\n", err)
+		}
+
+		fakeID := anon.intID(a.ID)
+		a.ID = fakeID
+		a.CourseID = anon.intID(a.CourseID)
+		a.Name = anon.name("Assignment", a.Name)
+		a.Intro = ""
+		a.URL = "https://example.com/redacted"
+		fixture.Assignments = append(fixture.Assignments, a)
+
+		if grade != nil {
+			grade.UserID = 1
+			grade.ItemID = anon.intID(grade.ItemID)
+			fixture.Grades[fakeID] = grade
+		}
+	}
+
+	return writeFixture(dir, "moodle_test_data.json", fixture)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnonymizerIsConsistent(t *testing.T) {
+	anon := newAnonymizer()
+
+	first := anon.trelloID("68c9a2c0a1b2c3d4e5f60718")
+	if first != anon.trelloID("68c9a2c0a1b2c3d4e5f60718") {
+		t.Error("same Trello ID should map to the same fixture ID")
+	}
+	if first == anon.trelloID("68c9a2c0ffffffffffffffff") {
+		t.Error("different Trello IDs should map to different fixture IDs")
+	}
+	if !cardCreatedAt(first).Equal(cardCreatedAt("68c9a2c0a1b2c3d4e5f60718")) {
+		t.Error("fixture ID should keep the creation timestamp")
+	}
+
+	if anon.intID(98765) != anon.intID(98765) || anon.intID(98765) == anon.intID(12345) {
+		t.Error("LMS IDs should map consistently and uniquely")
+	}
+}
+
+func TestAnonymizerName(t *testing.T) {
+	anon := newAnonymizer()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain", "Read chapter 4", "Card 1"},
+		{"same name again", "Read chapter 4", "Card 1"},
+		{"keeps keyword", "Unit 3 Quiz", "Card 2 Quiz"},
+		{"keeps prefix", "REDO - Read chapter 4", "REDO - Card 1"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := anon.name("Card", tt.input); got != tt.expected {
+				t.Errorf("name(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAnonymizerText(t *testing.T) {
+	anon := newAnonymizer()
+	desc := "Essay about my family\n\n---\nCourse: Biology 101\nCanvas Assignment ID: 55501\nLink: https://school.instructure.com/courses/1/assignments/55501"
+
+	got := anon.text(desc)
+	for _, leaked := range []string{"family", "Biology", "55501", "instructure"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("text() leaked %q: %q", leaked, got)
+		}
+	}
+	if !strings.Contains(got, "ID: 1001") {
+		t.Errorf("text() should remap the assignment ID, got %q", got)
+	}
+	if anon.text("no metadata here") != "" {
+		t.Error("descriptions without metadata should be dropped")
+	}
+}
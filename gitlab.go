@@ -0,0 +1,270 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Card metadata labels for GitLab items
+const (
+	gitlabIssueSource = "GitLab Issue"
+	gitlabMRSource    = "GitLab MR"
+)
+
+// GitLabClient reads issues and merge requests from GitLab.com or a
+// self-managed instance
+type GitLabClient struct {
+	Token   string
+	BaseURL string
+}
+
+// GitLabUser is the authenticated user
+type GitLabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+// GitLabIssue is an open issue assigned to the user
+type GitLabIssue struct {
+	ID          int      `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	WebURL      string   `json:"web_url"`
+	DueDate     string   `json:"due_date"` // YYYY-MM-DD
+	Labels      []string `json:"labels"`
+	References  struct {
+		Full string `json:"full"`
+	} `json:"references"`
+}
+
+// GitLabMergeRequest is an open merge request the user is reviewing
+type GitLabMergeRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	WebURL      string `json:"web_url"`
+	Draft       bool   `json:"draft"`
+	Author      struct {
+		Name string `json:"name"`
+	} `json:"author"`
+	References struct {
+		Full string `json:"full"`
+	} `json:"references"`
+}
+
+// NewGitLabClient creates a GitLab client; baseURL defaults to gitlab.com
+func NewGitLabClient(token, baseURL string) *GitLabClient {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com"
+	}
+	return &GitLabClient{Token: token, BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// gitlabClientFromEnv builds a GitLab client from GITLAB_TOKEN and GITLAB_URL
+func gitlabClientFromEnv() (*GitLabClient, error) {
+	token := os.Getenv("GITLAB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("GITLAB_TOKEN must be set")
+	}
+	return NewGitLabClient(token, os.Getenv("GITLAB_URL")), nil
+}
+
+// get fetches every page of a GitLab API list, following X-Next-Page
+func (g *GitLabClient) get(endpoint string, query url.Values) ([]json.RawMessage, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", "100")
+
+	var items []json.RawMessage
+	for {
+		req, err := http.NewRequest("GET", g.BaseURL+"/api/v4"+endpoint+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("PRIVATE-TOKEN", g.Token)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GitLab API request failed with status %d", resp.StatusCode)
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+			var page []json.RawMessage
+			if err := json.Unmarshal(body, &page); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal GitLab response: %w", err)
+			}
+			items = append(items, page...)
+		} else {
+			items = append(items, body)
+		}
+
+		next := resp.Header.Get("X-Next-Page")
+		if next == "" {
+			return items, nil
+		}
+		query.Set("page", next)
+	}
+}
+
+// GetCurrentUser returns the user the token belongs to
+func (g *GitLabClient) GetCurrentUser() (*GitLabUser, error) {
+	data, err := g.get("/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab user: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("failed to get GitLab user: empty response")
+	}
+
+	var user GitLabUser
+	if err := json.Unmarshal(data[0], &user); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal GitLab user: %w", err)
+	}
+	return &user, nil
+}
+
+// GetAssignedIssues returns open issues assigned to the user
+func (g *GitLabClient) GetAssignedIssues() ([]GitLabIssue, error) {
+	query := url.Values{}
+	query.Set("scope", "assigned_to_me")
+	query.Set("state", "opened")
+
+	data, err := g.get("/issues", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab issues: %w", err)
+	}
+
+	var issues []GitLabIssue
+	for _, item := range data {
+		var issue GitLabIssue
+		if err := json.Unmarshal(item, &issue); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GitLab issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// GetReviewRequests returns open merge requests with the user as reviewer
+func (g *GitLabClient) GetReviewRequests(userID int) ([]GitLabMergeRequest, error) {
+	query := url.Values{}
+	query.Set("scope", "all")
+	query.Set("state", "opened")
+	query.Set("reviewer_id", strconv.Itoa(userID))
+
+	data, err := g.get("/merge_requests", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab merge requests: %w", err)
+	}
+
+	var mrs []GitLabMergeRequest
+	for _, item := range data {
+		var mr GitLabMergeRequest
+		if err := json.Unmarshal(item, &mr); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GitLab merge request: %w", err)
+		}
+		mrs = append(mrs, mr)
+	}
+	return mrs, nil
+}
+
+// issueListName picks a list for an issue from GITLAB_LABEL_MAP entries like
+// "workflow::doing=In Progress"; "" leaves the card where it is
+func issueListName(labels []string, labelMap map[string]string) string {
+	for _, label := range labels {
+		if list, ok := labelMap[normalizeString(label)]; ok {
+			return list
+		}
+	}
+	return ""
+}
+
+// gitlabWorkItems converts issues and review requests to work items. Merge
+// requests go to mrList; drafts are skipped unless includeDrafts is set.
+func gitlabWorkItems(issues []GitLabIssue, mrs []GitLabMergeRequest, labelMap map[string]string, mrList string, includeDrafts bool) []WorkItem {
+	var items []WorkItem
+
+	for _, issue := range issues {
+		item := WorkItem{
+			Source: gitlabIssueSource,
+			ID:     strconv.Itoa(issue.ID),
+			Title:  fmt.Sprintf("%s: %s", issue.References.Full, issue.Title),
+			URL:    issue.WebURL,
+			Notes:  issue.Description,
+			List:   issueListName(issue.Labels, labelMap),
+		}
+		if day, err := time.ParseInLocation("2006-01-02", issue.DueDate, time.Local); err == nil {
+			due := day.Add(23*time.Hour + 59*time.Minute)
+			item.Due = &due
+		}
+		items = append(items, item)
+	}
+
+	for _, mr := range mrs {
+		if mr.Draft && !includeDrafts {
+			continue
+		}
+		items = append(items, WorkItem{
+			Source: gitlabMRSource,
+			ID:     strconv.Itoa(mr.ID),
+			Title:  fmt.Sprintf("Review %s: %s", mr.References.Full, mr.Title),
+			URL:    mr.WebURL,
+			Notes:  fmt.Sprintf("Author: %s\n\n%s", mr.Author.Name, mr.Description),
+			List:   mrList,
+		})
+	}
+
+	return items
+}
+
+// SyncGitLab mirrors assigned issues and merge requests awaiting the user's
+// review onto the work board (GITLAB_BOARD, default Mac). Closed issues and
+// merged, closed, or re-assigned MRs move to Done.
+func (c *TrelloClient) SyncGitLab(gitlab *GitLabClient) error {
+	boardName := os.Getenv("GITLAB_BOARD")
+	if boardName == "" {
+		boardName = "Mac"
+	}
+	boardName = c.Target.board(boardName)
+	mrList := os.Getenv("GITLAB_MR_LIST")
+	if mrList == "" {
+		mrList = "In Review"
+	}
+
+	labelMap := parseSectionMap(os.Getenv("GITLAB_LABEL_MAP"))
+
+	user, err := gitlab.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Syncing GitLab work for %s to %s board\n", user.Username, boardName)
+
+	issues, err := gitlab.GetAssignedIssues()
+	if err != nil {
+		return err
+	}
+	mrs, err := gitlab.GetReviewRequests(user.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Found %d assigned issue(s) and %d review request(s)\n", len(issues), len(mrs))
+
+	items := gitlabWorkItems(issues, mrs, labelMap, mrList, os.Getenv("GITLAB_INCLUDE_DRAFTS") == "true")
+	return c.mirrorWorkItems(boardName, c.Target.list(os.Getenv("GITLAB_ISSUE_LIST")), items, []string{gitlabIssueSource, gitlabMRSource})
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitLabGetFollowsPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("scope") != "assigned_to_me" || r.URL.Query().Get("state") != "opened" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id":101,"title":"Fix login","references":{"full":"team/app#1"}}]`)
+		case "2":
+			fmt.Fprint(w, `[{"id":102,"title":"Add metrics","due_date":"2025-10-10","references":{"full":"team/app#2"}}]`)
+		}
+	}))
+	defer server.Close()
+
+	gitlab := NewGitLabClient("token", server.URL+"/")
+	issues, err := gitlab.GetAssignedIssues()
+	if err != nil {
+		t.Fatalf("GetAssignedIssues() error = %v", err)
+	}
+	if len(issues) != 2 || issues[1].DueDate != "2025-10-10" {
+		t.Errorf("GetAssignedIssues() = %+v, want both pages", issues)
+	}
+}
+
+func TestGitLabWorkItems(t *testing.T) {
+	issue := GitLabIssue{ID: 101, Title: "Fix login", DueDate: "2025-10-10", Labels: []string{"bug", "workflow::doing"}}
+	issue.References.Full = "team/app#1"
+	plain := GitLabIssue{ID: 102, Title: "Add metrics"}
+	mr := GitLabMergeRequest{ID: 201, Title: "Refactor auth"}
+	mr.References.Full = "team/app!7"
+	draft := GitLabMergeRequest{ID: 202, Title: "WIP", Draft: true}
+
+	labelMap := parseSectionMap("workflow::doing=In Progress")
+	items := gitlabWorkItems([]GitLabIssue{issue, plain}, []GitLabMergeRequest{mr, draft}, labelMap, "In Review", false)
+
+	if len(items) != 3 {
+		t.Fatalf("expected drafts to be skipped, got %d items", len(items))
+	}
+	if items[0].Title != "team/app#1: Fix login" || items[0].List != "In Progress" || items[0].Due == nil {
+		t.Errorf("unexpected issue item: %+v", items[0])
+	}
+	if items[1].List != "" {
+		t.Errorf("issues without a mapped label should stay put, got list %q", items[1].List)
+	}
+	if items[2].Source != gitlabMRSource || items[2].Title != "Review team/app!7: Refactor auth" || items[2].List != "In Review" {
+		t.Errorf("unexpected MR item: %+v", items[2])
+	}
+
+	if items := gitlabWorkItems(nil, []GitLabMergeRequest{draft}, nil, "In Review", true); len(items) != 1 {
+		t.Error("drafts should be included when asked")
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// GradeBand maps scores at or above Min to a letter grade and GPA points
+type GradeBand struct {
+	Min    float64 `json:"min"`
+	Letter string  `json:"letter"`
+	Points float64 `json:"points"`
+}
+
+// GradeScale is the ordered set of bands used to estimate GPA and to read
+// letter grades, with optional bands for courses graded differently
+type GradeScale struct {
+	Bands   []GradeBand            `json:"bands"`
+	Courses map[string][]GradeBand `json:"courses,omitempty"` // by part of the course name
+}
+
+// CourseScore is a course's current score from a Canvas enrollment
+type CourseScore struct {
+	CourseID   int
+	CourseName string
+	Score      *float64
+}
+
+// CourseGrade is one course's contribution to a GPA estimate
+type CourseGrade struct {
+	CourseName string
+	Score      float64
+	Letter     string
+	Points     float64
+}
+
+// GPAEstimate is an unweighted GPA across courses with a current score
+type GPAEstimate struct {
+	GPA     float64
+	Courses []CourseGrade
+}
+
+// LoadGradeScale reads grade_scale.json, falling back to the embedded
+// standard 4.0 scale
+func LoadGradeScale() (*GradeScale, error) {
+	data, err := os.ReadFile(findConfigFile("grade_scale.json"))
+	if err != nil {
+		if data, err = defaultFiles.ReadFile("defaults/grade_scale.json"); err != nil {
+			return nil, fmt.Errorf("failed to read embedded grade scale: %w", err)
+		}
+	}
+
+	var scale GradeScale
+	if err := json.Unmarshal(data, &scale); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal grade scale: %w", err)
+	}
+	if len(scale.Bands) == 0 {
+		return nil, fmt.Errorf("grade scale has no bands")
+	}
+	for course, bands := range scale.Courses {
+		if len(bands) == 0 {
+			return nil, fmt.Errorf("grade scale for %s has no bands", course)
+		}
+	}
+
+	// Highest cutoff first so the first match wins
+	sortBands := func(bands []GradeBand) {
+		sort.SliceStable(bands, func(i, j int) bool { return bands[i].Min > bands[j].Min })
+	}
+	sortBands(scale.Bands)
+	for _, bands := range scale.Courses {
+		sortBands(bands)
+	}
+	return &scale, nil
+}
+
+// courseBands returns the bands for the longest course key in the course's
+// name, or the default bands
+func (s *GradeScale) courseBands(course string) []GradeBand {
+	bands, matched := s.Bands, ""
+	for name, courseBands := range s.Courses {
+		if strings.Contains(normalizeString(course), normalizeString(name)) && len(name) > len(matched) {
+			bands, matched = courseBands, name
+		}
+	}
+	return bands
+}
+
+// bandFor returns the band a score falls into, or the lowest band
+func (s *GradeScale) bandFor(score float64) GradeBand {
+	return bandIn(s.Bands, score)
+}
+
+// bandForCourse is bandFor with the course's own bands
+func (s *GradeScale) bandForCourse(course string, score float64) GradeBand {
+	return bandIn(s.courseBands(course), score)
+}
+
+func bandIn(bands []GradeBand, score float64) GradeBand {
+	for _, band := range bands {
+		if score >= band.Min {
+			return band
+		}
+	}
+	return bands[len(bands)-1]
+}
+
+// estimateGPA averages grade points across courses that have a score
+func estimateGPA(scores []CourseScore, scale *GradeScale) *GPAEstimate {
+	estimate := &GPAEstimate{}
+	total := 0.0
+	for _, course := range scores {
+		if course.Score == nil {
+			continue
+		}
+		band := scale.bandForCourse(course.CourseName, *course.Score)
+		estimate.Courses = append(estimate.Courses, CourseGrade{
+			CourseName: course.CourseName,
+			Score:      *course.Score,
+			Letter:     band.Letter,
+			Points:     band.Points,
+		})
+		total += band.Points
+	}
+
+	if len(estimate.Courses) > 0 {
+		estimate.GPA = total / float64(len(estimate.Courses))
+	}
+	return estimate
+}
+
+// Line renders the estimate as a single summary line
+func (e *GPAEstimate) Line() string {
+	if len(e.Courses) == 0 {
+		return "Estimated GPA: n/a (no graded courses yet)"
+	}
+
+	var courses []string
+	for _, course := range e.Courses {
+		courses = append(courses, fmt.Sprintf("%s %.1f%% %s", course.CourseName, course.Score, course.Letter))
+	}
+	return fmt.Sprintf("Estimated GPA: %.2f (%s)", e.GPA, strings.Join(courses, ", "))
+}
+
+// Format renders the estimate as a per-course report
+func (e *GPAEstimate) Format() string {
+	var out strings.Builder
+	for _, course := range e.Courses {
+		out.WriteString(fmt.Sprintf("%-40s %6.1f%%  %-2s  %.1f\n", course.CourseName, course.Score, course.Letter, course.Points))
+	}
+	if len(e.Courses) == 0 {
+		out.WriteString("No graded courses yet\n")
+	} else {
+		out.WriteString(fmt.Sprintf("\nEstimated GPA: %.2f\n", e.GPA))
+	}
+	return out.String()
+}
+
+// GetCourseScores returns the current score for each active course
+func (c *CanvasClient) GetCourseScores() ([]CourseScore, error) {
+	body, err := c.makeRequest("/courses?enrollment_state=active&include[]=total_scores&per_page=100")
+	if err != nil {
+		return nil, err
+	}
+
+	var courses []struct {
+		ID          int    `json:"id"`
+		Name        string `json:"name"`
+		Enrollments []struct {
+			Type                 string   `json:"type"`
+			ComputedCurrentScore *float64 `json:"computed_current_score"`
+		} `json:"enrollments"`
+	}
+	if err := json.Unmarshal(body, &courses); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal course scores: %w", err)
+	}
+
+	var scores []CourseScore
+	for _, course := range courses {
+		score := CourseScore{CourseID: course.ID, CourseName: course.Name}
+		for _, enrollment := range course.Enrollments {
+			if enrollment.Type == "student" {
+				score.Score = enrollment.ComputedCurrentScore
+				break
+			}
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
+
+// EstimateGPA pulls current course scores from Canvas and maps them
+// through the configured grade scale
+func (c *CanvasClient) EstimateGPA() (*GPAEstimate, error) {
+	scale, err := LoadGradeScale()
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := c.GetCourseScores()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course scores: %w", err)
+	}
+
+	return estimateGPA(scores, scale), nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateGPA(t *testing.T) {
+	scale, err := LoadGradeScale()
+	if err != nil {
+		t.Fatalf("LoadGradeScale() error = %v", err)
+	}
+
+	scores := []CourseScore{
+		{CourseName: "Math", Score: floatPtr(95.2)},
+		{CourseName: "Biology", Score: floatPtr(88)},
+		{CourseName: "Spanish", Score: floatPtr(59.9)},
+		{CourseName: "Orchestra"}, // no score yet
+	}
+
+	estimate := estimateGPA(scores, scale)
+	if len(estimate.Courses) != 3 {
+		t.Fatalf("expected 3 graded courses, got %d", len(estimate.Courses))
+	}
+
+	letters := []string{"A", "B+", "F"}
+	for i, course := range estimate.Courses {
+		if course.Letter != letters[i] {
+			t.Errorf("%s letter = %s, want %s", course.CourseName, course.Letter, letters[i])
+		}
+	}
+
+	if want := (4.0 + 3.3 + 0.0) / 3; math.Abs(estimate.GPA-want) > 0.001 {
+		t.Errorf("GPA = %.3f, want %.3f", estimate.GPA, want)
+	}
+
+	if line := estimate.Line(); line != "Estimated GPA: 2.43 (Math 95.2% A, Biology 88.0% B+, Spanish 59.9% F)" {
+		t.Errorf("Line() = %q", line)
+	}
+}
+
+func TestCustomGradeScale(t *testing.T) {
+	scale := &GradeScale{Bands: []GradeBand{
+		{Min: 90, Letter: "A", Points: 4},
+		{Min: 80, Letter: "B", Points: 3},
+		{Min: 0, Letter: "C", Points: 2},
+	}}
+	if band := scale.bandFor(89.99); band.Letter != "B" {
+		t.Errorf("bandFor(89.99) = %s, want B", band.Letter)
+	}
+	if band := scale.bandFor(-5); band.Letter != "C" {
+		t.Errorf("bandFor(-5) = %s, want C", band.Letter)
+	}
+}
+
+func TestCourseGradeScale(t *testing.T) {
+	scale := &GradeScale{
+		Bands: []GradeBand{{Min: 90, Letter: "A", Points: 4}, {Min: 0, Letter: "F", Points: 0}},
+		Courses: map[string][]GradeBand{
+			"Spanish":    {{Min: 85, Letter: "A", Points: 4}, {Min: 0, Letter: "F", Points: 0}},
+			"Spanish II": {{Min: 80, Letter: "A", Points: 4}, {Min: 0, Letter: "F", Points: 0}},
+		},
+	}
+	tests := []struct {
+		course string
+		score  float64
+		want   string
+	}{
+		{"Math", 86, "F"},
+		{"Spanish I", 86, "A"},
+		{"Spanish II", 82, "A"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.course, func(t *testing.T) {
+			if band := scale.bandForCourse(tt.course, tt.score); band.Letter != tt.want {
+				t.Errorf("bandForCourse(%s, %.0f) = %s, want %s", tt.course, tt.score, band.Letter, tt.want)
+			}
+		})
+	}
+}
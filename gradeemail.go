@@ -0,0 +1,527 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"mime"
+	"mime/quotedprintable"
+	"net/smtp"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// gradeHistoryFile holds one line of course scores per day they were checked
+const gradeHistoryFile = "grade_history.jsonl"
+
+// gradeChartWeeks is how far back the emailed chart goes
+const gradeChartWeeks = 12
+
+// gradeChartPalette colors one line per course, in course order
+var gradeChartPalette = []color.RGBA{
+	{0x1f, 0x77, 0xb4, 0xff}, // blue
+	{0xd6, 0x27, 0x28, 0xff}, // red
+	{0x2c, 0xa0, 0x2c, 0xff}, // green
+	{0x94, 0x67, 0xbd, 0xff}, // purple
+	{0xff, 0x7f, 0x0e, 0xff}, // orange
+	{0x17, 0xbe, 0xcf, 0xff}, // teal
+	{0x8c, 0x56, 0x4b, 0xff}, // brown
+	{0xe3, 0x77, 0xc2, 0xff}, // pink
+}
+
+// GradeRecord is every course's score on one day, with any REDOs that
+// passed that day
+type GradeRecord struct {
+	Date         string             `json:"date"`
+	Scores       map[string]float64 `json:"scores"`
+	Improvements []GradeImprovement `json:"improvements,omitempty"`
+}
+
+// LoadGradeHistory reads every grade record, oldest first
+func LoadGradeHistory(path string) ([]GradeRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open grade history: %w", err)
+	}
+	defer file.Close()
+
+	var records []GradeRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record GradeRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal grade record: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read grade history: %w", err)
+	}
+	return records, nil
+}
+
+// saveGradeRecord adds a record to the grade history, replacing any earlier
+// record from the same day
+func saveGradeRecord(path string, record GradeRecord) ([]GradeRecord, error) {
+	records, err := LoadGradeHistory(path)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := false
+	for i := range records {
+		if records[i].Date == record.Date {
+			// A new day's scores keep the improvements already recorded
+			if record.Improvements == nil {
+				record.Improvements = records[i].Improvements
+			}
+			records[i] = record
+			replaced = true
+		}
+	}
+	if !replaced {
+		records = append(records, record)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Date < records[j].Date })
+
+	var data bytes.Buffer
+	for _, r := range records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal grade record: %w", err)
+		}
+		data.Write(line)
+		data.WriteByte('\n')
+	}
+
+	// Write to a temp file first so a crash can't truncate the history
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write grade history: %w", err)
+	}
+	return records, os.Rename(tmpPath, path)
+}
+
+// gradeRecordFrom builds today's record from the courses that have a score
+func gradeRecordFrom(scores []CourseScore, now time.Time) GradeRecord {
+	record := GradeRecord{Date: now.Format("2006-01-02"), Scores: make(map[string]float64)}
+	for _, score := range scores {
+		if score.Score != nil {
+			record.Scores[score.CourseName] = *score.Score
+		}
+	}
+	return record
+}
+
+// gradeCourses lists every course in the records, sorted by name
+func gradeCourses(records []GradeRecord) []string {
+	var courses []string
+	for _, record := range records {
+		for course := range record.Scores {
+			if !containsFold(courses, course) {
+				courses = append(courses, course)
+			}
+		}
+	}
+	sort.Strings(courses)
+	return courses
+}
+
+// chartColor is the line color for the i-th course
+func chartColor(i int) color.RGBA {
+	return gradeChartPalette[i%len(gradeChartPalette)]
+}
+
+// renderGradeChart draws each course's score over time as a PNG line chart.
+// The chart has no text (the email's legend names the lines); gridlines sit
+// every 10 points, with the 90% REDO cutoff drawn in orange.
+func renderGradeChart(records []GradeRecord, courses []string) ([]byte, error) {
+	const width, height, margin = 640, 300, 20
+
+	low, high := 100.0, 100.0
+	for _, record := range records {
+		for _, score := range record.Scores {
+			low = math.Min(low, score)
+			high = math.Max(high, score)
+		}
+	}
+	low = math.Min(60, math.Floor(low/10)*10)
+	high = math.Ceil(high/10) * 10
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	x := func(i int) int {
+		if len(records) < 2 {
+			return width / 2
+		}
+		return margin + i*(width-2*margin)/(len(records)-1)
+	}
+	y := func(score float64) int {
+		return height - margin - int((score-low)/(high-low)*float64(height-2*margin))
+	}
+
+	gridColor := color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+	cutoffColor := color.RGBA{0xff, 0xa5, 0x00, 0xff}
+	for line := low; line <= high; line += 10 {
+		c := gridColor
+		if line == 90 {
+			c = cutoffColor
+		}
+		for px := margin; px <= width-margin; px++ {
+			img.Set(px, y(line), c)
+		}
+	}
+
+	for ci, course := range courses {
+		c := chartColor(ci)
+		prevX, prevY, havePrev := 0, 0, false
+		for i, record := range records {
+			score, ok := record.Scores[course]
+			if !ok {
+				havePrev = false
+				continue
+			}
+			px, py := x(i), y(score)
+			if havePrev {
+				drawLine(img, prevX, prevY, px, py, c)
+			}
+			fillSquare(img, px, py, 3, c)
+			prevX, prevY, havePrev = px, py, true
+		}
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, img); err != nil {
+		return nil, fmt.Errorf("failed to encode grade chart: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// drawLine draws a 2px line with Bresenham's algorithm
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		fillSquare(img, x0, y0, 1, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			x0 += sx
+		} else {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// fillSquare fills a square of side 2*r centered on x, y
+func fillSquare(img *image.RGBA, x, y, r int, c color.RGBA) {
+	for px := x - r; px < x+r; px++ {
+		for py := y - r; py < y+r; py++ {
+			img.SetRGBA(px, py, c)
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// GradeEmail is the weekly grade email for parents
+type GradeEmail struct {
+	Date    time.Time
+	Courses []string
+	Latest  map[string]float64
+	Chart   []byte
+	Board   []byte // PNG of the board, if it rendered
+	Redos   []Card
+	Missing []Card
+}
+
+// buildGradeEmail gathers REDO cards (including locked ones) and overdue
+// cards still on the board. Cards in Done, Submitted, or the daily list
+// don't count as missing.
+func buildGradeEmail(records []GradeRecord, cards []Card, lists []List, dailyListID string, now time.Time) (*GradeEmail, error) {
+	// Days with only REDO improvements have no scores to chart
+	var scored []GradeRecord
+	for _, record := range records {
+		if len(record.Scores) > 0 {
+			scored = append(scored, record)
+		}
+	}
+	records = scored
+
+	if len(records) > gradeChartWeeks {
+		records = records[len(records)-gradeChartWeeks:]
+	}
+	email := &GradeEmail{Date: now, Courses: gradeCourses(records), Latest: map[string]float64{}}
+	if len(records) > 0 {
+		email.Latest = records[len(records)-1].Scores
+	}
+
+	chart, err := renderGradeChart(records, email.Courses)
+	if err != nil {
+		return nil, err
+	}
+	email.Chart = chart
+
+	listNames := make(map[string]string)
+	for _, list := range lists {
+		listNames[list.ID] = normalizeString(list.Name)
+	}
+	doneLists := doneListIDs(lists)
+	for _, card := range cards {
+		if card.Closed || card.IDList == dailyListID {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(card.Name, "REDO - "), strings.HasPrefix(card.Name, "LOCKED - "):
+			email.Redos = append(email.Redos, card)
+		case card.Due != nil && card.Due.Before(now) && !isCardDone(card, doneLists) &&
+			listNames[card.IDList] != "submitted":
+			email.Missing = append(email.Missing, card)
+		}
+	}
+	sortCardsByDue(email.Redos)
+	sortCardsByDue(email.Missing)
+	return email, nil
+}
+
+// Subject is the email's subject line
+func (e *GradeEmail) Subject() string {
+	return fmt.Sprintf("Weekly grades - %s", e.Date.Format("January 2, 2006"))
+}
+
+// HTML renders the email body; the images are referenced as cid:grade-chart
+// and cid:board-image
+func (e *GradeEmail) HTML() string {
+	var out strings.Builder
+	loc := e.Date.Location()
+
+	out.WriteString("<html><body style=\"font-family: sans-serif\">\n")
+	out.WriteString(fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(e.Subject())))
+
+	if len(e.Courses) == 0 {
+		out.WriteString("<p>No course scores recorded yet.</p>\n")
+	} else {
+		out.WriteString("<img src=\"cid:grade-chart\" alt=\"Grade trends by course\" width=\"640\" height=\"300\">\n")
+		out.WriteString("<table cellpadding=\"4\">\n")
+		for i, course := range e.Courses {
+			c := chartColor(i)
+			score := "-"
+			if latest, ok := e.Latest[course]; ok {
+				score = fmt.Sprintf("%.1f%%", latest)
+			}
+			out.WriteString(fmt.Sprintf("<tr><td><span style=\"color: #%02x%02x%02x\">&#9632;</span> %s</td><td align=\"right\">%s</td></tr>\n",
+				c.R, c.G, c.B, html.EscapeString(course), score))
+		}
+		out.WriteString("</table>\n<p style=\"color: #888\">The orange line marks 90%, the REDO cutoff.</p>\n")
+	}
+
+	writeCards := func(title string, cards []Card) {
+		out.WriteString(fmt.Sprintf("<h3>%s (%d)</h3>\n", title, len(cards)))
+		if len(cards) == 0 {
+			out.WriteString("<p>Nothing here 🎉</p>\n")
+			return
+		}
+		out.WriteString("<table cellpadding=\"4\" border=\"1\" style=\"border-collapse: collapse\">\n<tr><th align=\"left\">Card</th><th align=\"left\">Due</th></tr>\n")
+		for _, card := range cards {
+			due := ""
+			if card.Due != nil {
+				due = friendlyTime(*card.Due, loc)
+			}
+			out.WriteString(fmt.Sprintf("<tr><td><a href=\"%s\">%s</a></td><td>%s</td></tr>\n",
+				html.EscapeString(cardLink(card)), html.EscapeString(card.Name), html.EscapeString(due)))
+		}
+		out.WriteString("</table>\n")
+	}
+	writeCards("Missing", e.Missing)
+	writeCards("REDO", e.Redos)
+
+	if len(e.Board) > 0 {
+		out.WriteString("<h3>The board</h3>\n<img src=\"cid:board-image\" alt=\"Trello board\" style=\"max-width: 100%\">\n")
+	}
+
+	out.WriteString("</body></html>\n")
+	return out.String()
+}
+
+// Message builds the MIME message with the images as inline attachments
+func (e *GradeEmail) Message(from string, to []string) []byte {
+	const boundary = "grade-email-boundary"
+	var msg bytes.Buffer
+
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(to, ", ")))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", e.Subject())))
+	msg.WriteString(fmt.Sprintf("Date: %s\r\n", e.Date.Format(time.RFC1123Z)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString(fmt.Sprintf("Content-Type: multipart/related; boundary=%q\r\n\r\n", boundary))
+
+	msg.WriteString("--" + boundary + "\r\n")
+	msg.WriteString("Content-Type: text/html; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n")
+	qp := quotedprintable.NewWriter(&msg)
+	qp.Write([]byte(e.HTML()))
+	qp.Close()
+	msg.WriteString("\r\n")
+
+	writeImage := func(id, filename string, data []byte) {
+		msg.WriteString("--" + boundary + "\r\n")
+		msg.WriteString(fmt.Sprintf("Content-Type: image/png; name=%q\r\nContent-Transfer-Encoding: base64\r\n", filename))
+		msg.WriteString(fmt.Sprintf("Content-ID: <%s>\r\nContent-Disposition: inline; filename=%q\r\n\r\n", id, filename))
+		encoded := base64.StdEncoding.EncodeToString(data)
+		for len(encoded) > 76 {
+			msg.WriteString(encoded[:76] + "\r\n")
+			encoded = encoded[76:]
+		}
+		msg.WriteString(encoded + "\r\n")
+	}
+	writeImage("grade-chart", "grades.png", e.Chart)
+	if len(e.Board) > 0 {
+		writeImage("board-image", "board.png", e.Board)
+	}
+	msg.WriteString("--" + boundary + "--\r\n")
+	return msg.Bytes()
+}
+
+// SMTPConfig is where the grade email is sent from and to
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// smtpConfigFromEnv reads SMTP_HOST, SMTP_PORT (default 587), SMTP_USERNAME,
+// SMTP_PASSWORD, EMAIL_FROM (default SMTP_USERNAME), and PARENT_EMAILS
+func smtpConfigFromEnv() (*SMTPConfig, error) {
+	return smtpConfigFor("PARENT_EMAILS")
+}
+
+// smtpConfigFor reads the SMTP settings, sending to the addresses in toEnv
+func smtpConfigFor(toEnv string) (*SMTPConfig, error) {
+	config := &SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("EMAIL_FROM"),
+		To:       splitList(os.Getenv(toEnv)),
+	}
+	if config.Port == "" {
+		config.Port = "587"
+	}
+	if config.From == "" {
+		config.From = config.Username
+	}
+	if config.Host == "" || config.From == "" || len(config.To) == 0 {
+		return nil, fmt.Errorf("SMTP_HOST, SMTP_USERNAME (or EMAIL_FROM), and %s must be set", toEnv)
+	}
+	return config, nil
+}
+
+// SendGradeEmail records today's Canvas scores in the grade history and
+// emails parents the trend chart with the board's missing and REDO work.
+// With dryRun the message is written to a .eml file instead of sent.
+func (c *TrelloClient) SendGradeEmail(canvas *CanvasClient, boardName, dailyListName string, dryRun bool) error {
+	var config *SMTPConfig
+	if !dryRun {
+		var err error
+		if config, err = smtpConfigFromEnv(); err != nil {
+			return err
+		}
+	}
+
+	scores, err := canvas.GetCourseScores()
+	if err != nil {
+		return fmt.Errorf("failed to get course scores: %w", err)
+	}
+	now := time.Now().In(displayLocation())
+	records, err := saveGradeRecord(gradeHistoryFile, gradeRecordFrom(scores, now))
+	if err != nil {
+		return err
+	}
+
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return err
+	}
+	var dailyListID string
+	if daily, err := findListByName(cache.Lists, board.ID, dailyListName); err == nil {
+		dailyListID = daily.ID
+	}
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get board cards: %w", err)
+	}
+
+	email, err := buildGradeEmail(records, cards, cache.Lists, dailyListID, now)
+	if err != nil {
+		return err
+	}
+
+	var boardLists []List
+	for _, list := range cache.Lists {
+		if list.BoardID == board.ID {
+			boardLists = append(boardLists, list)
+		}
+	}
+	if email.Board, err = layoutBoard(*board, boardLists, cards, now).PNG(); err != nil {
+		fmt.Printf("Warning: leaving the board image out of the email: %v\n", err)
+	}
+
+	if dryRun {
+		path := fmt.Sprintf("grade_email_%s.eml", now.Format("2006-01-02"))
+		if err := os.WriteFile(path, email.Message("preview@localhost", []string{"parents@localhost"}), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Printf("%s Wrote grade email preview to %s (%d missing, %d REDO)\n", iconSuccess, path, len(email.Missing), len(email.Redos))
+		return nil
+	}
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+	if err := smtp.SendMail(config.Host+":"+config.Port, auth, config.From, config.To, email.Message(config.From, config.To)); err != nil {
+		return fmt.Errorf("failed to send grade email: %w", err)
+	}
+	fmt.Printf("%s Sent grade email to %s (%d missing, %d REDO)\n", iconSuccess, strings.Join(config.To, ", "), len(email.Missing), len(email.Redos))
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveGradeRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), gradeHistoryFile)
+
+	steps := []struct {
+		record GradeRecord
+		want   int
+	}{
+		{GradeRecord{Date: "2026-10-04", Scores: map[string]float64{"Math": 91}}, 1},
+		{GradeRecord{Date: "2026-10-11", Scores: map[string]float64{"Math": 88}}, 2},
+		{GradeRecord{Date: "2026-10-11", Scores: map[string]float64{"Math": 89}}, 2},
+	}
+	for _, step := range steps {
+		records, err := saveGradeRecord(path, step.record)
+		if err != nil {
+			t.Fatalf("saveGradeRecord() error = %v", err)
+		}
+		if len(records) != step.want {
+			t.Errorf("len(records) = %d, want %d", len(records), step.want)
+		}
+	}
+
+	records, err := LoadGradeHistory(path)
+	if err != nil {
+		t.Fatalf("LoadGradeHistory() error = %v", err)
+	}
+	if got := records[len(records)-1].Scores["Math"]; got != 89 {
+		t.Errorf("latest Math score = %v, want 89 (same-day rerun replaces)", got)
+	}
+}
+
+func TestRenderGradeChart(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []GradeRecord
+	}{
+		{"empty", nil},
+		{"one record", []GradeRecord{{Date: "2026-10-04", Scores: map[string]float64{"Math": 91}}}},
+		{"gaps and extra credit", []GradeRecord{
+			{Date: "2026-10-04", Scores: map[string]float64{"Math": 91, "Art": 104}},
+			{Date: "2026-10-11", Scores: map[string]float64{"Art": 99}},
+			{Date: "2026-10-18", Scores: map[string]float64{"Math": 42, "Art": 100}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := renderGradeChart(tt.records, gradeCourses(tt.records))
+			if err != nil {
+				t.Fatalf("renderGradeChart() error = %v", err)
+			}
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("chart is not a PNG: %v", err)
+			}
+			if bounds := img.Bounds(); bounds.Dx() != 640 || bounds.Dy() != 300 {
+				t.Errorf("chart size = %v, want 640x300", bounds)
+			}
+		})
+	}
+}
+
+func TestBuildGradeEmail(t *testing.T) {
+	now := time.Date(2026, 10, 18, 18, 0, 0, 0, time.UTC)
+	past := now.Add(-48 * time.Hour)
+	future := now.Add(48 * time.Hour)
+	lists := []List{{ID: "weekly", Name: "Weekly"}, {ID: "done", Name: "Done"}, {ID: "submitted", Name: "Submitted"}, {ID: "daily", Name: "Daily"}}
+	cards := []Card{
+		{ID: "1", Name: "REDO - Essay", IDList: "weekly", Due: &future},
+		{ID: "2", Name: "LOCKED - Lab report", IDList: "weekly", Due: &past},
+		{ID: "3", Name: "Worksheet", IDList: "weekly", Due: &past},
+		{ID: "4", Name: "Finished worksheet", IDList: "done", Due: &past},
+		{ID: "5", Name: "Turned in", IDList: "submitted", Due: &past},
+		{ID: "6", Name: "Checked off", IDList: "weekly", Due: &past, DueComplete: true},
+		{ID: "7", Name: "Read 20 min", IDList: "daily", Due: &past},
+		{ID: "8", Name: "Project", IDList: "weekly", Due: &future},
+		{ID: "9", Name: "Old", IDList: "weekly", Due: &past, Closed: true},
+	}
+	records := []GradeRecord{{Date: "2026-10-18", Scores: map[string]float64{"Math": 87.5, "Art <3": 95}}}
+
+	email, err := buildGradeEmail(records, cards, lists, "daily", now)
+	if err != nil {
+		t.Fatalf("buildGradeEmail() error = %v", err)
+	}
+
+	cardIDs := func(cards []Card) string {
+		var ids []string
+		for _, card := range cards {
+			ids = append(ids, card.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+	if got := cardIDs(email.Missing); got != "3" {
+		t.Errorf("Missing = %s, want 3", got)
+	}
+	if got := cardIDs(email.Redos); got != "2,1" {
+		t.Errorf("Redos = %s, want 2,1", got)
+	}
+
+	body := email.HTML()
+	for _, want := range []string{"cid:grade-chart", "87.5%", "Art &lt;3", "Worksheet", "REDO - Essay"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("HTML() missing %q", want)
+		}
+	}
+
+	message := string(email.Message("me@example.com", []string{"mom@example.com", "dad@example.com"}))
+	for _, want := range []string{"To: mom@example.com, dad@example.com\r\n", "multipart/related", "Content-ID: <grade-chart>", "Content-Type: image/png"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Message() missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	gradeScaleOnce   sync.Once
+	loadedGradeScale *GradeScale
+)
+
+// activeGradeScale loads the grade scale once for reading letter grades.
+// It's nil when grade_scale.json is broken, and letters then go unread.
+func activeGradeScale() *GradeScale {
+	gradeScaleOnce.Do(func() {
+		scale, err := LoadGradeScale()
+		if err != nil {
+			fmt.Printf("Warning: letter grades won't be read: %v\n", err)
+			return
+		}
+		loadedGradeScale = scale
+	})
+	return loadedGradeScale
+}
+
+// Percent reads a grade as an LMS reports it: a percentage ("87%"), points
+// ("4/5", or "4" out of pointsPossible), complete/incomplete, or a letter
+// ("B+") from the course's bands, which counts as the band's cutoff. A bare
+// number with no points possible is taken as a percentage.
+func (s *GradeScale) Percent(course, grade string, pointsPossible float64) (float64, bool) {
+	grade = strings.TrimSpace(grade)
+	if grade == "" {
+		return 0, false
+	}
+
+	if value, found := strings.CutSuffix(grade, "%"); found {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return percent, err == nil
+	}
+	if earned, possible, found := strings.Cut(grade, "/"); found {
+		e, err1 := strconv.ParseFloat(strings.TrimSpace(earned), 64)
+		p, err2 := strconv.ParseFloat(strings.TrimSpace(possible), 64)
+		if err1 != nil || err2 != nil || p <= 0 {
+			return 0, false
+		}
+		return e / p * 100, true
+	}
+	if points, err := strconv.ParseFloat(grade, 64); err == nil {
+		if pointsPossible > 0 {
+			return points / pointsPossible * 100, true
+		}
+		return points, true
+	}
+
+	switch strings.ToLower(grade) {
+	case "complete", "pass":
+		return 100, true
+	case "incomplete", "fail":
+		return 0, true
+	}
+	if s != nil {
+		for _, band := range s.courseBands(course) {
+			if strings.EqualFold(band.Letter, grade) {
+				return band.Min, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// canvasPercent is a Canvas submission's grade as a percentage. The score
+// is points, so it's scaled by the points possible; without those (or a
+// score) the grade text is read through the grade scale. Scores on
+// assignments with no points possible are already percentages.
+func canvasPercent(assignment CanvasAssignment, submission *CanvasSubmission, course string, scale *GradeScale) (float64, bool) {
+	if submission == nil {
+		return 0, false
+	}
+	if submission.Score != nil && assignment.PointsPossible > 0 {
+		return *submission.Score / assignment.PointsPossible * 100, true
+	}
+	if percent, ok := scale.Percent(course, submission.Grade, assignment.PointsPossible); ok {
+		return percent, true
+	}
+	if submission.Score != nil {
+		return *submission.Score, true
+	}
+	return 0, false
+}
@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+func TestGradeScalePercent(t *testing.T) {
+	scale := &GradeScale{
+		Bands: []GradeBand{
+			{Min: 90, Letter: "A-", Points: 3.7},
+			{Min: 87, Letter: "B+", Points: 3.3},
+			{Min: 0, Letter: "F", Points: 0},
+		},
+		Courses: map[string][]GradeBand{"Art": {{Min: 80, Letter: "B+", Points: 3.3}, {Min: 0, Letter: "F", Points: 0}}},
+	}
+	tests := []struct {
+		name     string
+		course   string
+		grade    string
+		possible float64
+		want     float64
+		ok       bool
+	}{
+		{"percent", "Math", "87%", 0, 87, true},
+		{"fraction", "Math", "4/5", 0, 80, true},
+		{"points", "Math", "18", 20, 90, true},
+		{"bare number", "Math", "92.5", 0, 92.5, true},
+		{"letter", "Math", "b+", 0, 87, true},
+		{"course letter", "Art 2", "B+", 0, 80, true},
+		{"complete", "Math", "complete", 0, 100, true},
+		{"incomplete", "Math", "incomplete", 0, 0, true},
+		{"ungraded", "Math", "", 0, 0, false},
+		{"unknown letter", "Math", "Q", 0, 0, false},
+		{"zero out of zero", "Math", "0/0", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := scale.Percent(tt.course, tt.grade, tt.possible)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Percent(%q) = %v, %v, want %v, %v", tt.grade, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	var none *GradeScale
+	if _, ok := none.Percent("Math", "B+", 0); ok {
+		t.Error("a missing scale read a letter grade")
+	}
+}
+
+func TestCanvasPercent(t *testing.T) {
+	scale := &GradeScale{Bands: []GradeBand{{Min: 87, Letter: "B+"}, {Min: 0, Letter: "F"}}}
+	tests := []struct {
+		name       string
+		assignment CanvasAssignment
+		submission *CanvasSubmission
+		want       float64
+		ok         bool
+	}{
+		{"points", CanvasAssignment{PointsPossible: 5}, &CanvasSubmission{Score: floatPtr(4), Grade: "4"}, 80, true},
+		{"letter only", CanvasAssignment{PointsPossible: 0}, &CanvasSubmission{Grade: "B+"}, 87, true},
+		{"no points possible", CanvasAssignment{}, &CanvasSubmission{Score: floatPtr(95)}, 95, true},
+		{"ungraded", CanvasAssignment{PointsPossible: 10}, &CanvasSubmission{}, 0, false},
+		{"no submission", CanvasAssignment{PointsPossible: 10}, nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := canvasPercent(tt.assignment, tt.submission, "Math", scale)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("canvasPercent() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// historyFile holds one board-state snapshot per line, one line per day
+const historyFile = "board_history.jsonl"
+
+// StateSnapshot is a compact record of every board's cards on one day
+type StateSnapshot struct {
+	Date    string       `json:"date"`
+	TakenAt time.Time    `json:"takenAt"`
+	Boards  []BoardState `json:"boards"`
+}
+
+// BoardState is one board's lists and open cards in a snapshot
+type BoardState struct {
+	ID    string      `json:"id"`
+	Name  string      `json:"name"`
+	Lists []ListState `json:"lists"`
+	Cards []CardState `json:"cards"`
+}
+
+// ListState names a list so cards can refer to it by ID
+type ListState struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CardState is the part of a card needed for burndown, aging, and streaks
+type CardState struct {
+	ID      string     `json:"id"`
+	Name    string     `json:"name"`
+	List    string     `json:"list"`
+	Created time.Time  `json:"created"`
+	Due     *time.Time `json:"due,omitempty"`
+	Done    bool       `json:"done,omitempty"`
+}
+
+// cardCreatedAt decodes the creation time embedded in a Trello ID (the
+// first 8 hex digits are a Unix timestamp)
+func cardCreatedAt(id string) time.Time {
+	if len(id) < 8 {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
+
+// buildBoardState compacts a board's lists and cards for a snapshot
+func buildBoardState(board Board, lists []List, cards []Card) BoardState {
+	state := BoardState{ID: board.ID, Name: board.Name}
+	doneLists := doneListIDs(lists)
+	for _, list := range lists {
+		state.Lists = append(state.Lists, ListState{ID: list.ID, Name: list.Name})
+	}
+	for _, card := range cards {
+		if card.Closed {
+			continue
+		}
+		state.Cards = append(state.Cards, CardState{
+			ID:      card.ID,
+			Name:    card.Name,
+			List:    card.IDList,
+			Created: cardCreatedAt(card.ID),
+			Due:     card.Due,
+			Done:    isCardDone(card, doneLists),
+		})
+	}
+	return state
+}
+
+// LoadHistory reads every snapshot from a history file, oldest first. When
+// a day was snapshotted more than once, its last snapshot wins.
+func LoadHistory(path string) ([]StateSnapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer file.Close()
+
+	var snapshots []StateSnapshot
+	byDate := make(map[string]int)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024) // Lines hold whole boards
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var snapshot StateSnapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+		}
+		if i, ok := byDate[snapshot.Date]; ok {
+			snapshots[i] = snapshot
+			continue
+		}
+		byDate[snapshot.Date] = len(snapshots)
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// saveSnapshot appends a snapshot to the history file as one line, so the
+// nightly run costs the same however long the history grows. A rerun on
+// the same day adds another line, and LoadHistory keeps only the last.
+func saveSnapshot(path string, snapshot StateSnapshot) error {
+	line, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	// One write per line, so a crash can't leave half a snapshot mid-file
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// TakeSnapshot records today's state of every board in the history file
+func (c *TrelloClient) TakeSnapshot() error {
+	boards, err := c.GetBoards()
+	if err != nil {
+		return fmt.Errorf("failed to get boards: %w", err)
+	}
+
+	now := time.Now()
+	snapshot := StateSnapshot{Date: now.Format("2006-01-02"), TakenAt: now}
+	cardCount := 0
+	for _, board := range boards {
+		lists, cards, err := c.fetchBoardData(board.ID)
+		if err != nil {
+			fmt.Printf("Warning: skipping board %s in snapshot: %v\n", board.Name, err)
+			continue
+		}
+		state := buildBoardState(board, lists, cards)
+		cardCount += len(state.Cards)
+		snapshot.Boards = append(snapshot.Boards, state)
+	}
+
+	if err := saveSnapshot(historyFile, snapshot); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Saved snapshot of %d board(s), %d card(s) to %s\n", iconSuccess, len(snapshot.Boards), cardCount, historyFile)
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCardCreatedAt(t *testing.T) {
+	// 0x68c9a2c0 = 1758044864
+	created := cardCreatedAt("68c9a2c0a1b2c3d4e5f60718")
+	if expected := time.Unix(0x68c9a2c0, 0).UTC(); !created.Equal(expected) {
+		t.Errorf("cardCreatedAt() = %v, want %v", created, expected)
+	}
+	if !cardCreatedAt("bogus").IsZero() {
+		t.Error("expected zero time for an invalid ID")
+	}
+}
+
+func TestSaveSnapshotReplacesSameDay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	board := Board{ID: "b1", Name: "Makai School"}
+	lists := []List{{ID: "l1", Name: "Daily"}}
+
+	first := StateSnapshot{Date: "2025-10-06", Boards: []BoardState{buildBoardState(board, lists, []Card{{ID: "68c9a2c0a1b2c3d4e5f60718", IDList: "l1"}})}}
+	rerun := StateSnapshot{Date: "2025-10-06", Boards: []BoardState{buildBoardState(board, lists, nil)}}
+	next := StateSnapshot{Date: "2025-10-07", Boards: []BoardState{buildBoardState(board, lists, []Card{{ID: "c2", Closed: true}})}}
+
+	for _, snapshot := range []StateSnapshot{first, rerun, next} {
+		if err := saveSnapshot(path, snapshot); err != nil {
+			t.Fatalf("saveSnapshot() error = %v", err)
+		}
+	}
+
+	// Each save appends; the same-day rerun is resolved when reading
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Errorf("history file has %d lines, want 3 appended snapshots", lines)
+	}
+
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 days of history, got %d", len(history))
+	}
+	if len(history[0].Boards[0].Cards) != 0 {
+		t.Errorf("rerun should replace the first snapshot of the day")
+	}
+	if len(history[1].Boards[0].Cards) != 0 {
+		t.Errorf("closed cards should be left out of snapshots")
+	}
+}
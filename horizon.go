@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Horizon lists sort synced cards by how soon they're due. Routing by due
+// date only happens on boards that have all three.
+const (
+	horizonThisWeek = "This Week"
+	horizonNextWeek = "Next Week"
+	horizonLater    = "Later"
+)
+
+var horizonLists = []string{horizonThisWeek, horizonNextWeek, horizonLater}
+
+// horizonListForDue picks the horizon list for a due date. Weeks run
+// through Sunday night, and overdue work stays in This Week.
+func horizonListForDue(due, now time.Time) string {
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	daysUntilSunday := (7 - int(now.Weekday())) % 7
+	endOfWeek := startOfToday.AddDate(0, 0, daysUntilSunday+1)
+
+	switch {
+	case due.Before(endOfWeek):
+		return horizonThisWeek
+	case due.Before(endOfWeek.AddDate(0, 0, 7)):
+		return horizonNextWeek
+	}
+	return horizonLater
+}
+
+// horizonListIDs returns the board's horizon list IDs by name, or nil when
+// the board doesn't have all of them
+func (c *TrelloClient) horizonListIDs(boardName string) map[string]string {
+	ids := make(map[string]string)
+	for _, name := range horizonLists {
+		listID, err := c.FindListByName(boardName, name)
+		if err != nil {
+			return nil
+		}
+		ids[name] = listID
+	}
+	return ids
+}
+
+// horizonListForCard picks the horizon list for a card being placed. REDOs
+// belong in Weekly, so they get "", and undated cards go in This Week.
+func horizonListForCard(cardTitle string, due *time.Time, now time.Time) string {
+	if strings.HasPrefix(cardTitle, "REDO - ") {
+		return ""
+	}
+	if due == nil {
+		return horizonThisWeek
+	}
+	return horizonListForDue(*due, now)
+}
+
+// rebucketMoves finds the cards in a horizon list whose due date now puts
+// them in another one, returning the new list name by card ID
+func rebucketMoves(cards []Card, horizons map[string]string, now time.Time) map[string]string {
+	inHorizon := make(map[string]bool)
+	for _, listID := range horizons {
+		inHorizon[listID] = true
+	}
+
+	moves := make(map[string]string)
+	for _, card := range cards {
+		if !inHorizon[card.IDList] || card.Due == nil || card.DueComplete || isNoAuto(card) {
+			continue
+		}
+		if target := horizonListForDue(*card.Due, now); horizons[target] != card.IDList {
+			moves[card.ID] = target
+		}
+	}
+	return moves
+}
+
+// RebucketCards moves cards between This Week, Next Week, and Later as
+// their due dates get closer, then sorts each list
+func (c *TrelloClient) RebucketCards(boardName string) error {
+	horizons := c.horizonListIDs(boardName)
+	if horizons == nil {
+		return fmt.Errorf("%s needs %s lists to re-bucket cards", boardName, strings.Join(horizonLists, ", "))
+	}
+
+	cards, err := c.GetAllBoardCards(boardName)
+	if err != nil {
+		return fmt.Errorf("failed to get Trello cards: %w", err)
+	}
+
+	moves := rebucketMoves(cards, horizons, time.Now().In(displayLocation()))
+	for _, card := range cards {
+		target, ok := moves[card.ID]
+		if !ok {
+			continue
+		}
+		fmt.Printf("Moving %s to %s\n", card.Name, target)
+		if err := c.MoveCardToList(card.ID, horizons[target]); err != nil {
+			fmt.Printf("Warning: failed to move %s to %s: %v\n", card.Name, target, err)
+		}
+	}
+
+	for _, name := range horizonLists {
+		if err := c.SortListCards(horizons[name]); err != nil {
+			fmt.Printf("Warning: failed to sort %s: %v\n", name, err)
+		}
+	}
+
+	fmt.Printf("%s Re-bucketed %s\n", iconSuccess, plural(len(moves), "card"))
+	return nil
+}
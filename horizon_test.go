@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHorizonListForDue(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		name string
+		due  time.Time
+		want string
+	}{
+		{"overdue", now.AddDate(0, 0, -3), horizonThisWeek},
+		{"today", now.Add(2 * time.Hour), horizonThisWeek},
+		{"sunday night", time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC), horizonThisWeek},
+		{"monday", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), horizonNextWeek},
+		{"next sunday", time.Date(2026, 10, 25, 23, 0, 0, 0, time.UTC), horizonNextWeek},
+		{"week after", time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC), horizonLater},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := horizonListForDue(tt.due, now); got != tt.want {
+				t.Errorf("horizonListForDue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHorizonListForCard(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	later := now.AddDate(0, 0, 30)
+
+	tests := []struct {
+		name  string
+		title string
+		due   *time.Time
+		want  string
+	}{
+		{"dated", "Math - Ch 4", &later, horizonLater},
+		{"undated", "Math - Project", nil, horizonThisWeek},
+		{"redo stays in Weekly", "REDO - Math - Ch 3", &later, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := horizonListForCard(tt.title, tt.due, now); got != tt.want {
+				t.Errorf("horizonListForCard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRebucketMoves(t *testing.T) {
+	now := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC) // Monday
+	at := func(days int) *time.Time {
+		t := now.AddDate(0, 0, days)
+		return &t
+	}
+	horizons := map[string]string{horizonThisWeek: "this", horizonNextWeek: "next", horizonLater: "later"}
+
+	cards := []Card{
+		{ID: "advance", IDList: "next", Due: at(2)},
+		{ID: "stays", IDList: "this", Due: at(1)},
+		{ID: "two-weeks-out", IDList: "later", Due: at(8)},
+		{ID: "moved-back", IDList: "this", Due: at(20)},
+		{ID: "other-list", IDList: "weekly", Due: at(20)},
+		{ID: "undated", IDList: "later"},
+		{ID: "complete", IDList: "next", Due: at(1), DueComplete: true},
+		{ID: "no-auto", IDList: "next", Due: at(1), Name: "Essay [no-auto]"},
+	}
+
+	got := rebucketMoves(cards, horizons, now)
+	want := map[string]string{"advance": horizonThisWeek, "two-weeks-out": horizonNextWeek, "moved-back": horizonLater}
+	if len(got) != len(want) {
+		t.Fatalf("rebucketMoves() = %v, want %v", got, want)
+	}
+	for id, list := range want {
+		if got[id] != list {
+			t.Errorf("rebucketMoves()[%s] = %q, want %q", id, got[id], list)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Hygiene rules, in the order --hygiene reports them
+const (
+	hygieneNoDue      = "no-due"
+	hygieneDuplicates = "duplicates"
+	hygieneLabels     = "labels"
+	hygieneWrongList  = "wrong-list"
+	hygieneEmptyDesc  = "empty-desc"
+)
+
+var hygieneRules = []string{hygieneNoDue, hygieneDuplicates, hygieneLabels, hygieneWrongList, hygieneEmptyDesc}
+
+// emptyDescPlaceholder is written above the metadata of synced cards with no instructions
+const emptyDescPlaceholder = "No instructions came through the sync; check the source link below."
+
+// syncedSourceRegex finds the "<Source> ID: <id>" line sync metadata starts with
+var syncedSourceRegex = regexp.MustCompile(`(?m)^---\n([A-Za-z][A-Za-z ]*) ID: (\S+)`)
+
+// HygieneIssue is one problem found on a board, with the change that fixes it
+type HygieneIssue struct {
+	Rule    string
+	Card    Card
+	Problem string
+	Patch   CardPatch // applied by --hygiene-fix
+	LabelID string    // label added by --hygiene-fix
+}
+
+// syncedSourceKey identifies the item a synced card came from, or "" for
+// cards added by hand
+func syncedSourceKey(card Card) string {
+	match := syncedSourceRegex.FindStringSubmatch(card.Description)
+	if match == nil {
+		return ""
+	}
+	return match[1] + ":" + match[2]
+}
+
+// syncedBody is the part of a synced card's description above its metadata,
+// without the date lines the LMS syncs add
+func syncedBody(description string) string {
+	var kept []string
+	for _, line := range strings.Split(stripCanvasMetadata(description), "\n") {
+		if strings.HasPrefix(line, "🔓 Opens ") || strings.HasPrefix(line, "📅 Due ") || strings.HasPrefix(line, "🔒 Locks ") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// weekEndDue is 11:59 PM on the Sunday ending now's week
+func weekEndDue(now time.Time) time.Time {
+	daysUntilSunday := (7 - int(now.Weekday())) % 7
+	sunday := now.AddDate(0, 0, daysUntilSunday)
+	return time.Date(sunday.Year(), sunday.Month(), sunday.Day(), 23, 59, 0, 0, now.Location())
+}
+
+// checkHygiene finds problems with a board's open cards:
+//   - no-due: Weekly cards without a due date (fix: due end of this week)
+//   - duplicates: cards with the same title from the same source (fix: archive all but the oldest)
+//   - labels: "Subject - ..." cards missing the board's label named Subject (fix: add it)
+//   - wrong-list: finished cards outside the done lists (Daily and Submitted excepted), REDOs outside Weekly (fix: move them)
+//   - empty-desc: synced cards with no instructions (fix: add a placeholder pointing at the source)
+func checkHygiene(cards []Card, lists []List, labels []Label, now time.Time) []HygieneIssue {
+	listIDs := make(map[string]string)
+	for _, list := range lists {
+		listIDs[normalizeString(list.Name)] = list.ID
+	}
+	doneLists := doneListIDs(lists)
+
+	var issues []HygieneIssue
+	add := func(rule string, card Card, problem string, patch CardPatch, labelID string) {
+		issues = append(issues, HygieneIssue{Rule: rule, Card: card, Problem: problem, Patch: patch, LabelID: labelID})
+	}
+
+	// Trello IDs start with a timestamp, so sorting by ID puts the oldest card first
+	sorted := append([]Card(nil), cards...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	seen := make(map[string]Card)
+	for _, card := range sorted {
+		if card.Closed {
+			continue
+		}
+
+		if weekly, ok := listIDs["weekly"]; ok && card.IDList == weekly && card.Due == nil {
+			due := weekEndDue(now).UTC().Format(trelloDueLayout)
+			add(hygieneNoDue, card, "no due date in Weekly", CardPatch{Due: &due}, "")
+		}
+
+		key := normalizeString(card.Name) + "|" + syncedSourceKey(card)
+		if first, ok := seen[key]; ok {
+			add(hygieneDuplicates, card, fmt.Sprintf("duplicate of %s", cardLink(first)), CardPatch{Closed: boolPtr(true)}, "")
+		} else {
+			seen[key] = card
+		}
+
+		if subject, _, found := strings.Cut(card.Name, " - "); found {
+			label := findLabel(labels, LabelSpec{Name: subject})
+			if label != nil && findLabel(card.Labels, LabelSpec{Name: subject}) == nil {
+				add(hygieneLabels, card, fmt.Sprintf("missing the '%s' label", label.Name), CardPatch{}, label.ID)
+			}
+		}
+
+		target := ""
+		switch {
+		case strings.HasPrefix(card.Name, "REDO - ") && !card.DueComplete:
+			target = "Weekly"
+		case card.DueComplete && !doneLists[card.IDList] && card.IDList != listIDs["daily"] && card.IDList != listIDs["submitted"]:
+			target = "Done"
+		}
+		if listID, ok := listIDs[normalizeString(target)]; ok && card.IDList != listID {
+			add(hygieneWrongList, card, fmt.Sprintf("belongs in %s", target), CardPatch{IDList: &listID, Pos: stringPtr("top")}, "")
+		}
+
+		if syncedSourceKey(card) != "" && syncedBody(card.Description) == "" {
+			desc := emptyDescPlaceholder + "\n\n" + strings.TrimLeft(card.Description, "\n")
+			add(hygieneEmptyDesc, card, "synced with an empty description", CardPatch{Desc: &desc}, "")
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return ruleIndex(issues[i].Rule) < ruleIndex(issues[j].Rule)
+	})
+	return issues
+}
+
+// ruleIndex orders hygiene rules as listed in hygieneRules
+func ruleIndex(rule string) int {
+	for i, r := range hygieneRules {
+		if r == rule {
+			return i
+		}
+	}
+	return len(hygieneRules)
+}
+
+// parseHygieneRules turns a comma-separated rule list into a set; "all"
+// selects every rule
+func parseHygieneRules(value string) (map[string]bool, error) {
+	rules := make(map[string]bool)
+	for _, rule := range splitList(value) {
+		rule = normalizeString(rule)
+		if rule == "all" {
+			for _, r := range hygieneRules {
+				rules[r] = true
+			}
+			continue
+		}
+		if ruleIndex(rule) == len(hygieneRules) {
+			return nil, fmt.Errorf("unknown hygiene rule '%s' (expected one of: %s, or all)", rule, strings.Join(hygieneRules, ", "))
+		}
+		rules[rule] = true
+	}
+	return rules, nil
+}
+
+// BoardHygiene reports problems on a board's open cards and fixes those
+// whose rule is in fixRules
+func (c *TrelloClient) BoardHygiene(boardName string, fixRules map[string]bool) error {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return err
+	}
+
+	var lists []List
+	for _, list := range cache.Lists {
+		if list.BoardID == board.ID {
+			lists = append(lists, list)
+		}
+	}
+
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get board cards: %w", err)
+	}
+
+	labels, err := c.GetBoardLabels(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get board labels: %w", err)
+	}
+
+	issues := checkHygiene(withoutNoAuto(cards), lists, labels, time.Now())
+	if len(issues) == 0 {
+		fmt.Printf("%s %s looks tidy\n", iconSuccess, board.Name)
+		return nil
+	}
+
+	fixed := 0
+	rule := ""
+	for _, issue := range issues {
+		if issue.Rule != rule {
+			rule = issue.Rule
+			fmt.Printf("\n%s:\n", rule)
+		}
+		if !fixRules[issue.Rule] {
+			fmt.Printf("  - %s: %s\n", issue.Card.Name, issue.Problem)
+			continue
+		}
+
+		if issue.LabelID != "" {
+			err = c.AddLabelIDToCard(issue.Card.ID, issue.LabelID)
+		} else {
+			err = c.UpdateCardFields(issue.Card.ID, issue.Patch)
+		}
+		if err != nil {
+			fmt.Printf("Warning: failed to fix %s (%s): %v\n", issue.Card.Name, issue.Problem, err)
+			continue
+		}
+		fmt.Printf("  %s %s: %s (fixed)\n", iconCheck, issue.Card.Name, issue.Problem)
+		fixed++
+	}
+
+	fmt.Printf("\nFound %d issue(s) on %s, fixed %d\n", len(issues), board.Name, fixed)
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckHygiene(t *testing.T) {
+	now := time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC) // Wednesday
+	due := now.AddDate(0, 0, 1)
+	lists := []List{{ID: "d", Name: "Daily"}, {ID: "w", Name: "Weekly"}, {ID: "done", Name: "Done"}}
+	labels := []Label{{ID: "bio", Name: "Biology"}}
+	synced := "\n\n📅 Due Thu, Oct 9\n\n---\nCanvas Assignment ID: 7\nCourse: Biology"
+
+	cards := []Card{
+		{ID: "1", Name: "Biology - Lab Report", IDList: "w", Due: &due, Labels: []Label{{ID: "bio", Name: "Biology"}}, Description: "Write it up" + synced},
+		{ID: "2", Name: "biology - lab report", IDList: "w", Due: &due, Labels: []Label{{ID: "bio", Name: "Biology"}}, Description: "Write it up" + synced},
+		{ID: "3", Name: "Biology - Quiz", IDList: "w", Due: &due, Description: synced},
+		{ID: "4", Name: "Read a book", IDList: "w"},
+		{ID: "5", Name: "Make bed", IDList: "d", Due: &due, DueComplete: true},
+		{ID: "6", Name: "Essay", IDList: "w", Due: &due, DueComplete: true},
+		{ID: "7", Name: "REDO - Essay", IDList: "done", Due: &due},
+	}
+
+	got := make(map[string][]string)
+	for _, issue := range checkHygiene(cards, lists, labels, now) {
+		got[issue.Rule] = append(got[issue.Rule], issue.Card.ID)
+	}
+
+	tests := []struct {
+		rule string
+		want []string
+	}{
+		{hygieneNoDue, []string{"4"}},
+		{hygieneDuplicates, []string{"2"}},
+		{hygieneLabels, []string{"3"}},
+		{hygieneWrongList, []string{"6", "7"}},
+		{hygieneEmptyDesc, []string{"3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			if len(got[tt.rule]) != len(tt.want) {
+				t.Fatalf("%s issues = %v, want %v", tt.rule, got[tt.rule], tt.want)
+			}
+			for i := range tt.want {
+				if got[tt.rule][i] != tt.want[i] {
+					t.Errorf("%s issues = %v, want %v", tt.rule, got[tt.rule], tt.want)
+				}
+			}
+		})
+	}
+
+	for _, issue := range checkHygiene(cards[3:4], lists, labels, now) {
+		if issue.Patch.Due == nil || *issue.Patch.Due != "2025-10-12T23:59:00.000Z" {
+			t.Errorf("no-due fix = %v, want Sunday night", issue.Patch.Due)
+		}
+	}
+}
+
+func TestParseHygieneRules(t *testing.T) {
+	rules, err := parseHygieneRules("No-Due, labels")
+	if err != nil || !rules[hygieneNoDue] || !rules[hygieneLabels] || len(rules) != 2 {
+		t.Errorf("parseHygieneRules() = %v, %v", rules, err)
+	}
+	if all, _ := parseHygieneRules("all"); len(all) != len(hygieneRules) {
+		t.Errorf("all should enable every rule, got %v", all)
+	}
+	if _, err := parseHygieneRules("tidy"); err == nil {
+		t.Errorf("unknown rule should be an error")
+	}
+	if none, _ := parseHygieneRules(""); len(none) != 0 {
+		t.Errorf("empty value should fix nothing, got %v", none)
+	}
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// epicMetadataPrefix marks an epic overview card, followed by the epic's key
+const epicMetadataPrefix = "JIRA Epic ID: "
+
+// epicLabelColor is the color of the labels that group cards by epic
+const epicLabelColor = "purple"
+
+// epicLabelPrefix starts the name of every epic label, e.g. "Epic: Router Upgrade"
+const epicLabelPrefix = "Epic: "
+
+// jiraEpicRegex reads the epic or parent line from a task's STATUS.md or
+// task file, e.g. "- **Epic**: AK-100 (Router Upgrade)" or "- **Parent**: AK-100 Router Upgrade"
+var jiraEpicRegex = regexp.MustCompile(`(?m)^\s*- \*\*(?:Epic(?: Link)?|Parent)\*\*:[ \t]*([A-Z][A-Z0-9]+-\d+)[ \t]*(.*)$`)
+
+// parseJiraEpic returns the epic key and name from a task's notes
+func parseJiraEpic(content string) (key, name string) {
+	match := jiraEpicRegex.FindStringSubmatch(content)
+	if match == nil {
+		return "", ""
+	}
+	name = strings.TrimSpace(match[2])
+	name = strings.TrimSpace(strings.TrimLeft(name, "-–:"))
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "("), ")")
+	return match[1], strings.TrimSpace(name)
+}
+
+// epicGrouping reads JIRA_EPIC_GROUPING: label (the default) puts an
+// "Epic: <name>" label on each card, prefix puts "[<name>]" after the task
+// ID in its title, and off does neither
+func epicGrouping() string {
+	switch value := strings.ToLower(os.Getenv("JIRA_EPIC_GROUPING")); value {
+	case "", "label":
+		return "label"
+	case "prefix", "off":
+		return value
+	default:
+		fmt.Printf("Warning: invalid JIRA_EPIC_GROUPING '%s' (want label, prefix, or off), using label\n", value)
+		return "label"
+	}
+}
+
+// epicDisplayName is the epic's name, or its key when the name isn't known
+func (t JiraTask) epicDisplayName() string {
+	if t.EpicName != "" {
+		return t.EpicName
+	}
+	return t.Epic
+}
+
+// epicPrefixRegex matches the "[Epic] " a prefix grouping adds after the task ID
+var epicPrefixRegex = regexp.MustCompile(`^\[[^\]]*\] `)
+
+// epicTitle gives a card title with the task's epic prefix, replacing any
+// earlier one, or just strips it when the task has no epic
+func epicTitle(title string, task JiraTask) string {
+	rest, found := strings.CutPrefix(title, task.ID+": ")
+	if !found {
+		return title
+	}
+	rest = epicPrefixRegex.ReplaceAllString(rest, "")
+	if task.Epic == "" {
+		return task.ID + ": " + rest
+	}
+	return fmt.Sprintf("%s: [%s] %s", task.ID, task.epicDisplayName(), rest)
+}
+
+// staleEpicLabels returns a card's epic labels other than the one it should have
+func staleEpicLabels(card Card, want string) []Label {
+	var stale []Label
+	for _, label := range card.Labels {
+		if strings.HasPrefix(label.Name, epicLabelPrefix) && label.Name != want {
+			stale = append(stale, label)
+		}
+	}
+	return stale
+}
+
+// applyEpicGrouping labels or prefixes a task's card with its epic
+func (c *TrelloClient) applyEpicGrouping(card Card, task JiraTask, grouping string) {
+	switch grouping {
+	case "prefix":
+		if title := epicTitle(card.Name, task); title != card.Name {
+			if err := c.UpdateCardTitle(card.ID, title); err != nil {
+				fmt.Printf("  Warning: failed to add the epic to the title: %v\n", err)
+			}
+		}
+	case "label":
+		want := ""
+		if task.Epic != "" {
+			want = epicLabelPrefix + task.epicDisplayName()
+		}
+		for _, label := range staleEpicLabels(card, want) {
+			if err := c.RemoveLabelFromCard(card.ID, label.ID); err != nil {
+				fmt.Printf("  Warning: failed to remove the %s label: %v\n", label.Name, err)
+			}
+		}
+		if want == "" || hasLabelNamed(card, want) {
+			return
+		}
+		if err := c.AddLabelToCardWithOptions(card.ID, LabelSpec{Name: want, Color: epicLabelColor, CreateIfMissing: true}); err != nil {
+			fmt.Printf("  Warning: failed to add the epic label: %v\n", err)
+		}
+	}
+}
+
+// hasLabelNamed reports whether a card already has a label
+func hasLabelNamed(card Card, name string) bool {
+	for _, label := range card.Labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isEpicOverview reports whether a card is an epic overview card
+func isEpicOverview(card Card) bool {
+	return strings.Contains(card.Description, epicMetadataPrefix)
+}
+
+// findEpicOverview finds the overview card for an epic
+func findEpicOverview(cards []Card, epic string) *Card {
+	line := epicMetadataPrefix + epic
+	for i := range cards {
+		for _, descLine := range strings.Split(cards[i].Description, "\n") {
+			if strings.TrimSpace(descLine) == line {
+				return &cards[i]
+			}
+		}
+	}
+	return nil
+}
+
+// EpicChild is a card under an epic, with its status from its list
+type EpicChild struct {
+	Card   Card
+	Status string
+}
+
+// epicOverviewTitle names an epic's overview card
+func epicOverviewTitle(task JiraTask) string {
+	return fmt.Sprintf("📚 Epic: %s", task.epicDisplayName())
+}
+
+// buildEpicOverview lists an epic's child cards and their statuses, ending
+// with the metadata line the sync finds the card by
+func buildEpicOverview(epic, name string, children []EpicChild) string {
+	sort.SliceStable(children, func(i, j int) bool { return children[i].Card.Name < children[j].Card.Name })
+
+	var desc strings.Builder
+	if name != "" {
+		fmt.Fprintf(&desc, "**Epic**: %s (%s)\n\n", name, epic)
+	} else {
+		fmt.Fprintf(&desc, "**Epic**: %s\n\n", epic)
+	}
+	fmt.Fprintf(&desc, "**Cards (%d)**:\n", len(children))
+	for _, child := range children {
+		link := child.Card.ShortURL
+		if link == "" {
+			link = child.Card.URL
+		}
+		if link != "" {
+			fmt.Fprintf(&desc, "- %s [%s](%s)\n", child.Status, child.Card.Name, link)
+		} else {
+			fmt.Fprintf(&desc, "- %s %s\n", child.Status, child.Card.Name)
+		}
+	}
+	fmt.Fprintf(&desc, "\n**Links**:\n- [JIRA Epic](%s/browse/%s)\n", jiraSiteURL(), epic)
+	fmt.Fprintf(&desc, "\n---\n%s%s", epicMetadataPrefix, epic)
+	return desc.String()
+}
+
+// syncEpicOverviews keeps an overview card for each epic the tasks belong
+// to, listing its child cards with the status of the list each is in
+func (c *TrelloClient) syncEpicOverviews(snapshot *BoardSnapshot, tasks []JiraTask, listID string) (int, error) {
+	cards, err := snapshot.Cards()
+	if err != nil {
+		return 0, err
+	}
+	listNames := snapshot.ListNames()
+
+	epics := make(map[string]JiraTask)
+	children := make(map[string][]EpicChild)
+	var order []string
+	for _, task := range tasks {
+		if task.Epic == "" {
+			continue
+		}
+		if _, seen := epics[task.Epic]; !seen {
+			order = append(order, task.Epic)
+			epics[task.Epic] = task
+		} else if task.EpicName != "" {
+			epics[task.Epic] = task
+		}
+		if card := c.FindCardByTaskID(cards, task.ID); card != nil {
+			children[task.Epic] = append(children[task.Epic], EpicChild{Card: *card, Status: c.mapListNameToStatus(listNames[card.IDList])})
+		}
+	}
+
+	written := 0
+	for _, epic := range order {
+		task := epics[epic]
+		desc := buildEpicOverview(epic, task.EpicName, children[epic])
+		overview := findEpicOverview(cards, epic)
+		if overview == nil {
+			fmt.Printf("Creating epic overview: %s\n", epicOverviewTitle(task))
+			card, err := c.CreateCard(listID, epicOverviewTitle(task), desc, "")
+			if err != nil {
+				fmt.Printf("Warning: failed to create the overview for epic %s: %v\n", epic, err)
+				continue
+			}
+			snapshot.Add(*card)
+			written++
+			continue
+		}
+		if skipNoAuto(overview) {
+			continue
+		}
+		patch := CardPatch{}
+		if title := epicOverviewTitle(task); overview.Name != title {
+			patch.Name = &title
+		}
+		if overview.Description != desc {
+			patch.Desc = &desc
+		}
+		if patch.Name == nil && patch.Desc == nil {
+			continue
+		}
+		if err := c.UpdateCardFields(overview.ID, patch); err != nil {
+			fmt.Printf("Warning: failed to update the overview for epic %s: %v\n", epic, err)
+			continue
+		}
+		written++
+	}
+	return written, nil
+}
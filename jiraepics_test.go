@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseJiraEpic(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantKey  string
+		wantName string
+	}{
+		{"epic with name", "- **Priority**: High\n- **Epic**: AK-100 (Router Upgrade)\n", "AK-100", "Router Upgrade"},
+		{"parent with dash", "- **Parent**: AK-7 - Onboarding\n", "AK-7", "Onboarding"},
+		{"epic link without name", "- **Epic Link**: AK-100\n", "AK-100", ""},
+		{"no epic", "- **Priority**: High\n", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, name := parseJiraEpic(tt.content)
+			if key != tt.wantKey || name != tt.wantName {
+				t.Errorf("parseJiraEpic() = %q, %q; want %q, %q", key, name, tt.wantKey, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestParseJiraTaskEpic(t *testing.T) {
+	dir := t.TempDir()
+	taskDir := filepath.Join(dir, "AK-1")
+	if err := os.MkdirAll(taskDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	status := "## Current Status: 🔄 IN PROGRESS\n\n- **Issue Type**: Story\n- **Epic**: AK-100 (Router Upgrade)\n"
+	if err := os.WriteFile(filepath.Join(taskDir, "STATUS.md"), []byte(status), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &TrelloClient{}
+	tasks, _, err := client.parseJiraTasks(dir)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("parseJiraTasks() = %+v, %v", tasks, err)
+	}
+	if tasks[0].Epic != "AK-100" || tasks[0].EpicName != "Router Upgrade" {
+		t.Errorf("epic = %q, %q; want AK-100, Router Upgrade", tasks[0].Epic, tasks[0].EpicName)
+	}
+	if desc := client.buildJiraCardDescription(tasks[0]); !strings.Contains(desc, "- Epic: AK-100 Router Upgrade\n") {
+		t.Errorf("description doesn't mention the epic:\n%s", desc)
+	}
+}
+
+func TestEpicTitle(t *testing.T) {
+	epic := JiraTask{ID: "AK-1", Epic: "AK-100", EpicName: "Router Upgrade"}
+	tests := []struct {
+		name  string
+		title string
+		task  JiraTask
+		want  string
+	}{
+		{"adds the prefix", "AK-1: Fix login", epic, "AK-1: [Router Upgrade] Fix login"},
+		{"already prefixed", "AK-1: [Router Upgrade] Fix login", epic, "AK-1: [Router Upgrade] Fix login"},
+		{"replaces an old epic", "AK-1: [Old Epic] Fix login", epic, "AK-1: [Router Upgrade] Fix login"},
+		{"epic removed", "AK-1: [Old Epic] Fix login", JiraTask{ID: "AK-1"}, "AK-1: Fix login"},
+		{"key without a name", "AK-1: Fix login", JiraTask{ID: "AK-1", Epic: "AK-100"}, "AK-1: [AK-100] Fix login"},
+		{"renamed card left alone", "Fix login", epic, "Fix login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := epicTitle(tt.title, tt.task); got != tt.want {
+				t.Errorf("epicTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaleEpicLabels(t *testing.T) {
+	card := Card{Labels: []Label{{ID: "1", Name: "Bug"}, {ID: "2", Name: "Epic: Old"}, {ID: "3", Name: "Epic: Router Upgrade"}}}
+	stale := staleEpicLabels(card, "Epic: Router Upgrade")
+	if len(stale) != 1 || stale[0].ID != "2" {
+		t.Errorf("staleEpicLabels() = %+v, want just Epic: Old", stale)
+	}
+	if stale := staleEpicLabels(card, ""); len(stale) != 2 {
+		t.Errorf("staleEpicLabels(no epic) = %+v, want both epic labels", stale)
+	}
+}
+
+func TestBuildEpicOverview(t *testing.T) {
+	t.Setenv("JIRA_URL", "")
+	children := []EpicChild{
+		{Card: Card{Name: "AK-2: Docs", ShortURL: "https://trello.com/c/b"}, Status: statusCompleted},
+		{Card: Card{Name: "AK-1: Fix login", ShortURL: "https://trello.com/c/a"}, Status: statusInProgress},
+	}
+	desc := buildEpicOverview("AK-100", "Router Upgrade", children)
+
+	for _, want := range []string{
+		"**Epic**: Router Upgrade (AK-100)",
+		"**Cards (2)**:\n- " + statusInProgress + " [AK-1: Fix login](https://trello.com/c/a)\n- " + statusCompleted + " [AK-2: Docs]",
+		"[JIRA Epic](" + defaultJiraURL + "/browse/AK-100)",
+	} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("overview missing %q:\n%s", want, desc)
+		}
+	}
+
+	cards := []Card{
+		{ID: "task", Name: "AK-100: Router Upgrade"},
+		{ID: "overview", Name: "📚 Epic: Router Upgrade", Description: desc},
+	}
+	if found := findEpicOverview(cards, "AK-100"); found == nil || found.ID != "overview" {
+		t.Errorf("findEpicOverview() = %+v, want the overview card", found)
+	}
+	if found := findEpicOverview(cards, "AK-10"); found != nil {
+		t.Errorf("findEpicOverview(AK-10) = %+v, want nil", found)
+	}
+
+	// The overview names the epic's key in its title but isn't the task's card
+	client := &TrelloClient{}
+	cards[0].Name = "Something else"
+	cards[1].Name = "📚 Epic: AK-100"
+	if found := client.FindCardByTaskID(cards, "AK-100"); found != nil {
+		t.Errorf("FindCardByTaskID() = %+v, want the overview skipped", found)
+	}
+}
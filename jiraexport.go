@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// completedRecordFile is the permanent record written into a task's
+// directory when its card reaches a done list
+const completedRecordFile = "COMPLETED.md"
+
+// commentMarkerRegex matches the markers this tool adds to its own comments
+var commentMarkerRegex = regexp.MustCompile(`\s*\[trello-sync:[^\]]*\]`)
+
+// buildCompletedRecord renders a finished card as Markdown: its description,
+// checklists, and comments, oldest comment first
+func buildCompletedRecord(card Card, listName string, comments []CardComment, checklists []Checklist, now time.Time) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "# %s\n\n", card.Name)
+	if card.URL != "" {
+		fmt.Fprintf(&out, "- Trello card: %s\n", card.URL)
+	}
+	if listName != "" {
+		fmt.Fprintf(&out, "- Finished in: %s\n", listName)
+	}
+	fmt.Fprintf(&out, "- Exported: %s\n", now.Format("2006-01-02 15:04"))
+
+	out.WriteString("\n## Description\n\n")
+	if desc := strings.TrimSpace(card.Description); desc != "" {
+		out.WriteString(desc + "\n")
+	} else {
+		out.WriteString("_No description_\n")
+	}
+
+	if len(checklists) > 0 {
+		out.WriteString("\n## Checklists\n")
+		for _, checklist := range checklists {
+			fmt.Fprintf(&out, "\n### %s\n\n", checklist.Name)
+			for _, item := range checklist.CheckItems {
+				box := " "
+				if item.State == "complete" {
+					box = "x"
+				}
+				fmt.Fprintf(&out, "- [%s] %s\n", box, item.Name)
+			}
+		}
+	}
+
+	fmt.Fprintf(&out, "\n## Comments (%d)\n", len(comments))
+	// Trello returns comments newest first
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+		when := comment.Date
+		if at, err := time.Parse(time.RFC3339, comment.Date); err == nil {
+			when = at.In(now.Location()).Format("2006-01-02 15:04")
+		}
+		if author := comment.MemberCreator.FullName; author != "" {
+			when += " - " + author
+		}
+		fmt.Fprintf(&out, "\n**%s**\n\n%s\n", when, strings.TrimSpace(commentMarkerRegex.ReplaceAllString(comment.Data.Text, "")))
+	}
+	return out.String()
+}
+
+// exportCompletedTask writes a finished card to COMPLETED.md in its task's
+// directory. A task that already has one is left alone, so the record
+// keeps the card as it was when it was first finished.
+func (c *TrelloClient) exportCompletedTask(tasksDir, taskID string, card Card, listName string) (bool, error) {
+	path := filepath.Join(tasksDir, taskID, completedRecordFile)
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	}
+
+	comments, err := c.GetCardComments(card.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get comments: %w", err)
+	}
+	checklists, err := c.GetCardChecklists(card.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get checklists: %w", err)
+	}
+
+	record := buildCompletedRecord(card, listName, comments, checklists, time.Now())
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(record), 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", completedRecordFile, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", completedRecordFile, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildCompletedRecord(t *testing.T) {
+	card := Card{Name: "AK-1: Fix login", URL: "https://trello.com/c/abc/1-fix-login", Description: "**JIRA Task ID**: AK-1\n"}
+	newer := CardComment{Date: "2025-10-08T16:30:00.000Z"}
+	newer.Data.Text = "⏱ Time tracked: 2h 15m over 3 session(s)\n\n[trello-sync:time-tracked]"
+	older := CardComment{Date: "2025-10-07T09:00:00.000Z"}
+	older.Data.Text = "Root cause was the session cookie"
+	older.MemberCreator.FullName = "Mac"
+	checklists := []Checklist{{Name: "Release", CheckItems: []CheckItem{{Name: "Merge PR", State: "complete"}, {Name: "Deploy", State: "incomplete"}}}}
+
+	now := time.Date(2025, 10, 9, 12, 0, 0, 0, time.UTC)
+	record := buildCompletedRecord(card, "Done", []CardComment{newer, older}, checklists, now)
+
+	for _, want := range []string{
+		"# AK-1: Fix login\n\n- Trello card: https://trello.com/c/abc/1-fix-login\n- Finished in: Done\n- Exported: 2025-10-09 12:00\n",
+		"## Description\n\n**JIRA Task ID**: AK-1\n",
+		"### Release\n\n- [x] Merge PR\n- [ ] Deploy\n",
+		"## Comments (2)\n\n**2025-10-07 09:00 - Mac**\n\nRoot cause was the session cookie\n\n**2025-10-08 16:30**\n\n⏱ Time tracked: 2h 15m over 3 session(s)\n",
+	} {
+		if !strings.Contains(record, want) {
+			t.Errorf("record missing %q:\n%s", want, record)
+		}
+	}
+	if strings.Contains(record, "trello-sync") {
+		t.Errorf("record kept the comment marker:\n%s", record)
+	}
+}
+
+func TestExportCompletedTask(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "AK-1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &TrelloClient{BaseURL: server.URL}
+	card := Card{ID: "c1", Name: "AK-1: Fix login"}
+	exported, err := client.exportCompletedTask(dir, "AK-1", card, "Done")
+	if err != nil || !exported {
+		t.Fatalf("exportCompletedTask() = %v, %v; want it exported", exported, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "AK-1", completedRecordFile))
+	if err != nil || !strings.HasPrefix(string(data), "# AK-1: Fix login\n") {
+		t.Errorf("%s = %q, %v", completedRecordFile, data, err)
+	}
+
+	// The first record is kept
+	requests = 0
+	exported, err = client.exportCompletedTask(dir, "AK-1", card, "Done")
+	if err != nil || exported || requests != 0 {
+		t.Errorf("second export = %v, %v after %d requests; want it skipped", exported, err, requests)
+	}
+}
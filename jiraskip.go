@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// jiraSkipFile in a task directory keeps the task out of --sync-jira. Its
+// first line, if any, is the reason shown in the summary.
+const jiraSkipFile = ".skip"
+
+// SkippedTask is a local task left out of the sync
+type SkippedTask struct {
+	ID     string
+	Reason string
+}
+
+// frontmatterRegex matches a "---" block at the top of a Markdown file
+var frontmatterRegex = regexp.MustCompile(`(?s)^\s*---\r?\n(.*?)\r?\n---`)
+
+// frontmatterSkip reports whether a file's frontmatter opts the task out of
+// syncing with "sync: false" or "skip: true", returning any "skip-reason"
+func frontmatterSkip(content string) (bool, string) {
+	match := frontmatterRegex.FindStringSubmatch(content)
+	if match == nil {
+		return false, ""
+	}
+
+	skip := false
+	reason := ""
+	for _, line := range strings.Split(match[1], "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "sync":
+			skip = skip || isFalseValue(value)
+		case "skip":
+			skip = skip || isTrueValue(value)
+		case "skip-reason", "skip_reason":
+			reason = value
+		}
+	}
+	return skip, reason
+}
+
+func isTrueValue(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+func isFalseValue(value string) bool {
+	switch strings.ToLower(value) {
+	case "false", "no", "off":
+		return true
+	}
+	return false
+}
+
+// jiraTaskSkip reports whether a task directory opts out of syncing, through
+// a .skip file or the frontmatter of STATUS.md or the task file
+func jiraTaskSkip(taskDir, taskID string) (bool, string) {
+	if data, err := os.ReadFile(filepath.Join(taskDir, jiraSkipFile)); err == nil {
+		reason, _, _ := strings.Cut(strings.TrimSpace(string(data)), "\n")
+		if reason = strings.TrimSpace(reason); reason == "" {
+			reason = jiraSkipFile + " file"
+		}
+		return true, reason
+	}
+
+	for _, name := range []string{"STATUS.md", taskID + ".md"} {
+		data, err := os.ReadFile(filepath.Join(taskDir, name))
+		if err != nil {
+			continue
+		}
+		if skip, reason := frontmatterSkip(string(data)); skip {
+			if reason == "" {
+				reason = "opted out in " + name
+			}
+			return true, reason
+		}
+	}
+	return false, ""
+}
+
+// formatSkippedTasks lists skipped tasks for the sync summary
+func formatSkippedTasks(skipped []SkippedTask) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "Skipped: %d tasks\n", len(skipped))
+	for _, task := range skipped {
+		fmt.Fprintf(&out, "  - %s (%s)\n", task.ID, task.Reason)
+	}
+	return out.String()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFrontmatterSkip(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantSkip   bool
+		wantReason string
+	}{
+		{"sync false", "---\nsync: false\n---\n# AK-1\n", true, ""},
+		{"skip with reason", "---\r\nskip: yes\r\nskip-reason: \"confidential\"\r\n---\r\n", true, "confidential"},
+		{"sync true", "---\nsync: true\n---\n", false, ""},
+		{"no frontmatter", "# AK-1\n\nsync: false\n", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skip, reason := frontmatterSkip(tt.content)
+			if skip != tt.wantSkip || reason != tt.wantReason {
+				t.Errorf("frontmatterSkip() = %v, %q; want %v, %q", skip, reason, tt.wantSkip, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestParseJiraTasksSkips(t *testing.T) {
+	dir := t.TempDir()
+	write := func(task, name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, task), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, task, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("AK-1", "AK-1.md", "# Synced task\n")
+	write("AK-2", jiraSkipFile, "archived\n")
+	write("AK-3", jiraSkipFile, "")
+	write("AK-4", "AK-4.md", "---\nsync: false\n---\n# Confidential task\n")
+
+	client := &TrelloClient{}
+	tasks, skipped, err := client.parseJiraTasks(dir)
+	if err != nil {
+		t.Fatalf("parseJiraTasks() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "AK-1" {
+		t.Errorf("tasks = %+v, want just AK-1", tasks)
+	}
+
+	want := []SkippedTask{{"AK-2", "archived"}, {"AK-3", ".skip file"}, {"AK-4", "opted out in AK-4.md"}}
+	if len(skipped) != len(want) {
+		t.Fatalf("skipped = %+v, want %+v", skipped, want)
+	}
+	for i := range want {
+		if skipped[i] != want[i] {
+			t.Errorf("skipped[%d] = %+v, want %+v", i, skipped[i], want[i])
+		}
+	}
+
+	summary := formatSkippedTasks(skipped)
+	if !strings.HasPrefix(summary, "Skipped: 3 tasks\n") || !strings.Contains(summary, "  - AK-2 (archived)\n") {
+		t.Errorf("formatSkippedTasks() = %q", summary)
+	}
+}
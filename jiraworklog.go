@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// defaultJiraURL is the JIRA site the task sync links cards to
+const defaultJiraURL = "https://alkiranet.atlassian.net"
+
+// defaultWorklogRounding is the step tracked time is rounded to before it's logged
+const defaultWorklogRounding = 15 * time.Minute
+
+// jiraStartedLayout is the timestamp format JIRA expects for a worklog's start
+const jiraStartedLayout = "2006-01-02T15:04:05.000-0700"
+
+// JiraClient posts worklogs through the JIRA Cloud REST API
+type JiraClient struct {
+	BaseURL  string
+	Email    string
+	APIToken string
+	Rounding time.Duration
+}
+
+// jiraClientFromEnv builds a JIRA client from JIRA_EMAIL and JIRA_API_TOKEN,
+// with JIRA_URL for another site and JIRA_WORKLOG_ROUNDING (e.g. 15m) for
+// the rounding step. It returns nil when worklogs aren't set up.
+func jiraClientFromEnv() *JiraClient {
+	email, token := os.Getenv("JIRA_EMAIL"), os.Getenv("JIRA_API_TOKEN")
+	if email == "" || token == "" {
+		return nil
+	}
+	return &JiraClient{
+		BaseURL:  jiraSiteURL(),
+		Email:    email,
+		APIToken: token,
+		Rounding: worklogRounding(),
+	}
+}
+
+// jiraSiteURL is the JIRA site cards link to: JIRA_URL, or the default site
+func jiraSiteURL() string {
+	if site := os.Getenv("JIRA_URL"); site != "" {
+		return strings.TrimSuffix(site, "/")
+	}
+	return defaultJiraURL
+}
+
+// worklogRounding reads JIRA_WORKLOG_ROUNDING; 0 logs the exact minutes
+func worklogRounding() time.Duration {
+	value := os.Getenv("JIRA_WORKLOG_ROUNDING")
+	if value == "" {
+		return defaultWorklogRounding
+	}
+	rounding, err := time.ParseDuration(value)
+	if err != nil || rounding < 0 {
+		fmt.Printf("Warning: invalid JIRA_WORKLOG_ROUNDING '%s' (want e.g. 15m), using %s\n", value, defaultWorklogRounding)
+		return defaultWorklogRounding
+	}
+	return rounding
+}
+
+// roundWorklog rounds a session to the nearest step, or to the minute when
+// step is 0. JIRA won't take a worklog under a minute, so anything shorter
+// than one step still logs one step.
+func roundWorklog(d, step time.Duration) time.Duration {
+	if step <= 0 {
+		step = time.Minute
+	}
+	return max(d.Round(step), step)
+}
+
+var (
+	// jiraBrowseRegex finds the issue key in a "/browse/AK-123" link
+	jiraBrowseRegex = regexp.MustCompile(`/browse/([A-Z][A-Z0-9]+-\d+)\b`)
+	// jiraTitleKeyRegex finds the "AK-123:" prefix the task sync gives card titles
+	jiraTitleKeyRegex = regexp.MustCompile(`^([A-Z][A-Z0-9]+-\d+)\b`)
+)
+
+// jiraIssueKey returns the JIRA issue a card is linked to, from the card's
+// JIRA Ticket link or its title, or "" when it isn't a JIRA card
+func jiraIssueKey(card Card) string {
+	if match := jiraBrowseRegex.FindStringSubmatch(card.Description); match != nil {
+		return match[1]
+	}
+	if match := jiraTitleKeyRegex.FindStringSubmatch(strings.TrimSpace(card.Name)); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// worklogComment describes a session in JIRA's worklog list
+func worklogComment(session TimeSession) string {
+	return fmt.Sprintf("Tracked on Trello card: %s", session.CardName)
+}
+
+// AddWorklog logs a finished session against its issue and returns the
+// new worklog's ID
+func (j *JiraClient) AddWorklog(session TimeSession) (string, error) {
+	if session.End == nil {
+		return "", fmt.Errorf("session on %s is still running", session.CardName)
+	}
+	spent := roundWorklog(session.End.Sub(session.Start), j.Rounding)
+	payload, err := json.Marshal(map[string]any{
+		"started":          session.Start.Format(jiraStartedLayout),
+		"timeSpentSeconds": int(spent.Seconds()),
+		"comment":          worklogComment(session),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal worklog: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s/worklog", j.BaseURL, session.JiraIssue)
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(j.Email, j.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("JIRA API request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var worklog struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &worklog); err != nil {
+		return "", fmt.Errorf("failed to unmarshal worklog: %w", err)
+	}
+	return worklog.ID, nil
+}
+
+// pendingWorklogs returns the finished sessions on JIRA cards that haven't
+// been logged yet
+func (l *TimeLog) pendingWorklogs() []*TimeSession {
+	var pending []*TimeSession
+	for i := range l.Sessions {
+		session := &l.Sessions[i]
+		if session.JiraIssue != "" && session.End != nil && session.Worklog == "" {
+			pending = append(pending, session)
+		}
+	}
+	return pending
+}
+
+// pushWorklogs logs every pending session to JIRA, recording each worklog
+// ID on its session so it's never logged twice. Failures are left pending
+// for the next push.
+func pushWorklogs(jira *JiraClient, timeLog *TimeLog) (pushed, failed int) {
+	for _, session := range timeLog.pendingWorklogs() {
+		id, err := jira.AddWorklog(*session)
+		if err != nil {
+			fmt.Printf("Warning: failed to log %s on %s: %v\n", formatTrackedDuration(session.End.Sub(session.Start)), session.JiraIssue, err)
+			failed++
+			continue
+		}
+		session.Worklog = id
+		fmt.Printf("%s Logged %s on %s\n", iconSuccess, formatTrackedDuration(roundWorklog(session.End.Sub(session.Start), jira.Rounding)), session.JiraIssue)
+		pushed++
+	}
+	return pushed, failed
+}
+
+// PushWorklogs retries the JIRA worklogs that failed when their sessions stopped
+func PushWorklogs() error {
+	jira := jiraClientFromEnv()
+	if jira == nil {
+		return fmt.Errorf("set JIRA_EMAIL and JIRA_API_TOKEN in .env to log time to JIRA")
+	}
+	timeLog, err := LoadTimeLog(timeLogFile)
+	if err != nil {
+		return err
+	}
+	pushed, failed := pushWorklogs(jira, timeLog)
+	if pushed > 0 {
+		if err := timeLog.Save(timeLogFile); err != nil {
+			return err
+		}
+	}
+	if pushed == 0 && failed == 0 {
+		fmt.Println("No worklogs waiting to be logged")
+		return nil
+	}
+	if failed > 0 {
+		return fmt.Errorf("%s still waiting to be logged", plural(failed, "worklog"))
+	}
+	return nil
+}
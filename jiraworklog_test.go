@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRoundWorklog(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		step time.Duration
+		want time.Duration
+	}{
+		{"rounds down", 52 * time.Minute, 15 * time.Minute, 45 * time.Minute},
+		{"rounds up", 53 * time.Minute, 15 * time.Minute, time.Hour},
+		{"short session logs one step", 4 * time.Minute, 15 * time.Minute, 15 * time.Minute},
+		{"no rounding keeps the minutes", 52*time.Minute + 40*time.Second, 0, 53 * time.Minute},
+		{"under a minute logs a minute", 20 * time.Second, 0, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundWorklog(tt.d, tt.step); got != tt.want {
+				t.Errorf("roundWorklog(%v, %v) = %v, want %v", tt.d, tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJiraIssueKey(t *testing.T) {
+	tests := []struct {
+		name string
+		card Card
+		want string
+	}{
+		{"ticket link", Card{Name: "Fix the login page", Description: "**Links**:\n- [JIRA Ticket](https://alkiranet.atlassian.net/browse/AK-123)\n"}, "AK-123"},
+		{"title prefix", Card{Name: "AK-77: Upgrade the router"}, "AK-77"},
+		{"homework", Card{Name: "Algebra - Unit 3 Quiz", Description: "Canvas Assignment ID: 12"}, ""},
+		{"lowercase isn't a key", Card{Name: "ak-77: notes"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jiraIssueKey(tt.card); got != tt.want {
+				t.Errorf("jiraIssueKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPushWorklogs(t *testing.T) {
+	var posted []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, ok := r.BasicAuth(); !ok || user != "mac@example.com" {
+			t.Errorf("request without basic auth")
+		}
+		if r.URL.Path == "/rest/api/2/issue/AK-9/worklog" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		posted = append(posted, body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "10042"}`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2025, 10, 7, 9, 0, 0, 0, time.UTC)
+	end := start.Add(52 * time.Minute)
+	timeLog := &TimeLog{Sessions: []TimeSession{
+		{CardID: "a", CardName: "AK-1: Router", Start: start, End: &end, JiraIssue: "AK-1"},
+		{CardID: "b", CardName: "AK-2: Docs", Start: start, End: &end, JiraIssue: "AK-2", Worklog: "10001"}, // already logged
+		{CardID: "c", CardName: "Essay", Start: start, End: &end},                                           // not a JIRA card
+		{CardID: "d", CardName: "AK-9: Gone", Start: start, End: &end, JiraIssue: "AK-9"},                   // JIRA refuses it
+		{CardID: "e", CardName: "AK-3: Running", Start: start, JiraIssue: "AK-3"},                           // still running
+	}}
+
+	jira := &JiraClient{BaseURL: server.URL, Email: "mac@example.com", APIToken: "token", Rounding: 15 * time.Minute}
+	pushed, failed := pushWorklogs(jira, timeLog)
+	if pushed != 1 || failed != 1 {
+		t.Fatalf("pushWorklogs() = %d pushed, %d failed; want 1 and 1", pushed, failed)
+	}
+	if timeLog.Sessions[0].Worklog != "10042" {
+		t.Errorf("AK-1 worklog = %q, want 10042", timeLog.Sessions[0].Worklog)
+	}
+	if timeLog.Sessions[3].Worklog != "" {
+		t.Errorf("failed AK-9 worklog was recorded as %q", timeLog.Sessions[3].Worklog)
+	}
+	if len(posted) != 1 || posted[0]["timeSpentSeconds"] != float64(45*60) || posted[0]["started"] != "2025-10-07T09:00:00.000+0000" {
+		t.Errorf("posted %+v, want one 45m worklog started at 9:00", posted)
+	}
+
+	// The failed session is the only one left to retry
+	if pending := timeLog.pendingWorklogs(); len(pending) != 1 || pending[0].JiraIssue != "AK-9" {
+		t.Errorf("pendingWorklogs() = %+v, want just AK-9", pending)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Label is a Trello board label
+type Label struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Color   string `json:"color"`
+	IDBoard string `json:"idBoard"`
+}
+
+// LabelSpec describes the label AddLabelToCardWithOptions should apply
+type LabelSpec struct {
+	Name            string
+	Color           string
+	CreateIfMissing bool
+}
+
+// findLabel picks the board label matching a spec. With a name, a label of
+// that name wins (respecting color when set), then an unnamed label of the
+// requested color. Without a name, the first label of the color is used.
+func findLabel(labels []Label, spec LabelSpec) *Label {
+	if spec.Name != "" {
+		for i, label := range labels {
+			if normalizeString(label.Name) == normalizeString(spec.Name) && (spec.Color == "" || label.Color == spec.Color) {
+				return &labels[i]
+			}
+		}
+		for i, label := range labels {
+			if label.Name == "" && spec.Color != "" && label.Color == spec.Color {
+				return &labels[i]
+			}
+		}
+		return nil
+	}
+
+	for i, label := range labels {
+		if label.Color == spec.Color {
+			return &labels[i]
+		}
+	}
+	return nil
+}
+
+// AddLabelToCardWithOptions adds a label matched by name and/or color,
+// creating it on the board first when CreateIfMissing is set
+func (c *TrelloClient) AddLabelToCardWithOptions(cardID string, spec LabelSpec) error {
+	boardID, err := c.getCardBoardID(cardID)
+	if err != nil {
+		return err
+	}
+
+	labels, err := c.GetBoardLabels(boardID)
+	if err != nil {
+		return err
+	}
+
+	label := findLabel(labels, spec)
+	if label == nil {
+		if !spec.CreateIfMissing {
+			if spec.Name != "" {
+				return fmt.Errorf("no '%s' label found on board", spec.Name)
+			}
+			return fmt.Errorf("no %s label found on board", spec.Color)
+		}
+
+		label, err = c.CreateLabel(boardID, spec.Name, spec.Color)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.AddLabelIDToCard(cardID, label.ID)
+}
+
+// GetBoardLabels lists all labels defined on a board
+func (c *TrelloClient) GetBoardLabels(boardID string) ([]Label, error) {
+	endpoint := fmt.Sprintf("/boards/%s/labels", boardID)
+	body, err := c.makeRequest(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board labels: %v", err)
+	}
+
+	var labels []Label
+	if err := json.Unmarshal(body, &labels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// CreateLabel creates a label on a board
+func (c *TrelloClient) CreateLabel(boardID, name, color string) (*Label, error) {
+	fields := url.Values{}
+	fields.Set("idBoard", boardID)
+	fields.Set("name", name)
+	fields.Set("color", color)
+
+	body, err := c.sendForm("POST", "/labels", fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+
+	var label Label
+	if err := json.Unmarshal(body, &label); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal label: %w", err)
+	}
+
+	return &label, nil
+}
+
+// DeleteLabel removes a label from its board (and from every card using it)
+func (c *TrelloClient) DeleteLabel(labelID string) error {
+	if _, err := c.sendForm("DELETE", fmt.Sprintf("/labels/%s", labelID), nil); err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+
+	return nil
+}
+
+// AddLabelIDToCard attaches an existing label to a card
+func (c *TrelloClient) AddLabelIDToCard(cardID, labelID string) error {
+	fields := url.Values{}
+	fields.Set("value", labelID)
+
+	if _, err := c.sendForm("POST", fmt.Sprintf("/cards/%s/idLabels", cardID), fields); err != nil {
+		return fmt.Errorf("failed to add label: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveLabelFromCard detaches a label from a card without deleting it
+func (c *TrelloClient) RemoveLabelFromCard(cardID, labelID string) error {
+	if _, err := c.sendForm("DELETE", fmt.Sprintf("/cards/%s/idLabels/%s", cardID, labelID), nil); err != nil {
+		return fmt.Errorf("failed to remove label: %w", err)
+	}
+
+	return nil
+}
+
+// getCardBoardID looks up which board a card lives on
+func (c *TrelloClient) getCardBoardID(cardID string) (string, error) {
+	endpoint := fmt.Sprintf("/cards/%s", cardID)
+	body, err := c.makeRequest(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to get card: %v", err)
+	}
+
+	var card struct {
+		IDBoard string `json:"idBoard"`
+	}
+	if err := json.Unmarshal(body, &card); err != nil {
+		return "", fmt.Errorf("failed to unmarshal card: %w", err)
+	}
+
+	return card.IDBoard, nil
+}
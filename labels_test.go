@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestFindLabel(t *testing.T) {
+	labels := []Label{
+		{ID: "1", Name: "Urgent", Color: "red"},
+		{ID: "2", Name: "", Color: "orange"},
+		{ID: "3", Name: "bug", Color: "purple"},
+		{ID: "4", Name: "", Color: "red"},
+	}
+
+	tests := []struct {
+		name     string
+		spec     LabelSpec
+		expected string
+	}{
+		{"color only", LabelSpec{Color: "red"}, "1"},
+		{"name case insensitive", LabelSpec{Name: "BUG"}, "3"},
+		{"name with wrong color", LabelSpec{Name: "Bug", Color: "red"}, "4"},
+		{"unnamed label of color", LabelSpec{Name: "Later", Color: "orange"}, "2"},
+		{"missing name", LabelSpec{Name: "Later", Color: "green"}, ""},
+		{"missing color", LabelSpec{Color: "sky"}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := findLabel(labels, test.spec)
+			if test.expected == "" {
+				if result != nil {
+					t.Errorf("expected no label, got %s", result.ID)
+				}
+				return
+			}
+			if result == nil || result.ID != test.expected {
+				t.Errorf("findLabel(%+v) = %v, want %s", test.spec, result, test.expected)
+			}
+		})
+	}
+}
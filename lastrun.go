@@ -0,0 +1,316 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// runHistoryFile records when each command ran and whether it succeeded
+const runHistoryFile = "run_history.json"
+
+// maxRunsPerCommand caps how many runs of each command the history keeps
+const maxRunsPerCommand = 20
+
+// statusCommands are always listed by --status, even before their first run
+var statusCommands = []string{"daily-reset", "sync-canvas", "sync-moodle"}
+
+// CommandRun is one execution of a command
+type CommandRun struct {
+	Started time.Time `json:"started"`
+	Seconds float64   `json:"seconds"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// CommandHistory is a command's recent runs, newest last
+type CommandHistory struct {
+	LastSuccess time.Time    `json:"lastSuccess,omitempty"`
+	Runs        []CommandRun `json:"runs"`
+}
+
+// RunHistory is the local record of command runs, keyed by job name
+type RunHistory struct {
+	Commands map[string]*CommandHistory `json:"commands"`
+}
+
+// LoadRunHistory reads the run history, returning an empty one if there isn't one yet
+func LoadRunHistory(path string) (*RunHistory, error) {
+	history := &RunHistory{Commands: make(map[string]*CommandHistory)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, fmt.Errorf("failed to read run history: %w", err)
+	}
+
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal run history: %w", err)
+	}
+	if history.Commands == nil {
+		history.Commands = make(map[string]*CommandHistory)
+	}
+	return history, nil
+}
+
+// Save writes the history through a temp file so a crash can't truncate it
+func (h *RunHistory) Save(path string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run history: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write run history: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Record adds a run of a command, dropping the oldest runs past the cap
+func (h *RunHistory) Record(name string, started time.Time, duration time.Duration, runErr error) {
+	command := h.Commands[name]
+	if command == nil {
+		command = &CommandHistory{}
+		h.Commands[name] = command
+	}
+
+	run := CommandRun{Started: started, Seconds: duration.Round(time.Millisecond).Seconds()}
+	if runErr != nil {
+		run.Error = runErr.Error()
+	} else {
+		command.LastSuccess = started
+	}
+
+	command.Runs = append(command.Runs, run)
+	if len(command.Runs) > maxRunsPerCommand {
+		command.Runs = command.Runs[len(command.Runs)-maxRunsPerCommand:]
+	}
+}
+
+// recordRun saves one run of a command to the run history. A history that
+// can't be saved is only a warning; it must never fail the command itself.
+func recordRun(name string, started time.Time, runErr error) {
+	recordRuns(JobResult{Name: name, Started: started, Duration: time.Since(started), Err: runErr})
+}
+
+// recordRuns saves the results of a scheduled run to the run history and
+// sends notices for unattended failures
+func recordRuns(results ...JobResult) {
+	notifyRunFailures(results...)
+
+	history, err := LoadRunHistory(runHistoryFile)
+	if err != nil {
+		fmt.Printf("Warning: not recording run: %v\n", err)
+		return
+	}
+	for _, result := range results {
+		history.Record(result.Name, result.Started, result.Duration, result.Err)
+	}
+	if err := history.Save(runHistoryFile); err != nil {
+		fmt.Printf("Warning: not recording run: %v\n", err)
+	}
+}
+
+// ScheduledRun is a job that should run at a time of day, every day or on
+// the listed weekdays
+type ScheduledRun struct {
+	Job      string   `json:"job"`
+	At       string   `json:"at"`       // "20:00", local time
+	Days     []string `json:"days"`     // e.g. ["sunday"]; empty means every day
+	OnMissed string   `json:"onMissed"` // what --catch-up does: "run" (default), "prompt", or "skip"
+}
+
+// defaultSchedule matches the launchd agent that resets the dailies at 8 PM
+var defaultSchedule = []ScheduledRun{{Job: "daily-reset", At: "20:00"}}
+
+// LoadSchedule reads schedule.json from the working or config directory,
+// falling back to the default schedule
+func LoadSchedule() ([]ScheduledRun, error) {
+	data, err := os.ReadFile(findConfigFile("schedule.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return defaultSchedule, nil
+		}
+		return nil, fmt.Errorf("failed to read schedule.json: %w", err)
+	}
+
+	var schedule []ScheduledRun
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schedule: %w", err)
+	}
+	for _, run := range schedule {
+		if _, err := time.Parse("15:04", run.At); err != nil {
+			return nil, fmt.Errorf("schedule.json: %s has invalid time '%s' (want HH:MM)", run.Job, run.At)
+		}
+		for _, day := range run.Days {
+			if _, ok := parseWeekday(day); !ok {
+				return nil, fmt.Errorf("schedule.json: %s has invalid day '%s'", run.Job, day)
+			}
+		}
+		switch run.OnMissed {
+		case "", onMissedRun, onMissedPrompt, onMissedSkip:
+		default:
+			return nil, fmt.Errorf("schedule.json: %s has invalid onMissed '%s' (want run, prompt, or skip)", run.Job, run.OnMissed)
+		}
+	}
+	return schedule, nil
+}
+
+// parseWeekday reads a weekday name like "sunday" or "Sun"
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = normalizeString(name)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || (len(name) >= 3 && strings.HasPrefix(full, name)) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// runsOn reports whether the job is scheduled on a weekday
+func (r ScheduledRun) runsOn(day time.Weekday) bool {
+	if len(r.Days) == 0 {
+		return true
+	}
+	for _, name := range r.Days {
+		if d, ok := parseWeekday(name); ok && d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// occurrence is the scheduled time on the day offset days from now
+func (r ScheduledRun) occurrence(now time.Time, offset int) (time.Time, bool) {
+	at, err := time.Parse("15:04", r.At)
+	if err != nil {
+		return time.Time{}, false
+	}
+	day := now.AddDate(0, 0, offset)
+	return time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), 0, 0, now.Location()), r.runsOn(day.Weekday())
+}
+
+// Previous is the most recent scheduled time at or before now
+func (r ScheduledRun) Previous(now time.Time) (time.Time, bool) {
+	for offset := 0; offset >= -7; offset-- {
+		if t, ok := r.occurrence(now, offset); ok && !t.After(now) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Next is the first scheduled time after now
+func (r ScheduledRun) Next(now time.Time) (time.Time, bool) {
+	for offset := 0; offset <= 7; offset++ {
+		if t, ok := r.occurrence(now, offset); ok && t.After(now) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// catchUpLookbackDays is how far back missed runs are counted
+const catchUpLookbackDays = 14
+
+// MissedRun is a scheduled job that hasn't succeeded since one or more of
+// its scheduled times
+type MissedRun struct {
+	Run   ScheduledRun
+	Since time.Time // earliest missed scheduled time within the lookback
+	Count int       // scheduled times missed within the lookback
+}
+
+// missedRuns returns the scheduled jobs that haven't succeeded since their
+// most recent scheduled time, in schedule order
+func missedRuns(schedule []ScheduledRun, history *RunHistory, now time.Time) []MissedRun {
+	var missed []MissedRun
+	seen := make(map[string]bool)
+	for _, run := range schedule {
+		if seen[run.Job] {
+			continue
+		}
+		var lastSuccess time.Time
+		if command := history.Commands[run.Job]; command != nil {
+			lastSuccess = command.LastSuccess
+		}
+
+		var miss MissedRun
+		for offset := -catchUpLookbackDays; offset <= 0; offset++ {
+			t, ok := run.occurrence(now, offset)
+			if !ok || t.After(now) || !lastSuccess.Before(t) {
+				continue
+			}
+			if miss.Count == 0 {
+				miss.Since = t
+			}
+			miss.Count++
+		}
+		if miss.Count > 0 {
+			miss.Run = run
+			missed = append(missed, miss)
+			seen[run.Job] = true
+		}
+	}
+	return missed
+}
+
+// formatRunStatus renders the --status report: the last run of each command
+// and when the scheduled jobs run next
+func formatRunStatus(history *RunHistory, schedule []ScheduledRun, now time.Time) string {
+	var out strings.Builder
+
+	names := append([]string(nil), statusCommands...)
+	var others []string
+	for name := range history.Commands {
+		if !containsFold(names, name) {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	names = append(names, others...)
+
+	out.WriteString("Last runs:\n")
+	for _, name := range names {
+		command := history.Commands[name]
+		if command == nil || len(command.Runs) == 0 {
+			out.WriteString(fmt.Sprintf("- %s: never\n", name))
+			continue
+		}
+
+		last := command.Runs[len(command.Runs)-1]
+		if last.Error == "" {
+			out.WriteString(fmt.Sprintf("- %s: %s OK %s (%.0fs)\n", name, iconCheck, friendlyTime(last.Started, now.Location()), last.Seconds))
+			continue
+		}
+		out.WriteString(fmt.Sprintf("- %s: failed %s: %s\n", name, friendlyTime(last.Started, now.Location()), last.Error))
+		if command.LastSuccess.IsZero() {
+			out.WriteString("    never succeeded\n")
+		} else {
+			out.WriteString(fmt.Sprintf("    last success %s\n", friendlyTime(command.LastSuccess, now.Location())))
+		}
+	}
+
+	out.WriteString("\nNext scheduled runs:\n")
+	if len(schedule) == 0 {
+		out.WriteString("- nothing scheduled\n")
+	}
+	for _, run := range schedule {
+		if next, ok := run.Next(now); ok {
+			out.WriteString(fmt.Sprintf("- %s: %s\n", run.Job, friendlyTime(next, now.Location())))
+		}
+	}
+
+	if missed := missedRuns(schedule, history, now); len(missed) > 0 {
+		out.WriteString("\nMissed (run --catch-up):\n")
+		for _, miss := range missed {
+			out.WriteString(fmt.Sprintf("- %s: %s\n", miss.Run.Job, miss.Describe(now.Location())))
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunHistoryRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), runHistoryFile)
+	history, err := LoadRunHistory(path)
+	if err != nil {
+		t.Fatalf("LoadRunHistory() on a missing file: %v", err)
+	}
+
+	start := time.Date(2025, 10, 6, 20, 0, 0, 0, time.UTC)
+	for i := 0; i < maxRunsPerCommand+5; i++ {
+		history.Record("daily-reset", start.AddDate(0, 0, i), time.Second, nil)
+	}
+	history.Record("daily-reset", start.AddDate(0, 1, 0), time.Second, errors.New("boom"))
+	if err := history.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadRunHistory(path)
+	if err != nil {
+		t.Fatalf("LoadRunHistory() error = %v", err)
+	}
+	command := loaded.Commands["daily-reset"]
+	if len(command.Runs) != maxRunsPerCommand {
+		t.Errorf("kept %d runs, want %d", len(command.Runs), maxRunsPerCommand)
+	}
+	if want := start.AddDate(0, 0, maxRunsPerCommand+4); !command.LastSuccess.Equal(want) {
+		t.Errorf("LastSuccess = %v, want %v (failures don't count)", command.LastSuccess, want)
+	}
+	if last := command.Runs[len(command.Runs)-1]; last.Error != "boom" {
+		t.Errorf("last run error = %q, want boom", last.Error)
+	}
+}
+
+func TestMissedRuns(t *testing.T) {
+	loc := time.UTC
+	schedule := []ScheduledRun{
+		{Job: "daily-reset", At: "20:00"},
+		{Job: "week-review", At: "20:00", Days: []string{"sun"}},
+	}
+	wednesdayNight := time.Date(2025, 10, 8, 21, 0, 0, 0, loc)
+	history := func(reset, review time.Time) *RunHistory {
+		return &RunHistory{Commands: map[string]*CommandHistory{
+			"daily-reset": {LastSuccess: reset},
+			"week-review": {LastSuccess: review},
+		}}
+	}
+	sunday := time.Date(2025, 10, 5, 20, 0, 5, 0, loc)
+
+	tests := []struct {
+		name    string
+		history *RunHistory
+		now     time.Time
+		want    string
+	}{
+		{"up to date", history(time.Date(2025, 10, 8, 20, 0, 3, 0, loc), sunday), wednesdayNight, ""},
+		{"missed tonight's reset", history(time.Date(2025, 10, 7, 20, 0, 3, 0, loc), sunday), wednesdayNight, "daily-reset"},
+		{"before tonight's reset", history(time.Date(2025, 10, 7, 20, 0, 3, 0, loc), sunday), time.Date(2025, 10, 8, 19, 0, 0, 0, loc), ""},
+		{"never ran", &RunHistory{Commands: map[string]*CommandHistory{}}, wednesdayNight, "daily-reset,week-review"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, miss := range missedRuns(schedule, tt.history, tt.now) {
+				got = append(got, miss.Run.Job)
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("missedRuns() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if next, _ := schedule[1].Next(wednesdayNight); !next.Equal(time.Date(2025, 10, 12, 20, 0, 0, 0, loc)) {
+		t.Errorf("Next() = %v, want Sunday 8 PM", next)
+	}
+}
+
+func TestFormatRunStatus(t *testing.T) {
+	now := time.Date(2025, 10, 8, 21, 0, 0, 0, time.UTC)
+	history := &RunHistory{Commands: map[string]*CommandHistory{}}
+	history.Record("sync-canvas", now.Add(-2*time.Hour), 3*time.Second, nil)
+	history.Record("sync-moodle", now.Add(-time.Hour), time.Second, errors.New("token expired"))
+
+	got := formatRunStatus(history, defaultSchedule, now)
+	for _, want := range []string{"- daily-reset: never", "- sync-canvas: ✓ OK", "- sync-moodle: failed", "token expired", "never succeeded", "Next scheduled runs:\n- daily-reset: Thu, Oct 9", "Missed (run --catch-up):\n- daily-reset: missed"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("status missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestMissedRunCount(t *testing.T) {
+	now := time.Date(2025, 10, 8, 21, 0, 0, 0, time.UTC)
+	history := &RunHistory{Commands: map[string]*CommandHistory{
+		"daily-reset": {LastSuccess: time.Date(2025, 10, 5, 20, 0, 1, 0, time.UTC)},
+	}}
+
+	missed := missedRuns([]ScheduledRun{{Job: "daily-reset", At: "20:00"}}, history, now)
+	if len(missed) != 1 || missed[0].Count != 3 || !missed[0].Since.Equal(time.Date(2025, 10, 6, 20, 0, 0, 0, time.UTC)) {
+		t.Fatalf("missedRuns() = %+v, want 3 missed since Monday", missed)
+	}
+	if got := missed[0].Describe(time.UTC); got != "missed 3 times since Mon, Oct 6 at 8:00 PM UTC" {
+		t.Errorf("Describe() = %q", got)
+	}
+}
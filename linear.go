@@ -0,0 +1,434 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const linearAPIURL = "https://api.linear.app/graphql"
+
+// linearSource labels Linear cards in their metadata
+const linearSource = "Linear"
+
+// LinearClient talks to Linear's GraphQL API with a personal API key
+type LinearClient struct {
+	APIKey string
+	URL    string
+}
+
+// LinearState is a team workflow state; Type is one of backlog, unstarted,
+// started, completed, or canceled
+type LinearState struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Position float64 `json:"position"`
+	Team     struct {
+		ID string `json:"id"`
+	} `json:"team"`
+}
+
+// LinearIssue is an open issue assigned to the user
+type LinearIssue struct {
+	ID            string      `json:"id"`
+	Identifier    string      `json:"identifier"`
+	Title         string      `json:"title"`
+	Description   string      `json:"description"`
+	URL           string      `json:"url"`
+	DueDate       string      `json:"dueDate"` // YYYY-MM-DD
+	PriorityLabel string      `json:"priorityLabel"`
+	State         LinearState `json:"state"`
+	Team          struct {
+		ID string `json:"id"`
+	} `json:"team"`
+	Cycle *struct {
+		Number   int    `json:"number"`
+		Name     string `json:"name"`
+		StartsAt string `json:"startsAt"`
+		EndsAt   string `json:"endsAt"`
+	} `json:"cycle"`
+}
+
+// NewLinearClient creates a Linear client
+func NewLinearClient(apiKey string) *LinearClient {
+	return &LinearClient{APIKey: apiKey, URL: linearAPIURL}
+}
+
+// linearClientFromEnv builds a Linear client from LINEAR_API_KEY
+func linearClientFromEnv() (*LinearClient, error) {
+	apiKey := os.Getenv("LINEAR_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("LINEAR_API_KEY must be set")
+	}
+	return NewLinearClient(apiKey), nil
+}
+
+// query runs a GraphQL request and decodes its data into out
+func (l *LinearClient) query(query string, variables map[string]interface{}, out interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Linear query: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", l.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", l.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Linear API request failed with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to unmarshal Linear response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
+	}
+
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to unmarshal Linear data: %w", err)
+	}
+	return nil
+}
+
+const linearAssignedIssuesQuery = `query($after: String) {
+  viewer {
+    assignedIssues(first: 100, after: $after, filter: {state: {type: {nin: ["completed", "canceled"]}}}) {
+      nodes {
+        id identifier title description url dueDate priorityLabel
+        state { id name type position }
+        team { id }
+        cycle { number name startsAt endsAt }
+      }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+// GetAssignedIssues returns the user's open (not completed or canceled) issues
+func (l *LinearClient) GetAssignedIssues() ([]LinearIssue, error) {
+	var issues []LinearIssue
+	variables := map[string]interface{}{"after": nil}
+
+	for {
+		var data struct {
+			Viewer struct {
+				AssignedIssues struct {
+					Nodes    []LinearIssue `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"assignedIssues"`
+			} `json:"viewer"`
+		}
+		if err := l.query(linearAssignedIssuesQuery, variables, &data); err != nil {
+			return nil, fmt.Errorf("failed to get Linear issues: %w", err)
+		}
+
+		page := data.Viewer.AssignedIssues
+		issues = append(issues, page.Nodes...)
+		if !page.PageInfo.HasNextPage {
+			return issues, nil
+		}
+		variables["after"] = page.PageInfo.EndCursor
+	}
+}
+
+// GetWorkflowStates returns every team's workflow states
+func (l *LinearClient) GetWorkflowStates() ([]LinearState, error) {
+	var data struct {
+		WorkflowStates struct {
+			Nodes []LinearState `json:"nodes"`
+		} `json:"workflowStates"`
+	}
+	if err := l.query(`query { workflowStates(first: 250) { nodes { id name type position team { id } } } }`, nil, &data); err != nil {
+		return nil, fmt.Errorf("failed to get Linear workflow states: %w", err)
+	}
+	return data.WorkflowStates.Nodes, nil
+}
+
+// UpdateIssueState moves an issue to another workflow state
+func (l *LinearClient) UpdateIssueState(issueID, stateID string) error {
+	var data struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	}
+	mutation := `mutation($id: String!, $stateId: String!) { issueUpdate(id: $id, input: {stateId: $stateId}) { success } }`
+	if err := l.query(mutation, map[string]interface{}{"id": issueID, "stateId": stateID}, &data); err != nil {
+		return fmt.Errorf("failed to update Linear issue: %w", err)
+	}
+	if !data.IssueUpdate.Success {
+		return fmt.Errorf("failed to update Linear issue: update was rejected")
+	}
+	return nil
+}
+
+// mapListNameToLinearStateType maps common list names to a Linear state type,
+// like mapListNameToJiraStatus does for JIRA
+func mapListNameToLinearStateType(listName string) string {
+	switch strings.ToLower(listName) {
+	case "backlog":
+		return "backlog"
+	case "sprint", "to do", "todo":
+		return "unstarted"
+	case "doing", "in progress", "in review", "code review", "review":
+		return "started"
+	case "done", "completed":
+		return "completed"
+	}
+	return ""
+}
+
+// linearStateForList picks the team state a list stands for: the state named
+// by LINEAR_STATE_MAP or the list itself, else the first state of the list's type.
+// It returns nil when the list doesn't correspond to any state.
+func linearStateForList(listName, teamID string, mapping map[string]string, states []LinearState) *LinearState {
+	var teamStates []LinearState
+	for _, state := range states {
+		if state.Team.ID == teamID {
+			teamStates = append(teamStates, state)
+		}
+	}
+
+	name := listName
+	if mapped, ok := mapping[normalizeString(listName)]; ok {
+		name = mapped
+	}
+	for i, state := range teamStates {
+		if normalizeString(state.Name) == normalizeString(name) {
+			return &teamStates[i]
+		}
+	}
+
+	stateType := mapListNameToLinearStateType(listName)
+	var best *LinearState
+	for i, state := range teamStates {
+		if state.Type == stateType && (best == nil || state.Position < best.Position) {
+			best = &teamStates[i]
+		}
+	}
+	return best
+}
+
+// linearRecordedState reads the state the card was last synced with
+func linearRecordedState(card Card) string {
+	_, after, found := strings.Cut(card.Description, "\nLinear State: ")
+	if !found {
+		return ""
+	}
+	state, _, _ := strings.Cut(after, "\n")
+	return state
+}
+
+// buildLinearCardDescription formats the issue body plus state, priority,
+// and cycle in the metadata block
+func buildLinearCardDescription(issue LinearIssue) string {
+	var meta strings.Builder
+	meta.WriteString(fmt.Sprintf("Linear State: %s\n", issue.State.Name))
+	if issue.PriorityLabel != "" {
+		meta.WriteString(fmt.Sprintf("Priority: %s\n", issue.PriorityLabel))
+	}
+	if issue.Cycle != nil {
+		cycle := fmt.Sprintf("Cycle %d", issue.Cycle.Number)
+		if issue.Cycle.Name != "" {
+			cycle += " (" + issue.Cycle.Name + ")"
+		}
+		if ends, err := time.Parse(time.RFC3339, issue.Cycle.EndsAt); err == nil {
+			cycle += ", ends " + ends.Local().Format("Jan 2")
+		}
+		meta.WriteString(fmt.Sprintf("Cycle: %s\n", cycle))
+	}
+
+	item := WorkItem{Source: linearSource, ID: issue.ID, URL: issue.URL, Notes: issue.Description}
+	idLine := workItemIDPattern(linearSource, issue.ID)
+	return strings.Replace(formatWorkItemDescription(item), "---\n"+idLine, "---\n"+idLine+meta.String(), 1)
+}
+
+// linearDueDate converts an issue's due date to Trello's format
+func linearDueDate(issue LinearIssue) string {
+	day, err := time.ParseInLocation("2006-01-02", issue.DueDate, time.Local)
+	if err != nil {
+		return ""
+	}
+	return day.Add(23*time.Hour + 59*time.Minute).UTC().Format(trelloDueLayout)
+}
+
+// SyncLinearIssues syncs assigned Linear issues with the Mac board (or
+// LINEAR_BOARD), mirroring the JIRA flow. When a card has moved to another
+// list since the last sync, the issue's state is written back to Linear;
+// when the issue's state changed in Linear instead, the card follows it.
+func (c *TrelloClient) SyncLinearIssues(linear *LinearClient) error {
+	boardName := os.Getenv("LINEAR_BOARD")
+	if boardName == "" {
+		boardName = "Mac"
+	}
+	boardName = c.Target.board(boardName)
+	mapping := parseSectionMap(os.Getenv("LINEAR_STATE_MAP"))
+
+	issues, err := linear.GetAssignedIssues()
+	if err != nil {
+		return err
+	}
+	states, err := linear.GetWorkflowStates()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Found %d open Linear issue(s)\n", len(issues))
+
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return err
+	}
+
+	listIDToName := make(map[string]string)
+	var boardLists []List
+	for _, list := range cache.Lists {
+		if list.BoardID == board.ID {
+			listIDToName[list.ID] = list.Name
+			boardLists = append(boardLists, list)
+		}
+	}
+	if len(boardLists) == 0 {
+		return fmt.Errorf("no lists found on %s board", boardName)
+	}
+	fallbackList := boardLists[0]
+	if c.Target.List != "" {
+		list, err := findListByName(cache.Lists, board.ID, c.Target.List)
+		if err != nil {
+			return fmt.Errorf("%s in board '%s'", err.Error(), board.Name)
+		}
+		fallbackList = *list
+	}
+
+	// listForState finds the list standing for a state, falling back to the
+	// first list or --target-list
+	listForState := func(state LinearState, teamID string) (string, bool) {
+		for _, list := range boardLists {
+			if match := linearStateForList(list.Name, teamID, mapping, states); match != nil && match.ID == state.ID {
+				return list.ID, true
+			}
+		}
+		return fallbackList.ID, false
+	}
+
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get Trello cards: %w", err)
+	}
+
+	createdCards, updatedCards := 0, 0
+	open := make(map[string]bool)
+	for _, issue := range issues {
+		open[issue.ID] = true
+		title := fmt.Sprintf("%s: %s", issue.Identifier, issue.Title)
+		fmt.Printf("Processing issue: %s\n", issue.Identifier)
+
+		existing := findCardByWorkItem(cards, linearSource, issue.ID)
+		if skipNoAuto(existing) {
+			continue
+		}
+		if existing == nil {
+			listID, _ := listForState(issue.State, issue.Team.ID)
+			if _, err := c.CreateCard(listID, title, buildLinearCardDescription(issue), linearDueDate(issue)); err != nil {
+				fmt.Printf("  Warning: failed to create card: %v\n", err)
+				continue
+			}
+			fmt.Printf("  %s Created new card in %s\n", iconCheck, listIDToName[listID])
+			createdCards++
+			continue
+		}
+
+		recorded := linearRecordedState(*existing)
+		patch := CardPatch{}
+		listState := linearStateForList(listIDToName[existing.IDList], issue.Team.ID, mapping, states)
+
+		switch {
+		case listState != nil && listState.Name != recorded && listState.ID != issue.State.ID:
+			// The card moved in Trello since the last sync
+			if err := linear.UpdateIssueState(issue.ID, listState.ID); err != nil {
+				fmt.Printf("  Warning: failed to update Linear state: %v\n", err)
+			} else {
+				fmt.Printf("  %s Updated Linear state to: %s (from %s list)\n", iconCheck, listState.Name, listIDToName[existing.IDList])
+				issue.State = *listState
+			}
+		case issue.State.Name != recorded:
+			// The issue moved in Linear, so the card follows
+			if listID, ok := listForState(issue.State, issue.Team.ID); ok && listID != existing.IDList {
+				patch.IDList = &listID
+				fmt.Printf("  %s Moving card to %s (Linear state: %s)\n", iconCheck, listIDToName[listID], issue.State.Name)
+			}
+		}
+
+		description := buildLinearCardDescription(issue)
+		dueDate := linearDueDate(issue)
+		if existing.Name != title {
+			patch.Name = &title
+		}
+		if existing.Description != description {
+			patch.Desc = &description
+		}
+		if _, _, moved := dueDateMoved(existing, dueDate); moved || (existing.Due == nil) != (dueDate == "") {
+			patch.Due = &dueDate
+		}
+		if patch == (CardPatch{}) {
+			continue
+		}
+
+		if err := c.UpdateCardFields(existing.ID, patch); err != nil {
+			fmt.Printf("  Warning: failed to update card: %v\n", err)
+			continue
+		}
+		updatedCards++
+	}
+
+	// Issues completed or canceled in Linear (or unassigned) are done here too
+	if doneListID, err := c.FindListByName(boardName, "Done"); err == nil {
+		for _, card := range cards {
+			id := workItemCardID(card, linearSource)
+			if id == "" || open[id] || card.IDList == doneListID || skipNoAuto(&card) {
+				continue
+			}
+			fmt.Printf("Moving %s to Done (closed in Linear)\n", card.Name)
+			if err := c.MoveCardToList(card.ID, doneListID); err != nil {
+				fmt.Printf("Warning: failed to move %s to Done: %v\n", card.Name, err)
+			}
+		}
+	}
+
+	fmt.Printf("\nLinear sync completed!\n")
+	fmt.Printf("Created: %d cards\n", createdCards)
+	fmt.Printf("Updated: %d cards\n", updatedCards)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func linearTestStates() []LinearState {
+	var states []LinearState
+	json.Unmarshal([]byte(`[
+		{"id":"s1","name":"Todo","type":"unstarted","position":1,"team":{"id":"t1"}},
+		{"id":"s2","name":"In Progress","type":"started","position":2,"team":{"id":"t1"}},
+		{"id":"s3","name":"In Review","type":"started","position":3,"team":{"id":"t1"}},
+		{"id":"s4","name":"Done","type":"completed","position":4,"team":{"id":"t1"}},
+		{"id":"s5","name":"Doing","type":"started","position":1,"team":{"id":"t2"}}
+	]`), &states)
+	return states
+}
+
+func TestLinearStateForList(t *testing.T) {
+	states := linearTestStates()
+	mapping := parseSectionMap("QA=In Review")
+
+	tests := []struct {
+		listName string
+		expected string
+	}{
+		{"In Review", "s3"},
+		{"to do", "s1"},
+		{"Doing", "s2"}, // Doing belongs to another team, so fall back to the first started state
+		{"QA", "s3"},
+		{"Done", "s4"},
+		{"Ideas", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.listName, func(t *testing.T) {
+			state := linearStateForList(tt.listName, "t1", mapping, states)
+			got := ""
+			if state != nil {
+				got = state.ID
+			}
+			if got != tt.expected {
+				t.Errorf("linearStateForList(%q) = %q, want %q", tt.listName, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildLinearCardDescription(t *testing.T) {
+	issue := LinearIssue{ID: "abc", Identifier: "ENG-1", Description: "Body\n\n---\nnot metadata", URL: "https://linear.app/x", PriorityLabel: "High"}
+	issue.State.Name = "In Progress"
+
+	card := Card{Description: buildLinearCardDescription(issue)}
+	if state := linearRecordedState(card); state != "In Progress" {
+		t.Errorf("linearRecordedState() = %q, want In Progress", state)
+	}
+	if id := workItemCardID(card, linearSource); id != "abc" {
+		t.Errorf("workItemCardID() = %q, want abc", id)
+	}
+	if !strings.Contains(card.Description, "Priority: High") {
+		t.Errorf("description should include priority: %q", card.Description)
+	}
+}
+
+func TestLinearGetAssignedIssuesPages(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		calls++
+		if calls == 1 {
+			fmt.Fprint(w, `{"data":{"viewer":{"assignedIssues":{"nodes":[{"id":"1","identifier":"ENG-1"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}`)
+			return
+		}
+		if !strings.Contains(string(body), `"after":"c1"`) {
+			t.Errorf("second page should pass the cursor, got %s", body)
+		}
+		fmt.Fprint(w, `{"data":{"viewer":{"assignedIssues":{"nodes":[{"id":"2","identifier":"ENG-2"}],"pageInfo":{"hasNextPage":false}}}}}`)
+	}))
+	defer server.Close()
+
+	linear := &LinearClient{APIKey: "key", URL: server.URL}
+	issues, err := linear.GetAssignedIssues()
+	if err != nil {
+		t.Fatalf("GetAssignedIssues() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("expected 2 issues across pages, got %d", len(issues))
+	}
+}
+
+func TestLinearQueryErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"errors":[{"message":"Entity not found"}]}`)
+	}))
+	defer server.Close()
+
+	linear := &LinearClient{APIKey: "key", URL: server.URL}
+	if err := linear.UpdateIssueState("missing", "s1"); err == nil || !strings.Contains(err.Error(), "Entity not found") {
+		t.Errorf("expected the GraphQL error to surface, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// deadLinkLabel flags cards whose source link no longer resolves
+var deadLinkLabel = LabelSpec{Name: "dead link", Color: "black", CreateIfMissing: true}
+
+// linkCheckTimeout bounds each link check so one slow site can't stall the run
+const linkCheckTimeout = 15 * time.Second
+
+// sourceLinkRegexes find source links in sync metadata: "Canvas URL: ...",
+// "Moodle URL: ...", "Link: ...", each bare or as a Markdown link, and the
+// JIRA ticket link
+var sourceLinkRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^(?:[A-Za-z][A-Za-z ]* URL|Link): (https?://\S+)`),
+	regexp.MustCompile(`(?m)^(?:[A-Za-z][A-Za-z ]* URL|Link): \[[^\]]*\]\((https?://[^)\s]+)\)`),
+	regexp.MustCompile(`\[JIRA Ticket\]\((https?://[^)\s]+)\)`),
+}
+
+// sourceLinks returns the source links found in a card description. Links
+// to other Trello cards are left to the syncs that made them.
+func sourceLinks(description string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, re := range sourceLinkRegexes {
+		for _, match := range re.FindAllStringSubmatch(description, -1) {
+			if !seen[match[1]] && !strings.Contains(match[1], "trello.com/") {
+				seen[match[1]] = true
+				links = append(links, match[1])
+			}
+		}
+	}
+	return links
+}
+
+// LinkChecker checks whether links still resolve, remembering each result
+type LinkChecker struct {
+	HTTP    *http.Client
+	Auth    map[string]string // host -> Authorization header, e.g. for Canvas
+	results map[string]int
+}
+
+// NewLinkChecker returns a checker that signs Canvas links with
+// CANVAS_API_TOKEN, when set, so deleted assignments show up as 404s
+// instead of login redirects
+func NewLinkChecker() *LinkChecker {
+	checker := &LinkChecker{HTTP: &http.Client{Timeout: linkCheckTimeout}, Auth: make(map[string]string)}
+	token, base := os.Getenv("CANVAS_API_TOKEN"), os.Getenv("CANVAS_BASE_URL")
+	if u, err := url.Parse(base); err == nil && token != "" && u.Host != "" {
+		checker.Auth[u.Host] = "Bearer " + token
+	}
+	return checker
+}
+
+// Status returns the HTTP status a link answers with. It tries HEAD first and
+// falls back to GET for servers that don't support HEAD.
+func (l *LinkChecker) Status(link string) (int, error) {
+	if status, ok := l.results[link]; ok {
+		return status, nil
+	}
+
+	status, err := l.request("HEAD", link)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = l.request("GET", link)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if l.results == nil {
+		l.results = make(map[string]int)
+	}
+	l.results[link] = status
+	return status, nil
+}
+
+func (l *LinkChecker) request(method, link string) (int, error) {
+	req, err := http.NewRequest(method, link, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if auth := l.Auth[req.URL.Host]; auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	resp, err := l.HTTP.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach %s: %w", req.URL.Host, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// isDeadStatus reports whether a status means the source is gone. Auth
+// failures and server errors don't count: the page may still exist.
+func isDeadStatus(status int) bool {
+	return status == http.StatusNotFound || status == http.StatusGone
+}
+
+// CheckCardLinks checks the source links on every cached board's open cards.
+// Cards whose source is gone get the "dead link" label and a comment saying
+// which link failed; the label comes off once the link resolves again.
+func (c *TrelloClient) CheckCardLinks(checker *LinkChecker) error {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+
+	checked, flagged, cleared := 0, 0, 0
+	for _, board := range cache.Boards {
+		cards, err := c.GetBoardCardsByID(board.ID)
+		if err != nil {
+			fmt.Printf("Warning: skipping %s: %v\n", board.Name, err)
+			continue
+		}
+
+		for _, card := range withoutNoAuto(cards) {
+			links := sourceLinks(card.Description)
+			if len(links) == 0 {
+				continue
+			}
+
+			var dead []string
+			unreachable := false
+			for _, link := range links {
+				checked++
+				status, err := checker.Status(link)
+				if err != nil {
+					// A network blip isn't proof the source is gone
+					fmt.Printf("Warning: could not check %s on %s: %v\n", link, card.Name, err)
+					unreachable = true
+					continue
+				}
+				if isDeadStatus(status) {
+					dead = append(dead, fmt.Sprintf("%s (%d)", link, status))
+				}
+			}
+
+			label := findLabel(card.Labels, LabelSpec{Name: deadLinkLabel.Name})
+			switch {
+			case len(dead) > 0:
+				fmt.Println(consoleText(fmt.Sprintf("🔗 Dead link on %s: %s", card.Name, dead[0])))
+				text := "🔗 Source link no longer resolves, so the assignment may have been deleted or moved:\n"
+				for _, link := range dead {
+					text += "- " + link + "\n"
+				}
+				if err := c.UpsertComment(card.ID, "dead-link", text); err != nil {
+					fmt.Printf("Warning: failed to comment on %s: %v\n", card.Name, err)
+				}
+				if label == nil {
+					if err := c.AddLabelToCardWithOptions(card.ID, deadLinkLabel); err != nil {
+						fmt.Printf("Warning: failed to label %s: %v\n", card.Name, err)
+					}
+				}
+				flagged++
+			case label != nil && !unreachable:
+				fmt.Printf("%s Source link works again on %s\n", iconSuccess, card.Name)
+				if err := c.RemoveLabelFromCard(card.ID, label.ID); err != nil {
+					fmt.Printf("Warning: failed to unlabel %s: %v\n", card.Name, err)
+				}
+				if err := c.UpsertComment(card.ID, "dead-link", "🔗 Source link resolves again."); err != nil {
+					fmt.Printf("Warning: failed to comment on %s: %v\n", card.Name, err)
+				}
+				cleared++
+			}
+		}
+	}
+
+	fmt.Printf("%s Checked %d link(s): %d card(s) flagged, %d cleared\n", iconSuccess, checked, flagged, cleared)
+	return nil
+}
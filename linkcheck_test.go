@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSourceLinks(t *testing.T) {
+	desc := "Essay\n\n---\nCanvas Assignment ID: 5\nCanvas URL: https://school.instructure.com/courses/1/assignments/5\n" +
+		"Mirror ID: abc\nLink: https://trello.com/c/Abc123\n" +
+		"- [JIRA Ticket](https://example.atlassian.net/browse/PROJ-1)\n" +
+		"Link: https://school.instructure.com/courses/1/assignments/5"
+
+	got := sourceLinks(desc)
+	want := []string{"https://school.instructure.com/courses/1/assignments/5", "https://example.atlassian.net/browse/PROJ-1"}
+	if len(got) != len(want) {
+		t.Fatalf("sourceLinks() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sourceLinks()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLinkCheckerStatus(t *testing.T) {
+	var heads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gone":
+			heads++
+			w.WriteHeader(http.StatusNotFound)
+		case "/no-head":
+			if r.Method == "HEAD" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/private":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+	}))
+	defer server.Close()
+
+	checker := &LinkChecker{HTTP: server.Client(), Auth: map[string]string{server.Listener.Addr().String(): "Bearer secret"}}
+	tests := []struct {
+		path string
+		dead bool
+	}{
+		{"/gone", true},
+		{"/gone", true},
+		{"/no-head", false},
+		{"/private", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			status, err := checker.Status(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("Status() error = %v", err)
+			}
+			if isDeadStatus(status) != tt.dead {
+				t.Errorf("Status() = %d, want dead = %v", status, tt.dead)
+			}
+		})
+	}
+	if heads != 1 {
+		t.Errorf("repeated links should be checked once, got %d requests", heads)
+	}
+	if _, err := checker.Status("http://127.0.0.1:1/unreachable"); err == nil {
+		t.Errorf("unreachable link should be an error, not a status")
+	}
+}
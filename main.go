@@ -5,6 +5,8 @@ import (
     "fmt"
     "log"
     "os"
+    "strconv"
+    "strings"
     "time"
 
     "github.com/joho/godotenv"
@@ -12,7 +14,8 @@ import (
 
 func main() {
 	var (
-		refresh      = flag.Bool("refresh", false, "Refresh cache from Trello API")
+		refresh      = flag.Bool("refresh", false, "Refresh cache from Trello API (only --board's lists when --board is set)")
+		refreshCards = flag.Bool("with-cards", false, "With --refresh --board, also refresh that board's cards")
 		showCache    = flag.Bool("cache", false, "Show cached boards and lists")
 		board        = flag.String("board", "", "Board name to get cards from")
 		list         = flag.String("list", "", "List name to get cards from")
@@ -23,42 +26,496 @@ func main() {
 		testMoodle   = flag.Bool("test-moodle", false, "Test Moodle/Open LMS connection")
 		syncMoodle   = flag.Bool("sync-moodle", false, "Sync Moodle/Open LMS assignments to Trello")
 		syncMoodleDry= flag.Bool("sync-moodle-dry-run", false, "Preview Moodle sync without Trello changes")
-		moodleTo     = flag.String("moodle-to", "", "Sync Moodle assignments due up to this date (YYYY-MM-DD); defaults to 60 days ahead")
+		moodleTo     = flag.String("moodle-to", "", "Sync Moodle assignments due up to this date (YYYY-MM-DD); defaults to MOODLE_SYNC_TO, then SYNC_HORIZON_DAYS ahead")
+		pastDueDays  = flag.Int("include-past-due", 0, "Also sync LMS work due in the past N days that isn't turned in or passing; defaults to INCLUDE_PAST_DUE_DAYS")
+		canvasTo     = flag.String("canvas-to", "", "Sync Canvas assignments due up to this date (YYYY-MM-DD); defaults to CANVAS_SYNC_TO, then SYNC_HORIZON_DAYS ahead")
 		moodleTestFile = flag.String("moodle-test-file", "", "Use test data file instead of API calls for Moodle sync testing")
 		exportMoodle = flag.Bool("export-moodle", false, "Export all Moodle assignments to JSON file")
 		exportCanvas = flag.Bool("export-canvas", false, "Export all Canvas assignments to JSON file")
 		exportTo     = flag.String("export-to", "", "Export assignments due up to this date (YYYY-MM-DD); defaults to end of current year")
 		syncJira     = flag.Bool("sync-jira", false, "Sync JIRA tasks to Trello")
-		jiraTasksDir = flag.String("jira-tasks-dir", "/Users/macfarnsworth/Workspaces/Alkira/mac-tasks/open-tasks", "Directory containing JIRA tasks")
+		jiraTasksDir = flag.String("jira-tasks-dir", "", "Directory containing JIRA tasks (default: $JIRA_TASKS_DIR or ~/Workspaces/Alkira/mac-tasks/open-tasks)")
 		sundownNotify= flag.String("sundown-notify", "", "Create daily sundown notification on specified board")
+		weekReview   = flag.Bool("week-review", false, "Post a week-in-review card for Makai's past week (run on Sundays)")
+		today        = flag.Bool("today", false, "Print today's agenda for Makai from the cache")
+		todayPost    = flag.String("today-post", "", "Also post today's agenda as a card at the top of this Makai School list")
+		standup      = flag.Bool("standup", false, "Print a stand-up summary for the Mac board (or --board): yesterday's moves, today's work, and blockers")
+		standupSlack = flag.Bool("standup-slack", false, "Also post the stand-up to STANDUP_SLACK_WEBHOOK_URL")
+		serve        = flag.String("serve", "", "Serve the spoken /briefing endpoint for voice assistants on this address, e.g. 127.0.0.1:8080 (other addresses need BRIEFING_TOKEN)")
+		boardImage   = flag.String("board-image", "", "Render Makai School (or --board) to this .png or .svg file for printing")
+		rebucket     = flag.Bool("rebucket", false, "Move cards between This Week, Next Week, and Later as their due dates approach (Makai School, or --board)")
+		rollover     = flag.String("season-rollover", "", "Archive last season's lists from rollover.json (e.g. Weekly Q1) and create this season's: --season-rollover Q2")
+		cloneBoard   = flag.Bool("clone-board", false, "Copy a board's lists, cards, and labels to a new sandbox board: --clone-board SRC DST")
+		reconcile    = flag.String("reconcile", "", "Make a board match a YAML spec of its lists, labels, and standing cards (e.g. board.yaml); --board overrides the spec's board")
+		reconcileDry = flag.Bool("reconcile-dry-run", false, "With --reconcile, print the plan without changing the board")
+		bench        = flag.Bool("bench", false, "Time board and card fetches, a cache warm, and sync dry-runs against the live APIs with per-endpoint latencies (read-only)")
+		relink       = flag.Bool("relink", false, "Attach Canvas and Moodle IDs to cards that look like assignments but were never linked (Makai School, or --board)")
+		relinkMin    = flag.Float64("relink-min", 0, "With --relink, link matches scoring at least this (0-1) without asking, e.g. 0.9")
+		syncStats    = flag.Bool("sync-stats", false, "Show how the recent Canvas, Moodle, and plugin syncs went: duration and cards created, updated, skipped, and failed")
+		statsRuns    = flag.Int("sync-stats-runs", 10, "With --sync-stats, how many recent runs of each sync to show")
+		showSettings = flag.Bool("board-settings", false, "Show the automation settings from board_settings.json and the board's Automation Settings card (Makai School, or --board)")
+		triage       = flag.Bool("triage", false, "Accept, re-list, or reject the new synced cards waiting in the INBOX_LIST list (Makai School, or --board)")
+		weekView     = flag.Bool("week-view", false, "Print a 7-day calendar of due cards from the cache (Makai School, or --board)")
+		hygiene      = flag.Bool("hygiene", false, "Report board problems like missing due dates and duplicates (Makai School, or --board)")
+		checkLinks   = flag.Bool("check-links", false, "Check that Canvas, Moodle, and JIRA links on cards still resolve and flag dead ones")
+		hygieneFix   = flag.String("hygiene-fix", "", "Fix these hygiene rules (comma-separated, or all): no-due, duplicates, labels, wrong-list, empty-desc")
+		initConfig   = flag.Bool("init", false, "Write default .env, subjects.json, and cards.json to the config directory")
+		attendance   = flag.Bool("check-attendance", false, "Record today's absences and tardies from Moodle attendance and Canvas Roll Call for the week in review")
+		attendFollow = flag.Bool("attendance-followup", false, "With --check-attendance, add a Weekly card to catch up on each missed class")
+		canvasMsgs   = flag.Bool("canvas-messages", false, "Post unread teacher messages from the Canvas inbox as comments on a Messages card in Weekly")
+		markMsgsRead = flag.Bool("canvas-messages-mark-read", false, "With --canvas-messages, mark the posted threads read in Canvas")
+		gradeEmail   = flag.Bool("grade-email", false, "Email parents a chart of grade trends with missing and REDO work (needs Canvas and SMTP settings)")
+		gradeEmailDry = flag.Bool("grade-email-dry-run", false, "With --grade-email, write the email to a .eml file instead of sending it")
+		gradeReport  = flag.Bool("grade-report", false, "Print current Canvas course scores with an estimated GPA")
+		diffExports  = flag.Bool("diff-exports", false, "Compare two export files: --diff-exports old.json new.json")
+		snapshot     = flag.Bool("snapshot", false, "Append today's board state to the local history (run nightly)")
+		syncPlugins  = flag.String("sync-plugins", "", "Sync comma-separated plugins from plugins.json to Trello (\"all\" for every plugin)")
+		pluginDryRun = flag.Bool("plugin-dry-run", false, "Preview --sync-plugins without Trello changes")
+		syncOutlook  = flag.Bool("sync-outlook", false, "Sync flagged Outlook emails and Microsoft To Do tasks to the Mac board")
+		syncAsana    = flag.Bool("sync-asana", false, "Sync Asana tasks assigned to you in $ASANA_PROJECTS to a Trello board")
+		syncGitLab   = flag.Bool("sync-gitlab", false, "Sync assigned GitLab issues and MRs awaiting your review to the Mac board")
+		syncLinear   = flag.Bool("sync-linear", false, "Sync assigned Linear issues with the Mac board, writing list moves back as state changes")
+		track        = flag.String("track", "", "Log work on a card: --track start <card>, --track stop [<card>], or --track push")
+		syncOnCall   = flag.Bool("sync-oncall", false, "Create cards for upcoming PagerDuty/Opsgenie on-call shifts and active incidents")
+		syncMirrors  = flag.Bool("sync-mirrors", false, "Copy cards matching the rules in mirrors.json to other boards (e.g. Family) and keep them in sync")
+		split        = flag.String("split", "", "Create linked work-on cards for a big assignment: --split <card> <parts>")
+		updateParts  = flag.Bool("update-parts", false, "Refresh the parts-done comment on assignments split with --split")
+		snooze       = flag.String("snooze", "", "Push a card's due date back: --snooze <card> <length> (e.g. 3d, 1w, 12h)")
+		watchItems   = flag.Bool("watch", false, "Make the parent account watch the lists and cards in $WATCH_ITEMS and report what it watches")
+		deleteAll    = flag.Bool("delete-all", false, "Delete every card in --board/--list after backing them up (asks for confirmation)")
+		assumeYes    = flag.Bool("yes", false, "Skip the confirmation prompt for --delete-all, --purge-student-data, and --reconcile")
+		purgeData    = flag.Bool("purge-student-data", false, "Delete the student's local caches, grade history, and logs, e.g. at the end of the school year (asks for confirmation)")
+		purgeMeta    = flag.Bool("purge-card-metadata", false, "With --purge-student-data, also strip sync metadata from the synced cards on Makai School (or --board)")
+		archiveYear  = flag.String("archive-year", "", "Zip the school year's exports, grade history, board snapshots, and reports, then reset local state: --archive-year 2025-2026")
+		backupDir    = flag.String("backup-dir", "backups", "Directory for card backups written before --delete-all")
+		syncSheet    = flag.String("sync-sheet", "", "Sync assignments from a CSV file or Google Sheet link to Trello")
+		syncSheetDry = flag.Bool("sync-sheet-dry-run", false, "Preview --sync-sheet without Trello changes")
+		recordFixtures = flag.String("record-fixtures", "", "Write anonymized Trello/Canvas/Moodle fixtures to this directory (read-only)")
+		workspaces   = flag.String("workspaces", "", "Only use boards in these comma-separated workspaces (\"personal\" for boards outside any); overrides TRELLO_WORKSPACES")
+		targetBoard  = flag.String("target-board", "", "Point every sync at this board instead of its usual one (Makai School, Mac, or the board in .env)")
+		targetList   = flag.String("target-list", "", "Put new synced cards in this list instead of the sync's usual one")
+		inclClosed   = flag.Bool("include-closed", false, "Include closed boards in listings, cache, and syncs")
+		runJobs      = flag.String("run", "", "Run a comma-separated list of jobs (e.g. refresh,sync-canvas,daily-reset) and post a status card")
+		catchUp      = flag.Bool("catch-up", false, "Run (or ask about, per schedule.json) scheduled jobs missed since their last success, e.g. after the computer was off")
+		runStatus    = flag.Bool("status", false, "Show when each command last ran and when scheduled jobs run next")
+		summaryBoard = flag.String("summary-board", "Makai School", "Board holding the --run status card")
+		summaryList  = flag.String("summary-list", "Automation", "List holding the --run status card")
+		renewCanvas  = flag.Bool("renew-canvas", false, "Walk through making a new Canvas API token and save it to .env")
+		authorize    = flag.Bool("authorize-trello", false, "Authorize this app in the browser (OAuth) and save the Trello token; needs TRELLO_API_KEY and TRELLO_API_SECRET")
+		authScope    = flag.String("auth-scope", "read,write", "Scopes to request with --authorize-trello (read, write, account)")
+		authExpiry   = flag.String("auth-expiration", "never", "Token lifetime for --authorize-trello: 1hour, 1day, 30days, or never")
+		showVersion  = flag.Bool("version", false, "Print version and build information")
+		updateSelf   = flag.Bool("self-update", false, "Replace this binary with the latest GitHub release")
+		trace        = flag.Bool("trace", false, "Log every outbound HTTP request (secrets redacted) with status, latency, and retries to TRACE_FILE (default http_trace.jsonl)")
+		noEmoji      = flag.Bool("no-emoji", false, "Print plain ASCII markers instead of emoji (for log files and non-UTF-8 terminals)")
 	)
 	flag.Parse()
 
-	if err := godotenv.Load(); err != nil {
+	if *noEmoji {
+		disableEmoji()
+	}
+
+	if *showVersion {
+		fmt.Println(versionString())
+		return
+	}
+
+	if *updateSelf {
+		if err := SelfUpdate(); err != nil {
+			log.Fatalf("Failed to self-update: %v", err)
+		}
+		return
+	}
+
+	if *diffExports {
+		if flag.NArg() != 2 {
+			log.Fatal("Usage: --diff-exports old.json new.json")
+		}
+		diff, err := DiffExportFiles(flag.Arg(0), flag.Arg(1))
+		if err != nil {
+			log.Fatalf("Failed to diff exports: %v", err)
+		}
+		fmt.Print(consoleText(diff.Format()))
+		return
+	}
+
+	// --init runs before credentials are required, since it creates the .env
+	if *initConfig {
+		dir, err := configDir()
+		if err != nil {
+			log.Fatalf("Failed to initialize config: %v", err)
+		}
+		if err := InitConfig(dir); err != nil {
+			log.Fatalf("Failed to initialize config: %v", err)
+		}
+		fmt.Printf("Config directory: %s\n", dir)
+		fmt.Println("Edit .env with your API credentials and subjects.json with this quarter's schedule.")
+		return
+	}
+
+	envLoaded := ""
+	for _, envFile := range envFileCandidates() {
+		if err := godotenv.Load(envFile); err == nil {
+			envLoaded = envFile
+			break
+		}
+	}
+	if envLoaded == "" {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	if *trace {
+		traceFile, err := startTrace(traceFilePath())
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer traceFile.Close()
+	}
+
+	// Syncs and scheduled runs stop cleanly between cards when launchd or
+	// cron sends SIGTERM; interactive commands keep the default Ctrl+C
+	if *runJobs != "" || *catchUp || *syncCanvas || *syncMoodle || *syncPlugins != "" || *syncSheet != "" {
+		handleShutdownSignals()
+	}
+
+	// --renew-canvas only needs the Canvas URL
+	if *renewCanvas {
+		canvasURL := os.Getenv("CANVAS_BASE_URL")
+		if canvasURL == "" {
+			log.Fatal("Please set CANVAS_BASE_URL in .env file or environment variables")
+		}
+		envFile := envLoaded
+		if envFile == "" {
+			envFile = ".env"
+		}
+		if err := RenewCanvasToken(canvasURL, envFile, os.Stdin); err != nil {
+			log.Fatalf("Failed to renew Canvas token: %v", err)
+		}
+		return
+	}
+
+	// --grade-report only needs Canvas credentials
+	if *gradeReport {
+		canvasClient, err := canvasClientFromEnv()
+		if err != nil {
+			log.Fatal("Please set CANVAS_API_TOKEN and CANVAS_BASE_URL in .env file or environment variables")
+		}
+		estimate, err := canvasClient.EstimateGPA()
+		if err != nil {
+			log.Fatalf("Failed to estimate GPA: %v", err)
+		}
+		fmt.Print(estimate.Format())
+		return
+	}
+
 	apiKey := os.Getenv("TRELLO_API_KEY")
 	apiToken := os.Getenv("TRELLO_API_TOKEN")
 
+	if *authorize {
+		apiSecret := os.Getenv("TRELLO_API_SECRET")
+		if apiKey == "" || apiSecret == "" {
+			log.Fatal("Please set TRELLO_API_KEY and TRELLO_API_SECRET (from https://trello.com/app-key) in .env file or environment variables")
+		}
+		token, err := NewTrelloAuthorizer(apiKey, apiSecret).Authorize(*authScope, *authExpiry)
+		if err != nil {
+			log.Fatalf("Failed to authorize Trello: %v", err)
+		}
+		path, err := SaveStoredToken(*token)
+		if err != nil {
+			log.Fatalf("Failed to save Trello token: %v", err)
+		}
+		where := path
+		if token.InKeychain {
+			where = "the keychain (details in " + path + ")"
+		}
+		fmt.Printf("%s Trello authorized; token saved to %s\n", iconSuccess, where)
+		if !token.ExpiresAt.IsZero() {
+			fmt.Printf("It expires %s.\n", friendlyTime(token.ExpiresAt, displayLocation()))
+		}
+		return
+	}
+
+	// Fall back to the token saved by --authorize-trello
+	if apiToken == "" {
+		stored, err := storedTrelloToken(time.Now())
+		if err != nil {
+			log.Fatal(err)
+		}
+		apiToken = stored
+	}
+
 	if apiKey == "" || apiToken == "" {
-		log.Fatal("Please set TRELLO_API_KEY and TRELLO_API_TOKEN in .env file or environment variables")
+		log.Fatal("Please set TRELLO_API_KEY and TRELLO_API_TOKEN in .env file or environment variables, or run --authorize-trello")
 	}
 
 	client := NewTrelloClient(apiKey, apiToken)
+	if *workspaces != "" {
+		client.Filter.Workspaces = splitList(*workspaces)
+	}
+	if *inclClosed {
+		client.Filter.IncludeClosed = true
+	}
+	client.Target = SyncTarget{Board: *targetBoard, List: *targetList}
+
+	setFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+	if !setFlags["include-past-due"] {
+		*pastDueDays = includePastDueDays()
+	}
+	if command := writeCommand(setFlags); command != "" {
+		if err := client.RequireWriteAccess(); err != nil {
+			log.Fatalf("--%s changes Trello, but %v", command, err)
+		}
+	}
+
+	if *syncStats {
+		if err := ShowSyncStats(*statsRuns); err != nil {
+			log.Fatalf("Failed to show sync stats: %v", err)
+		}
+		return
+	}
+
+	if *runStatus {
+		history, err := LoadRunHistory(runHistoryFile)
+		if err != nil {
+			log.Fatalf("Failed to load run history: %v", err)
+		}
+		schedule, err := LoadSchedule()
+		if err != nil {
+			log.Fatalf("Failed to load schedule: %v", err)
+		}
+		fmt.Print(consoleText(formatRunStatus(history, schedule, time.Now())))
+		return
+	}
+
+	// Several commands at once run in a fixed order like --run, sharing one client
+	if combined := combinedJobs(setFlags, func(name string) string { return flag.Lookup(name).Value.String() }); len(combined) > 1 && *runJobs == "" && !*catchUp {
+		*runJobs = strings.Join(combined, ",")
+		handleShutdownSignals()
+	}
+
+	// `run <pipeline>` runs a named pipeline from pipelines.json
+	pipeline := ""
+	if args := flag.Args(); len(args) > 0 && args[0] == "run" {
+		if len(args) != 2 {
+			log.Fatal("Usage: trello-client [flags] run <pipeline>")
+		}
+		pipeline = args[1]
+		if err := client.RequireWriteAccess(); err != nil {
+			log.Fatalf("Pipelines change Trello, but %v", err)
+		}
+		handleShutdownSignals()
+	}
+
+	if *runJobs != "" || *catchUp || pipeline != "" {
+		tasksDir := resolveJiraTasksDir(*jiraTasksDir)
+
+		var summary RunSummary
+		var err error
+		if pipeline != "" {
+			var pipelines map[string]Pipeline
+			if pipelines, err = LoadPipelines(); err == nil {
+				summary, err = client.RunPipeline(pipeline, pipelines, tasksDir)
+			}
+		} else if *catchUp {
+			summary, err = client.CatchUp(tasksDir, time.Now())
+		} else {
+			summary, err = client.RunScheduledJobs(splitList(*runJobs), tasksDir)
+		}
+		if err != nil {
+			log.Fatalf("Failed to start run: %v", err)
+		}
+		if len(summary.Jobs) == 0 {
+			fmt.Printf("%s Nothing to catch up on\n", iconSuccess)
+			return
+		}
+		recordRuns(summary.Jobs...)
+
+		fmt.Print("\n" + consoleText(summary.Format()))
+		if err := client.PostRunSummary(*summaryBoard, *summaryList, summary); err != nil {
+			fmt.Printf("Warning: failed to post run summary: %v\n", err)
+		}
+
+		if failed := summary.Failed(); failed > 0 {
+			log.Fatalf("%d of %d job(s) failed", failed, len(summary.Jobs))
+		}
+		return
+	}
+
+	if *refresh && *board != "" {
+		fmt.Printf("Refreshing cache for %s...\n", *board)
+		if err := client.RefreshBoardCache(*board, *refreshCards); err != nil {
+			log.Fatalf("Failed to refresh board: %v", err)
+		}
+		return
+	}
 
 	if *refresh {
 		fmt.Println("Refreshing cache...")
-		if err := client.CacheData(); err != nil {
+		started := time.Now()
+		err := client.CacheData()
+		recordRun("refresh", started, err)
+		if err != nil {
 			log.Fatalf("Failed to cache data: %v", err)
 		}
 		fmt.Println("Cache updated successfully!")
 		return
 	}
 
+	if *snapshot {
+		if err := client.TakeSnapshot(); err != nil {
+			log.Fatalf("Failed to take snapshot: %v", err)
+		}
+		return
+	}
+
+	if *recordFixtures != "" {
+		fmt.Printf("Recording fixtures to %s...\n", *recordFixtures)
+		canvasClient, _ := canvasClientFromEnv()
+		moodleClient, _ := moodleClientFromEnv()
+		if err := client.RecordFixtures(*recordFixtures, canvasClient, moodleClient); err != nil {
+			log.Fatalf("Failed to record fixtures: %v", err)
+		}
+		return
+	}
+
+	if *syncOutlook {
+		graph, err := graphClientFromEnv()
+		if err != nil {
+			log.Fatalf("Failed to connect to Microsoft Graph: %v", err)
+		}
+		if err := client.SyncOutlookItems(graph); err != nil {
+			log.Fatalf("Failed to sync Outlook items: %v", err)
+		}
+		return
+	}
+
+	if *syncAsana {
+		asana, err := asanaClientFromEnv()
+		if err != nil {
+			log.Fatalf("Failed to connect to Asana: %v", err)
+		}
+		if err := client.SyncAsanaTasks(asana, splitList(os.Getenv("ASANA_PROJECTS"))); err != nil {
+			log.Fatalf("Failed to sync Asana tasks: %v", err)
+		}
+		return
+	}
+
+	if *syncGitLab {
+		gitlab, err := gitlabClientFromEnv()
+		if err != nil {
+			log.Fatalf("Failed to connect to GitLab: %v", err)
+		}
+		if err := client.SyncGitLab(gitlab); err != nil {
+			log.Fatalf("Failed to sync GitLab: %v", err)
+		}
+		return
+	}
+
+	if *syncLinear {
+		linear, err := linearClientFromEnv()
+		if err != nil {
+			log.Fatalf("Failed to connect to Linear: %v", err)
+		}
+		if err := client.SyncLinearIssues(linear); err != nil {
+			log.Fatalf("Failed to sync Linear issues: %v", err)
+		}
+		return
+	}
+
+	if *track != "" {
+		card := strings.Join(flag.Args(), " ")
+		var err error
+		switch *track {
+		case "start":
+			err = client.StartTracking(card)
+		case "stop":
+			err = client.StopTracking(card)
+		case "push":
+			err = PushWorklogs()
+		default:
+			log.Fatal("Usage: --track start <card>, --track stop [<card>], or --track push")
+		}
+		if err != nil {
+			log.Fatalf("Failed to track time: %v", err)
+		}
+		return
+	}
+
+	if *syncMirrors {
+		if err := client.SyncMirrors(); err != nil {
+			log.Fatalf("Failed to sync mirrors: %v", err)
+		}
+		return
+	}
+
+	if *split != "" {
+		parts, err := strconv.Atoi(strings.Join(flag.Args(), ""))
+		if err != nil {
+			log.Fatal("Usage: --split <card> <parts>, e.g. --split \"Science Fair Project\" 3")
+		}
+		if err := client.SplitCard(*split, parts); err != nil {
+			log.Fatalf("Failed to split card: %v", err)
+		}
+		return
+	}
+
+	if *updateParts {
+		if err := client.UpdatePartProgress("Makai School"); err != nil {
+			log.Fatalf("Failed to update part progress: %v", err)
+		}
+		return
+	}
+
+	if *snooze != "" {
+		if err := client.SnoozeCard(*snooze, strings.Join(flag.Args(), " ")); err != nil {
+			log.Fatalf("Failed to snooze card: %v", err)
+		}
+		return
+	}
+
+	if *watchItems {
+		parent, err := NewParentClient(client)
+		if err != nil {
+			log.Fatalf("Failed to set up watches: %v", err)
+		}
+		if err := client.SetupWatches(parent, watchItemsFromEnv()); err != nil {
+			log.Fatalf("Failed to set up watches: %v", err)
+		}
+		return
+	}
+
+	if *syncOnCall {
+		providers, err := onCallProvidersFromEnv()
+		if err != nil {
+			log.Fatalf("Failed to set up on-call sync: %v", err)
+		}
+		if err := client.SyncOnCall(providers, onCallDays()); err != nil {
+			log.Fatalf("Failed to sync on-call shifts: %v", err)
+		}
+		return
+	}
+
+	if *syncSheet != "" {
+		if err := client.SyncSheet(*syncSheet, *syncSheetDry); err != nil {
+			log.Fatalf("Failed to sync spreadsheet: %v", err)
+		}
+		return
+	}
+
+	if *syncPlugins != "" {
+		if err := client.SyncPlugins(splitList(*syncPlugins), time.Now().AddDate(0, 0, syncHorizonDays()), *pluginDryRun); err != nil {
+			log.Fatalf("Failed to sync plugins: %v", err)
+		}
+		return
+	}
+
 	if *dailyReset {
 		fmt.Println("Resetting Makai's daily tasks...")
-		if err := client.ResetDailyTasks("Makai School", "Daily"); err != nil {
+		started := time.Now()
+		err := client.ResetDailyTasks("Makai School", "Daily")
+		recordRun("daily-reset", started, err)
+		if err != nil {
 			log.Fatalf("Failed to reset daily tasks: %v", err)
 		}
 		return
@@ -66,7 +523,10 @@ func main() {
 
 	if *createWeekly {
 		fmt.Println("Creating weekly cards for next week...")
-		if err := client.CreateWeeklyCards(); err != nil {
+		started := time.Now()
+		err := client.CreateWeeklyCards()
+		recordRun("create-weekly", started, err)
+		if err != nil {
 			log.Fatalf("Failed to create weekly cards: %v", err)
 		}
 		return
@@ -105,30 +565,26 @@ func main() {
 		if err != nil {
 			log.Fatalf("Failed to get courses: %v", err)
 		}
-		fmt.Printf("✅ Moodle connected. UserID: %d, Courses: %d\n", userID, len(courses))
+		fmt.Printf("%s Moodle connected. UserID: %d, Courses: %d\n", iconSuccess, userID, len(courses))
 		return
 	}
 
 
 	if *syncCanvas {
-		canvasToken := os.Getenv("CANVAS_API_TOKEN")
-		canvasURL := os.Getenv("CANVAS_BASE_URL")
-
-		if canvasToken == "" || canvasURL == "" {
-			log.Fatal("Please set CANVAS_API_TOKEN and CANVAS_BASE_URL in .env file or environment variables")
+		canvasClients, err := canvasClientsFromEnv()
+		if err != nil {
+			log.Fatalf("Please set up Canvas in .env file or environment variables: %v", err)
 		}
 
-		canvasClient := NewCanvasClient(canvasToken, canvasURL)
-
-		// Get Canvas user ID for grade lookups
-		user, err := canvasClient.GetCurrentUser()
+		end, err := syncEndDate(*canvasTo, "canvas-to", "CANVAS_SYNC_TO", time.Now())
 		if err != nil {
-			log.Fatalf("Failed to get Canvas user: %v", err)
+			log.Fatal(err)
 		}
 
-		fmt.Printf("Syncing Canvas assignments for user: %s (ID: %d)\n", user.Name, user.ID)
-
-		if err := client.SyncCanvasAssignments(canvasClient, user.ID); err != nil {
+		started := time.Now()
+		err = client.SyncAllCanvas(canvasClients, pastDueSince(time.Now(), *pastDueDays), end)
+		recordRun("sync-canvas", started, err)
+		if err != nil {
 			log.Fatalf("Failed to sync Canvas assignments: %v", err)
 		}
 		return
@@ -143,24 +599,18 @@ func main() {
 		moodleClient := NewMoodleClient(moodleURL, moodleToken)
 
 		// Determine end date
-		var end time.Time
-		if *moodleTo != "" {
-			var err error
-			end, err = time.Parse("2006-01-02", *moodleTo)
-			if err != nil {
-				log.Fatalf("Invalid --moodle-to date format (want YYYY-MM-DD): %v", err)
-			}
-		} else if envTo := os.Getenv("MOODLE_SYNC_TO"); envTo != "" {
-			var err error
-			end, err = time.Parse("2006-01-02", envTo)
-			if err != nil {
-				log.Fatalf("Invalid MOODLE_SYNC_TO date (want YYYY-MM-DD): %v", err)
-			}
-		} else {
-			end = time.Now().AddDate(0, 3, 0) // default 3 months ahead
+		end, err := syncEndDate(*moodleTo, "moodle-to", "MOODLE_SYNC_TO", time.Now())
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		if err := client.SyncMoodleAssignments(moodleClient, end, *syncMoodleDry, *moodleTestFile); err != nil {
+		started := time.Now()
+		err = client.SyncMoodleAssignments(moodleClient, pastDueSince(time.Now(), *pastDueDays), end, *syncMoodleDry, *moodleTestFile)
+		// Dry runs and test files don't count as a sync
+		if !*syncMoodleDry && *moodleTestFile == "" {
+			recordRun("sync-moodle", started, err)
+		}
+		if err != nil {
 			log.Fatalf("Failed to sync Moodle assignments: %v", err)
 		}
 		return
@@ -174,24 +624,12 @@ func main() {
 		}
 		moodleClient := NewMoodleClient(moodleURL, moodleToken)
 
-		var end time.Time
-		if *moodleTo != "" {
-			var err error
-			end, err = time.Parse("2006-01-02", *moodleTo)
-			if err != nil {
-				log.Fatalf("Invalid --moodle-to date format (want YYYY-MM-DD): %v", err)
-			}
-		} else if envTo := os.Getenv("MOODLE_SYNC_TO"); envTo != "" {
-			var err error
-			end, err = time.Parse("2006-01-02", envTo)
-			if err != nil {
-				log.Fatalf("Invalid MOODLE_SYNC_TO date (want YYYY-MM-DD): %v", err)
-			}
-		} else {
-			end = time.Now().AddDate(0, 3, 0) // default 3 months ahead
+		end, err := syncEndDate(*moodleTo, "moodle-to", "MOODLE_SYNC_TO", time.Now())
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		if err := client.SyncMoodleAssignments(moodleClient, end, true, *moodleTestFile); err != nil {
+		if err := client.SyncMoodleAssignments(moodleClient, pastDueSince(time.Now(), *pastDueDays), end, true, *moodleTestFile); err != nil {
 			log.Fatalf("Failed to preview Moodle assignments: %v", err)
 		}
 		return
@@ -199,7 +637,11 @@ func main() {
 
 	if *syncJira {
 		fmt.Println("Syncing JIRA tasks to Trello...")
-		if err := client.SyncJiraTasks(*jiraTasksDir); err != nil {
+		tasksDir := resolveJiraTasksDir(*jiraTasksDir)
+		started := time.Now()
+		err := client.SyncJiraTasks(tasksDir)
+		recordRun("sync-jira", started, err)
+		if err != nil {
 			log.Fatalf("Failed to sync JIRA tasks: %v", err)
 		}
 		return
@@ -207,7 +649,10 @@ func main() {
 
 	if *sundownNotify != "" {
 		fmt.Printf("Creating sundown notification on board: %s\n", *sundownNotify)
-		if err := client.CreateDailySundownNotification(*sundownNotify); err != nil {
+		started := time.Now()
+		err := client.CreateDailySundownNotification(*sundownNotify)
+		recordRun("sundown:"+*sundownNotify, started, err)
+		if err != nil {
 			log.Fatalf("Failed to create sundown notification: %v", err)
 		}
 		return
@@ -281,6 +726,253 @@ func main() {
 		return
 	}
 
+	if *attendance {
+		followUp := *attendFollow || os.Getenv("ATTENDANCE_FOLLOWUP") == "true"
+		started := time.Now()
+		err := client.CheckAttendance("Makai School", followUp)
+		recordRun("check-attendance", started, err)
+		if err != nil {
+			log.Fatalf("Failed to check attendance: %v", err)
+		}
+		return
+	}
+
+	if *canvasMsgs {
+		canvasClient, err := canvasClientFromEnv()
+		if err != nil {
+			log.Fatal(err)
+		}
+		markRead := *markMsgsRead || os.Getenv("CANVAS_MARK_MESSAGES_READ") == "true"
+		if err := client.SyncCanvasMessages(canvasClient, "Makai School", "Weekly", markRead); err != nil {
+			log.Fatalf("Failed to sync Canvas messages: %v", err)
+		}
+		return
+	}
+
+	if *gradeEmail {
+		canvasClient, err := canvasClientFromEnv()
+		if err != nil {
+			log.Fatal("Please set CANVAS_API_TOKEN and CANVAS_BASE_URL in .env file or environment variables")
+		}
+		started := time.Now()
+		err = client.SendGradeEmail(canvasClient, "Makai School", "Daily", *gradeEmailDry)
+		if !*gradeEmailDry {
+			recordRun("grade-email", started, err)
+		}
+		if err != nil {
+			log.Fatalf("Failed to send grade email: %v", err)
+		}
+		return
+	}
+
+	if *weekReview {
+		fmt.Println("Creating week in review card...")
+		started := time.Now()
+		err := client.CreateWeekInReview("Makai School", "Daily", "Weekly", optionalGPAEstimate())
+		recordRun("week-review", started, err)
+		if err != nil {
+			log.Fatalf("Failed to create week in review: %v", err)
+		}
+		return
+	}
+
+	if *today {
+		agenda, err := client.GetTodayAgenda("Makai School", "Daily")
+		if err != nil {
+			log.Fatalf("Failed to build today's agenda: %v", err)
+		}
+		fmt.Print(consoleText(agenda.Format()))
+
+		if *todayPost != "" {
+			if err := client.PostTodayAgenda("Makai School", *todayPost, agenda); err != nil {
+				log.Fatalf("Failed to post today's agenda: %v", err)
+			}
+			fmt.Printf("%s Posted agenda to '%s'\n", iconSuccess, *todayPost)
+		}
+		return
+	}
+
+	if *standup {
+		boardName := "Mac"
+		if *board != "" {
+			boardName = *board
+		}
+		summary, err := client.GetStandup(boardName)
+		if err != nil {
+			log.Fatalf("Failed to build the stand-up: %v", err)
+		}
+		fmt.Print(consoleText(summary.Format()))
+
+		if *standupSlack {
+			if err := PostStandup(summary); err != nil {
+				log.Fatalf("Failed to post the stand-up: %v", err)
+			}
+			fmt.Printf("%s Posted the stand-up to Slack\n", iconSuccess)
+		}
+		return
+	}
+
+	if *weekView {
+		boardName := "Makai School"
+		if *board != "" {
+			boardName = *board
+		}
+		if err := client.PrintWeekView(boardName); err != nil {
+			log.Fatalf("Failed to build week view: %v", err)
+		}
+		return
+	}
+
+	if *serve != "" {
+		if err := client.Serve(*serve); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *cloneBoard {
+		if flag.NArg() != 2 {
+			log.Fatal("Usage: --clone-board \"Makai School\" \"Makai School Sandbox\"")
+		}
+		if err := client.CloneBoard(flag.Arg(0), flag.Arg(1)); err != nil {
+			log.Fatalf("Failed to clone board: %v", err)
+		}
+		return
+	}
+
+	if *archiveYear != "" {
+		if err := client.ArchiveYear(*archiveYear, *backupDir); err != nil {
+			log.Fatalf("Failed to archive the school year: %v", err)
+		}
+		return
+	}
+
+	if *purgeData {
+		metadataBoard := ""
+		if *purgeMeta {
+			metadataBoard = "Makai School"
+			if *board != "" {
+				metadataBoard = *board
+			}
+		}
+		if err := client.PurgeStudentData(metadataBoard, *assumeYes, *backupDir); err != nil {
+			log.Fatalf("Failed to purge student data: %v", err)
+		}
+		return
+	}
+
+	if *reconcile != "" {
+		if err := client.Reconcile(*reconcile, *board, *reconcileDry, *assumeYes); err != nil {
+			log.Fatalf("Failed to reconcile board: %v", err)
+		}
+		return
+	}
+
+	if *rollover != "" {
+		if err := client.SeasonRollover(*rollover); err != nil {
+			log.Fatalf("Failed to roll over lists: %v", err)
+		}
+		return
+	}
+
+	if *bench {
+		boardName := "Makai School"
+		if *board != "" {
+			boardName = *board
+		}
+		if err := client.Bench(boardName); err != nil {
+			log.Fatalf("Benchmark failed: %v", err)
+		}
+		return
+	}
+
+	if *rebucket {
+		boardName := "Makai School"
+		if *board != "" {
+			boardName = *board
+		}
+		started := time.Now()
+		err := client.RebucketCards(boardName)
+		recordRun("rebucket", started, err)
+		if err != nil {
+			log.Fatalf("Failed to re-bucket cards: %v", err)
+		}
+		return
+	}
+
+	if *relink {
+		boardName := "Makai School"
+		if *board != "" {
+			boardName = *board
+		}
+		if *relinkMin < 0 || *relinkMin > 1 {
+			log.Fatalf("--relink-min must be between 0 and 1, got %g", *relinkMin)
+		}
+		candidates, err := relinkCandidatesFromEnv(time.Now())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := client.Relink(boardName, candidates, *relinkMin, os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("Failed to relink cards: %v", err)
+		}
+		return
+	}
+
+	if *showSettings {
+		boardName := "Makai School"
+		if *board != "" {
+			boardName = *board
+		}
+		if err := client.ShowBoardSettings(boardName); err != nil {
+			log.Fatalf("Failed to show board settings: %v", err)
+		}
+		return
+	}
+
+	if *triage {
+		boardName := "Makai School"
+		if *board != "" {
+			boardName = *board
+		}
+		if err := client.TriageInbox(boardName, "Weekly", os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("Failed to triage inbox: %v", err)
+		}
+		return
+	}
+
+	if *boardImage != "" {
+		boardName := "Makai School"
+		if *board != "" {
+			boardName = *board
+		}
+		if err := client.SaveBoardImage(boardName, *boardImage); err != nil {
+			log.Fatalf("Failed to render board image: %v", err)
+		}
+		return
+	}
+
+	if *checkLinks {
+		if err := client.CheckCardLinks(NewLinkChecker()); err != nil {
+			log.Fatalf("Failed to check card links: %v", err)
+		}
+		return
+	}
+
+	if *hygiene || *hygieneFix != "" {
+		fixRules, err := parseHygieneRules(*hygieneFix)
+		if err != nil {
+			log.Fatal(err)
+		}
+		boardName := "Makai School"
+		if *board != "" {
+			boardName = *board
+		}
+		if err := client.BoardHygiene(boardName, fixRules); err != nil {
+			log.Fatalf("Failed to check board hygiene: %v", err)
+		}
+		return
+	}
+
 	if *showCache {
 		cache, err := client.LoadCache()
 		if err != nil {
@@ -297,6 +989,13 @@ func main() {
 			}
 			fmt.Println()
 		}
+
+		if !cache.UpdatedAt.IsZero() {
+			fmt.Printf("Last refreshed: %s\n", cache.UpdatedAt.Format("2006-01-02 15:04"))
+		}
+		for _, boardErr := range cache.BoardErrors {
+			fmt.Printf("Warning: %s is stale (last refresh failed: %s)\n", boardErr.BoardName, boardErr.Error)
+		}
 		return
 	}
 
@@ -306,6 +1005,13 @@ func main() {
 			log.Fatalf("Failed to find list: %v", err)
 		}
 
+		if *deleteAll {
+			if err := client.DeleteAllCardsFromList(listID, *list, *assumeYes, *backupDir); err != nil {
+				log.Fatalf("Failed to delete cards: %v", err)
+			}
+			return
+		}
+
 		cards, err := client.GetCardsInList(listID)
 		if err != nil {
 			log.Fatalf("Failed to get cards: %v", err)
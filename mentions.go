@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Member is a Trello board member
+type Member struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	FullName string `json:"fullName"`
+}
+
+// mentionRegex finds @username mentions, skipping email addresses
+var mentionRegex = regexp.MustCompile(`(^|\s)@([A-Za-z0-9_]+)`)
+
+// GetBoardMembers returns a board's members. Results are kept for the rest
+// of the run so syncs that comment on many cards only look them up once.
+func (c *TrelloClient) GetBoardMembers(boardID string) ([]Member, error) {
+	if members, ok := c.members[boardID]; ok {
+		return members, nil
+	}
+
+	body, err := c.makeRequest(fmt.Sprintf("/boards/%s/members", boardID))
+	if err != nil {
+		return nil, err
+	}
+
+	var members []Member
+	if err := json.Unmarshal(body, &members); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal board members: %w", err)
+	}
+
+	if c.members == nil {
+		c.members = make(map[string][]Member)
+	}
+	c.members[boardID] = members
+	return members, nil
+}
+
+// unknownMentions returns the usernames mentioned in text that aren't board
+// members. Trello's @card and @board mentions are always allowed.
+func unknownMentions(text string, members []Member) []string {
+	known := map[string]bool{"card": true, "board": true}
+	for _, member := range members {
+		known[strings.ToLower(member.Username)] = true
+	}
+
+	var unknown []string
+	for _, match := range mentionRegex.FindAllStringSubmatch(text, -1) {
+		if name := match[2]; !known[strings.ToLower(name)] {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// removeMentions drops the given @usernames from text, tidying the spacing
+// on lines that had one
+func removeMentions(text string, names []string) string {
+	drop := make(map[string]bool)
+	for _, name := range names {
+		drop[strings.ToLower(name)] = true
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		changed := false
+		line = mentionRegex.ReplaceAllStringFunc(line, func(match string) string {
+			submatch := mentionRegex.FindStringSubmatch(match)
+			if !drop[strings.ToLower(submatch[2])] {
+				return match
+			}
+			changed = true
+			return submatch[1]
+		})
+		if changed {
+			line = strings.Join(strings.Fields(line), " ")
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkMentions makes sure everyone @mentioned in text is a member of the
+// board, since Trello silently skips notifying anyone who isn't. Unknown
+// mentions are removed with a warning. If members can't be looked up, the
+// text is returned unchanged.
+func (c *TrelloClient) checkMentions(boardID, text string) string {
+	if !mentionRegex.MatchString(text) {
+		return text
+	}
+	if boardID == "" {
+		fmt.Println("Warning: can't check @mentions without a board; posting them unchecked")
+		return text
+	}
+
+	members, err := c.GetBoardMembers(boardID)
+	if err != nil {
+		fmt.Printf("Warning: can't check @mentions (failed to get board members: %v); posting them unchecked\n", err)
+		return text
+	}
+
+	unknown := unknownMentions(text, members)
+	if len(unknown) == 0 {
+		return text
+	}
+	for _, name := range unknown {
+		fmt.Printf("Warning: @%s is not a member of this board, so Trello wouldn't notify them. Posting without the mention; check the username or add them to the board.\n", name)
+	}
+	return removeMentions(text, unknown)
+}
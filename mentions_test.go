@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRemoveMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		drop []string
+		want string
+	}{
+		{"leading", "@nalani_farnsworth Sundown today is at 7:02 PM", []string{"nalani_farnsworth"}, "Sundown today is at 7:02 PM"},
+		{"one of two", "@makai @nalani Week in review is ready", []string{"Makai"}, "@nalani Week in review is ready"},
+		{"keeps other lines", "@typo hi\n\nsecond  line", []string{"typo"}, "hi\n\nsecond  line"},
+		{"email untouched", "mail mom@example.com", []string{"example"}, "mail mom@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := removeMentions(tt.text, tt.drop); got != tt.want {
+				t.Errorf("removeMentions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMentions(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/boards/b1/members" {
+			http.NotFound(w, r)
+			return
+		}
+		requests++
+		w.Write([]byte(`[{"id": "m1", "username": "nalani_farnsworth"}, {"id": "m2", "username": "makai"}]`))
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	if got := client.checkMentions("b1", "@Makai @nalani_farnsworth @card ready"); got != "@Makai @nalani_farnsworth @card ready" {
+		t.Errorf("checkMentions() with members = %q", got)
+	}
+	if got := client.checkMentions("b1", "@nalani_farnswroth Sundown is at 7:02 PM"); got != "Sundown is at 7:02 PM" {
+		t.Errorf("checkMentions() with a typo = %q", got)
+	}
+	if requests != 1 {
+		t.Errorf("member lookups = %d, want 1 (cached)", requests)
+	}
+
+	if got := client.checkMentions("missing", "@makai hi"); got != "@makai hi" {
+		t.Errorf("checkMentions() when lookup fails = %q, want text unchanged", got)
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// mirrorSource labels the metadata tying a mirror card to its original.
+// Cards carrying it are never mirrored again, so rules can't loop.
+const mirrorSource = "Mirror"
+
+// MirrorRule copies matching cards from one board to another. Every
+// selector that is set must match; a rule with none mirrors every card.
+type MirrorRule struct {
+	Name          string   `json:"name"`
+	From          string   `json:"from"`          // source board
+	To            string   `json:"to"`            // target board, e.g. "Family"
+	ToList        string   `json:"toList"`        // defaults to the target board's first list
+	Lists         []string `json:"lists"`         // source lists to mirror from
+	Labels        []string `json:"labels"`        // card needs one of these labels
+	Keywords      []string `json:"keywords"`      // card name contains one of these
+	DueWithinDays int      `json:"dueWithinDays"` // due between today and this many days out
+}
+
+// MirrorConfig is the contents of mirrors.json
+type MirrorConfig struct {
+	Rules []MirrorRule `json:"rules"`
+}
+
+// LoadMirrorConfig reads mirrors.json from the working or config directory
+func LoadMirrorConfig() (*MirrorConfig, error) {
+	data, err := os.ReadFile(findConfigFile("mirrors.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mirrors.json: %w", err)
+	}
+
+	var config MirrorConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mirrors config: %w", err)
+	}
+
+	for i, rule := range config.Rules {
+		if rule.Name == "" || rule.From == "" || rule.To == "" {
+			return nil, fmt.Errorf("mirror rule %d in mirrors.json needs a name, a from board, and a to board", i+1)
+		}
+		if normalizeString(rule.From) == normalizeString(rule.To) {
+			return nil, fmt.Errorf("mirror rule %d in mirrors.json mirrors a board onto itself", i+1)
+		}
+	}
+
+	return &config, nil
+}
+
+// matches reports whether a source card should be mirrored by the rule.
+// listNames maps the source board's list IDs to names.
+func (r MirrorRule) matches(card Card, listNames map[string]string, now time.Time) bool {
+	if card.Closed || workItemCardID(card, mirrorSource) != "" || isNoAuto(card) {
+		return false
+	}
+	if len(r.Lists) > 0 && !containsFold(r.Lists, listNames[card.IDList]) {
+		return false
+	}
+	if len(r.Labels) > 0 {
+		found := false
+		for _, label := range card.Labels {
+			if containsFold(r.Labels, label.Name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.Keywords) > 0 {
+		name := strings.ToLower(card.Name)
+		found := false
+		for _, keyword := range r.Keywords {
+			if strings.Contains(name, strings.ToLower(keyword)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if r.DueWithinDays > 0 {
+		startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if card.Due == nil || card.Due.Before(startOfToday) || !card.Due.Before(startOfToday.AddDate(0, 0, r.DueWithinDays+1)) {
+			return false
+		}
+	}
+	return true
+}
+
+// mirrorRuleTag marks which rule owns a mirror card
+func mirrorRuleTag(ruleName string) string {
+	return fmt.Sprintf("(rule: %s)", ruleName)
+}
+
+// mirrorDescription describes where a mirror card comes from, with the
+// metadata that ties it back to the original
+func mirrorDescription(card Card, fromBoard, fromList, ruleName string) string {
+	return formatWorkItemDescription(WorkItem{
+		Source: mirrorSource,
+		ID:     card.ID,
+		URL:    cardLink(card),
+		Notes: fmt.Sprintf("🪞 Mirrored from %s → %s %s. Changes made here are overwritten; edit the original card.",
+			fromBoard, fromList, mirrorRuleTag(ruleName)),
+	})
+}
+
+// mirrorPatch returns the changes that bring a mirror in line with its original
+func mirrorPatch(mirror, original Card, description string) CardPatch {
+	patch := CardPatch{}
+	if mirror.Name != original.Name {
+		patch.Name = &original.Name
+	}
+	if mirror.Description != description {
+		patch.Desc = &description
+	}
+	if (mirror.Due == nil) != (original.Due == nil) || (mirror.Due != nil && !mirror.Due.Equal(*original.Due)) {
+		due := ""
+		if original.Due != nil {
+			due = original.Due.UTC().Format(trelloDueLayout)
+		}
+		patch.Due = &due
+	}
+	if mirror.DueComplete != original.DueComplete {
+		patch.DueComplete = boolPtr(original.DueComplete)
+	}
+	return patch
+}
+
+// SyncMirrors applies each rule in mirrors.json: matching cards get a copy
+// on the target board whose title, due date, and completion follow the
+// original. Mirrors whose original no longer matches are archived.
+func (c *TrelloClient) SyncMirrors() error {
+	config, err := LoadMirrorConfig()
+	if err != nil {
+		return err
+	}
+
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+
+	for _, rule := range config.Rules {
+		fmt.Printf("Mirroring %s → %s (%s)\n", rule.From, rule.To, rule.Name)
+		if err := c.syncMirrorRule(cache, rule, time.Now()); err != nil {
+			return fmt.Errorf("mirror rule '%s': %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// syncMirrorRule creates, updates, and archives the mirrors for one rule
+func (c *TrelloClient) syncMirrorRule(cache *CachedData, rule MirrorRule, now time.Time) error {
+	from, err := findBoardByName(cache.Boards, rule.From)
+	if err != nil {
+		return err
+	}
+	to, err := findBoardByName(cache.Boards, rule.To)
+	if err != nil {
+		return err
+	}
+
+	listNames := make(map[string]string)
+	var targetListID string
+	for _, list := range cache.Lists {
+		if list.BoardID == from.ID {
+			listNames[list.ID] = list.Name
+		}
+		if list.BoardID == to.ID && targetListID == "" && rule.ToList == "" {
+			targetListID = list.ID
+		}
+	}
+	if rule.ToList != "" {
+		list, err := findListByName(cache.Lists, to.ID, rule.ToList)
+		if err != nil {
+			return fmt.Errorf("%s in board '%s'", err.Error(), to.Name)
+		}
+		targetListID = list.ID
+	}
+	if targetListID == "" {
+		return fmt.Errorf("no lists found on %s board", to.Name)
+	}
+
+	sourceCards, err := c.GetBoardCardsByID(from.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get %s cards: %w", from.Name, err)
+	}
+	targetCards, err := c.GetBoardCardsByID(to.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get %s cards: %w", to.Name, err)
+	}
+
+	mirrored := make(map[string]bool)
+	created, updated := 0, 0
+	for _, card := range sourceCards {
+		if !rule.matches(card, listNames, now) {
+			continue
+		}
+		mirrored[card.ID] = true
+
+		description := mirrorDescription(card, from.Name, listNames[card.IDList], rule.Name)
+		mirror := findCardByWorkItem(targetCards, mirrorSource, card.ID)
+		if mirror == nil {
+			var due string
+			if card.Due != nil {
+				due = card.Due.UTC().Format(trelloDueLayout)
+			}
+			fmt.Printf("  Creating mirror: %s\n", card.Name)
+			newCard, err := c.CreateCard(targetListID, card.Name, description, due)
+			if err != nil {
+				fmt.Printf("Warning: failed to mirror %s: %v\n", card.Name, err)
+				continue
+			}
+			if card.DueComplete {
+				if err := c.UpdateCardFields(newCard.ID, CardPatch{DueComplete: boolPtr(true)}); err != nil {
+					fmt.Printf("Warning: failed to mark mirror of %s complete: %v\n", card.Name, err)
+				}
+			}
+			created++
+			continue
+		}
+		// A card matched by several rules belongs to the one that mirrored it first
+		if !strings.Contains(mirror.Description, mirrorRuleTag(rule.Name)) || skipNoAuto(mirror) {
+			continue
+		}
+
+		if patch := mirrorPatch(*mirror, card, description); patch != (CardPatch{}) {
+			fmt.Printf("  Updating mirror: %s\n", card.Name)
+			if err := c.UpdateCardFields(mirror.ID, patch); err != nil {
+				fmt.Printf("Warning: failed to update mirror of %s: %v\n", card.Name, err)
+				continue
+			}
+			updated++
+		}
+	}
+
+	// This rule's mirrors whose original was archived or stopped matching
+	archived := 0
+	for i, card := range targetCards {
+		id := workItemCardID(card, mirrorSource)
+		if id == "" || mirrored[id] || !strings.Contains(card.Description, mirrorRuleTag(rule.Name)) || skipNoAuto(&targetCards[i]) {
+			continue
+		}
+		fmt.Printf("  Archiving mirror: %s\n", card.Name)
+		if err := c.UpdateCardFields(card.ID, CardPatch{Closed: boolPtr(true)}); err != nil {
+			fmt.Printf("Warning: failed to archive mirror %s: %v\n", card.Name, err)
+			continue
+		}
+		archived++
+	}
+
+	fmt.Printf("  Created %d, updated %d, archived %d mirror(s)\n", created, updated, archived)
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMirrorRuleMatches(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	soon := now.AddDate(0, 0, 3)
+	later := now.AddDate(0, 0, 20)
+	listNames := map[string]string{"w": "Weekly", "d": "Daily"}
+	rule := MirrorRule{Name: "tests", Lists: []string{"weekly"}, Keywords: []string{"Quiz", "test"}, DueWithinDays: 7}
+
+	tests := []struct {
+		name string
+		card Card
+		want bool
+	}{
+		{"quiz this week", Card{Name: "Bio - Chapter 4 Quiz", IDList: "w", Due: &soon}, true},
+		{"due too late", Card{Name: "Bio - Unit Test", IDList: "w", Due: &later}, false},
+		{"no keyword", Card{Name: "Bio - Worksheet", IDList: "w", Due: &soon}, false},
+		{"other list", Card{Name: "Quiz prep", IDList: "d", Due: &soon}, false},
+		{"undated", Card{Name: "Bio - Quiz", IDList: "w"}, false},
+		{"already a mirror", Card{Name: "Bio - Quiz", IDList: "w", Due: &soon, Description: mirrorDescription(Card{ID: "x"}, "Family", "This Week", "back")}, false},
+		{"no-auto", Card{Name: "Bio - Quiz [no-auto]", IDList: "w", Due: &soon}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.matches(tt.card, listNames, now); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	labelRule := MirrorRule{Labels: []string{"Family"}}
+	if !labelRule.matches(Card{Labels: []Label{{Name: "family"}}}, listNames, now) {
+		t.Errorf("label rule should match a card with the label")
+	}
+}
+
+func TestMirrorPatch(t *testing.T) {
+	due := time.Date(2025, 10, 8, 5, 59, 0, 0, time.UTC)
+	moved := due.AddDate(0, 0, 1)
+	original := Card{ID: "o", Name: "Bio - Quiz", Due: &moved, DueComplete: true}
+	desc := mirrorDescription(original, "Makai School", "Weekly", "tests")
+
+	patch := mirrorPatch(Card{Name: "Bio - Quiz", Description: desc, Due: &due}, original, desc)
+	if patch.Name != nil || patch.Desc != nil {
+		t.Errorf("unchanged name/description should not be patched: %+v", patch)
+	}
+	if patch.Due == nil || *patch.Due != "2025-10-09T05:59:00.000Z" {
+		t.Errorf("patch.Due = %v, want the original's new date", patch.Due)
+	}
+	if patch.DueComplete == nil || !*patch.DueComplete {
+		t.Errorf("patch.DueComplete should follow the original")
+	}
+
+	inSync := Card{Name: original.Name, Description: desc, Due: &moved, DueComplete: true}
+	if got := mirrorPatch(inSync, original, desc); got != (CardPatch{}) {
+		t.Errorf("mirror in sync should need no patch, got %+v", got)
+	}
+}
@@ -17,6 +17,13 @@ import (
 type MoodleClient struct {
     BaseURL string
     Token   string
+
+    // Fetched once and shared by every call in a sync
+    userID  int
+    courses []MoodleCourse
+
+    // Warnings from every response, for the sync summary
+    warnings []MoodleWarning
 }
 
 type moodleSiteInfo struct {
@@ -38,6 +45,8 @@ type MoodleAssignment struct {
     DueDateUnix int64  `json:"duedate"`
     URL         string `json:"url"`
     Type        string // "assignment" or "quiz"
+    Visible     *int   `json:"visible,omitempty"` // 0 when the module is hidden
+    OpensUnix   int64  `json:"allowsubmissionsfromdate,omitempty"`
 }
 
 type MoodleGrade struct {
@@ -54,7 +63,7 @@ type moodleAssignmentsResponse struct {
         FullName    string              `json:"fullname"`
         Assignments []MoodleAssignment  `json:"assignments"`
     } `json:"courses"`
-    Warnings []any `json:"warnings"`
+    Warnings []MoodleWarning `json:"warnings"`
 }
 
 type moodleQuiz struct {
@@ -62,13 +71,15 @@ type moodleQuiz struct {
     Name        string `json:"name"`
     Intro       string `json:"intro"`
     CourseID    int    `json:"course"`
+    TimeOpen    int64  `json:"timeopen"`
     TimeClose   int64  `json:"timeclose"`
     URL         string `json:"url"`
+    Visible     *int   `json:"visible"`
 }
 
 type moodleQuizzesResponse struct {
     Quizzes  []moodleQuiz `json:"quizzes"`
-    Warnings []any        `json:"warnings"`
+    Warnings []MoodleWarning `json:"warnings"`
 }
 
 func NewMoodleClient(baseURL, token string) *MoodleClient {
@@ -105,18 +116,28 @@ func (m *MoodleClient) makeRequest(wsfunction string, params url.Values) ([]byte
 
     endpoint := m.BaseURL + "/webservice/rest/server.php?" + params.Encode()
 
-    resp, err := http.Get(endpoint)
-    if err != nil {
-        return nil, fmt.Errorf("moodle request failed: %w", err)
-    }
-    defer resp.Body.Close()
+    var body []byte
+    err := withMoodleBackoff(func() error {
+        resp, err := http.Get(endpoint)
+        if err != nil {
+            return fmt.Errorf("moodle request failed: %w", err)
+        }
+        defer resp.Body.Close()
 
-    if resp.StatusCode != http.StatusOK {
-        return nil, fmt.Errorf("moodle request status %d", resp.StatusCode)
-    }
-    body, err := io.ReadAll(resp.Body)
+        if moodleBusy(resp.StatusCode) {
+            return fmt.Errorf("%w (status %d)", errMoodleBusy, resp.StatusCode)
+        }
+        if resp.StatusCode != http.StatusOK {
+            return fmt.Errorf("moodle request status %d", resp.StatusCode)
+        }
+        body, err = io.ReadAll(resp.Body)
+        if err != nil {
+            return fmt.Errorf("read moodle response: %w", err)
+        }
+        return nil
+    })
     if err != nil {
-        return nil, fmt.Errorf("read moodle response: %w", err)
+        return nil, err
     }
     // Basic error envelope check
     if strings.Contains(string(body), "exception") && strings.Contains(string(body), "errorcode") {
@@ -125,7 +146,11 @@ func (m *MoodleClient) makeRequest(wsfunction string, params url.Values) ([]byte
     return body, nil
 }
 
+// GetSiteInfo returns the token's user ID, asking Moodle only the first time
 func (m *MoodleClient) GetSiteInfo() (int, error) {
+    if m.userID != 0 {
+        return m.userID, nil
+    }
     body, err := m.makeRequest("core_webservice_get_site_info", nil)
     if err != nil {
         return 0, err
@@ -134,10 +159,16 @@ func (m *MoodleClient) GetSiteInfo() (int, error) {
     if err := json.Unmarshal(body, &info); err != nil {
         return 0, fmt.Errorf("decode site info: %w", err)
     }
+    m.userID = info.UserID
     return info.UserID, nil
 }
 
+// GetCourses returns the user's enrolled courses, asking Moodle only the
+// first time for the token's own user
 func (m *MoodleClient) GetCourses(userID int) ([]MoodleCourse, error) {
+    if m.courses != nil && userID == m.userID {
+        return m.courses, nil
+    }
     params := url.Values{}
     params.Set("userid", fmt.Sprintf("%d", userID))
     body, err := m.makeRequest("core_enrol_get_users_courses", params)
@@ -148,6 +179,12 @@ func (m *MoodleClient) GetCourses(userID int) ([]MoodleCourse, error) {
     if err := json.Unmarshal(body, &courses); err != nil {
         return nil, fmt.Errorf("decode courses: %w", err)
     }
+    if userID == m.userID {
+        if courses == nil {
+            courses = []MoodleCourse{}
+        }
+        m.courses = courses
+    }
     return courses, nil
 }
 
@@ -155,26 +192,25 @@ func (m *MoodleClient) GetAssignments(courseIDs []int) ([]MoodleAssignment, map[
     if len(courseIDs) == 0 {
         return nil, nil, nil
     }
-    params := url.Values{}
-    for i, id := range courseIDs {
-        params.Set(fmt.Sprintf("courseids[%d]", i), fmt.Sprintf("%d", id))
-    }
-    body, err := m.makeRequest("mod_assign_get_assignments", params)
-    if err != nil {
-        return nil, nil, err
-    }
-    var resp moodleAssignmentsResponse
-    if err := json.Unmarshal(body, &resp); err != nil {
-        return nil, nil, fmt.Errorf("decode assignments: %w", err)
-    }
     var out []MoodleAssignment
     courseNames := make(map[int]string)
-    for _, c := range resp.Courses {
-        courseNames[c.ID] = c.FullName
-        for _, a := range c.Assignments {
-            a.CourseID = c.ID // ensure set from container
-            a.Type = "assignment"
-            out = append(out, a)
+    for _, batch := range batchCourseIDs(courseIDs, moodleCourseBatch) {
+        body, err := m.makeRequest("mod_assign_get_assignments", courseIDParams(batch))
+        if err != nil {
+            return nil, nil, err
+        }
+        var resp moodleAssignmentsResponse
+        if err := json.Unmarshal(body, &resp); err != nil {
+            return nil, nil, fmt.Errorf("decode assignments: %w", err)
+        }
+        m.recordWarnings(resp.Warnings)
+        for _, c := range resp.Courses {
+            courseNames[c.ID] = c.FullName
+            for _, a := range c.Assignments {
+                a.CourseID = c.ID // ensure set from container
+                a.Type = "assignment"
+                out = append(out, a)
+            }
         }
     }
     // stable order by duedate
@@ -186,28 +222,27 @@ func (m *MoodleClient) GetQuizzes(courseIDs []int) ([]MoodleAssignment, map[int]
     if len(courseIDs) == 0 {
         return nil, nil, nil
     }
-    params := url.Values{}
-    for i, id := range courseIDs {
-        params.Set(fmt.Sprintf("courseids[%d]", i), fmt.Sprintf("%d", id))
-    }
-    body, err := m.makeRequest("mod_quiz_get_quizzes_by_courses", params)
-    if err != nil {
-        return nil, nil, err
-    }
-    var resp moodleQuizzesResponse
-    if err := json.Unmarshal(body, &resp); err != nil {
-        return nil, nil, fmt.Errorf("decode quizzes: %w", err)
-    }
     var out []MoodleAssignment
     courseNames := make(map[int]string)
 
     // Group quizzes by course
     quizzesByCourse := make(map[int][]moodleQuiz)
-    for _, quiz := range resp.Quizzes {
-        quizzesByCourse[quiz.CourseID] = append(quizzesByCourse[quiz.CourseID], quiz)
+    for _, batch := range batchCourseIDs(courseIDs, moodleCourseBatch) {
+        body, err := m.makeRequest("mod_quiz_get_quizzes_by_courses", courseIDParams(batch))
+        if err != nil {
+            return nil, nil, err
+        }
+        var resp moodleQuizzesResponse
+        if err := json.Unmarshal(body, &resp); err != nil {
+            return nil, nil, fmt.Errorf("decode quizzes: %w", err)
+        }
+        m.recordWarnings(resp.Warnings)
+        for _, quiz := range resp.Quizzes {
+            quizzesByCourse[quiz.CourseID] = append(quizzesByCourse[quiz.CourseID], quiz)
+        }
     }
 
-    // Get course names by fetching course info
+    // Course names come from the session's course list
     userID, err := m.GetSiteInfo()
     if err == nil {
         courses, err := m.GetCourses(userID)
@@ -229,6 +264,8 @@ func (m *MoodleClient) GetQuizzes(courseIDs []int) ([]MoodleAssignment, map[int]
                 DueDateUnix: quiz.TimeClose, // Use timeclose as due date
                 URL:         quiz.URL,
                 Type:        "quiz",
+                Visible:     quiz.Visible,
+                OpensUnix:   quiz.TimeOpen,
             }
             out = append(out, assignment)
         }
@@ -239,8 +276,8 @@ func (m *MoodleClient) GetQuizzes(courseIDs []int) ([]MoodleAssignment, map[int]
     return out, courseNames, nil
 }
 
-// GetUpcomingAssignments returns assignments with due dates between now and toDate.
-func (m *MoodleClient) GetUpcomingAssignments(toDate time.Time) ([]MoodleAssignment, map[int]string, error) {
+// GetUpcomingAssignments returns assignments with due dates between since and toDate.
+func (m *MoodleClient) GetUpcomingAssignments(since, toDate time.Time) ([]MoodleAssignment, map[int]string, error) {
     userID, err := m.GetSiteInfo()
     if err != nil {
         return nil, nil, err
@@ -275,14 +312,13 @@ func (m *MoodleClient) GetUpcomingAssignments(toDate time.Time) ([]MoodleAssignm
     for k, v := range quizNames {
         names[k] = v
     }
-    now := time.Now()
     var filtered []MoodleAssignment
     for _, a := range all {
         if a.DueDateUnix == 0 {
             continue
         }
         due := time.Unix(a.DueDateUnix, 0)
-        if due.After(now.Add(-24*time.Hour)) && due.Before(toDate.Add(24*time.Hour)) {
+        if due.After(since) && due.Before(toDate.Add(24*time.Hour)) {
             filtered = append(filtered, a)
         }
     }
@@ -332,6 +368,7 @@ func (m *MoodleClient) parseQuizGrade(body []byte, userID int) (*MoodleGrade, er
             State  string  `json:"state"`
             Quiz   json.RawMessage `json:"quiz"` // Use RawMessage to handle variable structure
         } `json:"attempts"`
+        Warnings []MoodleWarning `json:"warnings"`
     }
 
     if err := json.Unmarshal(body, &response); err != nil {
@@ -339,6 +376,7 @@ func (m *MoodleClient) parseQuizGrade(body []byte, userID int) (*MoodleGrade, er
         fmt.Printf("Debug: Quiz API response: %s\n", string(body))
         return nil, nil // Return nil instead of error to avoid breaking sync
     }
+    m.recordWarnings(response.Warnings)
 
     // Find the latest attempt for this user
     for _, attempt := range response.Attempts {
@@ -381,11 +419,13 @@ func (m *MoodleClient) parseAssignmentGrade(body []byte, userID int) (*MoodleGra
                 } `json:"assignment"`
             } `json:"submissions"`
         } `json:"assignments"`
+        Warnings []MoodleWarning `json:"warnings"`
     }
 
     if err := json.Unmarshal(body, &response); err != nil {
         return nil, fmt.Errorf("failed to parse assignment submissions: %w", err)
     }
+    m.recordWarnings(response.Warnings)
 
     // Find submission for this user
     for _, assignment := range response.Assignments {
@@ -439,7 +479,12 @@ func formatMoodleMetadata(a MoodleAssignment, courseName string, grade *MoodleGr
         activityType = "Quiz"
     }
 
-    return fmt.Sprintf("\n\n---\nMoodle %s ID: %d\nCourse: %s\nOriginal Due Date: %s\nGrade: %s\nMoodle URL: %s",
-        activityType, a.ID, courseName, due, gradeStr, a.URL)
+    opens := a.opens()
+    metadata := friendlyDateLines(opens, due, "", displayLocation()) + fmt.Sprintf("\n\n---\nMoodle %s ID: %d\nCourse: %s\nOriginal Due Date: %s\nGrade: %s\n%s",
+        activityType, a.ID, courseName, due, gradeStr, sourceURLLine("Moodle", a.URL))
+    if opens != "" {
+        metadata += fmt.Sprintf("\nOpens: %s", opens)
+    }
+    return metadata
 }
 
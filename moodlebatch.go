@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// moodleCourseBatch is how many course IDs go in one request, keeping URLs
+// well under server limits for students with long enrollment lists
+const moodleCourseBatch = 25
+
+// errMoodleBusy is wrapped by request errors for responses worth retrying
+var errMoodleBusy = errors.New("moodle is busy")
+
+// moodleBusy reports whether a status means Moodle is throttling or briefly
+// unavailable rather than refusing the request
+func moodleBusy(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// withMoodleBackoff runs a Moodle request, retrying busy responses with the
+// same exponential backoff as Trello writes
+func withMoodleBackoff(request func() error) error {
+	backoff := rateLimitBackoff
+	for attempt := 1; ; attempt++ {
+		err := request()
+		if !errors.Is(err, errMoodleBusy) || attempt == rateLimitAttempts {
+			return err
+		}
+		sleepFor(backoff)
+		backoff *= 2
+	}
+}
+
+// batchCourseIDs splits course IDs into groups of at most size
+func batchCourseIDs(ids []int, size int) [][]int {
+	var batches [][]int
+	for len(ids) > size {
+		batches = append(batches, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		batches = append(batches, ids)
+	}
+	return batches
+}
+
+// courseIDParams is the courseids[] parameter list Moodle's course
+// functions take
+func courseIDParams(ids []int) url.Values {
+	params := url.Values{}
+	for i, id := range ids {
+		params.Set(fmt.Sprintf("courseids[%d]", i), fmt.Sprintf("%d", id))
+	}
+	return params
+}
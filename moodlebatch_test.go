@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBatchCourseIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []int
+		want [][]int
+	}{
+		{"none", nil, nil},
+		{"one batch", []int{1, 2}, [][]int{{1, 2}}},
+		{"exact", []int{1, 2, 3, 4}, [][]int{{1, 2}, {3, 4}}},
+		{"remainder", []int{1, 2, 3, 4, 5}, [][]int{{1, 2}, {3, 4}, {5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchCourseIDs(tt.ids, 2); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("batchCourseIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoodleSessionSharesSiteInfo(t *testing.T) {
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		function := r.URL.Query().Get("wsfunction")
+		calls[function]++
+		switch function {
+		case "core_webservice_get_site_info":
+			fmt.Fprint(w, `{"userid": 7}`)
+		case "core_enrol_get_users_courses":
+			fmt.Fprint(w, `[{"id": 1, "fullname": "Biology"}, {"id": 2, "fullname": "Chemistry"}]`)
+		case "mod_assign_get_assignments":
+			fmt.Fprint(w, `{"courses": [{"id": 1, "fullname": "Biology", "assignments": [{"id": 10, "name": "Lab", "duedate": 1759363200}]}]}`)
+		case "mod_quiz_get_quizzes_by_courses":
+			fmt.Fprint(w, `{"quizzes": [{"id": 20, "name": "Quiz 1", "course": 2, "timeclose": 1759363200}]}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewMoodleClient(server.URL, "token")
+	assignments, names, err := client.GetUpcomingAssignments(time.Unix(1759276800, 0), time.Unix(1759449600, 0))
+	if err != nil {
+		t.Fatalf("GetUpcomingAssignments() error = %v", err)
+	}
+	if len(assignments) != 2 || names[2] != "Chemistry" {
+		t.Errorf("GetUpcomingAssignments() = %+v, %v", assignments, names)
+	}
+	if _, err := client.GetSiteInfo(); err != nil {
+		t.Fatalf("GetSiteInfo() error = %v", err)
+	}
+	if calls["core_webservice_get_site_info"] != 1 || calls["core_enrol_get_users_courses"] != 1 {
+		t.Errorf("calls = %v, want site info and courses fetched once", calls)
+	}
+}
+
+func TestMoodleBackoff(t *testing.T) {
+	var slept []time.Duration
+	sleepFor = func(d time.Duration) { slept = append(slept, d) }
+	defer func() { sleepFor = time.Sleep }()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"userid": 7}`)
+	}))
+	defer server.Close()
+
+	userID, err := NewMoodleClient(server.URL, "token").GetSiteInfo()
+	if err != nil || userID != 7 {
+		t.Fatalf("GetSiteInfo() = %d, %v", userID, err)
+	}
+	if len(slept) != 2 || slept[1] != 2*rateLimitBackoff {
+		t.Errorf("slept %v", slept)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MoodleWarning is an entry in a Moodle response's warnings array. Moodle
+// leaves out what it can't show (a hidden module, a course the token has
+// no permission for) and says so only here.
+type MoodleWarning struct {
+	Item        string `json:"item"`
+	ItemID      any    `json:"itemid"` // usually a number, sometimes a string
+	WarningCode string `json:"warningcode"`
+	Message     string `json:"message"`
+}
+
+// code is the warning code, or "other" when Moodle didn't give one
+func (w MoodleWarning) code() string {
+	if w.WarningCode == "" {
+		return "other"
+	}
+	return w.WarningCode
+}
+
+func (w MoodleWarning) String() string {
+	text := w.code()
+	if w.Item != "" {
+		text += fmt.Sprintf(" (%s", w.Item)
+		if w.ItemID != nil {
+			text += fmt.Sprintf(" %v", w.ItemID)
+		}
+		text += ")"
+	}
+	if w.Message != "" {
+		text += ": " + w.Message
+	}
+	return text
+}
+
+// recordWarnings logs a response's warnings and keeps each distinct one
+// for ReportWarnings
+func (m *MoodleClient) recordWarnings(warnings []MoodleWarning) {
+	for _, warning := range warnings {
+		seen := false
+		for _, kept := range m.warnings {
+			if kept.String() == warning.String() {
+				seen = true
+				break
+			}
+		}
+		if seen {
+			continue
+		}
+		fmt.Printf("Warning: Moodle: %s\n", warning)
+		m.warnings = append(m.warnings, warning)
+	}
+}
+
+// ReportWarnings prints the sync's Moodle warnings grouped by code, so
+// assignments missing from the board can be traced to a hidden module or a
+// permission problem
+func (m *MoodleClient) ReportWarnings() {
+	if len(m.warnings) == 0 {
+		return
+	}
+	byCode := make(map[string][]MoodleWarning)
+	var codes []string
+	for _, warning := range m.warnings {
+		code := warning.code()
+		if byCode[code] == nil {
+			codes = append(codes, code)
+		}
+		byCode[code] = append(byCode[code], warning)
+	}
+	sort.Strings(codes)
+
+	fmt.Printf("Moodle sent %s, so some items may be missing:\n", plural(len(m.warnings), "warning"))
+	for _, code := range codes {
+		fmt.Printf("  %s: %d\n", code, len(byCode[code]))
+		for _, warning := range byCode[code] {
+			fmt.Printf("    %s\n", warning)
+		}
+	}
+}
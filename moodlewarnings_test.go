@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMoodleWarningString(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"full", `{"item": "module", "itemid": 42, "warningcode": "modulehidden", "message": "The module is hidden"}`, "modulehidden (module 42): The module is hidden"},
+		{"string id", `{"item": "course", "itemid": "7", "warningcode": "nopermissions", "message": "No access"}`, "nopermissions (course 7): No access"},
+		{"no item", `{"warningcode": "nopermissions"}`, "nopermissions"},
+		{"no code", `{"message": "Something was skipped"}`, "other: Something was skipped"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warning MoodleWarning
+			if err := json.Unmarshal([]byte(tt.json), &warning); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got := warning.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordWarningsKeepsDistinct(t *testing.T) {
+	client := &MoodleClient{}
+	hidden := MoodleWarning{Item: "module", ItemID: 42.0, WarningCode: "modulehidden"}
+	client.recordWarnings([]MoodleWarning{hidden, {WarningCode: "nopermissions"}})
+	client.recordWarnings([]MoodleWarning{hidden})
+	if len(client.warnings) != 2 {
+		t.Errorf("kept %d warnings, want 2: %v", len(client.warnings), client.warnings)
+	}
+}
+
+func TestParseAssignmentGradeRecordsWarnings(t *testing.T) {
+	client := &MoodleClient{}
+	body := `{"assignments": [], "warnings": [{"item": "assignment", "itemid": 9, "warningcode": "3", "message": "No access rights in module context"}]}`
+	grade, err := client.parseAssignmentGrade([]byte(body), 7)
+	if err != nil || grade != nil {
+		t.Fatalf("parseAssignmentGrade() = %v, %v", grade, err)
+	}
+	if len(client.warnings) != 1 || client.warnings[0].Message != "No access rights in module context" {
+		t.Errorf("warnings = %v", client.warnings)
+	}
+}
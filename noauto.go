@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// noAutoTag in a card's name or description, or a label named noAutoLabel,
+// keeps every automated job from touching the card
+const (
+	noAutoTag   = "[no-auto]"
+	noAutoLabel = "no-auto"
+)
+
+// isNoAuto reports whether a card has opted out of automation
+func isNoAuto(card Card) bool {
+	if strings.Contains(strings.ToLower(card.Name), noAutoTag) || strings.Contains(strings.ToLower(card.Description), noAutoTag) {
+		return true
+	}
+	for _, label := range card.Labels {
+		if normalizeString(label.Name) == noAutoLabel {
+			return true
+		}
+	}
+	return false
+}
+
+// skipNoAuto reports whether a card has opted out of automation, noting
+// that it was left alone
+func skipNoAuto(card *Card) bool {
+	if card == nil || !isNoAuto(*card) {
+		return false
+	}
+	fmt.Printf("Skipping %s (marked %s)\n", card.Name, noAutoTag)
+	return true
+}
+
+// withoutNoAuto drops cards that have opted out of automation
+func withoutNoAuto(cards []Card) []Card {
+	var kept []Card
+	for i := range cards {
+		if !skipNoAuto(&cards[i]) {
+			kept = append(kept, cards[i])
+		}
+	}
+	return kept
+}
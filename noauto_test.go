@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestIsNoAuto(t *testing.T) {
+	tests := []struct {
+		name string
+		card Card
+		want bool
+	}{
+		{"plain card", Card{Name: "Math - Week 3"}, false},
+		{"tag in name", Card{Name: "Piano practice [no-auto]"}, true},
+		{"tag in description", Card{Name: "Piano", Description: "Mom sets this one.\n\n[No-Auto]"}, true},
+		{"label", Card{Name: "Piano", Labels: []Label{{Name: "Music"}, {Name: "No-Auto"}}}, true},
+		{"similar label", Card{Name: "Piano", Labels: []Label{{Name: "auto"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNoAuto(tt.card); got != tt.want {
+				t.Errorf("isNoAuto() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithoutNoAuto(t *testing.T) {
+	cards := []Card{{ID: "a", Name: "Read"}, {ID: "b", Name: "Piano [no-auto]"}, {ID: "c", Name: "Chores"}}
+	got := withoutNoAuto(cards)
+	if len(got) != 2 || got[0].ID != "a" || got[1].ID != "c" {
+		t.Errorf("withoutNoAuto() = %+v, want a and c", got)
+	}
+	if skipNoAuto(nil) {
+		t.Errorf("skipNoAuto(nil) should be false")
+	}
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// trelloTokenFile holds the token saved by --authorize-trello, in the config directory
+const trelloTokenFile = "trello_token.json"
+
+// keychainService names the macOS keychain item holding the Trello token
+const keychainService = "trello-daily-reset"
+
+// authorizeTimeout is how long --authorize-trello waits for the browser
+const authorizeTimeout = 5 * time.Minute
+
+// trelloExpirations maps Trello's token expirations to their length; zero never expires
+var trelloExpirations = map[string]time.Duration{
+	"1hour":  time.Hour,
+	"1day":   24 * time.Hour,
+	"30days": 30 * 24 * time.Hour,
+	"never":  0,
+}
+
+// StoredToken is a Trello token from --authorize-trello. Token is empty in
+// the file when it lives in the macOS keychain instead.
+type StoredToken struct {
+	Token        string    `json:"token,omitempty"`
+	InKeychain   bool      `json:"inKeychain,omitempty"`
+	Scope        string    `json:"scope"`
+	Expiration   string    `json:"expiration"`
+	AuthorizedAt time.Time `json:"authorizedAt"`
+	ExpiresAt    time.Time `json:"expiresAt,omitempty"`
+}
+
+// Expired reports whether the token has run out
+func (t StoredToken) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// TrelloAuthorizer runs Trello's OAuth 1.0a flow to get a user token
+type TrelloAuthorizer struct {
+	APIKey      string
+	APISecret   string
+	BaseURL     string // https://trello.com/1
+	AppName     string
+	HTTP        *http.Client
+	OpenBrowser func(link string) error
+}
+
+// NewTrelloAuthorizer returns an authorizer for the app's API key and secret
+func NewTrelloAuthorizer(apiKey, apiSecret string) *TrelloAuthorizer {
+	return &TrelloAuthorizer{
+		APIKey:      apiKey,
+		APISecret:   apiSecret,
+		BaseURL:     "https://trello.com/1",
+		AppName:     "Trello Daily Reset",
+		HTTP:        &http.Client{Timeout: 30 * time.Second},
+		OpenBrowser: openBrowser,
+	}
+}
+
+// oauthEscape percent-encodes a value the way OAuth 1.0a signatures require (RFC 3986)
+func oauthEscape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(url.QueryEscape(s), "+", "%20"), "%7E", "~")
+}
+
+// oauthSignature signs a request with HMAC-SHA1. params holds the oauth_*
+// parameters and any query or form parameters.
+func oauthSignature(method, rawURL string, params url.Values, consumerSecret, tokenSecret string) string {
+	var pairs []string
+	for key, values := range params {
+		for _, value := range values {
+			pairs = append(pairs, oauthEscape(key)+"="+oauthEscape(value))
+		}
+	}
+	sort.Strings(pairs)
+
+	base := strings.ToUpper(method) + "&" + oauthEscape(rawURL) + "&" + oauthEscape(strings.Join(pairs, "&"))
+	mac := hmac.New(sha1.New, []byte(oauthEscape(consumerSecret)+"&"+oauthEscape(tokenSecret)))
+	mac.Write([]byte(base))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// oauthNonce returns a random value so Trello can reject replayed requests
+func oauthNonce() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(b)
+}
+
+// post makes a signed OAuth request and returns the form-encoded reply
+func (a *TrelloAuthorizer) post(endpoint string, extra url.Values, tokenSecret string) (url.Values, error) {
+	params := url.Values{
+		"oauth_consumer_key":     {a.APIKey},
+		"oauth_nonce":            {oauthNonce()},
+		"oauth_signature_method": {"HMAC-SHA1"},
+		"oauth_timestamp":        {strconv.FormatInt(time.Now().Unix(), 10)},
+		"oauth_version":          {"1.0"},
+	}
+	for key, values := range extra {
+		params[key] = values
+	}
+	requestURL := a.BaseURL + endpoint
+	params.Set("oauth_signature", oauthSignature("POST", requestURL, params, a.APISecret, tokenSecret))
+
+	var header []string
+	for key := range params {
+		header = append(header, fmt.Sprintf(`%s="%s"`, key, oauthEscape(params.Get(key))))
+	}
+	sort.Strings(header)
+
+	req, err := http.NewRequest("POST", requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "OAuth "+strings.Join(header, ", "))
+
+	resp, err := a.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s failed with status %d: %s", endpoint, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s response: %w", endpoint, err)
+	}
+	if values.Get("oauth_token") == "" {
+		return nil, fmt.Errorf("%s returned no token", endpoint)
+	}
+	return values, nil
+}
+
+// Authorize runs the OAuth flow: it gets a request token, sends the user to
+// Trello to approve it, catches the redirect on a local callback server, and
+// trades the approval for an access token with the given scope and expiration.
+func (a *TrelloAuthorizer) Authorize(scope, expiration string) (*StoredToken, error) {
+	lifetime, ok := trelloExpirations[expiration]
+	if !ok {
+		return nil, fmt.Errorf("invalid expiration '%s' (want 1hour, 1day, 30days, or never)", expiration)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start callback server: %w", err)
+	}
+	defer listener.Close()
+
+	type approval struct{ token, verifier string }
+	approved := make(chan approval, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/callback" || query.Get("oauth_verifier") == "" {
+			http.Error(w, "Authorization was not approved. You can close this window.", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, "Trello authorized. You can close this window.")
+		select {
+		case approved <- approval{query.Get("oauth_token"), query.Get("oauth_verifier")}:
+		default:
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	callback := fmt.Sprintf("http://%s/callback", listener.Addr())
+	request, err := a.post("/OAuthGetRequestToken", url.Values{"oauth_callback": {callback}}, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request token: %w", err)
+	}
+
+	authURL := a.BaseURL + "/OAuthAuthorizeToken?" + url.Values{
+		"oauth_token": {request.Get("oauth_token")},
+		"name":        {a.AppName},
+		"scope":       {scope},
+		"expiration":  {expiration},
+	}.Encode()
+	fmt.Printf("Opening Trello to authorize %s (scope: %s, expires: %s).\nIf the browser doesn't open, visit:\n%s\n", a.AppName, scope, expiration, authURL)
+	if err := a.OpenBrowser(authURL); err != nil {
+		fmt.Printf("Warning: failed to open browser: %v\n", err)
+	}
+
+	var result approval
+	select {
+	case result = <-approved:
+	case <-time.After(authorizeTimeout):
+		return nil, fmt.Errorf("timed out after %s waiting for authorization", authorizeTimeout)
+	}
+	if result.token != request.Get("oauth_token") {
+		return nil, fmt.Errorf("callback was for a different request token")
+	}
+
+	access, err := a.post("/OAuthGetAccessToken", url.Values{
+		"oauth_token":    {result.token},
+		"oauth_verifier": {result.verifier},
+	}, request.Get("oauth_token_secret"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	now := time.Now()
+	token := &StoredToken{Token: access.Get("oauth_token"), Scope: scope, Expiration: expiration, AuthorizedAt: now}
+	if lifetime > 0 {
+		token.ExpiresAt = now.Add(lifetime)
+	}
+	return token, nil
+}
+
+// openBrowser opens a link in the default browser
+func openBrowser(link string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", link).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", link).Start()
+	default:
+		return exec.Command("xdg-open", link).Start()
+	}
+}
+
+// useKeychain reports whether tokens go in the macOS keychain
+func useKeychain() bool {
+	if runtime.GOOS != "darwin" {
+		return false
+	}
+	_, err := exec.LookPath("security")
+	return err == nil
+}
+
+// SaveStoredToken saves the token in the macOS keychain when available and
+// its details in the config directory. Elsewhere the token is kept in the
+// file, readable only by the user.
+func SaveStoredToken(token StoredToken) (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if useKeychain() {
+		cmd := exec.Command("security", "add-generic-password", "-U", "-a", os.Getenv("USER"), "-s", keychainService, "-w", token.Token)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			fmt.Printf("Warning: failed to save token to the keychain, saving it to the config file instead: %v (%s)\n", err, strings.TrimSpace(string(output)))
+		} else {
+			token.Token = ""
+			token.InKeychain = true
+		}
+	}
+
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token: %w", err)
+	}
+	path := filepath.Join(dir, trelloTokenFile)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write token file: %w", err)
+	}
+	return path, nil
+}
+
+// LoadStoredToken reads the token saved by --authorize-trello, or returns
+// nil if there isn't one
+func LoadStoredToken() (*StoredToken, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, trelloTokenFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var token StoredToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token file: %w", err)
+	}
+
+	if token.InKeychain {
+		output, err := exec.Command("security", "find-generic-password", "-a", os.Getenv("USER"), "-s", keychainService, "-w").Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token from the keychain: %w", err)
+		}
+		token.Token = strings.TrimSpace(string(output))
+	}
+	return &token, nil
+}
+
+// storedTrelloToken returns the saved token for use when TRELLO_API_TOKEN
+// isn't set, failing once it has expired and warning as expiry nears
+func storedTrelloToken(now time.Time) (string, error) {
+	token, err := LoadStoredToken()
+	if err != nil || token == nil {
+		return "", err
+	}
+	if token.Expired(now) {
+		return "", fmt.Errorf("saved Trello authorization expired %s; run --authorize-trello again", friendlyTime(token.ExpiresAt, displayLocation()))
+	}
+	if !token.ExpiresAt.IsZero() && token.ExpiresAt.Sub(now) < 3*24*time.Hour {
+		fmt.Printf("Warning: Trello authorization expires %s; run --authorize-trello to renew it\n", friendlyTime(token.ExpiresAt, displayLocation()))
+	}
+	return token.Token, nil
+}
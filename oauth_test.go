@@ -0,0 +1,105 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOAuthSignature(t *testing.T) {
+	// Example from RFC 5849 section 1.2
+	params := url.Values{
+		"file":                   {"vacation.jpg"},
+		"size":                   {"original"},
+		"oauth_consumer_key":     {"dpf43f3p2l4k3l03"},
+		"oauth_token":            {"nnch734d00sl2jdk"},
+		"oauth_signature_method": {"HMAC-SHA1"},
+		"oauth_timestamp":        {"1191242096"},
+		"oauth_nonce":            {"kllo9940pd9333jh"},
+		"oauth_version":          {"1.0"},
+	}
+	got := oauthSignature("GET", "http://photos.example.net/photos", params, "kd94hf93k423kf44", "pfkkdhi9sl3r4s00")
+	if want := "tR3+Ty81lMeYAr/Fid0kMTYa/WM="; got != want {
+		t.Errorf("oauthSignature() = %q, want %q", got, want)
+	}
+}
+
+func TestTrelloAuthorize(t *testing.T) {
+	var callback string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.Contains(auth, `oauth_consumer_key="key"`) || !strings.Contains(auth, "oauth_signature=") {
+			http.Error(w, "unsigned", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/OAuthGetRequestToken":
+			_, after, _ := strings.Cut(auth, `oauth_callback="`)
+			escaped, _, _ := strings.Cut(after, `"`)
+			callback, _ = url.QueryUnescape(escaped)
+			w.Write([]byte("oauth_token=req&oauth_token_secret=reqsecret&oauth_callback_confirmed=true"))
+		case "/OAuthGetAccessToken":
+			if !strings.Contains(auth, `oauth_verifier="ver"`) {
+				http.Error(w, "bad verifier", http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte("oauth_token=access&oauth_token_secret=accesssecret"))
+		}
+	}))
+	defer server.Close()
+
+	authorizer := NewTrelloAuthorizer("key", "secret")
+	authorizer.BaseURL = server.URL
+	authorizer.HTTP = server.Client()
+	var opened string
+	authorizer.OpenBrowser = func(link string) error {
+		// Stand in for the user approving in the browser
+		opened = link
+		go http.Get(callback + "?oauth_token=req&oauth_verifier=ver")
+		return nil
+	}
+
+	before := time.Now()
+	token, err := authorizer.Authorize("read,write", "30days")
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if token.Token != "access" || token.Scope != "read,write" {
+		t.Errorf("token = %+v", token)
+	}
+	if token.ExpiresAt.Before(before.Add(30*24*time.Hour)) || token.Expired(time.Now()) {
+		t.Errorf("ExpiresAt = %v, want 30 days out", token.ExpiresAt)
+	}
+	if !strings.Contains(opened, "scope=read%2Cwrite") || !strings.Contains(opened, "expiration=30days") {
+		t.Errorf("authorize URL = %q", opened)
+	}
+
+	if _, err := authorizer.Authorize("read", "forever"); err == nil {
+		t.Errorf("unknown expiration should be an error")
+	}
+}
+
+func TestStoredTrelloToken(t *testing.T) {
+	if useKeychain() {
+		t.Skip("would write to the keychain")
+	}
+	t.Setenv("TRELLO_CONFIG_DIR", t.TempDir())
+	now := time.Now()
+
+	if token, err := storedTrelloToken(now); token != "" || err != nil {
+		t.Errorf("no saved token should be empty, got %q, %v", token, err)
+	}
+
+	if _, err := SaveStoredToken(StoredToken{Token: "tok", Expiration: "30days", ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("SaveStoredToken() error = %v", err)
+	}
+	if token, err := storedTrelloToken(now); token != "tok" || err != nil {
+		t.Errorf("storedTrelloToken() = %q, %v", token, err)
+	}
+	if _, err := storedTrelloToken(now.Add(2 * time.Hour)); err == nil {
+		t.Errorf("expired token should be an error")
+	}
+}
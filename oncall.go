@@ -0,0 +1,559 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OnCallItem is an on-call shift or an active incident
+type OnCallItem struct {
+	Source string // e.g. "PagerDuty Shift" or "Opsgenie Incident"
+	ID     string
+	Title  string
+	Start  time.Time // shift start; zero for incidents
+	End    time.Time // shift end; zero for incidents
+	URL    string
+	Notes  string
+}
+
+// OnCallProvider is a paging service that reports shifts and incidents
+type OnCallProvider interface {
+	Name() string
+	Shifts(from, to time.Time) ([]OnCallItem, error)
+	Incidents() ([]OnCallItem, error)
+}
+
+// shiftTitle names a shift card after the schedule and its time span
+func shiftTitle(schedule string, start, end time.Time) string {
+	const layout = "Mon Jan 2 3:04 PM"
+	return fmt.Sprintf("📟 On call: %s (%s – %s)", schedule, start.Local().Format(layout), end.Local().Format(layout))
+}
+
+// shiftID identifies a shift by schedule and start, since the APIs don't give
+// shifts IDs of their own
+func shiftID(scheduleID string, start time.Time) string {
+	return fmt.Sprintf("%s@%d", scheduleID, start.Unix())
+}
+
+// mergeShifts joins back-to-back periods from the same schedule, which
+// paging services often split at rotation or layer boundaries
+func mergeShifts(shifts []OnCallItem) []OnCallItem {
+	sort.SliceStable(shifts, func(i, j int) bool { return shifts[i].Start.Before(shifts[j].Start) })
+
+	var merged []OnCallItem
+	for _, shift := range shifts {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			lastSchedule, _, _ := strings.Cut(last.ID, "@")
+			schedule, _, _ := strings.Cut(shift.ID, "@")
+			if lastSchedule == schedule && !shift.Start.After(last.End) {
+				if shift.End.After(last.End) {
+					last.End = shift.End
+				}
+				continue
+			}
+		}
+		merged = append(merged, shift)
+	}
+	return merged
+}
+
+// PagerDutyClient reads on-call shifts and incidents from PagerDuty
+type PagerDutyClient struct {
+	Token   string
+	BaseURL string
+}
+
+// Name labels PagerDuty cards
+func (p *PagerDutyClient) Name() string { return "PagerDuty" }
+
+// get calls the PagerDuty REST API
+func (p *PagerDutyClient) get(endpoint string, query url.Values) ([]byte, error) {
+	req, err := http.NewRequest("GET", p.BaseURL+endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token token="+p.Token)
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("PagerDuty API request failed with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, nil
+}
+
+// userID returns the ID of the user the token belongs to
+func (p *PagerDutyClient) userID() (string, error) {
+	body, err := p.get("/users/me", url.Values{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get PagerDuty user: %w", err)
+	}
+
+	var me struct {
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	if err := json.Unmarshal(body, &me); err != nil {
+		return "", fmt.Errorf("failed to unmarshal PagerDuty user: %w", err)
+	}
+	return me.User.ID, nil
+}
+
+// Shifts returns the user's scheduled on-call shifts overlapping from..to
+func (p *PagerDutyClient) Shifts(from, to time.Time) ([]OnCallItem, error) {
+	userID, err := p.userID()
+	if err != nil {
+		return nil, err
+	}
+
+	var shifts []OnCallItem
+	query := url.Values{}
+	query.Set("user_ids[]", userID)
+	query.Set("since", from.Format(time.RFC3339))
+	query.Set("until", to.Format(time.RFC3339))
+	query.Set("limit", "100")
+
+	for offset := 0; ; {
+		query.Set("offset", strconv.Itoa(offset))
+		body, err := p.get("/oncalls", query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PagerDuty on-calls: %w", err)
+		}
+
+		var page struct {
+			Oncalls []struct {
+				Start    *time.Time `json:"start"`
+				End      *time.Time `json:"end"`
+				Schedule *struct {
+					ID      string `json:"id"`
+					Summary string `json:"summary"`
+					HTMLURL string `json:"html_url"`
+				} `json:"schedule"`
+				EscalationPolicy struct {
+					Summary string `json:"summary"`
+				} `json:"escalation_policy"`
+				EscalationLevel int `json:"escalation_level"`
+			} `json:"oncalls"`
+			More bool `json:"more"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal PagerDuty on-calls: %w", err)
+		}
+
+		for _, oncall := range page.Oncalls {
+			// Permanent escalation-policy entries have no schedule or end
+			if oncall.Schedule == nil || oncall.Start == nil || oncall.End == nil {
+				continue
+			}
+			shifts = append(shifts, OnCallItem{
+				Source: "PagerDuty Shift",
+				ID:     shiftID(oncall.Schedule.ID, *oncall.Start),
+				Title:  oncall.Schedule.Summary,
+				Start:  *oncall.Start,
+				End:    *oncall.End,
+				URL:    oncall.Schedule.HTMLURL,
+				Notes:  fmt.Sprintf("Escalation policy: %s (level %d)", oncall.EscalationPolicy.Summary, oncall.EscalationLevel),
+			})
+		}
+
+		if !page.More {
+			break
+		}
+		offset += len(page.Oncalls)
+	}
+
+	return dedupeShifts(shifts), nil
+}
+
+// dedupeShifts drops shifts reported once per escalation policy, then merges
+// back-to-back periods
+func dedupeShifts(shifts []OnCallItem) []OnCallItem {
+	seen := make(map[string]bool)
+	var unique []OnCallItem
+	for _, shift := range shifts {
+		key := shift.ID + "-" + strconv.FormatInt(shift.End.Unix(), 10)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, shift)
+		}
+	}
+	return mergeShifts(unique)
+}
+
+// Incidents returns triggered or acknowledged incidents assigned to the user
+func (p *PagerDutyClient) Incidents() ([]OnCallItem, error) {
+	userID, err := p.userID()
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Add("statuses[]", "triggered")
+	query.Add("statuses[]", "acknowledged")
+	query.Set("user_ids[]", userID)
+	query.Set("limit", "100")
+
+	body, err := p.get("/incidents", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PagerDuty incidents: %w", err)
+	}
+
+	var page struct {
+		Incidents []struct {
+			ID             string `json:"id"`
+			IncidentNumber int    `json:"incident_number"`
+			Title          string `json:"title"`
+			Status         string `json:"status"`
+			Urgency        string `json:"urgency"`
+			HTMLURL        string `json:"html_url"`
+			Service        struct {
+				Summary string `json:"summary"`
+			} `json:"service"`
+		} `json:"incidents"`
+	}
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PagerDuty incidents: %w", err)
+	}
+
+	var incidents []OnCallItem
+	for _, incident := range page.Incidents {
+		incidents = append(incidents, OnCallItem{
+			Source: "PagerDuty Incident",
+			ID:     incident.ID,
+			Title:  fmt.Sprintf("🚨 #%d %s", incident.IncidentNumber, incident.Title),
+			URL:    incident.HTMLURL,
+			Notes:  fmt.Sprintf("Service: %s\nStatus: %s\nUrgency: %s", incident.Service.Summary, incident.Status, incident.Urgency),
+		})
+	}
+	return incidents, nil
+}
+
+// OpsgenieClient reads schedule timelines and open incidents from Opsgenie
+type OpsgenieClient struct {
+	APIKey    string
+	BaseURL   string
+	Schedules []string // schedule names to check
+	User      string   // the user's Opsgenie login (email)
+}
+
+// Name labels Opsgenie cards
+func (o *OpsgenieClient) Name() string { return "Opsgenie" }
+
+// get calls the Opsgenie REST API
+func (o *OpsgenieClient) get(endpoint string, query url.Values) ([]byte, error) {
+	req, err := http.NewRequest("GET", o.BaseURL+endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "GenieKey "+o.APIKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Opsgenie API request failed with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, nil
+}
+
+// Shifts returns the user's periods in each configured schedule
+func (o *OpsgenieClient) Shifts(from, to time.Time) ([]OnCallItem, error) {
+	days := int(to.Sub(from).Hours()/24) + 1
+
+	var shifts []OnCallItem
+	for _, schedule := range o.Schedules {
+		query := url.Values{}
+		query.Set("identifierType", "name")
+		query.Set("interval", strconv.Itoa(days))
+		query.Set("intervalUnit", "days")
+		query.Set("date", from.Format(time.RFC3339))
+
+		body, err := o.get(fmt.Sprintf("/v2/schedules/%s/timeline", url.PathEscape(schedule)), query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Opsgenie timeline for %s: %w", schedule, err)
+		}
+
+		var timeline struct {
+			Data struct {
+				FinalTimeline struct {
+					Rotations []struct {
+						Periods []struct {
+							StartDate time.Time `json:"startDate"`
+							EndDate   time.Time `json:"endDate"`
+							Recipient struct {
+								Name string `json:"name"`
+							} `json:"recipient"`
+						} `json:"periods"`
+					} `json:"rotations"`
+				} `json:"finalTimeline"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &timeline); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Opsgenie timeline: %w", err)
+		}
+
+		for _, rotation := range timeline.Data.FinalTimeline.Rotations {
+			for _, period := range rotation.Periods {
+				if !strings.EqualFold(period.Recipient.Name, o.User) || !period.EndDate.After(from) {
+					continue
+				}
+				shifts = append(shifts, OnCallItem{
+					Source: "Opsgenie Shift",
+					ID:     shiftID(schedule, period.StartDate),
+					Title:  schedule,
+					Start:  period.StartDate,
+					End:    period.EndDate,
+				})
+			}
+		}
+	}
+
+	return mergeShifts(shifts), nil
+}
+
+// Incidents returns open Opsgenie incidents
+func (o *OpsgenieClient) Incidents() ([]OnCallItem, error) {
+	query := url.Values{}
+	query.Set("query", "status:open")
+	query.Set("limit", "100")
+
+	body, err := o.get("/v1/incidents", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Opsgenie incidents: %w", err)
+	}
+
+	var page struct {
+		Data []struct {
+			ID       string `json:"id"`
+			TinyID   string `json:"tinyId"`
+			Message  string `json:"message"`
+			Status   string `json:"status"`
+			Priority string `json:"priority"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Opsgenie incidents: %w", err)
+	}
+
+	var incidents []OnCallItem
+	for _, incident := range page.Data {
+		incidents = append(incidents, OnCallItem{
+			Source: "Opsgenie Incident",
+			ID:     incident.ID,
+			Title:  fmt.Sprintf("🚨 #%s %s", incident.TinyID, incident.Message),
+			Notes:  fmt.Sprintf("Status: %s\nPriority: %s", incident.Status, incident.Priority),
+		})
+	}
+	return incidents, nil
+}
+
+// onCallProvidersFromEnv returns PagerDuty when PAGERDUTY_TOKEN is set and
+// Opsgenie when OPSGENIE_API_KEY, OPSGENIE_SCHEDULES, and OPSGENIE_USER are
+func onCallProvidersFromEnv() ([]OnCallProvider, error) {
+	var providers []OnCallProvider
+
+	if token := os.Getenv("PAGERDUTY_TOKEN"); token != "" {
+		providers = append(providers, &PagerDutyClient{Token: token, BaseURL: "https://api.pagerduty.com"})
+	}
+
+	if apiKey := os.Getenv("OPSGENIE_API_KEY"); apiKey != "" {
+		schedules := splitList(os.Getenv("OPSGENIE_SCHEDULES"))
+		user := os.Getenv("OPSGENIE_USER")
+		if len(schedules) == 0 || user == "" {
+			return nil, fmt.Errorf("OPSGENIE_SCHEDULES and OPSGENIE_USER must be set with OPSGENIE_API_KEY")
+		}
+		baseURL := os.Getenv("OPSGENIE_API_URL")
+		if baseURL == "" {
+			baseURL = "https://api.opsgenie.com"
+		}
+		providers = append(providers, &OpsgenieClient{APIKey: apiKey, BaseURL: baseURL, Schedules: schedules, User: user})
+	}
+
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("PAGERDUTY_TOKEN or OPSGENIE_API_KEY must be set")
+	}
+	return providers, nil
+}
+
+// onCallCardTitle is the card title for a shift or incident
+func onCallCardTitle(item OnCallItem) string {
+	if item.Start.IsZero() {
+		return item.Title
+	}
+	return shiftTitle(item.Title, item.Start, item.End)
+}
+
+// onCallNotes adds the shift span to the notes so it's readable in the card
+func onCallNotes(item OnCallItem) string {
+	if item.Start.IsZero() {
+		return item.Notes
+	}
+	loc := displayLocation()
+	span := fmt.Sprintf("Shift: %s to %s", friendlyTime(item.Start, loc), friendlyTime(item.End, loc))
+	if item.Notes == "" {
+		return span
+	}
+	return span + "\n" + item.Notes
+}
+
+// SyncOnCall keeps a card on the work board (ONCALL_BOARD, default Mac) for
+// each upcoming shift and active incident. Shift cards are due at the start
+// of the shift. Cards are archived once the shift ends or the incident closes.
+func (c *TrelloClient) SyncOnCall(providers []OnCallProvider, days int) error {
+	boardName := os.Getenv("ONCALL_BOARD")
+	if boardName == "" {
+		boardName = "Mac"
+	}
+	boardName = c.Target.board(boardName)
+
+	now := time.Now()
+	var items []OnCallItem
+	var sources []string
+	for _, provider := range providers {
+		shifts, err := provider.Shifts(now, now.AddDate(0, 0, days))
+		if err != nil {
+			return err
+		}
+		incidents, err := provider.Incidents()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %d shift(s) in the next %d days, %d active incident(s)\n", provider.Name(), len(shifts), days, len(incidents))
+		items = append(items, shifts...)
+		items = append(items, incidents...)
+		sources = append(sources, provider.Name()+" Shift", provider.Name()+" Incident")
+	}
+
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return err
+	}
+
+	var listID string
+	if listName := c.Target.list(os.Getenv("ONCALL_LIST")); listName != "" {
+		list, err := findListByName(cache.Lists, board.ID, listName)
+		if err != nil {
+			return fmt.Errorf("%s in board '%s'", err.Error(), board.Name)
+		}
+		listID = list.ID
+	} else {
+		for _, list := range cache.Lists {
+			if list.BoardID == board.ID {
+				listID = list.ID
+				break
+			}
+		}
+	}
+	if listID == "" {
+		return fmt.Errorf("no lists found on %s board", boardName)
+	}
+
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get Trello cards: %w", err)
+	}
+
+	current := make(map[string]bool)
+	for _, item := range items {
+		current[item.Source+"\x00"+item.ID] = true
+
+		title := onCallCardTitle(item)
+		description := formatWorkItemDescription(WorkItem{Source: item.Source, ID: item.ID, URL: item.URL, Notes: onCallNotes(item)})
+		var dueDate string
+		if !item.Start.IsZero() {
+			dueDate = item.Start.UTC().Format(trelloDueLayout)
+		}
+
+		existing := findCardByWorkItem(cards, item.Source, item.ID)
+		if skipNoAuto(existing) {
+			continue
+		}
+		if existing == nil {
+			fmt.Printf("Creating card: %s\n", title)
+			newCard, err := c.CreateCard(listID, title, description, dueDate)
+			if err != nil {
+				fmt.Printf("Warning: failed to create card %s: %v\n", title, err)
+				continue
+			}
+			if !item.Start.IsZero() {
+				continue
+			}
+			// Active incidents belong at the top where they can't be missed
+			if err := c.UpdateCardPosition(newCard.ID, "top"); err != nil {
+				fmt.Printf("Warning: failed to move %s to the top: %v\n", title, err)
+			}
+			continue
+		}
+
+		patch := CardPatch{}
+		if existing.Name != title {
+			patch.Name = &title
+		}
+		if existing.Description != description {
+			patch.Desc = &description
+		}
+		if patch != (CardPatch{}) {
+			fmt.Printf("Updating card: %s\n", title)
+			if err := c.UpdateCardFields(existing.ID, patch); err != nil {
+				fmt.Printf("Warning: failed to update card %s: %v\n", title, err)
+			}
+		}
+	}
+
+	// Shifts that ended (or were given away) and closed incidents are archived
+	for _, card := range cards {
+		for _, source := range sources {
+			id := workItemCardID(card, source)
+			if id == "" || current[source+"\x00"+id] || skipNoAuto(&card) {
+				continue
+			}
+			fmt.Printf("Archiving %s\n", card.Name)
+			if err := c.UpdateCardFields(card.ID, CardPatch{Closed: boolPtr(true)}); err != nil {
+				fmt.Printf("Warning: failed to archive %s: %v\n", card.Name, err)
+			}
+		}
+	}
+
+	fmt.Printf("On-call sync completed!\n")
+	return nil
+}
+
+// onCallDays is how many days ahead to look for shifts (ONCALL_DAYS, default 14)
+func onCallDays() int {
+	if days, err := strconv.Atoi(os.Getenv("ONCALL_DAYS")); err == nil && days > 0 {
+		return days
+	}
+	return 14
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMergeShifts(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 9, 0, 0, 0, time.UTC) }
+	shift := func(schedule string, start, end int) OnCallItem {
+		return OnCallItem{ID: shiftID(schedule, day(start)), Title: schedule, Start: day(start), End: day(end)}
+	}
+
+	tests := []struct {
+		name   string
+		shifts []OnCallItem
+		want   [][2]int
+	}{
+		{"back to back", []OnCallItem{shift("P1", 8, 13), shift("P1", 6, 8)}, [][2]int{{6, 13}}},
+		{"gap", []OnCallItem{shift("P1", 6, 7), shift("P1", 9, 10)}, [][2]int{{6, 7}, {9, 10}}},
+		{"other schedule", []OnCallItem{shift("P1", 6, 8), shift("P2", 8, 9)}, [][2]int{{6, 8}, {8, 9}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeShifts(tt.shifts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("mergeShifts() returned %d shifts, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !got[i].Start.Equal(day(want[0])) || !got[i].End.Equal(day(want[1])) {
+					t.Errorf("shift %d = %v to %v, want Oct %d to Oct %d", i, got[i].Start, got[i].End, want[0], want[1])
+				}
+			}
+		})
+	}
+}
+
+func TestPagerDutyShifts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token token=token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/users/me":
+			fmt.Fprint(w, `{"user":{"id":"PUSER"}}`)
+		case "/oncalls":
+			if r.URL.Query().Get("user_ids[]") != "PUSER" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			// The same shift reported for two escalation policies, plus a
+			// permanent policy entry with no schedule
+			fmt.Fprint(w, `{"oncalls":[
+				{"start":"2025-10-06T09:00:00Z","end":"2025-10-13T09:00:00Z","schedule":{"id":"PSCHED","summary":"Platform Primary"},"escalation_policy":{"summary":"Platform"},"escalation_level":1},
+				{"start":"2025-10-06T09:00:00Z","end":"2025-10-13T09:00:00Z","schedule":{"id":"PSCHED","summary":"Platform Primary"},"escalation_policy":{"summary":"Payments"},"escalation_level":2},
+				{"start":null,"end":null,"schedule":null,"escalation_policy":{"summary":"Ops"},"escalation_level":1}
+			],"more":false}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	pagerduty := &PagerDutyClient{Token: "token", BaseURL: server.URL}
+	shifts, err := pagerduty.Shifts(time.Now(), time.Now().AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatalf("Shifts() error = %v", err)
+	}
+	if len(shifts) != 1 {
+		t.Fatalf("Shifts() returned %d shifts, want 1: %+v", len(shifts), shifts)
+	}
+	if shifts[0].Title != "Platform Primary" || shifts[0].Start.Day() != 6 || shifts[0].End.Day() != 13 {
+		t.Errorf("unexpected shift: %+v", shifts[0])
+	}
+}
+
+func TestOpsgenieShiftsFiltersRecipient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "GenieKey key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/v2/schedules/Platform/timeline" || r.URL.Query().Get("identifierType") != "name" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"data":{"finalTimeline":{"rotations":[{"periods":[
+			{"startDate":"2025-10-06T09:00:00Z","endDate":"2025-10-08T09:00:00Z","recipient":{"name":"Me@example.com"}},
+			{"startDate":"2025-10-08T09:00:00Z","endDate":"2025-10-10T09:00:00Z","recipient":{"name":"someone@example.com"}},
+			{"startDate":"2025-10-10T09:00:00Z","endDate":"2025-10-12T09:00:00Z","recipient":{"name":"me@example.com"}}
+		]}]}}}`)
+	}))
+	defer server.Close()
+
+	opsgenie := &OpsgenieClient{APIKey: "key", BaseURL: server.URL, Schedules: []string{"Platform"}, User: "me@example.com"}
+	from := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	shifts, err := opsgenie.Shifts(from, from.AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatalf("Shifts() error = %v", err)
+	}
+	if len(shifts) != 2 || shifts[0].Source != "Opsgenie Shift" || shifts[1].Start.Day() != 10 {
+		t.Errorf("Shifts() = %+v, want my two periods", shifts)
+	}
+}
+
+func TestOnCallCardTitle(t *testing.T) {
+	incident := OnCallItem{Title: "🚨 #12 Checkout latency"}
+	if got := onCallCardTitle(incident); got != incident.Title {
+		t.Errorf("onCallCardTitle(incident) = %q, want %q", got, incident.Title)
+	}
+
+	start := time.Date(2025, 10, 6, 9, 0, 0, 0, time.Local)
+	shift := OnCallItem{Title: "Platform Primary", Start: start, End: start.AddDate(0, 0, 7)}
+	want := "📟 On call: Platform Primary (Mon Oct 6 9:00 AM – Mon Oct 13 9:00 AM)"
+	if got := onCallCardTitle(shift); got != want {
+		t.Errorf("onCallCardTitle(shift) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	graphBaseURL = "https://graph.microsoft.com/v1.0"
+	graphScopes  = "offline_access Mail.Read Tasks.Read"
+)
+
+// workItemSources maps OUTLOOK_SOURCES names to the labels used on cards
+var workItemSources = map[string]string{"flagged": "Outlook", "todo": "To Do"}
+
+// GraphClient reads flagged mail and To Do tasks from Microsoft Graph
+type GraphClient struct {
+	Token   string
+	BaseURL string
+}
+
+// GraphDateTime is Graph's date/time-with-zone pair
+type GraphDateTime struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+// GraphMessage is a flagged Outlook email
+type GraphMessage struct {
+	ID          string `json:"id"`
+	Subject     string `json:"subject"`
+	WebLink     string `json:"webLink"`
+	BodyPreview string `json:"bodyPreview"`
+	From        struct {
+		EmailAddress struct {
+			Name    string `json:"name"`
+			Address string `json:"address"`
+		} `json:"emailAddress"`
+	} `json:"from"`
+	Flag struct {
+		FlagStatus  string         `json:"flagStatus"`
+		DueDateTime *GraphDateTime `json:"dueDateTime"`
+	} `json:"flag"`
+}
+
+// TodoList is a Microsoft To Do list
+type TodoList struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+// TodoTask is a Microsoft To Do task
+type TodoTask struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Status      string         `json:"status"`
+	Importance  string         `json:"importance"`
+	DueDateTime *GraphDateTime `json:"dueDateTime"`
+	Body        struct {
+		Content string `json:"content"`
+	} `json:"body"`
+}
+
+// graphClientFromEnv builds a Graph client from MS_GRAPH_TOKEN, or exchanges
+// MS_REFRESH_TOKEN for an access token using MS_CLIENT_ID (and MS_TENANT)
+func graphClientFromEnv() (*GraphClient, error) {
+	if token := os.Getenv("MS_GRAPH_TOKEN"); token != "" {
+		return &GraphClient{Token: token, BaseURL: graphBaseURL}, nil
+	}
+
+	clientID := os.Getenv("MS_CLIENT_ID")
+	refreshToken := os.Getenv("MS_REFRESH_TOKEN")
+	if clientID == "" || refreshToken == "" {
+		return nil, fmt.Errorf("MS_GRAPH_TOKEN, or MS_CLIENT_ID and MS_REFRESH_TOKEN, must be set")
+	}
+
+	tenant := os.Getenv("MS_TENANT")
+	if tenant == "" {
+		tenant = "common"
+	}
+
+	token, err := refreshGraphToken(fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", tenant), clientID, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return &GraphClient{Token: token, BaseURL: graphBaseURL}, nil
+}
+
+// refreshGraphToken trades a refresh token for an access token
+func refreshGraphToken(tokenURL, clientID, refreshToken string) (string, error) {
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	form.Set("scope", graphScopes)
+
+	resp, err := http.PostForm(tokenURL, form)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh Microsoft token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Microsoft token refresh failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("failed to unmarshal token response: %w", err)
+	}
+	return token.AccessToken, nil
+}
+
+// getPages follows @odata.nextLink and returns the raw value of every page
+func (g *GraphClient) getPages(endpoint string) ([]json.RawMessage, error) {
+	var values []json.RawMessage
+	next := g.BaseURL + endpoint
+
+	for next != "" {
+		req, err := http.NewRequest("GET", next, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+g.Token)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Microsoft Graph request failed with status %d", resp.StatusCode)
+		}
+
+		var page struct {
+			Value    []json.RawMessage `json:"value"`
+			NextLink string            `json:"@odata.nextLink"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Graph response: %w", err)
+		}
+		values = append(values, page.Value...)
+		next = page.NextLink
+	}
+
+	return values, nil
+}
+
+// GetFlaggedMessages returns emails flagged for follow-up
+func (g *GraphClient) GetFlaggedMessages() ([]GraphMessage, error) {
+	query := url.Values{}
+	query.Set("$filter", "flag/flagStatus eq 'flagged'")
+	query.Set("$select", "id,subject,webLink,bodyPreview,from,flag")
+	query.Set("$top", "50")
+
+	values, err := g.getPages("/me/messages?" + query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flagged messages: %w", err)
+	}
+
+	var messages []GraphMessage
+	for _, value := range values {
+		var message GraphMessage
+		if err := json.Unmarshal(value, &message); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// GetTodoLists returns the user's To Do lists
+func (g *GraphClient) GetTodoLists() ([]TodoList, error) {
+	values, err := g.getPages("/me/todo/lists")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get To Do lists: %w", err)
+	}
+
+	var lists []TodoList
+	for _, value := range values {
+		var list TodoList
+		if err := json.Unmarshal(value, &list); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal To Do list: %w", err)
+		}
+		lists = append(lists, list)
+	}
+	return lists, nil
+}
+
+// GetOpenTodoTasks returns the tasks in a To Do list that aren't completed
+func (g *GraphClient) GetOpenTodoTasks(listID string) ([]TodoTask, error) {
+	query := url.Values{}
+	query.Set("$filter", "status ne 'completed'")
+
+	values, err := g.getPages(fmt.Sprintf("/me/todo/lists/%s/tasks?%s", url.PathEscape(listID), query.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get To Do tasks: %w", err)
+	}
+
+	var tasks []TodoTask
+	for _, value := range values {
+		var task TodoTask
+		if err := json.Unmarshal(value, &task); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal To Do task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// parseGraphDateTime converts a Graph date/time pair, which has no offset
+// in the string, to a time in its named zone
+func parseGraphDateTime(dt *GraphDateTime) *time.Time {
+	if dt == nil || dt.DateTime == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(dt.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	parsed, err := time.ParseInLocation("2006-01-02T15:04:05.9999999", dt.DateTime, loc)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+// FetchWorkItems collects flagged emails and open To Do tasks. sources picks
+// "flagged" and/or "todo"; todoLists limits To Do to lists with those names.
+func (g *GraphClient) FetchWorkItems(sources, todoLists []string) ([]WorkItem, error) {
+	var items []WorkItem
+
+	for _, source := range sources {
+		switch strings.ToLower(source) {
+		case "flagged":
+			messages, err := g.GetFlaggedMessages()
+			if err != nil {
+				return nil, err
+			}
+			for _, message := range messages {
+				notes := message.BodyPreview
+				if from := message.From.EmailAddress.Name; from != "" {
+					notes = fmt.Sprintf("From: %s\n\n%s", from, notes)
+				}
+				items = append(items, WorkItem{
+					Source: workItemSources["flagged"],
+					ID:     message.ID,
+					Title:  message.Subject,
+					Due:    parseGraphDateTime(message.Flag.DueDateTime),
+					URL:    message.WebLink,
+					Notes:  notes,
+				})
+			}
+
+		case "todo":
+			lists, err := g.GetTodoLists()
+			if err != nil {
+				return nil, err
+			}
+			for _, list := range lists {
+				if len(todoLists) > 0 && !containsFold(todoLists, list.DisplayName) {
+					continue
+				}
+				tasks, err := g.GetOpenTodoTasks(list.ID)
+				if err != nil {
+					return nil, err
+				}
+				for _, task := range tasks {
+					items = append(items, WorkItem{
+						Source: workItemSources["todo"],
+						ID:     task.ID,
+						Title:  task.Title,
+						Due:    parseGraphDateTime(task.DueDateTime),
+						URL:    "https://to-do.office.com/tasks/id/" + url.PathEscape(task.ID) + "/details",
+						Notes:  task.Body.Content,
+					})
+				}
+			}
+
+		default:
+			return nil, fmt.Errorf("unknown Outlook source '%s' (want flagged or todo)", source)
+		}
+	}
+
+	return items, nil
+}
+
+// SyncOutlookItems mirrors flagged emails and To Do tasks onto the Mac board
+// (or OUTLOOK_BOARD). New cards go to OUTLOOK_LIST, or the first list as with
+// JIRA. Unflagged emails and completed tasks move to Done.
+func (c *TrelloClient) SyncOutlookItems(graph *GraphClient) error {
+	boardName := os.Getenv("OUTLOOK_BOARD")
+	if boardName == "" {
+		boardName = "Mac"
+	}
+	boardName = c.Target.board(boardName)
+
+	sources := splitList(os.Getenv("OUTLOOK_SOURCES"))
+	if len(sources) == 0 {
+		sources = []string{"flagged", "todo"}
+	}
+
+	fmt.Printf("Syncing %s to %s board\n", strings.Join(sources, " and "), boardName)
+
+	items, err := graph.FetchWorkItems(sources, splitList(os.Getenv("OUTLOOK_TODO_LISTS")))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Found %d open item(s)\n", len(items))
+
+	var labels []string
+	for _, source := range sources {
+		labels = append(labels, workItemSources[strings.ToLower(source)])
+	}
+
+	return c.mirrorWorkItems(boardName, c.Target.list(os.Getenv("OUTLOOK_LIST")), items, labels)
+}
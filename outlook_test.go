@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseGraphDateTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *GraphDateTime
+		expected string
+	}{
+		{"utc", &GraphDateTime{DateTime: "2025-10-10T00:00:00.0000000", TimeZone: "UTC"}, "2025-10-10T00:00:00Z"},
+		{"named zone", &GraphDateTime{DateTime: "2025-10-10T17:30:00.0000000", TimeZone: "Pacific/Honolulu"}, "2025-10-10T17:30:00-10:00"},
+		{"unknown zone", &GraphDateTime{DateTime: "2025-10-10T08:00:00", TimeZone: "Nowhere Standard Time"}, "2025-10-10T08:00:00Z"},
+		{"nil", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseGraphDateTime(tt.input)
+			if tt.expected == "" {
+				if got != nil {
+					t.Errorf("parseGraphDateTime() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Format(time.RFC3339) != tt.expected {
+				t.Errorf("parseGraphDateTime() = %v, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFetchWorkItemsFollowsPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/me/messages" && r.URL.Query().Get("page") == "":
+			fmt.Fprintf(w, `{"value":[{"id":"m1","subject":"Review doc","from":{"emailAddress":{"name":"Sam"}}}],"@odata.nextLink":"%s/me/messages?page=2"}`, server.URL)
+		case r.URL.Path == "/me/messages":
+			fmt.Fprint(w, `{"value":[{"id":"m2","subject":"Reply to vendor","flag":{"dueDateTime":{"dateTime":"2025-10-10T00:00:00","timeZone":"UTC"}}}]}`)
+		case r.URL.Path == "/me/todo/lists":
+			fmt.Fprint(w, `{"value":[{"id":"l1","displayName":"Work"},{"id":"l2","displayName":"Groceries"}]}`)
+		case r.URL.Path == "/me/todo/lists/l1/tasks":
+			fmt.Fprint(w, `{"value":[{"id":"t1","title":"File expenses"}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	graph := &GraphClient{Token: "token", BaseURL: server.URL}
+	items, err := graph.FetchWorkItems([]string{"flagged", "todo"}, []string{"work"})
+	if err != nil {
+		t.Fatalf("FetchWorkItems() error = %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d: %+v", len(items), items)
+	}
+	if items[0].Notes != "From: Sam\n\n" || items[1].Due == nil || items[2].Source != "To Do" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
+
+func TestWorkItemCardMatching(t *testing.T) {
+	item := WorkItem{Source: "Outlook", ID: "AAMk=", Title: "Review doc", URL: "https://outlook.office.com/x"}
+	card := Card{ID: "c1", Description: formatWorkItemDescription(item)}
+
+	if found := findCardByWorkItem([]Card{card}, "Outlook", "AAMk="); found == nil {
+		t.Error("expected the card to match its email")
+	}
+	if found := findCardByWorkItem([]Card{card}, "To Do", "AAMk="); found != nil {
+		t.Error("a To Do task should not match an email card")
+	}
+	if id := workItemCardID(card, "Outlook"); id != "AAMk=" {
+		t.Errorf("workItemCardID() = %q, want AAMk=", id)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Console icons. disableEmoji swaps these for plain text in --no-emoji mode.
+var (
+	iconSuccess = "✅"
+	iconCheck   = "✓"
+)
+
+// plainOutput is set by --no-emoji
+var plainOutput = false
+
+// Local task statuses written to STATUS.md. These are data, not console
+// output, so they keep their emoji even in --no-emoji mode.
+const (
+	statusPlanned     = "🎯 PLANNED"
+	statusInProgress  = "🔄 IN PROGRESS"
+	statusInReview    = "👀 IN REVIEW"
+	statusCompleted   = "✅ COMPLETED"
+	statusOtherPrefix = "🔄 "
+)
+
+// disableEmoji switches console output to plain ASCII markers
+func disableEmoji() {
+	plainOutput = true
+	iconSuccess = "[OK]"
+	iconCheck = "+"
+}
+
+// consoleText strips emoji from text headed for the terminal in --no-emoji mode
+func consoleText(s string) string {
+	if !plainOutput {
+		return s
+	}
+	return stripEmoji(s)
+}
+
+// isEmojiRune reports whether r is a pictograph or emoji joiner/modifier
+func isEmojiRune(r rune) bool {
+	switch {
+	case r == 0x200D, r == 0xFE0F, r == 0x20E3: // ZWJ, variation selector, keycap
+		return true
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, emoticons, transport, etc.
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // misc symbols and dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // arrows and stars like ⭐
+		return true
+	}
+	return unicode.Is(unicode.So, r) && r > 0x2000 && !unicode.Is(unicode.Sm, r)
+}
+
+// stripEmoji removes emoji and tidies the whitespace they leave behind,
+// keeping each line's original indentation
+func stripEmoji(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+
+		var kept strings.Builder
+		for _, r := range line {
+			if !isEmojiRune(r) {
+				kept.WriteRune(r)
+			}
+		}
+		lines[i] = indent + strings.Join(strings.Fields(kept.String()), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cp1252Bytes maps the Windows-1252 characters in 0x80-0x9F back to their byte
+var cp1252Bytes = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// cleanText makes text safe to write: invalid UTF-8 is dropped and UTF-8
+// that was mis-decoded as Windows-1252 (e.g. "âœ…" for "✅") is repaired
+func cleanText(s string) string {
+	s = strings.ToValidUTF8(s, "")
+	if !strings.ContainsAny(s, "ÃÂâð") {
+		return s
+	}
+
+	raw := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r < 0x100:
+			raw = append(raw, byte(r))
+		case cp1252Bytes[r] != 0:
+			raw = append(raw, cp1252Bytes[r])
+		default:
+			return s // Contains real non-Latin text, so it isn't mojibake
+		}
+	}
+
+	if !utf8.Valid(raw) {
+		return s
+	}
+	return string(raw)
+}
@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+func TestCleanText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain ascii", "Fix authentication bug", "Fix authentication bug"},
+		{"already utf-8", "✅ COMPLETED", "✅ COMPLETED"},
+		{"mojibake check mark", "âœ… COMPLETED", "✅ COMPLETED"},
+		{"mojibake arrows", "ðŸ”„ IN PROGRESS", "🔄 IN PROGRESS"},
+		{"mojibake accent", "cafÃ©", "café"},
+		{"legit latin-1", "Résumé review", "Résumé review"},
+		{"invalid bytes", "bad\xffbyte", "badbyte"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := cleanText(test.input); result != test.expected {
+				t.Errorf("cleanText(%q) = %q, want %q", test.input, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestStripEmoji(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"✅ Created sundown card", "Created sundown card"},
+		{"Sundown today is at 7:35 PM 🌅", "Sundown today is at 7:35 PM"},
+		{"⚠️ Locked", "Locked"},
+		{"Week of May 1 – May 7 → done", "Week of May 1 – May 7 → done"},
+		{"  - Nothing here 🎉", "  - Nothing here"},
+	}
+
+	for _, test := range tests {
+		if result := stripEmoji(test.input); result != test.expected {
+			t.Errorf("stripEmoji(%q) = %q, want %q", test.input, result, test.expected)
+		}
+	}
+}
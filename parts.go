@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// maxParts caps how many work-on cards --split creates for one assignment
+const maxParts = 10
+
+// partOfRegex finds the parent link written into a part card's description
+var partOfRegex = regexp.MustCompile(`(?m)^Part of: \S*trello\.com/c/([A-Za-z0-9]+)`)
+
+// cardLink returns a card's short URL, falling back to its full URL
+func cardLink(card Card) string {
+	if card.ShortURL != "" {
+		return card.ShortURL
+	}
+	return card.URL
+}
+
+// partCardName names the i-th of n work-on cards for an assignment
+func partCardName(parentName string, i, n int) string {
+	return fmt.Sprintf("Work on: %s (part %d/%d)", parentName, i, n)
+}
+
+// partDueDates spreads n checkpoint due dates evenly between now and the
+// assignment's due date, with the last part due with the assignment.
+// Parts get no due date when the assignment has none or is already due.
+func partDueDates(due *time.Time, n int, now time.Time) []string {
+	dates := make([]string, n)
+	if due == nil || !due.After(now) {
+		return dates
+	}
+
+	step := due.Sub(now) / time.Duration(n)
+	for i := range dates {
+		checkpoint := now.Add(step * time.Duration(i+1))
+		if i == n-1 {
+			checkpoint = *due
+		}
+		dates[i] = checkpoint.UTC().Format(trelloDueLayout)
+	}
+	return dates
+}
+
+// partsProgress is the parent card's comment summarizing its parts
+func partsProgress(done, total int) string {
+	text := fmt.Sprintf("🧩 %d/%d parts done", done, total)
+	if done == total {
+		text += " – all parts finished ✅"
+	}
+	return text
+}
+
+// groupParts maps each parent card's short link to its part cards
+func groupParts(cards []Card) map[string][]Card {
+	parts := make(map[string][]Card)
+	for _, card := range cards {
+		if match := partOfRegex.FindStringSubmatch(card.Description); match != nil {
+			parts[match[1]] = append(parts[match[1]], card)
+		}
+	}
+	return parts
+}
+
+// countDone counts parts that are marked complete
+func countDone(parts []Card) int {
+	done := 0
+	for _, part := range parts {
+		if part.DueComplete {
+			done++
+		}
+	}
+	return done
+}
+
+// SplitCard creates n work-on cards for a large assignment, with checkpoint
+// due dates leading up to its due date. Parts and parent are linked to each
+// other by attachments, and the parent gets a progress comment.
+func (c *TrelloClient) SplitCard(query string, n int) error {
+	if n < 2 || n > maxParts {
+		return fmt.Errorf("number of parts must be between 2 and %d", maxParts)
+	}
+
+	parent, err := c.findCardByQuery(query)
+	if err != nil {
+		return err
+	}
+	if skipNoAuto(parent) {
+		return nil
+	}
+
+	parentLink := cardLink(*parent)
+	dueDates := partDueDates(parent.Due, n, time.Now())
+	for i := 1; i <= n; i++ {
+		name := partCardName(parent.Name, i, n)
+		part, err := c.CreateCard(parent.IDList, name, "Part of: "+parentLink, dueDates[i-1])
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", name, err)
+		}
+		fmt.Printf("Created: %s\n", name)
+
+		if err := c.EnsureLinkAttachment(part.ID, "Parent: "+parent.Name, parentLink); err != nil {
+			fmt.Printf("Warning: failed to link %s to its assignment: %v\n", name, err)
+		}
+		if err := c.EnsureLinkAttachment(parent.ID, fmt.Sprintf("Part %d/%d", i, n), cardLink(*part)); err != nil {
+			fmt.Printf("Warning: failed to link %s from its assignment: %v\n", name, err)
+		}
+	}
+
+	if err := c.UpsertComment(parent.ID, "parts", partsProgress(0, n)); err != nil {
+		fmt.Printf("Warning: failed to comment on %s: %v\n", parent.Name, err)
+	}
+
+	fmt.Printf("%s Split %s into %d parts\n", iconSuccess, parent.Name, n)
+	return nil
+}
+
+// UpdatePartProgress refreshes the "parts done" comment on every assignment
+// on a board that was split with --split
+func (c *TrelloClient) UpdatePartProgress(boardName string) error {
+	cards, err := c.GetAllBoardCards(boardName)
+	if err != nil {
+		return fmt.Errorf("failed to get board cards: %w", err)
+	}
+
+	groups := groupParts(cards)
+	updated := 0
+	for i, card := range cards {
+		match := cardShortLinkRegex.FindStringSubmatch(card.ShortURL)
+		if match == nil {
+			continue
+		}
+		parts, ok := groups[match[1]]
+		if !ok || skipNoAuto(&cards[i]) {
+			continue
+		}
+
+		if err := c.UpsertComment(card.ID, "parts", partsProgress(countDone(parts), len(parts))); err != nil {
+			fmt.Printf("Warning: failed to update part progress on %s: %v\n", card.Name, err)
+			continue
+		}
+		updated++
+	}
+
+	fmt.Printf("%s Updated part progress on %d assignment(s) in %s\n", iconSuccess, updated, boardName)
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPartDueDates(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	due := now.AddDate(0, 0, 9)
+
+	got := partDueDates(&due, 3, now)
+	want := []string{"2025-10-04T12:00:00.000Z", "2025-10-07T12:00:00.000Z", "2025-10-10T12:00:00.000Z"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("partDueDates()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	past := now.Add(-time.Hour)
+	for _, dates := range [][]string{partDueDates(nil, 2, now), partDueDates(&past, 2, now)} {
+		if dates[0] != "" || dates[1] != "" {
+			t.Errorf("parts without a future due date should be undated, got %q", dates)
+		}
+	}
+}
+
+func TestGroupParts(t *testing.T) {
+	cards := []Card{
+		{ID: "p", Name: "Science Fair Project", ShortURL: "https://trello.com/c/Par3nt"},
+		{ID: "1", Name: partCardName("Science Fair Project", 1, 2), Description: "Part of: https://trello.com/c/Par3nt", DueComplete: true},
+		{ID: "2", Name: partCardName("Science Fair Project", 2, 2), Description: "Part of: https://trello.com/c/Par3nt"},
+		{ID: "3", Name: "Essay", Description: "Notes mention Part of: nothing"},
+	}
+
+	groups := groupParts(cards)
+	if len(groups) != 1 || len(groups["Par3nt"]) != 2 {
+		t.Fatalf("groupParts() = %+v, want two parts of Par3nt", groups)
+	}
+	if got := partsProgress(countDone(groups["Par3nt"]), 2); got != "🧩 1/2 parts done" {
+		t.Errorf("progress = %q", got)
+	}
+	if got := partsProgress(2, 2); got != "🧩 2/2 parts done – all parts finished ✅" {
+		t.Errorf("finished progress = %q", got)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// pastDueSince is the earliest due date an LMS sync includes. Syncs keep
+// work due in the last day; --include-past-due reaches back that many days.
+func pastDueSince(now time.Time, days int) time.Time {
+	if days > 1 {
+		return now.AddDate(0, 0, -days)
+	}
+	return now.Add(-24 * time.Hour)
+}
+
+// isPastDueExtra reports whether work is only in the sync because of
+// --include-past-due, being due more than a day ago
+func isPastDueExtra(due, now time.Time) bool {
+	return due.Before(now.Add(-24 * time.Hour))
+}
+
+// pastDueNeedsCard reports whether older past-due work still needs a card:
+// a grade under 90% needs a redo, and ungraded work needs turning in
+func pastDueNeedsCard(submitted bool, score *float64) bool {
+	if score != nil {
+		return *score < 90
+	}
+	return !submitted
+}
+
+// Submitted reports whether the work was turned in, graded or not
+func (s *CanvasSubmission) Submitted() bool {
+	return s.WorkflowState == "submitted" || s.WorkflowState == "pending_review" || s.WorkflowState == "graded"
+}
+
+// includePastDueDays reads INCLUDE_PAST_DUE_DAYS, the --include-past-due
+// setting for scheduled syncs; 0 keeps only work due in the last day
+func includePastDueDays() int {
+	value := os.Getenv("INCLUDE_PAST_DUE_DAYS")
+	if value == "" {
+		return 0
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		fmt.Printf("Warning: invalid INCLUDE_PAST_DUE_DAYS '%s' (want a number of days), using 0\n", value)
+		return 0
+	}
+	return days
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPastDueSince(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		days int
+		want time.Time
+	}{
+		{0, now.Add(-24 * time.Hour)},
+		{1, now.Add(-24 * time.Hour)},
+		{14, now.AddDate(0, 0, -14)},
+	}
+
+	for _, tt := range tests {
+		if got := pastDueSince(now, tt.days); !got.Equal(tt.want) {
+			t.Errorf("pastDueSince(%d) = %v, want %v", tt.days, got, tt.want)
+		}
+	}
+}
+
+func TestPastDueNeedsCard(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		due       time.Time
+		submitted bool
+		score     *float64
+		extra     bool
+		want      bool
+	}{
+		{"missing", now.AddDate(0, 0, -5), false, nil, true, true},
+		{"turned in, ungraded", now.AddDate(0, 0, -5), true, nil, true, false},
+		{"failing grade", now.AddDate(0, 0, -5), true, score(72), true, true},
+		{"passing grade", now.AddDate(0, 0, -5), true, score(95), true, false},
+		{"scored zero without submitting", now.AddDate(0, 0, -5), false, score(0), true, true},
+		{"due this morning", now.Add(-3 * time.Hour), false, nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPastDueExtra(tt.due, now); got != tt.extra {
+				t.Errorf("isPastDueExtra() = %v, want %v", got, tt.extra)
+			}
+			if got := pastDueNeedsCard(tt.submitted, tt.score); got != tt.want {
+				t.Errorf("pastDueNeedsCard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultJiraTasksDir returns JIRA_TASKS_DIR when set, otherwise the
+// mac-tasks open-tasks folder under the user's home directory
+func defaultJiraTasksDir() string {
+	if dir := os.Getenv("JIRA_TASKS_DIR"); dir != "" {
+		return expandHome(dir)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join("mac-tasks", "open-tasks")
+	}
+	return filepath.Join(home, "Workspaces", "Alkira", "mac-tasks", "open-tasks")
+}
+
+// resolveJiraTasksDir returns the --jira-tasks-dir value, or the default when unset
+func resolveJiraTasksDir(flagValue string) string {
+	if flagValue == "" {
+		return defaultJiraTasksDir()
+	}
+	return expandHome(flagValue)
+}
+
+// expandHome replaces a leading ~ with the user's home directory and
+// converts slashes to the OS separator
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	return filepath.Clean(filepath.FromSlash(path))
+}
+
+// envFileCandidates lists where .env may live: the working directory first,
+// then next to the executable (for schedulers that start elsewhere), then
+// the config directory written by --init
+func envFileCandidates() []string {
+	candidates := []string{".env"}
+	if exe, err := os.Executable(); err == nil {
+		candidates = append(candidates, filepath.Join(filepath.Dir(exe), ".env"))
+	}
+	if dir, err := configDir(); err == nil {
+		candidates = append(candidates, filepath.Join(dir, ".env"))
+	}
+	return candidates
+}
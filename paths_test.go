@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultJiraTasksDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	t.Setenv("JIRA_TASKS_DIR", "")
+	expected := filepath.Join(home, "Workspaces", "Alkira", "mac-tasks", "open-tasks")
+	if dir := defaultJiraTasksDir(); dir != expected {
+		t.Errorf("defaultJiraTasksDir() = %q, want %q", dir, expected)
+	}
+
+	t.Setenv("JIRA_TASKS_DIR", "~/tasks/open")
+	expected = filepath.Join(home, "tasks", "open")
+	if dir := defaultJiraTasksDir(); dir != expected {
+		t.Errorf("defaultJiraTasksDir() with env = %q, want %q", dir, expected)
+	}
+}
+
+func TestParseJiraTaskCRLF(t *testing.T) {
+	dir := t.TempDir()
+	taskDir := filepath.Join(dir, "AK-100")
+	if err := os.MkdirAll(taskDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	status := "# AK-100\r\n\r\n## Current Status: 🔄 IN PROGRESS\r\n\r\n## Next Steps:\r\n- Write tests\r\n"
+	if err := os.WriteFile(filepath.Join(taskDir, "STATUS.md"), []byte(status), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(taskDir, "AK-100.md"), []byte("# Fix login\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &TrelloClient{}
+	tasks, _, err := client.parseJiraTasks(dir)
+	if err != nil {
+		t.Fatalf("parseJiraTasks() error = %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+	if tasks[0].Title != "Fix login" {
+		t.Errorf("Title = %q, want %q", tasks[0].Title, "Fix login")
+	}
+	if tasks[0].Status != statusInProgress {
+		t.Errorf("Status = %q, want %q", tasks[0].Status, statusInProgress)
+	}
+
+	if err := client.updateLocalTaskStatus(dir, "AK-100", statusInReview); err != nil {
+		t.Fatalf("updateLocalTaskStatus() error = %v", err)
+	}
+	updated, err := os.ReadFile(filepath.Join(taskDir, "STATUS.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# AK-100\r\n\r\n## Current Status: 👀 IN REVIEW\r\n\r\n## Next Steps:\r\n- Write tests\r\n"
+	if string(updated) != want {
+		t.Errorf("updated STATUS.md = %q, want %q", updated, want)
+	}
+}
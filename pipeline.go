@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// pipelinesFile holds named pipelines, in the working or config directory
+const pipelinesFile = "pipelines.json"
+
+// What a pipeline does when a step fails
+const (
+	onErrorContinue = "continue"
+	onErrorStop     = "stop"
+)
+
+// PipelineStep is one job in a pipeline, with its options
+type PipelineStep struct {
+	Job     string `json:"job"`
+	OnError string `json:"onError,omitempty"` // continue (the default) or stop
+	JobOptions
+}
+
+// Pipeline is an ordered list of jobs run by `run <name>`
+type Pipeline struct {
+	Steps []PipelineStep `json:"steps"`
+}
+
+// LoadPipelines reads pipelines.json from the working or config directory
+func LoadPipelines() (map[string]Pipeline, error) {
+	data, err := os.ReadFile(findConfigFile(pipelinesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no %s found in the working or config directory", pipelinesFile)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", pipelinesFile, err)
+	}
+	return parsePipelines(data)
+}
+
+// parsePipelines decodes and checks pipelines, so a bad step fails before
+// anything runs
+func parsePipelines(data []byte) (map[string]Pipeline, error) {
+	var pipelines map[string]Pipeline
+	if err := json.Unmarshal(data, &pipelines); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pipelines: %w", err)
+	}
+
+	for name, pipeline := range pipelines {
+		if len(pipeline.Steps) == 0 {
+			return nil, fmt.Errorf("%s: pipeline %s has no steps", pipelinesFile, name)
+		}
+		for i, step := range pipeline.Steps {
+			switch step.OnError {
+			case "", onErrorContinue, onErrorStop:
+			default:
+				return nil, fmt.Errorf("%s: %s step %d has invalid onError '%s' (want continue or stop)", pipelinesFile, name, i+1, step.OnError)
+			}
+			if step.To != "" {
+				if _, err := time.Parse("2006-01-02", step.To); err != nil {
+					return nil, fmt.Errorf("%s: %s step %d has invalid to date '%s' (want YYYY-MM-DD)", pipelinesFile, name, i+1, step.To)
+				}
+			}
+		}
+	}
+	return pipelines, nil
+}
+
+// pipelineNames lists the pipelines for error messages
+func pipelineNames(pipelines map[string]Pipeline) string {
+	var names []string
+	for name := range pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// RunPipeline runs a pipeline's steps in order like --run. A failing step
+// with onError "stop" skips the rest.
+func (c *TrelloClient) RunPipeline(name string, pipelines map[string]Pipeline, jiraTasksDir string) (RunSummary, error) {
+	pipeline, ok := pipelines[name]
+	if !ok {
+		return RunSummary{}, fmt.Errorf("unknown pipeline '%s' (%s has %s)", name, pipelinesFile, pipelineNames(pipelines))
+	}
+
+	// Resolve every step up front so a typo fails before anything runs
+	var jobs []func() error
+	for i, step := range pipeline.Steps {
+		job, err := c.scheduledJob(step.Job, jiraTasksDir, step.JobOptions)
+		if err != nil {
+			return RunSummary{}, fmt.Errorf("%s step %d: %w", name, i+1, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	fmt.Printf("Running pipeline %s (%s)\n", name, plural(len(jobs), "step"))
+	summary := RunSummary{Started: time.Now()}
+	for i, job := range jobs {
+		step := pipeline.Steps[i]
+		if shuttingDown() {
+			fmt.Printf("Shutting down; skipping the rest of %s\n", name)
+			break
+		}
+		result := c.runJob(step.Job, job)
+		summary.Jobs = append(summary.Jobs, result)
+		if result.Err != nil && step.OnError == onErrorStop {
+			var skipped []string
+			for _, rest := range pipeline.Steps[i+1:] {
+				skipped = append(skipped, rest.Job)
+			}
+			if len(skipped) > 0 {
+				fmt.Printf("Stopping %s after %s failed; skipping %s\n", name, step.Job, strings.Join(skipped, ", "))
+			}
+			break
+		}
+	}
+	summary.Finished = time.Now()
+
+	return summary, nil
+}
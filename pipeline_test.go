@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePipelines(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"valid", `{"nightly": {"steps": [{"job": "refresh", "onError": "stop"}, {"job": "sync-canvas", "to": "2026-12-19"}]}}`, ""},
+		{"no steps", `{"nightly": {"steps": []}}`, "has no steps"},
+		{"bad onError", `{"nightly": {"steps": [{"job": "refresh", "onError": "retry"}]}}`, "invalid onError"},
+		{"bad date", `{"nightly": {"steps": [{"job": "sync-moodle", "to": "12/19"}]}}`, "invalid to date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelines, err := parsePipelines([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				step := pipelines["nightly"].Steps[1]
+				if step.Job != "sync-canvas" || step.To != "2026-12-19" {
+					t.Errorf("step = %+v", step)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parsePipelines() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunPipelineRejectsBadSteps(t *testing.T) {
+	client := &TrelloClient{}
+	pipelines := map[string]Pipeline{"nightly": {Steps: []PipelineStep{{Job: "refresh"}, {Job: "sync-cavnas"}}}}
+
+	if _, err := client.RunPipeline("weekly", pipelines, ""); err == nil || !strings.Contains(err.Error(), "nightly") {
+		t.Errorf("unknown pipeline error = %v, want it to list nightly", err)
+	}
+	if _, err := client.RunPipeline("nightly", pipelines, ""); err == nil || !strings.Contains(err.Error(), "step 2") {
+		t.Errorf("bad step error = %v, want step 2", err)
+	}
+}
+
+func TestRunPipelineStopsOnError(t *testing.T) {
+	client := &TrelloClient{}
+	// A missing SHEET_SOURCE makes sync-sheet fail without any API calls
+	t.Setenv("SHEET_SOURCE", "")
+	pipelines := map[string]Pipeline{
+		"continue": {Steps: []PipelineStep{{Job: "sync-sheet"}, {Job: "sync-sheet"}}},
+		"stop":     {Steps: []PipelineStep{{Job: "sync-sheet", OnError: onErrorStop}, {Job: "sync-sheet"}}},
+	}
+
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"continue", 2},
+		{"stop", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, err := client.RunPipeline(tt.name, pipelines, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(summary.Jobs) != tt.want || summary.Failed() != tt.want {
+				t.Errorf("ran %d job(s) with %d failed, want %d", len(summary.Jobs), summary.Failed(), tt.want)
+			}
+		})
+	}
+}
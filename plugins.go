@@ -0,0 +1,402 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// pluginProtocolVersion is sent to plugins so they can reject requests they
+// don't understand
+const pluginProtocolVersion = 1
+
+// defaultPluginTimeout bounds a plugin run when its config sets no timeout
+const defaultPluginTimeout = 60 * time.Second
+
+// PluginConfig describes one external sync source in plugins.json
+type PluginConfig struct {
+	Name    string            `json:"name"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env"`
+	Board   string            `json:"board"`   // defaults to Makai School
+	List    string            `json:"list"`    // defaults to Weekly
+	Timeout int               `json:"timeout"` // seconds
+}
+
+// PluginsConfig is the contents of plugins.json
+type PluginsConfig struct {
+	Plugins []PluginConfig `json:"plugins"`
+}
+
+// PluginRequest is written to a plugin's stdin
+type PluginRequest struct {
+	Protocol int    `json:"protocol"`
+	Name     string `json:"name"`
+	Until    string `json:"until"` // YYYY-MM-DD; plugins may ignore it
+}
+
+// PluginItem is one assignment reported by a plugin
+type PluginItem struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Course      string   `json:"course"`
+	Due         string   `json:"due"` // RFC 3339, or empty
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Submitted   bool     `json:"submitted"`
+	Score       *float64 `json:"score"`
+	MaxScore    float64  `json:"maxScore"`
+	Grade       string   `json:"grade"` // e.g. "B+", "4/5" or "87%", when there's no score
+}
+
+// PluginResponse is what a plugin prints to stdout
+type PluginResponse struct {
+	Items []PluginItem `json:"items"`
+}
+
+// percent returns the item's grade as a percentage, if it has one
+func (item PluginItem) percent() (float64, bool) {
+	if item.Score == nil || item.MaxScore <= 0 {
+		return activeGradeScale().Percent(item.Course, item.Grade, item.MaxScore)
+	}
+	return *item.Score / item.MaxScore * 100, true
+}
+
+// LoadPluginsConfig reads plugins.json from the working or config directory
+func LoadPluginsConfig() (*PluginsConfig, error) {
+	data, err := os.ReadFile(findConfigFile("plugins.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins.json: %w", err)
+	}
+
+	var config PluginsConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plugins config: %w", err)
+	}
+
+	for i, plugin := range config.Plugins {
+		if plugin.Name == "" || plugin.Command == "" {
+			return nil, fmt.Errorf("plugin %d in plugins.json needs a name and a command", i+1)
+		}
+	}
+
+	return &config, nil
+}
+
+// Find returns the plugin with the given name
+func (c *PluginsConfig) Find(name string) (*PluginConfig, error) {
+	for i, plugin := range c.Plugins {
+		if strings.EqualFold(plugin.Name, name) {
+			return &c.Plugins[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no plugin named '%s' in plugins.json", name)
+}
+
+// parsePluginResponse decodes and validates a plugin's output
+func parsePluginResponse(output []byte) ([]PluginItem, error) {
+	var response PluginResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plugin output: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, item := range response.Items {
+		if item.ID == "" || item.Title == "" {
+			return nil, fmt.Errorf("every plugin item needs an id and a title")
+		}
+		if seen[item.ID] {
+			return nil, fmt.Errorf("plugin returned id '%s' more than once", item.ID)
+		}
+		seen[item.ID] = true
+		if item.Due != "" {
+			if _, err := time.Parse(time.RFC3339, item.Due); err != nil {
+				return nil, fmt.Errorf("invalid due date for '%s' (want RFC 3339): %w", item.Title, err)
+			}
+		}
+	}
+
+	return response.Items, nil
+}
+
+// RunPlugin executes a plugin and returns the items it reports. The request
+// goes to stdin, the response comes from stdout, and stderr is passed through.
+func RunPlugin(plugin PluginConfig, until time.Time) ([]PluginItem, error) {
+	timeout := defaultPluginTimeout
+	if plugin.Timeout > 0 {
+		timeout = time.Duration(plugin.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	request, err := json.Marshal(PluginRequest{Protocol: pluginProtocolVersion, Name: plugin.Name, Until: until.Format("2006-01-02")})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin request: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, plugin.Command, plugin.Args...)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	for key, value := range plugin.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	output, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("plugin %s timed out after %s", plugin.Name, timeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to run plugin %s: %w", plugin.Name, err)
+	}
+
+	return parsePluginResponse(output)
+}
+
+// pluginIDPattern is the metadata line that ties a card to a plugin item
+func pluginIDPattern(pluginName, id string) string {
+	return fmt.Sprintf("%s Item ID: %s\n", pluginName, id)
+}
+
+// formatPluginMetadata builds the metadata block appended to plugin cards
+func formatPluginMetadata(pluginName string, item PluginItem) string {
+	gradeStr := "Not graded"
+	if percent, ok := item.percent(); ok {
+		gradeStr = fmt.Sprintf("%.1f%%", percent)
+		if percent < passingGrade {
+			gradeStr += " (REDO NEEDED)"
+		}
+	}
+
+	return friendlyDueLines(item.Due, "", displayLocation()) + fmt.Sprintf("\n\n---\n%sCourse: %s\nOriginal Due Date: %s\nGrade: %s\n%s",
+		pluginIDPattern(pluginName, item.ID), item.Course, item.Due, gradeStr, sourceURLLine(pluginName, item.URL))
+}
+
+// findCardByPluginID finds the card synced from a plugin item
+func findCardByPluginID(cards []Card, pluginName, id string) *Card {
+	pattern := pluginIDPattern(pluginName, id)
+	for i, card := range cards {
+		if strings.Contains(card.Description, pattern) {
+			return &cards[i]
+		}
+	}
+	return nil
+}
+
+// pluginCardTitle builds a card title the same way the LMS syncs do
+func pluginCardTitle(item PluginItem, needsRedo bool) string {
+	title := item.Title
+	if item.Course != "" {
+		title = fmt.Sprintf("%s - %s", item.Course, item.Title)
+	}
+	if needsRedo {
+		title = "REDO - " + title
+	}
+	return title
+}
+
+// SyncPlugin runs one plugin and syncs the items it reports
+func (c *TrelloClient) SyncPlugin(plugin PluginConfig, until time.Time, dryRun bool) error {
+	fmt.Printf("Starting %s plugin sync...\n", plugin.Name)
+
+	items, err := RunPlugin(plugin, until)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Plugin %s reported %d item(s)\n", plugin.Name, len(items))
+
+	return c.syncSourceItems(plugin.Name, plugin.Board, plugin.List, items, dryRun)
+}
+
+// syncSourceItems creates or updates a card for each item from a plugin or
+// other generic source, with the same REDO, routing, and due-move handling
+// as the LMS syncs. Board and list default to Makai School and Weekly, and
+// --target-board and --target-list override them.
+func (c *TrelloClient) syncSourceItems(source, boardName, listName string, items []PluginItem, dryRun bool) error {
+	boardName, listName = c.Target.board(boardName), c.Target.list(listName)
+	if boardName == "" {
+		boardName = "Makai School"
+	}
+	if listName == "" {
+		listName = "Weekly"
+	}
+
+	allCards, err := c.GetAllBoardCards(boardName)
+	if err != nil {
+		return fmt.Errorf("failed to get Trello cards: %w", err)
+	}
+
+	var listID string
+	if !dryRun {
+		listID, err = c.FindListByName(boardName, listName)
+		if err != nil {
+			return fmt.Errorf("failed to find %s list: %w", listName, err)
+		}
+	}
+	placement := c.newCardPlacement(boardName, listID)
+	passing := c.boardSettings(boardName).passing()
+
+	var progress *syncProgress
+	var stats *SyncStats
+	if !dryRun {
+		progress = resumeSync(syncCursorFile, source, time.Now())
+		stats = newSyncStats(source)
+	}
+
+	for _, item := range items {
+		if done, err := progress.Step(item.ID); err != nil {
+			return err
+		} else if done {
+			continue
+		}
+		stats.Evaluate()
+
+		existing := findCardByPluginID(allCards, source, item.ID)
+		if skipNoAuto(existing) {
+			continue
+		}
+		percent, graded := item.percent()
+		state := AssignmentState{Submitted: item.Submitted, Graded: graded, Percent: percent, NeedsRedo: graded && percent < passing, Passing: passing}
+
+		// Passing work needs no card, but an existing one moves to Done
+		if graded && percent >= passing {
+			fmt.Printf("Skipping item with passing grade: %s (%.1f%%)\n", item.Title, percent)
+			if existing != nil && !dryRun {
+				c.routeCard(existing, boardName, state)
+			}
+			continue
+		}
+
+		cardTitle := pluginCardTitle(item, state.NeedsRedo)
+		body := strings.TrimSpace(item.Description)
+		metadata := formatPluginMetadata(source, item) + descriptionHashLine(body)
+		fullDescription, truncated := fitCardDescription(body, metadata, item.URL)
+		if truncated {
+			fmt.Printf("Note: truncated long description for %s\n", cardTitle)
+		}
+
+		var dueDate string
+		if due, err := time.Parse(time.RFC3339, item.Due); err == nil {
+			dueDate = due.UTC().Format(trelloDueLayout)
+		}
+
+		if dryRun {
+			verb := "create"
+			if existing != nil {
+				verb = "update"
+			}
+			fmt.Printf("[DRY RUN] Would %s card: %s (due %s)\n", verb, cardTitle, dueDate)
+			continue
+		}
+
+		if existing != nil {
+			fmt.Printf("Updating existing %s card: %s\n", source, cardTitle)
+			if truncated {
+				fullDescription = c.linkDescriptionOverflow(existing, body, metadata, fullDescription)
+			}
+			dueDate = c.preserveSnooze(existing, dueDate)
+			c.noteDueDateMove(existing, dueDate, source)
+			c.noteDescriptionChange(existing, body, source)
+
+			patch := CardPatch{Due: &dueDate}
+			if existing.Name != cardTitle {
+				patch.Name = &cardTitle
+			}
+			if existing.Description != fullDescription {
+				patch.Desc = &fullDescription
+			}
+			_, _, moved := dueDateMoved(existing, dueDate)
+			err := c.UpdateCardFields(existing.ID, patch)
+			if err != nil {
+				fmt.Printf("Warning: failed to update card %s: %v\n", cardTitle, err)
+			}
+			stats.Existing(moved || patch.Name != nil || patch.Desc != nil, err != nil)
+
+			if item.URL != "" {
+				if err := c.EnsureLinkAttachment(existing.ID, source, item.URL); err != nil {
+					fmt.Printf("Warning: failed to attach %s link to %s: %v\n", source, cardTitle, err)
+				}
+			}
+
+			c.routeCard(existing, boardName, state)
+			continue
+		}
+
+		if placement.Rejected(cardTitle, fullDescription) {
+			continue
+		}
+		fmt.Printf("Creating new %s card: %s\n", source, cardTitle)
+		newCard, err := c.CreateCard(placement.ListFor(cardTitle, dueDate), cardTitle, fullDescription, dueDate)
+		if err != nil {
+			fmt.Printf("Warning: failed to create card %s: %v\n", cardTitle, err)
+			stats.Fail()
+			continue
+		}
+		stats.Create()
+		if truncated {
+			c.linkNewCardOverflow(newCard, body, metadata, fullDescription)
+		}
+		if item.URL != "" {
+			if err := c.EnsureLinkAttachment(newCard.ID, source, item.URL); err != nil {
+				fmt.Printf("Warning: failed to attach %s link to %s: %v\n", source, cardTitle, err)
+			}
+		}
+	}
+
+	progress.Finish()
+	stats.Save(syncStatsFile)
+	fmt.Printf("%s sync completed successfully!\n", source)
+
+	if !dryRun {
+		fmt.Println("Sorting cards...")
+		if err := c.SortListCards(listID); err != nil {
+			fmt.Printf("Warning: failed to sort cards: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// SyncPlugins runs the named plugins from plugins.json, or all of them for
+// "all", continuing past failures
+func (c *TrelloClient) SyncPlugins(names []string, until time.Time, dryRun bool) error {
+	config, err := LoadPluginsConfig()
+	if err != nil {
+		return err
+	}
+
+	var plugins []PluginConfig
+	if len(names) == 1 && names[0] == "all" {
+		plugins = config.Plugins
+	} else {
+		for _, name := range names {
+			plugin, err := config.Find(name)
+			if err != nil {
+				return err
+			}
+			plugins = append(plugins, *plugin)
+		}
+	}
+
+	failed := 0
+	for _, plugin := range plugins {
+		if err := c.SyncPlugin(plugin, until, dryRun); errors.Is(err, errSyncInterrupted) {
+			return err
+		} else if err != nil {
+			fmt.Printf("Warning: %s plugin sync failed: %v\n", plugin.Name, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d plugin(s) failed", failed, len(plugins))
+	}
+
+	return nil
+}
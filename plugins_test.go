@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePluginResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		items   int
+		wantErr bool
+	}{
+		{"valid", `{"items":[{"id":"1","title":"Worksheet","due":"2025-10-10T23:59:00-10:00"},{"id":"2","title":"Reading"}]}`, 2, false},
+		{"empty", `{"items":[]}`, 0, false},
+		{"missing id", `{"items":[{"title":"Worksheet"}]}`, 0, true},
+		{"duplicate id", `{"items":[{"id":"1","title":"A"},{"id":"1","title":"B"}]}`, 0, true},
+		{"bad due date", `{"items":[{"id":"1","title":"A","due":"Friday"}]}`, 0, true},
+		{"not json", `oops`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := parsePluginResponse([]byte(tt.output))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePluginResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(items) != tt.items {
+				t.Errorf("parsePluginResponse() returned %d items, want %d", len(items), tt.items)
+			}
+		})
+	}
+}
+
+func TestRunPlugin(t *testing.T) {
+	// The plugin echoes the name it was sent back as an item title
+	plugin := PluginConfig{
+		Name:    "Tutor",
+		Command: "sh",
+		Args:    []string{"-c", `read req; echo "{\"items\":[{\"id\":\"1\",\"title\":\"$GREETING\"}]}"; echo "$req" >&2`},
+		Env:     map[string]string{"GREETING": "Hello"},
+	}
+
+	items, err := RunPlugin(plugin, time.Now())
+	if err != nil {
+		t.Fatalf("RunPlugin() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Title != "Hello" {
+		t.Errorf("RunPlugin() = %+v, want one item titled Hello", items)
+	}
+
+	plugin.Args = []string{"-c", "exit 3"}
+	if _, err := RunPlugin(plugin, time.Now()); err == nil {
+		t.Error("expected an error when the plugin exits non-zero")
+	}
+}
+
+func TestFindCardByPluginID(t *testing.T) {
+	item := PluginItem{ID: "row-1", Title: "Worksheet", Course: "Math"}
+	cards := []Card{
+		{ID: "a", Description: "Body" + formatPluginMetadata("Tutor", PluginItem{ID: "row-10", Title: "Other"})},
+		{ID: "b", Description: "Body" + formatPluginMetadata("Tutor", item)},
+	}
+
+	if card := findCardByPluginID(cards, "Tutor", "row-1"); card == nil || card.ID != "b" {
+		t.Errorf("findCardByPluginID() = %v, want card b", card)
+	}
+	if card := findCardByPluginID(cards, "Sheets", "row-1"); card != nil {
+		t.Error("IDs from another plugin should not match")
+	}
+}
+
+func TestFormatPluginMetadataGrade(t *testing.T) {
+	score := 7.0
+	meta := formatPluginMetadata("Tutor", PluginItem{ID: "1", Score: &score, MaxScore: 10})
+	if !strings.Contains(meta, "Grade: 70.0% (REDO NEEDED)") {
+		t.Errorf("formatPluginMetadata() = %q, want a REDO grade", meta)
+	}
+	if title := pluginCardTitle(PluginItem{Title: "Worksheet", Course: "Math"}, true); title != "REDO - Math - Worksheet" {
+		t.Errorf("pluginCardTitle() = %q", title)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How cards are ordered in lists and the --today agenda, set with CARD_SORT
+const (
+	sortByPriority = "priority"
+	sortByDue      = "due"
+)
+
+// PriorityInput is what a card's priority score is built from
+type PriorityInput struct {
+	Due         *time.Time
+	Points      float64  // 0 when unknown
+	CourseScore *float64 // current course percentage, nil when unknown
+	Redo        bool
+}
+
+// priorityScore rates how urgently work needs doing, from 0 to 100:
+//   - up to 40 for due proximity (overdue is 40, dropping 5 a day; undated is 10)
+//   - up to 20 for points, full marks at 100 points or more
+//   - up to 20 for a weak course grade, full marks at 70% or below
+//   - 20 for a REDO
+func priorityScore(in PriorityInput, now time.Time) int {
+	score := 10.0
+	if in.Due != nil {
+		days := in.Due.Sub(now).Hours() / 24
+		score = math.Max(0, 40-5*math.Max(0, days))
+	}
+	score += math.Min(in.Points, 100) / 100 * 20
+	if in.CourseScore != nil {
+		score += math.Max(0, math.Min(20, (100-*in.CourseScore)/30*20))
+	}
+	if in.Redo {
+		score += 20
+	}
+	return int(math.Round(math.Min(score, 100)))
+}
+
+var (
+	pointsLineRegex      = regexp.MustCompile(`(?m)^Points: ([0-9.]+)`)
+	courseScoreLineRegex = regexp.MustCompile(`(?m)^Course Score: ([0-9.]+)%`)
+	priorityLineRegex    = regexp.MustCompile(`(?m)^Priority: (\d+)/100`)
+)
+
+// priorityMetadata is the metadata block's priority badge, with the points
+// and course score it came from so it can be recomputed as the due date nears
+func priorityMetadata(in PriorityInput, now time.Time) string {
+	var lines strings.Builder
+	if in.Points > 0 {
+		lines.WriteString(fmt.Sprintf("\nPoints: %g", in.Points))
+	}
+	if in.CourseScore != nil {
+		lines.WriteString(fmt.Sprintf("\nCourse Score: %.1f%%", *in.CourseScore))
+	}
+	lines.WriteString(fmt.Sprintf("\nPriority: %d/100", priorityScore(in, now)))
+	return lines.String()
+}
+
+// storedPriority returns the priority badge in a card description, or -1
+func storedPriority(desc string) int {
+	if match := priorityLineRegex.FindStringSubmatch(desc); len(match) > 1 {
+		if priority, err := strconv.Atoi(match[1]); err == nil {
+			return priority
+		}
+	}
+	return -1
+}
+
+// priorityInputFromCard rebuilds a card's priority input from its due date,
+// title, and metadata
+func priorityInputFromCard(card Card) PriorityInput {
+	in := PriorityInput{Due: card.Due, Redo: strings.HasPrefix(card.Name, "REDO - ")}
+	if match := pointsLineRegex.FindStringSubmatch(card.Description); len(match) > 1 {
+		in.Points, _ = strconv.ParseFloat(match[1], 64)
+	}
+	if match := courseScoreLineRegex.FindStringSubmatch(card.Description); len(match) > 1 {
+		if score, err := strconv.ParseFloat(match[1], 64); err == nil {
+			in.CourseScore = &score
+		}
+	}
+	return in
+}
+
+// cardPriority is a card's priority score as of now
+func cardPriority(card Card, now time.Time) int {
+	return priorityScore(priorityInputFromCard(card), now)
+}
+
+// cardSortMode reads CARD_SORT: "priority" (the default) or "due"
+func cardSortMode() string {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv("CARD_SORT"))); mode {
+	case "", sortByPriority:
+		return sortByPriority
+	case sortByDue:
+		return sortByDue
+	default:
+		fmt.Printf("Warning: invalid CARD_SORT '%s' (want priority or due), using priority\n", mode)
+		return sortByPriority
+	}
+}
+
+// sortCardsByPriority orders cards highest priority first, breaking ties by
+// due date
+func sortCardsByPriority(cards []Card, now time.Time) {
+	sortCardsByDue(cards)
+	priorities := make(map[string]int, len(cards))
+	for _, card := range cards {
+		priorities[card.ID] = cardPriority(card, now)
+	}
+	sort.SliceStable(cards, func(i, j int) bool { return priorities[cards[i].ID] > priorities[cards[j].ID] })
+}
+
+// sortCards orders cards the CARD_SORT way
+func sortCards(cards []Card, now time.Time) {
+	if cardSortMode() == sortByDue {
+		sortCardsByDue(cards)
+		return
+	}
+	sortCardsByPriority(cards, now)
+}
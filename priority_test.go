@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPriorityScore(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	in := func(days float64) *time.Time {
+		t := now.Add(time.Duration(days * 24 * float64(time.Hour)))
+		return &t
+	}
+	score := func(s float64) *float64 { return &s }
+
+	tests := []struct {
+		name string
+		in   PriorityInput
+		want int
+	}{
+		{"undated", PriorityInput{}, 10},
+		{"overdue", PriorityInput{Due: in(-2)}, 40},
+		{"due tomorrow", PriorityInput{Due: in(1)}, 35},
+		{"due in two weeks", PriorityInput{Due: in(14)}, 0},
+		{"big test tomorrow", PriorityInput{Due: in(1), Points: 200}, 55},
+		{"weak course", PriorityInput{Due: in(1), Points: 50, CourseScore: score(85)}, 55},
+		{"failing course caps at 20", PriorityInput{Due: in(1), CourseScore: score(40)}, 55},
+		{"redo", PriorityInput{Due: in(7), Redo: true}, 25},
+		{"capped at 100", PriorityInput{Due: in(-1), Points: 100, CourseScore: score(50), Redo: true}, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := priorityScore(tt.in, now); got != tt.want {
+				t.Errorf("priorityScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriorityMetadataRoundTrip(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	due := now.AddDate(0, 0, 2)
+	courseScore := 82.5
+	in := PriorityInput{Due: &due, Points: 25, CourseScore: &courseScore}
+
+	metadata := priorityMetadata(in, now)
+	if metadata != "\nPoints: 25\nCourse Score: 82.5%\nPriority: 47/100" {
+		t.Errorf("priorityMetadata() = %q", metadata)
+	}
+
+	card := Card{Name: "REDO - Math - Ch 3", Due: &due, Description: "Do it\n\n---\nCanvas Assignment ID: 1" + metadata}
+	if got := storedPriority(card.Description); got != 47 {
+		t.Errorf("storedPriority() = %d, want 47", got)
+	}
+	// The title says REDO, which the stored badge didn't know about
+	if got := cardPriority(card, now); got != 67 {
+		t.Errorf("cardPriority() = %d, want 67", got)
+	}
+	if got := storedPriority("no badge"); got != -1 {
+		t.Errorf("storedPriority() without a badge = %d, want -1", got)
+	}
+}
+
+func TestSortCards(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		t := now.AddDate(0, 0, days)
+		return &t
+	}
+	cards := func() []Card {
+		return []Card{
+			{ID: "soon", Due: at(1)},
+			{ID: "undated"},
+			{ID: "big-test", Due: at(3), Description: "\n---\nPoints: 100"},
+			{ID: "redo", Name: "REDO - Essay", Due: at(7)},
+		}
+	}
+	ids := func(cards []Card) string {
+		var out []string
+		for _, card := range cards {
+			out = append(out, card.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{"", "big-test,soon,redo,undated"},
+		{"priority", "big-test,soon,redo,undated"},
+		{"due", "soon,big-test,redo,undated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			t.Setenv("CARD_SORT", tt.mode)
+			sorted := cards()
+			sortCards(sorted, now)
+			if got := ids(sorted); got != tt.want {
+				t.Errorf("sortCards() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
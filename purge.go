@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// studentDataFiles are the local files holding a student's data, by kind.
+// Config, credentials, and .env are never purged.
+var studentDataFiles = []struct {
+	Kind    string
+	Pattern string
+}{
+	{"cache", cacheFile},
+	{"cache", syncCursorFile},
+	{"export", "canvas_assignments_*.json"},
+	{"export", "moodle_assignments_*.json"},
+	{"grade history", gradeHistoryFile},
+	{"grade history", attendanceLogFile},
+	{"grade history", historyFile},
+	{"report", "grade_email_*.eml"},
+	{"activity", snoozeFile},
+	{"activity", timeLogFile},
+	{"audit log", runHistoryFile},
+	{"audit log", syncStatsFile},
+	{"audit log", failureNoticesFile},
+	{"audit log", triageRejectedFile},
+	{"audit log", defaultTraceFile},
+	{"audit log", "daily-reset.log"},
+	{"audit log", "daily-reset-error.log"},
+}
+
+// PurgeItem is one local file --purge-student-data deletes
+type PurgeItem struct {
+	Kind string
+	Path string
+}
+
+// findStudentData lists the student data files in dir, plus any extra
+// files of a kind (the trace file and card backups) that exist
+func findStudentData(dir string, extra []PurgeItem) []PurgeItem {
+	var items []PurgeItem
+	seen := make(map[string]bool)
+	add := func(kind, path string) {
+		if seen[path] {
+			return
+		}
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			return
+		}
+		seen[path] = true
+		items = append(items, PurgeItem{Kind: kind, Path: path})
+	}
+
+	for _, file := range studentDataFiles {
+		matches, _ := filepath.Glob(filepath.Join(dir, file.Pattern))
+		sort.Strings(matches)
+		for _, path := range matches {
+			add(file.Kind, path)
+		}
+	}
+	for _, item := range extra {
+		add(item.Kind, item.Path)
+	}
+	return items
+}
+
+// backupFiles lists the card backups --delete-all wrote to backupDir. Only
+// its list_<id>_<timestamp>.json files match, so config kept in the same
+// directory is left alone.
+func backupFiles(backupDir string) []PurgeItem {
+	matches, _ := filepath.Glob(filepath.Join(backupDir, "list_*.json"))
+	sort.Strings(matches)
+	var items []PurgeItem
+	for _, path := range matches {
+		items = append(items, PurgeItem{Kind: "card backup", Path: path})
+	}
+	return items
+}
+
+// stripCardMetadata removes the sync metadata block (source IDs, course,
+// grade) from a synced card's description
+func stripCardMetadata(description string) string {
+	return strings.TrimRight(stripCanvasMetadata(description), "\n")
+}
+
+// confirmPurge lists what will be deleted and asks before going ahead
+func confirmPurge(in io.Reader, out io.Writer, items []PurgeItem, boardName string, cards int) bool {
+	fmt.Fprintln(out, "This deletes the student's data:")
+	for _, item := range items {
+		fmt.Fprintf(out, "  - %s (%s)\n", item.Path, item.Kind)
+	}
+	if boardName != "" {
+		fmt.Fprintf(out, "  - sync metadata on %s on %s\n", plural(cards, "card"), boardName)
+	}
+	fmt.Fprint(out, "It can't be undone. Type 'yes' to continue: ")
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	return strings.EqualFold(strings.TrimSpace(answer), "yes")
+}
+
+// PurgeStudentData deletes the student data this tool keeps in the working
+// directory: caches and LMS exports, grade and attendance history, board
+// snapshots, run and sync logs, and the card backups in backupDir. With
+// metadataBoard set it also strips the sync metadata block from that
+// board's synced cards, after which syncs no longer recognize them. Unless
+// yes is set, it asks for confirmation on stdin first.
+func (c *TrelloClient) PurgeStudentData(metadataBoard string, yes bool, backupDir string) error {
+	extra := backupFiles(backupDir)
+	if trace := traceFilePath(); trace != defaultTraceFile {
+		extra = append(extra, PurgeItem{Kind: "audit log", Path: trace})
+	}
+	items := findStudentData(".", extra)
+
+	var board *Board
+	var synced []Card
+	if metadataBoard != "" {
+		boards, err := c.GetBoards()
+		if err != nil {
+			return fmt.Errorf("failed to get boards: %w", err)
+		}
+		if board, err = findBoardByName(boards, metadataBoard); err != nil {
+			return err
+		}
+		cards, err := c.GetBoardCardsByID(board.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get cards for board %s: %w", board.Name, err)
+		}
+		// Cards added by hand have no metadata to strip
+		for _, card := range cards {
+			if syncedSourceKey(card) != "" {
+				synced = append(synced, card)
+			}
+		}
+	}
+
+	if len(items) == 0 && len(synced) == 0 {
+		fmt.Println("No student data found to purge")
+		return nil
+	}
+	boardName := ""
+	if board != nil {
+		boardName = board.Name
+	}
+	if !yes && !confirmPurge(os.Stdin, os.Stdout, items, boardName, len(synced)) {
+		fmt.Println("Cancelled, nothing was deleted")
+		return nil
+	}
+
+	stripped := 0
+	for _, card := range synced {
+		desc := stripCardMetadata(card.Description)
+		if err := c.UpdateCardFields(card.ID, CardPatch{Desc: &desc}); err != nil {
+			fmt.Printf("Warning: failed to strip metadata from %s: %v\n", card.Name, err)
+			continue
+		}
+		stripped++
+	}
+
+	deleted := 0
+	for _, item := range items {
+		if err := os.Remove(item.Path); err != nil {
+			fmt.Printf("Warning: failed to delete %s: %v\n", item.Path, err)
+			continue
+		}
+		deleted++
+	}
+
+	fmt.Printf("%s Purged student data: deleted %s", iconSuccess, plural(deleted, "file"))
+	if board != nil {
+		fmt.Printf(", stripped metadata from %s on %s", plural(stripped, "card"), board.Name)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindStudentData(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{cacheFile, gradeHistoryFile, runHistoryFile, "moodle_assignments_2025-09-30_11-14-23.json", ".env", rolloverFile, "trello_token.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	backups := filepath.Join(dir, "backups")
+	if err := os.MkdirAll(backups, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"list_abc123_2026-06-01_09-00-00.json", pipelinesFile, "mirrors.json", boardSettingsFile} {
+		if err := os.WriteFile(filepath.Join(backups, name), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	extra := append(backupFiles(backups), PurgeItem{Kind: "audit log", Path: filepath.Join(dir, "missing.jsonl")})
+	items := findStudentData(dir, extra)
+
+	want := []PurgeItem{
+		{"cache", filepath.Join(dir, cacheFile)},
+		{"export", filepath.Join(dir, "moodle_assignments_2025-09-30_11-14-23.json")},
+		{"grade history", filepath.Join(dir, gradeHistoryFile)},
+		{"audit log", filepath.Join(dir, runHistoryFile)},
+		{"card backup", filepath.Join(backups, "list_abc123_2026-06-01_09-00-00.json")},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("findStudentData() = %+v, want %+v", items, want)
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("items[%d] = %+v, want %+v", i, items[i], want[i])
+		}
+	}
+}
+
+func TestFindStudentDataCoversDataFiles(t *testing.T) {
+	// Every file the tool writes about the student; add new ones here and to
+	// studentDataFiles
+	dataFiles := []string{
+		cacheFile, syncCursorFile, gradeHistoryFile, attendanceLogFile, historyFile,
+		snoozeFile, timeLogFile, runHistoryFile, syncStatsFile, failureNoticesFile,
+		triageRejectedFile, defaultTraceFile,
+	}
+	dir := t.TempDir()
+	for _, name := range dataFiles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found := make(map[string]bool)
+	for _, item := range findStudentData(dir, nil) {
+		found[filepath.Base(item.Path)] = true
+	}
+	for _, name := range dataFiles {
+		if !found[name] {
+			t.Errorf("findStudentData() misses %s", name)
+		}
+	}
+}
+
+func TestStripCardMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		desc string
+		want string
+	}{
+		{"canvas card", "Read chapter 4\n\n📅 Due Fri, Oct 3 at 6:00 PM MDT\n\n---\nCanvas Assignment ID: 42\nCourse: Biology\nGrade: 95%\n", "Read chapter 4\n\n📅 Due Fri, Oct 3 at 6:00 PM MDT"},
+		{"no body", "\n\n---\nMoodle Assignment ID: 7\nCourse: English\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripCardMetadata(tt.desc); got != tt.want {
+				t.Errorf("stripCardMetadata() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmPurge(t *testing.T) {
+	items := []PurgeItem{{"grade history", gradeHistoryFile}}
+	tests := []struct {
+		name   string
+		answer string
+		want   bool
+	}{
+		{"yes", "yes\n", true},
+		{"no", "n\n", false},
+		{"nothing", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if got := confirmPurge(strings.NewReader(tt.answer), &out, items, "Makai School", 3); got != tt.want {
+				t.Errorf("confirmPurge() = %v, want %v", got, tt.want)
+			}
+			if !strings.Contains(out.String(), "  - grade_history.jsonl (grade history)\n  - sync metadata on 3 cards on Makai School\n") {
+				t.Errorf("prompt = %q", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// errTrelloRateLimited is wrapped by request errors for HTTP 429 responses
+var errTrelloRateLimited = errors.New("rate limited by Trello")
+
+// Backoff for rate-limited writes: 500ms, 1s, 2s, 4s between five attempts
+const (
+	rateLimitBackoff  = 500 * time.Millisecond
+	rateLimitAttempts = 5
+)
+
+// sleepFor waits between paced requests; tests replace it
+var sleepFor = time.Sleep
+
+// trelloRateLimit is what Trello's x-rate-limit headers said about the
+// tightest limit (per token or per key) on the last response
+type trelloRateLimit struct {
+	Remaining int
+	Max       int
+	Interval  time.Duration
+}
+
+// parseTrelloRateLimit reads the per-token and per-key rate-limit headers,
+// keeping whichever has less room left
+func parseTrelloRateLimit(header http.Header) (trelloRateLimit, bool) {
+	var tightest trelloRateLimit
+	found := false
+	for _, scope := range []string{"token", "key"} {
+		prefix := "X-Rate-Limit-Api-" + scope + "-"
+		remaining, err1 := strconv.Atoi(header.Get(prefix + "Remaining"))
+		max, err2 := strconv.Atoi(header.Get(prefix + "Max"))
+		intervalMS, err3 := strconv.Atoi(header.Get(prefix + "Interval-Ms"))
+		if err1 != nil || err2 != nil || err3 != nil || max <= 0 {
+			continue
+		}
+		limit := trelloRateLimit{Remaining: remaining, Max: max, Interval: time.Duration(intervalMS) * time.Millisecond}
+		if !found || limit.Remaining*tightest.Max < tightest.Remaining*limit.Max {
+			tightest = limit
+		}
+		found = true
+	}
+	return tightest, found
+}
+
+// pace is how long to wait before the next request. With at least half the
+// window left there's no wait; below that the remaining requests are spread
+// over the interval, and an empty window waits it out.
+func (l trelloRateLimit) pace() time.Duration {
+	if l.Max == 0 || l.Remaining*2 >= l.Max {
+		return 0
+	}
+	return l.Interval / time.Duration(l.Remaining+1)
+}
+
+// recordRateLimit keeps the rate-limit headers from a Trello response
+func (c *TrelloClient) recordRateLimit(resp *http.Response) {
+	if limit, ok := parseTrelloRateLimit(resp.Header); ok {
+		c.rateLimit = limit
+	}
+}
+
+// pacedWrite runs a Trello write after any pause the rate-limit headers call
+// for, retrying with exponential backoff when Trello answers 429
+func (c *TrelloClient) pacedWrite(write func() error) error {
+	if wait := c.rateLimit.pace(); wait > 0 {
+		sleepFor(wait)
+	}
+	backoff := rateLimitBackoff
+	for attempt := 1; ; attempt++ {
+		err := write()
+		if !errors.Is(err, errTrelloRateLimited) || attempt == rateLimitAttempts {
+			return err
+		}
+		sleepFor(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseTrelloRateLimit(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Rate-Limit-Api-Token-Remaining", "40")
+	header.Set("X-Rate-Limit-Api-Token-Max", "100")
+	header.Set("X-Rate-Limit-Api-Token-Interval-Ms", "10000")
+	header.Set("X-Rate-Limit-Api-Key-Remaining", "90")
+	header.Set("X-Rate-Limit-Api-Key-Max", "300")
+	header.Set("X-Rate-Limit-Api-Key-Interval-Ms", "10000")
+
+	limit, ok := parseTrelloRateLimit(header)
+	if !ok {
+		t.Fatal("headers weren't parsed")
+	}
+	// 90/300 of the key limit is tighter than 40/100 of the token limit
+	if limit.Remaining != 90 || limit.Max != 300 || limit.Interval != 10*time.Second {
+		t.Errorf("parseTrelloRateLimit() = %+v", limit)
+	}
+
+	if _, ok := parseTrelloRateLimit(http.Header{}); ok {
+		t.Error("parsed a rate limit from no headers")
+	}
+}
+
+func TestRateLimitPace(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit trelloRateLimit
+		want  time.Duration
+	}{
+		{"no headers yet", trelloRateLimit{}, 0},
+		{"plenty left", trelloRateLimit{Remaining: 80, Max: 100, Interval: 10 * time.Second}, 0},
+		{"running low", trelloRateLimit{Remaining: 9, Max: 100, Interval: 10 * time.Second}, time.Second},
+		{"empty", trelloRateLimit{Remaining: 0, Max: 100, Interval: 10 * time.Second}, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limit.pace(); got != tt.want {
+				t.Errorf("pace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPacedWriteBacksOff(t *testing.T) {
+	var slept []time.Duration
+	sleepFor = func(d time.Duration) { slept = append(slept, d) }
+	defer func() { sleepFor = time.Sleep }()
+
+	client := &TrelloClient{}
+	attempts := 0
+	err := client.pacedWrite(func() error {
+		attempts++
+		if attempts < 3 {
+			return errTrelloRateLimited
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Fatalf("pacedWrite() = %v after %d attempts", err, attempts)
+	}
+	if len(slept) != 2 || slept[0] != rateLimitBackoff || slept[1] != 2*rateLimitBackoff {
+		t.Errorf("slept %v, want %v then %v", slept, rateLimitBackoff, 2*rateLimitBackoff)
+	}
+
+	attempts = 0
+	err = client.pacedWrite(func() error {
+		attempts++
+		return errTrelloRateLimited
+	})
+	if !errors.Is(err, errTrelloRateLimited) || attempts != rateLimitAttempts {
+		t.Errorf("pacedWrite() = %v after %d attempts, want to give up after %d", err, attempts, rateLimitAttempts)
+	}
+}
+
+func TestSendFormRecordsRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Rate-Limit-Api-Token-Remaining", "0")
+		w.Header().Set("X-Rate-Limit-Api-Token-Max", "100")
+		w.Header().Set("X-Rate-Limit-Api-Token-Interval-Ms", "10000")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	_, err := client.sendForm("PUT", "/cards/1", nil)
+	if !errors.Is(err, errTrelloRateLimited) {
+		t.Errorf("sendForm() = %v, want errTrelloRateLimited", err)
+	}
+	if client.rateLimit.Remaining != 0 || client.rateLimit.pace() != 10*time.Second {
+		t.Errorf("rateLimit = %+v", client.rateLimit)
+	}
+}
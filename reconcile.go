@@ -0,0 +1,413 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BoardSpec is a board described as code: the lists, labels, and standing
+// cards it should have. --reconcile brings the live board in line with it.
+type BoardSpec struct {
+	Board  string      `yaml:"board"` // defaults to Makai School
+	Lists  []string    `yaml:"lists"`
+	Labels []SpecLabel `yaml:"labels"`
+	Cards  []SpecCard  `yaml:"cards"`
+	Prune  bool        `yaml:"prune"` // archive lists and delete labels the spec doesn't name
+}
+
+// SpecLabel is a label the board should have
+type SpecLabel struct {
+	Name  string `yaml:"name"`
+	Color string `yaml:"color"`
+}
+
+// SpecCard is a standing card, matched to the board's cards by name. An
+// empty description leaves the card's description alone.
+type SpecCard struct {
+	Name        string   `yaml:"name"`
+	List        string   `yaml:"list"`
+	Description string   `yaml:"description"`
+	Labels      []string `yaml:"labels"`
+}
+
+// ParseBoardSpec reads a board spec and checks that its cards only use the
+// lists and labels it declares
+func ParseBoardSpec(data []byte) (*BoardSpec, error) {
+	var spec BoardSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	if spec.Board == "" {
+		spec.Board = "Makai School"
+	}
+
+	lists := make(map[string]bool)
+	for _, name := range spec.Lists {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("a list has no name")
+		}
+		lists[normalizeString(name)] = true
+	}
+	labels := make(map[string]bool)
+	for _, label := range spec.Labels {
+		if strings.TrimSpace(label.Name) == "" {
+			return nil, fmt.Errorf("a label has no name")
+		}
+		labels[normalizeString(label.Name)] = true
+	}
+	for _, card := range spec.Cards {
+		if strings.TrimSpace(card.Name) == "" {
+			return nil, fmt.Errorf("a card has no name")
+		}
+		if !lists[normalizeString(card.List)] {
+			return nil, fmt.Errorf("card '%s' is in list '%s', which isn't under lists", card.Name, card.List)
+		}
+		for _, label := range card.Labels {
+			if !labels[normalizeString(label)] {
+				return nil, fmt.Errorf("card '%s' uses label '%s', which isn't under labels", card.Name, label)
+			}
+		}
+	}
+	return &spec, nil
+}
+
+// LoadBoardSpec reads a board spec from the working or config directory
+func LoadBoardSpec(path string) (*BoardSpec, error) {
+	data, err := os.ReadFile(findConfigFile(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	spec, err := ParseBoardSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid board spec %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ReconcileChange is one step of a reconcile plan
+type ReconcileChange struct {
+	Op     string // "create", "update", "archive", or "delete"
+	Kind   string // "list", "label", or "card"
+	Name   string
+	ID     string   // the existing list, label, or card
+	Color  string   // a label's color
+	List   string   // the list a card belongs in, "" when it's already there
+	Desc   *string  // a card's new description
+	Labels []string // labels to add to a card
+	Detail string   // what changes, for the plan
+}
+
+// symbol is the change's marker in the plan, as in terraform plan
+func (ch ReconcileChange) symbol() string {
+	switch ch.Op {
+	case "create":
+		return "+"
+	case "update":
+		return "~"
+	}
+	return "-"
+}
+
+// String is the change's line in the plan
+func (ch ReconcileChange) String() string {
+	line := fmt.Sprintf("%s %s %s", ch.symbol(), ch.Kind, ch.Name)
+	if ch.Detail != "" {
+		line += " (" + ch.Detail + ")"
+	}
+	return line
+}
+
+// planReconcile works out what it takes to make a board match its spec:
+// lists first, then labels, then cards, so each step can use the ones
+// before it. Cards the spec doesn't name are never touched.
+func planReconcile(spec *BoardSpec, lists []List, labels []Label, cards []Card) []ReconcileChange {
+	var changes []ReconcileChange
+
+	listNames := make(map[string]string)
+	haveLists := make(map[string]bool)
+	for _, list := range lists {
+		listNames[list.ID] = list.Name
+		haveLists[normalizeString(list.Name)] = true
+	}
+	wantLists := make(map[string]bool)
+	for _, name := range spec.Lists {
+		wantLists[normalizeString(name)] = true
+		if !haveLists[normalizeString(name)] {
+			changes = append(changes, ReconcileChange{Op: "create", Kind: "list", Name: name})
+		}
+	}
+
+	wantLabels := make(map[string]bool)
+	for _, want := range spec.Labels {
+		wantLabels[normalizeString(want.Name)] = true
+		label := findLabelByName(labels, want.Name)
+		switch {
+		case label == nil:
+			changes = append(changes, ReconcileChange{Op: "create", Kind: "label", Name: want.Name, Color: want.Color, Detail: want.Color})
+		case want.Color != "" && label.Color != want.Color:
+			changes = append(changes, ReconcileChange{Op: "update", Kind: "label", Name: want.Name, ID: label.ID, Color: want.Color,
+				Detail: fmt.Sprintf("color %s -> %s", label.Color, want.Color)})
+		}
+	}
+
+	for _, want := range spec.Cards {
+		card := findCardNamed(cards, want.Name)
+		if card == nil {
+			change := ReconcileChange{Op: "create", Kind: "card", Name: want.Name, List: want.List, Labels: want.Labels, Detail: "in " + want.List}
+			if want.Description != "" {
+				desc := want.Description
+				change.Desc = &desc
+			}
+			changes = append(changes, change)
+			continue
+		}
+
+		change := ReconcileChange{Op: "update", Kind: "card", Name: want.Name, ID: card.ID}
+		var details []string
+		if current := listNames[card.IDList]; normalizeString(current) != normalizeString(want.List) {
+			change.List = want.List
+			details = append(details, fmt.Sprintf("move %s -> %s", current, want.List))
+		}
+		if want.Description != "" && strings.TrimSpace(card.Description) != strings.TrimSpace(want.Description) {
+			desc := want.Description
+			change.Desc = &desc
+			details = append(details, "description")
+		}
+		for _, name := range want.Labels {
+			if findLabelByName(card.Labels, name) == nil {
+				change.Labels = append(change.Labels, name)
+			}
+		}
+		if len(change.Labels) > 0 {
+			details = append(details, "labels +"+strings.Join(change.Labels, ", +"))
+		}
+		if len(details) > 0 {
+			change.Detail = strings.Join(details, ", ")
+			changes = append(changes, change)
+		}
+	}
+
+	if spec.Prune {
+		for _, list := range lists {
+			if !wantLists[normalizeString(list.Name)] {
+				changes = append(changes, ReconcileChange{Op: "archive", Kind: "list", Name: list.Name, ID: list.ID, Detail: "archive"})
+			}
+		}
+		for _, label := range labels {
+			// Trello's unnamed color labels aren't worth declaring
+			if label.Name != "" && !wantLabels[normalizeString(label.Name)] {
+				changes = append(changes, ReconcileChange{Op: "delete", Kind: "label", Name: label.Name, ID: label.ID, Detail: "delete"})
+			}
+		}
+	}
+	return changes
+}
+
+// findLabelByName finds a board label by name, ignoring case
+func findLabelByName(labels []Label, name string) *Label {
+	for i := range labels {
+		if normalizeString(labels[i].Name) == normalizeString(name) {
+			return &labels[i]
+		}
+	}
+	return nil
+}
+
+// findCardNamed finds a card by name, ignoring case
+func findCardNamed(cards []Card, name string) *Card {
+	for i := range cards {
+		if normalizeString(cards[i].Name) == normalizeString(name) {
+			return &cards[i]
+		}
+	}
+	return nil
+}
+
+// formatReconcilePlan prints a plan the way terraform plan does, with a
+// count of additions, changes, and removals
+func formatReconcilePlan(boardName string, changes []ReconcileChange) string {
+	var out strings.Builder
+	add, change, remove := 0, 0, 0
+	fmt.Fprintf(&out, "Plan for %s:\n", boardName)
+	for _, ch := range changes {
+		fmt.Fprintf(&out, "  %s\n", ch)
+		switch ch.symbol() {
+		case "+":
+			add++
+		case "~":
+			change++
+		default:
+			remove++
+		}
+	}
+	fmt.Fprintf(&out, "Plan: %d to add, %d to change, %d to remove.\n", add, change, remove)
+	return out.String()
+}
+
+// removals counts the changes that archive lists or delete labels
+func removals(changes []ReconcileChange) int {
+	count := 0
+	for _, ch := range changes {
+		if ch.Op == "archive" || ch.Op == "delete" {
+			count++
+		}
+	}
+	return count
+}
+
+// confirmReconcile asks before a plan archives lists or deletes labels.
+// Deleted labels come off every card that had them.
+func confirmReconcile(in io.Reader, out io.Writer, removing int) bool {
+	fmt.Fprintf(out, "This plan makes %s. Archived lists can be restored, but deleted labels come off every card. Type 'yes' to apply it: ", plural(removing, "removal"))
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	return strings.EqualFold(strings.TrimSpace(answer), "yes")
+}
+
+// UpdateLabelColor changes a label's color
+func (c *TrelloClient) UpdateLabelColor(labelID, color string) error {
+	fields := url.Values{}
+	fields.Set("color", color)
+	if _, err := c.sendForm("PUT", fmt.Sprintf("/labels/%s", labelID), fields); err != nil {
+		return fmt.Errorf("failed to update label: %w", err)
+	}
+	return nil
+}
+
+// applyReconcileChange makes one planned change, recording new lists and
+// labels so later changes can use them
+func (c *TrelloClient) applyReconcileChange(boardID string, ch ReconcileChange, listIDs, labelIDs map[string]string) error {
+	switch {
+	case ch.Kind == "list" && ch.Op == "create":
+		list, err := c.CreateList(boardID, ch.Name, "bottom")
+		if err != nil {
+			return err
+		}
+		listIDs[normalizeString(list.Name)] = list.ID
+	case ch.Kind == "list" && ch.Op == "archive":
+		return c.ArchiveList(ch.ID)
+	case ch.Kind == "label" && ch.Op == "create":
+		label, err := c.CreateLabel(boardID, ch.Name, ch.Color)
+		if err != nil {
+			return err
+		}
+		labelIDs[normalizeString(label.Name)] = label.ID
+	case ch.Kind == "label" && ch.Op == "update":
+		return c.UpdateLabelColor(ch.ID, ch.Color)
+	case ch.Kind == "label" && ch.Op == "delete":
+		return c.DeleteLabel(ch.ID)
+	case ch.Kind == "card":
+		cardID := ch.ID
+		if ch.Op == "create" {
+			desc := ""
+			if ch.Desc != nil {
+				desc = *ch.Desc
+			}
+			card, err := c.CreateCard(listIDs[normalizeString(ch.List)], ch.Name, desc, "")
+			if err != nil {
+				return err
+			}
+			cardID = card.ID
+		} else {
+			patch := CardPatch{Desc: ch.Desc}
+			if ch.List != "" {
+				listID := listIDs[normalizeString(ch.List)]
+				patch.IDList = &listID
+			}
+			if err := c.UpdateCardFields(cardID, patch); err != nil {
+				return err
+			}
+		}
+		for _, name := range ch.Labels {
+			if err := c.AddLabelIDToCard(cardID, labelIDs[normalizeString(name)]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Reconcile diffs a board against its spec and prints the plan, then, unless
+// dryRun, applies it. A plan that archives or deletes anything asks for
+// confirmation on stdin first, unless yes is set. boardName overrides the
+// spec's board, e.g. to try the spec on a copy from --clone-board first.
+func (c *TrelloClient) Reconcile(specPath, boardName string, dryRun, yes bool) error {
+	spec, err := LoadBoardSpec(specPath)
+	if err != nil {
+		return err
+	}
+	if boardName == "" {
+		boardName = spec.Board
+	}
+
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return err
+	}
+	lists, err := c.GetListsInBoard(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get lists for board %s: %w", board.Name, err)
+	}
+	labels, err := c.GetBoardLabels(board.ID)
+	if err != nil {
+		return err
+	}
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get cards for board %s: %w", board.Name, err)
+	}
+
+	changes := planReconcile(spec, lists, labels, cards)
+	if len(changes) == 0 {
+		fmt.Printf("%s %s already matches %s\n", iconSuccess, board.Name, specPath)
+		return nil
+	}
+	fmt.Print(formatReconcilePlan(board.Name, changes))
+	if dryRun {
+		fmt.Println("Dry run: nothing changed. Run without --reconcile-dry-run to apply.")
+		return nil
+	}
+	if removing := removals(changes); removing > 0 && !yes && !confirmReconcile(os.Stdin, os.Stdout, removing) {
+		fmt.Println("Cancelled, nothing was changed")
+		return nil
+	}
+
+	listIDs := make(map[string]string)
+	for _, list := range lists {
+		listIDs[normalizeString(list.Name)] = list.ID
+	}
+	labelIDs := make(map[string]string)
+	for _, label := range labels {
+		labelIDs[normalizeString(label.Name)] = label.ID
+	}
+
+	applied, failed := 0, 0
+	for _, ch := range changes {
+		if err := c.applyReconcileChange(board.ID, ch, listIDs, labelIDs); err != nil {
+			fmt.Printf("Warning: failed to apply %s: %v\n", ch, err)
+			failed++
+			continue
+		}
+		applied++
+	}
+
+	// Keep the cached lists in step for FindListByName
+	if err := c.RefreshBoardCache(board.Name, false); err != nil {
+		fmt.Printf("Warning: failed to refresh the cache: %v\n", err)
+	}
+
+	fmt.Printf("%s Applied %s to %s\n", iconSuccess, plural(applied, "change"), board.Name)
+	if failed > 0 {
+		return fmt.Errorf("%s failed; run --reconcile again to retry them", plural(failed, "change"))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testBoardSpec = `
+lists: [Weekly, Done]
+labels:
+  - {name: Urgent, color: red}
+  - {name: Reading, color: blue}
+cards:
+  - name: Reading Log
+    list: Weekly
+    description: Twenty minutes a night.
+    labels: [Reading]
+  - name: Field Trip Forms
+    list: Weekly
+`
+
+func TestParseBoardSpec(t *testing.T) {
+	spec, err := ParseBoardSpec([]byte(testBoardSpec))
+	if err != nil {
+		t.Fatalf("ParseBoardSpec() error = %v", err)
+	}
+	if spec.Board != "Makai School" || len(spec.Lists) != 2 || len(spec.Labels) != 2 || len(spec.Cards) != 2 {
+		t.Errorf("ParseBoardSpec() = %+v", spec)
+	}
+	if spec.Cards[0].Labels[0] != "Reading" || spec.Labels[0].Color != "red" {
+		t.Errorf("ParseBoardSpec() cards = %+v, labels = %+v", spec.Cards, spec.Labels)
+	}
+
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"unknown list", "lists: [Weekly]\ncards:\n  - {name: A, list: Later}\n", "isn't under lists"},
+		{"unknown label", "lists: [Weekly]\ncards:\n  - {name: A, list: Weekly, labels: [Urgent]}\n", "isn't under labels"},
+		{"unnamed card", "lists: [Weekly]\ncards:\n  - {list: Weekly}\n", "has no name"},
+		{"bad yaml", "lists: [Weekly\n", "did not find"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBoardSpec([]byte(tt.spec))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseBoardSpec() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlanReconcile(t *testing.T) {
+	spec, err := ParseBoardSpec([]byte(testBoardSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lists := []List{{ID: "l1", Name: "Weekly"}, {ID: "l2", Name: "Old Stuff"}}
+	labels := []Label{{ID: "lb1", Name: "urgent", Color: "orange"}, {ID: "lb2", Name: "Retired", Color: "green"}, {ID: "lb3", Color: "purple"}}
+	cards := []Card{
+		{ID: "c1", Name: "Reading Log", IDList: "l2", Description: "Old notes"},
+		{ID: "c2", Name: "Field Trip Forms", IDList: "l1"},
+		{ID: "c3", Name: "Unmanaged", IDList: "l2"},
+	}
+
+	tests := []struct {
+		name  string
+		prune bool
+		want  []string
+	}{
+		{"without prune", false, []string{
+			"+ list Done",
+			"~ label Urgent (color orange -> red)",
+			"+ label Reading (blue)",
+			"~ card Reading Log (move Old Stuff -> Weekly, description, labels +Reading)",
+		}},
+		{"with prune", true, []string{
+			"+ list Done",
+			"~ label Urgent (color orange -> red)",
+			"+ label Reading (blue)",
+			"~ card Reading Log (move Old Stuff -> Weekly, description, labels +Reading)",
+			"- list Old Stuff (archive)",
+			"- label Retired (delete)",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec.Prune = tt.prune
+			changes := planReconcile(spec, lists, labels, cards)
+			var got []string
+			for _, ch := range changes {
+				got = append(got, ch.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("planReconcile() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+
+	if got := removals(planReconcile(spec, lists, labels, cards)); got != 2 {
+		t.Errorf("removals() = %d, want 2", got)
+	}
+
+	plan := formatReconcilePlan("Makai School", planReconcile(spec, lists, labels, cards))
+	if !strings.HasSuffix(plan, "Plan: 2 to add, 2 to change, 2 to remove.\n") {
+		t.Errorf("formatReconcilePlan() = %q", plan)
+	}
+}
+
+func TestApplyReconcileChange(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.FormValue("idList")+r.FormValue("value"))
+		switch r.URL.Path {
+		case "/lists":
+			w.Write([]byte(`{"id": "l9", "name": "Done"}`))
+		case "/cards":
+			w.Write([]byte(`{"id": "c9", "name": "Field Trip Forms"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	listIDs := map[string]string{}
+	labelIDs := map[string]string{"reading": "lb2"}
+	changes := []ReconcileChange{
+		{Op: "create", Kind: "list", Name: "Done"},
+		{Op: "create", Kind: "card", Name: "Field Trip Forms", List: "Done", Labels: []string{"Reading"}},
+	}
+	for _, ch := range changes {
+		if err := client.applyReconcileChange("b1", ch, listIDs, labelIDs); err != nil {
+			t.Fatalf("applyReconcileChange(%s) error = %v", ch, err)
+		}
+	}
+
+	want := []string{"POST /lists ", "POST /cards l9", "POST /cards/c9/idLabels lb2"}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
+
+func TestConfirmReconcile(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"yes\n", true},
+		{"YES\n", true},
+		{"no\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			var out strings.Builder
+			if got := confirmReconcile(strings.NewReader(tt.answer), &out, 2); got != tt.want {
+				t.Errorf("confirmReconcile(%q) = %v, want %v", tt.answer, got, tt.want)
+			}
+			if !strings.Contains(out.String(), "makes 2 removals.") {
+				t.Errorf("prompt = %q", out.String())
+			}
+		})
+	}
+}
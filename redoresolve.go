@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redoLabelName is the label some boards use on REDO cards alongside the prefix
+const redoLabelName = "REDO"
+
+// GradeImprovement is a REDO that was resubmitted and passed
+type GradeImprovement struct {
+	Source     string  `json:"source"`
+	Course     string  `json:"course"`
+	Assignment string  `json:"assignment"`
+	From       float64 `json:"from"` // percent, from the card's Grade line
+	To         float64 `json:"to"`
+}
+
+// gradePercent reads the percent from a Grade metadata value like
+// "72.0% (REDO NEEDED)"
+func gradePercent(grade string) (float64, bool) {
+	value, _, found := strings.Cut(grade, "%")
+	if !found {
+		return 0, false
+	}
+	percent, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	return percent, err == nil
+}
+
+// redoResolved reports whether a REDO card's assignment now meets the
+// board's passing threshold
+func redoResolved(card Card, graded bool, percent, passing float64) bool {
+	return strings.HasPrefix(card.Name, "REDO - ") && graded && percent >= passing
+}
+
+// recordGradeImprovement adds an improvement to today's grade history
+// record, starting one when today's scores haven't been checked yet
+func recordGradeImprovement(path string, improvement GradeImprovement, now time.Time) error {
+	records, err := LoadGradeHistory(path)
+	if err != nil {
+		return err
+	}
+	today := GradeRecord{Date: now.Format("2006-01-02"), Scores: map[string]float64{}}
+	for _, record := range records {
+		if record.Date == today.Date {
+			today = record
+		}
+	}
+	today.Improvements = append(today.Improvements, improvement)
+	_, err = saveGradeRecord(path, today)
+	return err
+}
+
+// resolveRedo finishes a REDO that passed on resubmission: it drops the
+// prefix and any REDO label, marks the card complete, moves it to Done, and
+// records the improvement in the grade history
+func (c *TrelloClient) resolveRedo(card *Card, boardName, source, course string, percent float64) {
+	title := strings.TrimPrefix(card.Name, "REDO - ")
+	fmt.Printf("REDO passed: %s (%.1f%%)\n", title, percent)
+	if err := c.UpdateCardFields(card.ID, CardPatch{Name: &title, DueComplete: boolPtr(true)}); err != nil {
+		fmt.Printf("Warning: failed to clear REDO on %s: %v\n", title, err)
+		return
+	}
+	card.Name, card.DueComplete = title, true
+
+	if label := findLabel(card.Labels, LabelSpec{Name: redoLabelName}); label != nil {
+		if err := c.RemoveLabelFromCard(card.ID, label.ID); err != nil {
+			fmt.Printf("Warning: failed to remove the REDO label from %s: %v\n", title, err)
+		}
+	}
+	c.routeCard(card, boardName, AssignmentState{Submitted: true, Graded: true, Percent: percent})
+
+	improvement := GradeImprovement{Source: source, Course: course, Assignment: title, To: percent}
+	improvement.From, _ = gradePercent(extractGradeLine(card.Description))
+	comment := fmt.Sprintf("✅ REDO passed: %.1f%% → %.1f%%", improvement.From, percent)
+	if err := c.UpsertComment(card.ID, "redo-passed", comment); err != nil {
+		fmt.Printf("Warning: failed to comment on %s: %v\n", title, err)
+	}
+	if err := recordGradeImprovement(gradeHistoryFile, improvement, time.Now()); err != nil {
+		fmt.Printf("Warning: failed to record the improvement on %s: %v\n", title, err)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGradePercent(t *testing.T) {
+	tests := []struct {
+		grade  string
+		want   float64
+		wantOK bool
+	}{
+		{"72.0% (REDO NEEDED)", 72, true},
+		{"95.5%", 95.5, true},
+		{"Not graded", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.grade, func(t *testing.T) {
+			got, ok := gradePercent(tt.grade)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("gradePercent() = %v, %t, want %v, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRedoResolved(t *testing.T) {
+	redo := Card{Name: "REDO - Biology - Lab Report"}
+	tests := []struct {
+		name    string
+		card    Card
+		graded  bool
+		percent float64
+		want    bool
+	}{
+		{"passed", redo, true, 95, true},
+		{"still failing", redo, true, 80, false},
+		{"not regraded", redo, false, 0, false},
+		{"not a redo", Card{Name: "Biology - Lab Report"}, true, 95, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redoResolved(tt.card, tt.graded, tt.percent, passingGrade); got != tt.want {
+				t.Errorf("redoResolved() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+
+	if !redoResolved(redo, true, 85, 80) {
+		t.Error("an 85% didn't pass a board with an 80% threshold")
+	}
+}
+
+func TestRecordGradeImprovement(t *testing.T) {
+	path := filepath.Join(t.TempDir(), gradeHistoryFile)
+	now := time.Date(2025, 10, 8, 18, 0, 0, 0, time.UTC)
+	improvement := GradeImprovement{Source: "Canvas", Course: "Biology", Assignment: "Lab Report", From: 72, To: 95}
+
+	if err := recordGradeImprovement(path, improvement, now); err != nil {
+		t.Fatal(err)
+	}
+	// Today's scores arrive later and keep the improvement
+	records, err := saveGradeRecord(path, GradeRecord{Date: "2025-10-08", Scores: map[string]float64{"Biology": 91}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Scores["Biology"] != 91 || len(records[0].Improvements) != 1 || records[0].Improvements[0] != improvement {
+		t.Errorf("records = %+v", records)
+	}
+}
+
+func TestSyncMoodleResolvesRedo(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	t.Setenv("TRELLO_CONFIG_DIR", dir)
+
+	cache := CachedData{
+		Boards: []Board{{ID: "b1", Name: "Makai School"}},
+		Lists:  []List{{ID: "l1", Name: "Weekly", BoardID: "b1"}, {ID: "l2", Name: "Done", BoardID: "b1"}},
+	}
+	data, _ := json.Marshal(cache)
+	if err := os.WriteFile(cacheFile, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	testData := `{
+		"assignments": [{"id": 7, "name": "Lab Report", "course": 3, "url": "https://moodle.example.com/mod/assign/view.php?id=7"}],
+		"course_names": {"3": "Biology"},
+		"grades": {"7": {"grade": 19, "grademax": 20}}
+	}`
+	if err := os.WriteFile("moodle_test.json", []byte(testData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	redoCard := `[{"id": "c1", "name": "REDO - Biology - Lab Report", "idList": "l1", "desc": "Write it up\n\n---\nMoodle Assignment ID: 7\nGrade: 72.0% (REDO NEEDED)", "labels": [{"id": "lb1", "name": "REDO"}]}]`
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		r.PostForm.Del("key")
+		r.PostForm.Del("token")
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.PostForm.Encode())
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/boards/b1/cards":
+			w.Write([]byte(redoCard))
+		case r.Method == "GET":
+			w.Write([]byte(`[]`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &TrelloClient{BaseURL: server.URL}
+	now := time.Now()
+	if err := client.SyncMoodleAssignments(&MoodleClient{}, now, now, false, "moodle_test.json"); err != nil {
+		t.Fatalf("SyncMoodleAssignments() error = %v", err)
+	}
+
+	got := strings.Join(requests, "\n")
+	for _, want := range []string{
+		"PUT /cards/c1 dueComplete=true&name=Biology+-+Lab+Report",
+		"DELETE /cards/c1/idLabels/lb1 ",
+		"PUT /cards/c1 idList=l2&pos=top",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("requests missing %q:\n%s", want, got)
+		}
+	}
+
+	records, err := LoadGradeHistory(gradeHistoryFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || len(records[0].Improvements) != 1 || records[0].Improvements[0].From != 72 || records[0].Improvements[0].To != 95 {
+		t.Errorf("grade history = %+v", records)
+	}
+}
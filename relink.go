@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relinkLookback is how far back --relink looks for assignments, since the
+// cards it repairs usually predate the sync
+const relinkLookback = 180 * 24 * time.Hour
+
+// relinkFloor is the lowest score worth offering as a match
+const relinkFloor = 0.5
+
+// relinkChoices is how many matches are offered for each card
+const relinkChoices = 3
+
+// RelinkCandidate is an LMS assignment a card could be linked to
+type RelinkCandidate struct {
+	Source      string // Canvas or Moodle
+	Kind        string // Assignment or Quiz
+	ID          int
+	Institution string // Canvas only; "" for the main account
+	Course      string
+	Name        string
+	URL         string
+}
+
+// Title is the card title a sync would give the assignment
+func (a RelinkCandidate) Title() string {
+	return fmt.Sprintf("%s - %s", a.Course, a.Name)
+}
+
+// idLine is the metadata line syncs look cards up by
+func (a RelinkCandidate) idLine() string {
+	return fmt.Sprintf("%s %s ID: %d", a.Source, a.Kind, a.ID)
+}
+
+// key tells candidates apart across Canvas institutions
+func (a RelinkCandidate) key() string {
+	return a.Institution + "|" + a.idLine()
+}
+
+// metadata is the section appended to a relinked card, in the same shape
+// the sync writes so the next sync finds the card
+func (a RelinkCandidate) metadata() string {
+	metadata := fmt.Sprintf("\n\n---\n%s\nCourse: %s\n%s", a.idLine(), a.Course, sourceURLLine(a.Source, a.URL))
+	if a.Source == "Canvas" {
+		metadata += institutionMetadata(a.Institution)
+	}
+	return metadata
+}
+
+// linkedTo reports whether a card on the board already carries this assignment's ID
+func (a RelinkCandidate) linkedTo(cards []Card) bool {
+	for _, card := range cards {
+		if a.Source == "Canvas" && !strings.EqualFold(cardCanvasInstitution(card.Description), a.Institution) {
+			continue
+		}
+		for _, line := range strings.Split(card.Description, "\n") {
+			if strings.TrimSpace(line) == a.idLine() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var titleWordRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// titleWords returns a title's distinct lowercase words, without the
+// REDO and LOCKED prefixes syncs add
+func titleWords(title string) map[string]bool {
+	for _, prefix := range []string{"REDO - ", "LOCKED - "} {
+		title = strings.TrimPrefix(title, prefix)
+	}
+	words := make(map[string]bool)
+	for _, word := range titleWordRegex.FindAllString(strings.ToLower(title), -1) {
+		words[word] = true
+	}
+	return words
+}
+
+// titleSimilarity scores two titles from 0 to 1 by the words they share
+func titleSimilarity(a, b string) float64 {
+	wordsA, wordsB := titleWords(a), titleWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(wordsA)+len(wordsB))
+}
+
+// relinkScore is how likely a card is the assignment's: certain when the
+// card mentions the assignment's URL, otherwise the title similarity with
+// or without the course name
+func relinkScore(card Card, a RelinkCandidate) float64 {
+	if a.URL != "" && strings.Contains(card.Description, a.URL) {
+		return 1
+	}
+	return max(titleSimilarity(card.Name, a.Title()), titleSimilarity(card.Name, a.Name))
+}
+
+// RelinkMatch is a candidate with its score for one card
+type RelinkMatch struct {
+	Candidate RelinkCandidate
+	Score     float64
+}
+
+// rankRelinkMatches returns the best few candidates for a card, best first
+func rankRelinkMatches(card Card, candidates []RelinkCandidate) []RelinkMatch {
+	var matches []RelinkMatch
+	for _, candidate := range candidates {
+		if score := relinkScore(card, candidate); score >= relinkFloor {
+			matches = append(matches, RelinkMatch{Candidate: candidate, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > relinkChoices {
+		matches = matches[:relinkChoices]
+	}
+	return matches
+}
+
+// unlinkedCards returns the open cards with no sync metadata that automation
+// may touch
+func unlinkedCards(cards []Card) []Card {
+	var unlinked []Card
+	for _, card := range cards {
+		if !card.Closed && !isNoAuto(card) && syncedItemKey(card.Description) == "" {
+			unlinked = append(unlinked, card)
+		}
+	}
+	return unlinked
+}
+
+// RelinkDecision links a card to an assignment
+type RelinkDecision struct {
+	Card      Card
+	Candidate RelinkCandidate
+	Score     float64
+}
+
+// askRelink reads which match to use. ok is false at end of input or on quit.
+func askRelink(reader *bufio.Reader, out io.Writer, choices int) (choice int, ok bool) {
+	for {
+		fmt.Fprintf(out, "  [1-%d] link, [s]kip, [q]uit: ", choices)
+		answer, err := reader.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer == "" && err != nil {
+			return 0, false
+		}
+
+		switch answer {
+		case "s", "skip", "":
+			return 0, true
+		case "q", "quit":
+			return 0, false
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= choices {
+			return n, true
+		}
+		fmt.Fprintf(out, "  Unknown answer '%s'\n", answer)
+	}
+}
+
+// chooseRelinks pairs unlinked cards with assignments. With minScore set,
+// a card takes its best match when it scores at least minScore and no other
+// candidate ties it; otherwise each card's matches are offered on in. An
+// assignment is only ever linked to one card.
+func chooseRelinks(cards []Card, candidates []RelinkCandidate, minScore float64, in io.Reader, out io.Writer) []RelinkDecision {
+	reader := bufio.NewReader(in)
+	taken := make(map[string]bool)
+	var decisions []RelinkDecision
+	for i, card := range cards {
+		var matches []RelinkMatch
+		for _, match := range rankRelinkMatches(card, candidates) {
+			if !taken[match.Candidate.key()] {
+				matches = append(matches, match)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		choice := 0
+		if minScore > 0 {
+			if matches[0].Score >= minScore && (len(matches) == 1 || matches[1].Score < matches[0].Score) {
+				choice = 1
+			}
+		} else {
+			fmt.Fprintf(out, "[%d/%d] %s\n", i+1, len(cards), card.Name)
+			for n, match := range matches {
+				fmt.Fprintf(out, "  %d) %3.0f%% %s: %s\n", n+1, match.Score*100, match.Candidate.Source, match.Candidate.Title())
+			}
+			var ok bool
+			if choice, ok = askRelink(reader, out, len(matches)); !ok {
+				break
+			}
+		}
+		if choice == 0 {
+			continue
+		}
+
+		match := matches[choice-1]
+		taken[match.Candidate.key()] = true
+		decisions = append(decisions, RelinkDecision{Card: card, Candidate: match.Candidate, Score: match.Score})
+	}
+	return decisions
+}
+
+// relinkCandidatesFromEnv fetches recent and upcoming assignments from
+// whichever of Canvas and Moodle are configured
+func relinkCandidatesFromEnv(now time.Time) ([]RelinkCandidate, error) {
+	since := now.Add(-relinkLookback)
+	end := now.AddDate(0, 0, syncHorizonDays())
+	var candidates []RelinkCandidate
+	configured := false
+
+	if canvasClients, err := canvasClientsFromEnv(); err == nil {
+		configured = true
+		for _, canvasClient := range canvasClients {
+			name := canvasClient.Institution
+			if name == "" {
+				name = canvasClient.BaseURL
+			}
+			user, err := canvasClient.GetCurrentUser()
+			if err != nil {
+				fmt.Printf("Warning: skipping Canvas at %s: %v\n", name, err)
+				continue
+			}
+			assignments, err := canvasClient.GetUpcomingAssignments(user.ID, since, end)
+			if err != nil {
+				fmt.Printf("Warning: skipping Canvas at %s: %v\n", name, err)
+				continue
+			}
+			for _, assignment := range assignments {
+				courseName, err := canvasClient.GetCourseNameByID(assignment.CourseID)
+				if err != nil {
+					courseName = fmt.Sprintf("Course %d", assignment.CourseID)
+				}
+				candidates = append(candidates, RelinkCandidate{
+					Source:      "Canvas",
+					Kind:        "Assignment",
+					ID:          assignment.ID,
+					Institution: canvasClient.Institution,
+					Course:      institutionCourseName(courseName, canvasClient.Institution),
+					Name:        assignment.Name,
+					URL:         assignment.HTMLURL,
+				})
+			}
+		}
+	}
+
+	if moodleClient, err := moodleClientFromEnv(); err == nil {
+		configured = true
+		assignments, courseNames, err := moodleClient.GetUpcomingAssignments(since, end)
+		if err != nil {
+			fmt.Printf("Warning: skipping Moodle: %v\n", err)
+		}
+		for _, a := range assignments {
+			kind := "Assignment"
+			if a.Type == "quiz" {
+				kind = "Quiz"
+			}
+			courseName := courseNames[a.CourseID]
+			if courseName == "" {
+				courseName = fmt.Sprintf("Course %d", a.CourseID)
+			}
+			candidates = append(candidates, RelinkCandidate{
+				Source: "Moodle",
+				Kind:   kind,
+				ID:     a.ID,
+				Course: courseName,
+				Name:   a.Name,
+				URL:    a.URL,
+			})
+		}
+	}
+
+	if !configured {
+		return nil, fmt.Errorf("set up Canvas or Moodle in .env to relink cards")
+	}
+	return candidates, nil
+}
+
+// Relink finds cards on a board that look like LMS assignments but have no
+// sync metadata, and adds the matching assignment's ID and link so the next
+// sync updates them instead of creating duplicates
+func (c *TrelloClient) Relink(boardName string, candidates []RelinkCandidate, minScore float64, in io.Reader, out io.Writer) error {
+	cards, err := c.GetAllBoardCards(boardName)
+	if err != nil {
+		return fmt.Errorf("failed to get board cards: %w", err)
+	}
+
+	var open []RelinkCandidate
+	for _, candidate := range candidates {
+		if !candidate.linkedTo(cards) {
+			open = append(open, candidate)
+		}
+	}
+	unlinked := unlinkedCards(cards)
+	fmt.Fprintf(out, "Checking %s against %s\n", plural(len(unlinked), "unlinked card"), plural(len(open), "unlinked assignment"))
+
+	linked := 0
+	for _, decision := range chooseRelinks(unlinked, open, minScore, in, out) {
+		card, candidate := decision.Card, decision.Candidate
+		desc := strings.TrimRight(card.Description, "\n") + candidate.metadata()
+		if err := c.UpdateCardFields(card.ID, CardPatch{Desc: &desc}); err != nil {
+			fmt.Fprintf(out, "Warning: failed to link %s: %v\n", card.Name, err)
+			continue
+		}
+		if err := c.EnsureLinkAttachment(card.ID, candidate.Source, candidate.URL); err != nil {
+			fmt.Fprintf(out, "Warning: failed to attach %s link to %s: %v\n", candidate.Source, card.Name, err)
+		}
+		fmt.Fprintf(out, "Linked %s to %s %d (%.0f%%)\n", card.Name, candidate.Source, candidate.ID, decision.Score*100)
+		linked++
+	}
+
+	fmt.Fprintf(out, "%s Linked %s\n", iconSuccess, plural(linked, "card"))
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Biology - Lab Report", "biology: lab report", 1},
+		{"REDO - Biology - Lab Report", "Biology - Lab Report", 1},
+		{"Lab Report", "Biology - Lab Report", 0.8},
+		{"Essay", "Lab Report", 0},
+		{"", "Lab Report", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"|"+tt.b, func(t *testing.T) {
+			if got := titleSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("titleSimilarity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChooseRelinks(t *testing.T) {
+	lab := RelinkCandidate{Source: "Canvas", Kind: "Assignment", ID: 7, Course: "Biology", Name: "Lab Report", URL: "https://school.instructure.com/courses/1/assignments/7"}
+	quiz := RelinkCandidate{Source: "Moodle", Kind: "Quiz", ID: 3, Course: "History", Name: "Unit 2 Quiz", URL: "https://moodle.example.com/mod/quiz/view.php?id=3"}
+	essay := RelinkCandidate{Source: "Canvas", Kind: "Assignment", ID: 9, Course: "English", Name: "Essay"}
+	candidates := []RelinkCandidate{lab, quiz, essay}
+
+	cards := []Card{
+		{ID: "1", Name: "Biology - Lab Report"},
+		{ID: "2", Name: "Quiz", Description: "See " + quiz.URL},
+		{ID: "3", Name: "Make bed"},
+		{ID: "4", Name: "Lab report"}, // lab is taken by card 1
+	}
+
+	t.Run("threshold", func(t *testing.T) {
+		decisions := chooseRelinks(cards, candidates, 0.9, strings.NewReader(""), &strings.Builder{})
+		if len(decisions) != 2 || decisions[0].Candidate.ID != 7 || decisions[1].Candidate.ID != 3 {
+			t.Errorf("decisions = %+v, want card 1 to lab and card 2 to the quiz", decisions)
+		}
+	})
+
+	t.Run("interactive", func(t *testing.T) {
+		var out strings.Builder
+		decisions := chooseRelinks(cards, candidates, 0, strings.NewReader("s\n1\n"), &out)
+		if len(decisions) != 1 || decisions[0].Card.ID != "2" {
+			t.Errorf("decisions = %+v, want only card 2 linked", decisions)
+		}
+		if !strings.Contains(out.String(), "100% Moodle: History - Unit 2 Quiz") {
+			t.Errorf("output doesn't offer the quiz:\n%s", out.String())
+		}
+	})
+}
+
+func TestRelinkCandidateMetadata(t *testing.T) {
+	candidate := RelinkCandidate{Source: "Canvas", Kind: "Assignment", ID: 7, Institution: "Alpine", Course: "Biology", Name: "Lab Report"}
+	card := Card{Description: "Notes" + candidate.metadata()}
+
+	if !candidate.linkedTo([]Card{card}) {
+		t.Error("a card with the metadata isn't linked")
+	}
+	if got := syncedItemKey(card.Description); got == "" {
+		t.Error("syncedItemKey() doesn't see the metadata")
+	}
+	other := candidate
+	other.Institution = ""
+	if other.linkedTo([]Card{card}) {
+		t.Error("linked across institutions")
+	}
+}
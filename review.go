@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// GradeChange records a grade line that changed on a synced card
+type GradeChange struct {
+	CardName string
+	OldGrade string
+	NewGrade string
+}
+
+// WeekReview summarizes a week of work on the school board
+type WeekReview struct {
+	Start        time.Time
+	End          time.Time
+	Completed    []Card
+	Missed       []Card
+	GradeChanges []GradeChange
+	DailyStreak  int
+	PerfectDays  int
+	GPA          *GPAEstimate // nil when Canvas isn't configured
+	TimeSpent    []TrackedTime
+	Snoozed      []Snooze // cards originally due this week that were pushed back with --snooze
+	Attendance   []AttendanceMark
+}
+
+var gradeLineRegex = regexp.MustCompile(`(?m)^Grade: (.+)$`)
+
+// extractGradeLine returns the value of the "Grade:" metadata line, if any
+func extractGradeLine(desc string) string {
+	if match := gradeLineRegex.FindStringSubmatch(desc); len(match) > 1 {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// buildWeekReview summarizes cards and board actions for the week [start, end).
+// Cards in a done list count as completed, and so does moving a daily there.
+func buildWeekReview(cards []Card, actions []BoardAction, dailyListID string, doneLists map[string]bool, start, end time.Time) WeekReview {
+	review := WeekReview{Start: start, End: end}
+
+	dailyCount := 0
+	for _, card := range cards {
+		if card.IDList == dailyListID {
+			dailyCount++
+			continue
+		}
+		if card.Due == nil || card.Due.Before(start) || !card.Due.Before(end) {
+			continue
+		}
+		if isCardDone(card, doneLists) {
+			review.Completed = append(review.Completed, card)
+		} else {
+			review.Missed = append(review.Missed, card)
+		}
+	}
+
+	// Count daily completions per day and pick up grade changes
+	dailyDone := make(map[string]map[string]bool)
+	for _, action := range actions {
+		if action.Date.Before(start) || !action.Date.Before(end) {
+			continue
+		}
+		card := action.Data.Card
+
+		checkedOff := action.Data.Old.DueComplete != nil && card.DueComplete && card.IDList == dailyListID
+		movedDone := action.Data.Old.IDList != nil && *action.Data.Old.IDList == dailyListID && doneLists[card.IDList]
+		if checkedOff || movedDone {
+			day := action.Date.In(start.Location()).Format("2006-01-02")
+			if dailyDone[day] == nil {
+				dailyDone[day] = make(map[string]bool)
+			}
+			dailyDone[day][card.ID] = true
+		}
+
+		if action.Data.Old.Desc != nil {
+			oldGrade := extractGradeLine(*action.Data.Old.Desc)
+			newGrade := extractGradeLine(card.Desc)
+			if newGrade != "" && oldGrade != newGrade {
+				review.GradeChanges = append(review.GradeChanges, GradeChange{
+					CardName: card.Name,
+					OldGrade: oldGrade,
+					NewGrade: newGrade,
+				})
+			}
+		}
+	}
+
+	if dailyCount == 0 {
+		return review
+	}
+
+	// Walk the week backwards from the last day to find the current streak
+	streakBroken := false
+	for day := end.AddDate(0, 0, -1); !day.Before(start); day = day.AddDate(0, 0, -1) {
+		perfect := len(dailyDone[day.Format("2006-01-02")]) >= dailyCount
+		if perfect {
+			review.PerfectDays++
+		}
+		if !streakBroken {
+			if perfect {
+				review.DailyStreak++
+			} else {
+				streakBroken = true
+			}
+		}
+	}
+
+	return review
+}
+
+// Format renders the review as a Trello card description
+func (r WeekReview) Format() string {
+	var desc strings.Builder
+
+	desc.WriteString(fmt.Sprintf("**Week of %s – %s**\n\n",
+		r.Start.Format("January 2"), r.End.AddDate(0, 0, -1).Format("January 2")))
+
+	desc.WriteString(fmt.Sprintf("**✅ Completed (%d)**\n", len(r.Completed)))
+	for _, card := range r.Completed {
+		desc.WriteString(fmt.Sprintf("- %s\n", card.Name))
+	}
+
+	desc.WriteString(fmt.Sprintf("\n**❌ Missed (%d)**\n", len(r.Missed)))
+	for _, card := range r.Missed {
+		desc.WriteString(fmt.Sprintf("- %s (was due %s)\n", card.Name, card.Due.In(r.Start.Location()).Format("Mon Jan 2")))
+	}
+
+	if len(r.Snoozed) > 0 {
+		desc.WriteString(fmt.Sprintf("\n**😴 Snoozed (%d)**\n", len(r.Snoozed)))
+		for _, snooze := range r.Snoozed {
+			desc.WriteString(fmt.Sprintf("- %s (was due %s, snoozed to %s)\n", snooze.CardName,
+				snooze.OriginalDue.In(r.Start.Location()).Format("Mon Jan 2"), snooze.Until.In(r.Start.Location()).Format("Mon Jan 2")))
+		}
+	}
+
+	desc.WriteString(fmt.Sprintf("\n**📈 Grade Changes (%d)**\n", len(r.GradeChanges)))
+	for _, change := range r.GradeChanges {
+		oldGrade := change.OldGrade
+		if oldGrade == "" {
+			oldGrade = "none"
+		}
+		desc.WriteString(fmt.Sprintf("- %s: %s → %s\n", change.CardName, oldGrade, change.NewGrade))
+	}
+
+	desc.WriteString("\n**🔥 Dailies**\n")
+	desc.WriteString(fmt.Sprintf("- All dailies done on %d of 7 days\n", r.PerfectDays))
+	desc.WriteString(fmt.Sprintf("- Current streak: %d day(s)\n", r.DailyStreak))
+
+	if len(r.TimeSpent) > 0 {
+		var total time.Duration
+		for _, tracked := range r.TimeSpent {
+			total += tracked.Duration
+		}
+		desc.WriteString(fmt.Sprintf("\n**⏱ Time Tracked (%s)**\n", formatTrackedDuration(total)))
+		for _, tracked := range r.TimeSpent {
+			desc.WriteString(fmt.Sprintf("- %s: %s\n", tracked.CardName, formatTrackedDuration(tracked.Duration)))
+		}
+	}
+
+	if len(r.Attendance) > 0 {
+		desc.WriteString(fmt.Sprintf("\n**🏫 Attendance (%d)**\n", len(r.Attendance)))
+		for _, mark := range r.Attendance {
+			desc.WriteString(fmt.Sprintf("- %s: %s\n", mark.Date.In(r.Start.Location()).Format("Mon Jan 2"), mark.Describe()))
+		}
+	}
+
+	if r.GPA != nil {
+		desc.WriteString("\n**🎓 Grades**\n")
+		desc.WriteString(fmt.Sprintf("- %s\n", r.GPA.Line()))
+	}
+
+	return desc.String()
+}
+
+// reviewMentions returns who gets @mentioned on the review card: the parent
+// by default. Set WEEKLY_REVIEW_MENTIONS to a comma-separated list of Trello
+// usernames to change them, e.g. to add the student.
+func reviewMentions() []string {
+	if mentions := mentionsFromEnv("WEEKLY_REVIEW_MENTIONS"); len(mentions) > 0 {
+		return mentions
+	}
+	return []string{"@nalani_farnsworth"}
+}
+
+// CreateWeekInReview posts a retrospective card for the week that just ended.
+// gpa is optional and adds an estimated GPA line.
+func (c *TrelloClient) CreateWeekInReview(boardName, dailyListName, reviewListName string, gpa *GPAEstimate) error {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return err
+	}
+
+	dailyList, err := findListByName(cache.Lists, board.ID, dailyListName)
+	if err != nil {
+		return fmt.Errorf("%s in board '%s'", err.Error(), board.Name)
+	}
+
+	reviewList, err := findListByName(cache.Lists, board.ID, reviewListName)
+	if err != nil {
+		return fmt.Errorf("%s in board '%s'", err.Error(), board.Name)
+	}
+
+	// Review the seven days ending at midnight this morning, local to the
+	// family rather than the runner, which is UTC in the workflow
+	now := time.Now().In(displayLocation())
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	start := end.AddDate(0, 0, -7)
+
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get board cards: %w", err)
+	}
+
+	actions, err := c.GetBoardActions(board.ID, "updateCard", start)
+	if err != nil {
+		return fmt.Errorf("failed to get board activity: %w", err)
+	}
+
+	review := buildWeekReview(cards, actions, dailyList.ID, doneListIDs(cache.Lists), start, end)
+	review.GPA = gpa
+	if timeLog, err := LoadTimeLog(timeLogFile); err != nil {
+		fmt.Printf("Warning: skipping tracked time: %v\n", err)
+	} else {
+		review.TimeSpent = timeLog.Between(start, end)
+	}
+	if snoozes, err := LoadSnoozes(snoozeFile); err != nil {
+		fmt.Printf("Warning: skipping snoozed cards: %v\n", err)
+	} else {
+		onBoard := make(map[string]bool)
+		for _, card := range cards {
+			onBoard[card.ID] = true
+		}
+		for _, snooze := range snoozes.Between(start, end) {
+			if onBoard[snooze.CardID] {
+				review.Snoozed = append(review.Snoozed, snooze)
+			}
+		}
+	}
+
+	if attendance, err := LoadAttendanceLog(attendanceLogFile); err != nil {
+		fmt.Printf("Warning: skipping attendance: %v\n", err)
+	} else {
+		review.Attendance = attendance.Between(start, end)
+	}
+
+	cardTitle := fmt.Sprintf("Week in Review - %s", start.Format("January 2, 2006"))
+	reviewCard, err := c.CreateCard(reviewList.ID, cardTitle, review.Format(), "")
+	if err != nil {
+		return fmt.Errorf("failed to create review card: %w", err)
+	}
+
+	comment := fmt.Sprintf("%s Week in review is ready: %d completed, %d missed, %d grade change(s). Take a few minutes to go over it together! 📋",
+		strings.Join(reviewMentions(), " "), len(review.Completed), len(review.Missed), len(review.GradeChanges))
+	comment = c.checkMentions(board.ID, comment)
+	if err := c.UpsertComment(reviewCard.ID, "week-review", comment); err != nil {
+		return fmt.Errorf("failed to add comment to review card: %w", err)
+	}
+
+	fmt.Printf("%s Created week in review card: %s\n", iconSuccess, cardTitle)
+	return nil
+}
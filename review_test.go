@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractGradeLine(t *testing.T) {
+	tests := []struct {
+		desc     string
+		expected string
+	}{
+		{"Intro\n\n---\nCanvas Assignment ID: 1\nGrade: 85.0% (REDO NEEDED)\nCanvas URL: x", "85.0% (REDO NEEDED)"},
+		{"Intro\n\n---\nMoodle Assignment ID: 1\nGrade: Not graded", "Not graded"},
+		{"No metadata here", ""},
+	}
+
+	for _, test := range tests {
+		if result := extractGradeLine(test.desc); result != test.expected {
+			t.Errorf("extractGradeLine(%q) = %q, want %q", test.desc, result, test.expected)
+		}
+	}
+}
+
+func dailyCompletion(cardID string, date time.Time) BoardAction {
+	var action BoardAction
+	done := false
+	action.Date = date
+	action.Data.Card.ID = cardID
+	action.Data.Card.IDList = "daily"
+	action.Data.Card.DueComplete = true
+	action.Data.Old.DueComplete = &done
+	return action
+}
+
+func TestBuildWeekReview(t *testing.T) {
+	start := time.Date(2025, 9, 21, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+
+	cards := []Card{
+		{ID: "d1", Name: "Read", IDList: "daily"},
+		{ID: "d2", Name: "Practice", IDList: "daily"},
+		{ID: "w1", Name: "Math - HW", IDList: "weekly", Due: timePtr(start.AddDate(0, 0, 2)), DueComplete: true},
+		{ID: "w2", Name: "English - Essay", IDList: "weekly", Due: timePtr(start.AddDate(0, 0, 3))},
+		{ID: "w3", Name: "Next week", IDList: "weekly", Due: timePtr(end.AddDate(0, 0, 1))},
+	}
+
+	// Both dailies done on the last two days, only one on the day before
+	var actions []BoardAction
+	for _, offset := range []int{5, 6} {
+		day := start.AddDate(0, 0, offset).Add(18 * time.Hour)
+		actions = append(actions, dailyCompletion("d1", day), dailyCompletion("d2", day))
+	}
+	actions = append(actions, dailyCompletion("d1", start.AddDate(0, 0, 4).Add(18*time.Hour)))
+
+	oldDesc := "---\nGrade: Not graded"
+	var gradeAction BoardAction
+	gradeAction.Date = start.AddDate(0, 0, 1)
+	gradeAction.Data.Card.Name = "Math - HW"
+	gradeAction.Data.Card.Desc = "---\nGrade: 95.0%"
+	gradeAction.Data.Old.Desc = &oldDesc
+	actions = append(actions, gradeAction)
+
+	review := buildWeekReview(cards, actions, "daily", nil, start, end)
+
+	if len(review.Completed) != 1 || review.Completed[0].ID != "w1" {
+		t.Errorf("expected w1 completed, got %v", review.Completed)
+	}
+	if len(review.Missed) != 1 || review.Missed[0].ID != "w2" {
+		t.Errorf("expected w2 missed, got %v", review.Missed)
+	}
+	if len(review.GradeChanges) != 1 || review.GradeChanges[0].NewGrade != "95.0%" {
+		t.Errorf("expected one grade change to 95.0%%, got %v", review.GradeChanges)
+	}
+	if review.DailyStreak != 2 {
+		t.Errorf("DailyStreak = %d, want 2", review.DailyStreak)
+	}
+	if review.PerfectDays != 2 {
+		t.Errorf("PerfectDays = %d, want 2", review.PerfectDays)
+	}
+}
+
+func TestBuildWeekReviewDoneLists(t *testing.T) {
+	start := time.Date(2025, 9, 21, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	doneLists := map[string]bool{"done": true}
+
+	cards := []Card{
+		{ID: "d1", Name: "Read", IDList: "daily"},
+		{ID: "w1", Name: "Math - HW", IDList: "done", Due: timePtr(start.AddDate(0, 0, 2))},
+	}
+
+	// The daily was dragged to Done on the last day instead of checked off
+	var moved BoardAction
+	daily := "daily"
+	moved.Date = end.Add(-6 * time.Hour)
+	moved.Data.Card.ID = "d1"
+	moved.Data.Card.IDList = "done"
+	moved.Data.Old.IDList = &daily
+
+	review := buildWeekReview(cards, []BoardAction{moved}, "daily", doneLists, start, end)
+	if len(review.Completed) != 1 || len(review.Missed) != 0 {
+		t.Errorf("Completed = %v, Missed = %v, want the card in Done completed", review.Completed, review.Missed)
+	}
+	if review.DailyStreak != 1 {
+		t.Errorf("DailyStreak = %d, want 1", review.DailyStreak)
+	}
+}
+
+func TestReviewMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want string
+	}{
+		{"parent by default", "", "@nalani_farnsworth"},
+		{"from env", "nalani_farnsworth, @makai_k", "@nalani_farnsworth @makai_k"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WEEKLY_REVIEW_MENTIONS", tt.env)
+			if got := strings.Join(reviewMentions(), " "); got != tt.want {
+				t.Errorf("reviewMentions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// rolloverFile lists the seasonal lists --season-rollover replaces
+const rolloverFile = "rollover.json"
+
+// seasonPlaceholder marks where the season goes in a list name template
+const seasonPlaceholder = "{season}"
+
+// SeasonalList is one list that's replaced each season, e.g. "Weekly {season}"
+// for "Weekly Q1", "Weekly Q2", and so on, with the cards a fresh one starts with
+type SeasonalList struct {
+	Template string   `json:"template"`
+	Cards    []string `json:"cards,omitempty"`
+}
+
+// RolloverConfig is rollover.json
+type RolloverConfig struct {
+	Board string         `json:"board"` // defaults to Makai School
+	Lists []SeasonalList `json:"lists"`
+}
+
+// LoadRolloverConfig reads rollover.json from the working or config directory
+func LoadRolloverConfig() (*RolloverConfig, error) {
+	data, err := os.ReadFile(findConfigFile(rolloverFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no %s found; list the seasonal lists there, e.g. {\"lists\": [{\"template\": \"Weekly {season}\"}]}", rolloverFile)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", rolloverFile, err)
+	}
+
+	var config RolloverConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", rolloverFile, err)
+	}
+	if config.Board == "" {
+		config.Board = "Makai School"
+	}
+	for _, list := range config.Lists {
+		if !strings.Contains(list.Template, seasonPlaceholder) {
+			return nil, fmt.Errorf("%s: template '%s' has no %s", rolloverFile, list.Template, seasonPlaceholder)
+		}
+	}
+	return &config, nil
+}
+
+// seasonalListName fills in a template's season
+func seasonalListName(template, season string) string {
+	return strings.ReplaceAll(template, seasonPlaceholder, season)
+}
+
+// templateRegex matches list names made from a template, capturing the season
+func templateRegex(template string) *regexp.Regexp {
+	pattern := strings.ReplaceAll(regexp.QuoteMeta(template), regexp.QuoteMeta(seasonPlaceholder), "(.+)")
+	return regexp.MustCompile("^" + pattern + "$")
+}
+
+// RolloverPlan is what a rollover does to one seasonal list
+type RolloverPlan struct {
+	Template SeasonalList
+	Archive  []List  // earlier seasons' lists
+	Create   string  // the new list's name, "" when it already exists
+	Pos      float64 // the first old list's position, 0 if there isn't one
+}
+
+// planRollover finds each template's lists from other seasons and whether
+// the new season's list still needs creating. The new list takes the place
+// of the first old one.
+func planRollover(config *RolloverConfig, lists []List, season string) []RolloverPlan {
+	var plans []RolloverPlan
+	for _, seasonal := range config.Lists {
+		plan := RolloverPlan{Template: seasonal, Create: seasonalListName(seasonal.Template, season)}
+		matcher := templateRegex(seasonal.Template)
+		for _, list := range lists {
+			match := matcher.FindStringSubmatch(list.Name)
+			if match == nil {
+				continue
+			}
+			if strings.EqualFold(match[1], season) {
+				plan.Create = ""
+				continue
+			}
+			plan.Archive = append(plan.Archive, list)
+			if plan.Pos == 0 {
+				plan.Pos = list.Pos
+			}
+		}
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+// CreateList adds a list to a board. pos is "top", "bottom", or a position.
+func (c *TrelloClient) CreateList(boardID, name, pos string) (*List, error) {
+	fields := url.Values{}
+	fields.Set("idBoard", boardID)
+	fields.Set("name", name)
+	if pos != "" {
+		fields.Set("pos", pos)
+	}
+
+	body, err := c.sendForm("POST", "/lists", fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var list List
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal created list: %w", err)
+	}
+	return &list, nil
+}
+
+// ArchiveList closes a list. Its cards stay with it and it can be restored
+// from the board's archive.
+func (c *TrelloClient) ArchiveList(listID string) error {
+	fields := url.Values{}
+	fields.Set("value", "true")
+	_, err := c.sendForm("PUT", fmt.Sprintf("/lists/%s/closed", listID), fields)
+	return err
+}
+
+// SeasonRollover archives the seasonal lists from rollover.json that belong
+// to earlier seasons and creates this season's lists in their place, with
+// the template cards. Running it again for the same season does nothing.
+func (c *TrelloClient) SeasonRollover(season string) error {
+	season = strings.TrimSpace(season)
+	if season == "" {
+		return fmt.Errorf("season rollover needs the new season's name, e.g. Q2")
+	}
+	config, err := LoadRolloverConfig()
+	if err != nil {
+		return err
+	}
+
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+	board, err := findBoardByName(cache.Boards, config.Board)
+	if err != nil {
+		return err
+	}
+	lists, err := c.GetListsInBoard(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get lists for board %s: %w", board.Name, err)
+	}
+
+	archived, created := 0, 0
+	for _, plan := range planRollover(config, lists, season) {
+		if plan.Create != "" {
+			pos := "bottom"
+			if plan.Pos > 1 {
+				// Just above the old list, so it slots into the same place
+				pos = fmt.Sprintf("%g", plan.Pos-0.5)
+			}
+			fmt.Printf("Creating list: %s\n", plan.Create)
+			list, err := c.CreateList(board.ID, plan.Create, pos)
+			if err != nil {
+				return fmt.Errorf("failed to create list %s: %w", plan.Create, err)
+			}
+			created++
+			for _, name := range plan.Template.Cards {
+				if _, err := c.CreateCard(list.ID, name, "", ""); err != nil {
+					fmt.Printf("Warning: failed to create card %s in %s: %v\n", name, plan.Create, err)
+				}
+			}
+		}
+
+		for _, old := range plan.Archive {
+			fmt.Printf("Archiving list: %s\n", old.Name)
+			if err := c.ArchiveList(old.ID); err != nil {
+				fmt.Printf("Warning: failed to archive %s: %v\n", old.Name, err)
+				continue
+			}
+			archived++
+		}
+	}
+
+	// Keep the cached lists in step for FindListByName
+	if err := c.RefreshBoardCache(board.Name, false); err != nil {
+		fmt.Printf("Warning: failed to refresh the cache: %v\n", err)
+	}
+
+	fmt.Printf("%s Rolled over to %s: archived %s, created %s\n", iconSuccess, season, plural(archived, "list"), plural(created, "list"))
+	return nil
+}
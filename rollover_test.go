@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlanRollover(t *testing.T) {
+	config := &RolloverConfig{Lists: []SeasonalList{
+		{Template: "Weekly {season}", Cards: []string{"Plan the week"}},
+		{Template: "Reading ({season})"},
+		{Template: "Labs {season}"},
+	}}
+	lists := []List{
+		{ID: "daily", Name: "Daily", Pos: 100},
+		{ID: "weekly-q1", Name: "Weekly Q1", Pos: 200},
+		{ID: "weekly", Name: "Weekly", Pos: 300},
+		{ID: "reading-q1", Name: "Reading (Q1)", Pos: 400},
+		{ID: "reading-q2", Name: "Reading (q2)", Pos: 500},
+	}
+
+	plans := planRollover(config, lists, "Q2")
+	if len(plans) != 3 {
+		t.Fatalf("planRollover() = %d plans, want 3", len(plans))
+	}
+
+	tests := []struct {
+		name    string
+		plan    RolloverPlan
+		create  string
+		archive string
+		pos     float64
+	}{
+		{"weekly", plans[0], "Weekly Q2", "weekly-q1", 200},
+		{"reading already rolled over", plans[1], "", "reading-q1", 400},
+		{"labs is new", plans[2], "Labs Q2", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var archived []string
+			for _, list := range tt.plan.Archive {
+				archived = append(archived, list.ID)
+			}
+			if tt.plan.Create != tt.create || strings.Join(archived, ",") != tt.archive || tt.plan.Pos != tt.pos {
+				t.Errorf("plan = create %q, archive %v, pos %g; want %q, %s, %g", tt.plan.Create, archived, tt.plan.Pos, tt.create, tt.archive, tt.pos)
+			}
+		})
+	}
+}
+
+func TestTemplateRegex(t *testing.T) {
+	matcher := templateRegex("Weekly {season}")
+	if match := matcher.FindStringSubmatch("Weekly Fall 2025"); match == nil || match[1] != "Fall 2025" {
+		t.Errorf("match = %v, want Fall 2025", match)
+	}
+	if matcher.MatchString("Weekly") {
+		t.Error("plain Weekly matched the template")
+	}
+	if got := seasonalListName("Weekly {season}", "Q3"); got != "Weekly Q3" {
+		t.Errorf("seasonalListName() = %q", got)
+	}
+}
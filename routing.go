@@ -0,0 +1,94 @@
+package main
+
+import "fmt"
+
+// passingGrade is the percentage at or above which work counts as done
+const passingGrade = 90.0
+
+// AssignmentState is what the LMS says about a synced assignment
+type AssignmentState struct {
+	Submitted bool
+	Graded    bool
+	Percent   float64
+	NeedsRedo bool
+	Passing   float64 // the board's REDO threshold; 0 means passingGrade
+}
+
+// passing is the percentage the state's grade is held to
+func (s AssignmentState) passing() float64 {
+	if s.Passing == 0 {
+		return passingGrade
+	}
+	return s.Passing
+}
+
+// targetListForState returns the list a synced card belongs in, or "" to leave it
+func targetListForState(state AssignmentState) string {
+	switch {
+	case state.NeedsRedo:
+		return "Weekly"
+	case state.Graded && state.Percent >= state.passing():
+		return "Done"
+	case state.Submitted && !state.Graded:
+		return "Submitted"
+	}
+	return ""
+}
+
+// MoveCardToList moves a card to the top of another list
+func (c *TrelloClient) MoveCardToList(cardID, listID string) error {
+	return c.UpdateCardFields(cardID, CardPatch{IDList: &listID, Pos: stringPtr("top")})
+}
+
+// routeCard moves a synced card to the list matching its state, if that
+// list exists on the board and the card isn't already there. A card the
+// student already moved to one of the done lists counts as being in Done.
+// The board's settings can rename or turn off a move, set the threshold,
+// and keep cards in quiet lists where they are.
+func (c *TrelloClient) routeCard(card *Card, boardName string, state AssignmentState) {
+	settings := c.boardSettings(boardName)
+	if state.Passing == 0 {
+		state.Passing = settings.passing()
+	}
+	target := targetListForState(state)
+	if target == "" {
+		return
+	}
+	if target == "Done" && c.boardDoneLists(boardName)[card.IDList] {
+		return
+	}
+	listName, ok := settings.routeList(target)
+	if !ok || c.inQuietList(boardName, *card) {
+		return
+	}
+
+	listID, err := c.FindListByName(boardName, listName)
+	if err != nil {
+		c.noteOnce(fmt.Sprintf("Note: no '%s' list on %s, leaving cards that belong there in place", listName, boardName))
+		return
+	}
+
+	if card.IDList == listID {
+		return
+	}
+
+	fmt.Printf("Moving %s to %s\n", card.Name, listName)
+	if err := c.MoveCardToList(card.ID, listID); err != nil {
+		fmt.Printf("Warning: failed to move %s to %s: %v\n", card.Name, listName, err)
+		return
+	}
+	card.IDList = listID
+}
+
+// noteOnce prints a note the first time it comes up in a run, so a missing
+// list doesn't repeat for every card of a sync
+func (c *TrelloClient) noteOnce(note string) {
+	if c.noted[note] {
+		return
+	}
+	if c.noted == nil {
+		c.noted = make(map[string]bool)
+	}
+	c.noted[note] = true
+	fmt.Println(note)
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestTargetListForState(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    AssignmentState
+		expected string
+	}{
+		{"not started", AssignmentState{}, ""},
+		{"submitted awaiting grade", AssignmentState{Submitted: true}, "Submitted"},
+		{"graded passing", AssignmentState{Submitted: true, Graded: true, Percent: 95}, "Done"},
+		{"graded at threshold", AssignmentState{Graded: true, Percent: 90}, "Done"},
+		{"needs redo", AssignmentState{Submitted: true, Graded: true, Percent: 72, NeedsRedo: true}, "Weekly"},
+		{"graded failing without redo", AssignmentState{Graded: true, Percent: 50}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := targetListForState(test.state); result != test.expected {
+				t.Errorf("targetListForState(%+v) = %q, want %q", test.state, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestNoteOnce(t *testing.T) {
+	client := &TrelloClient{}
+	for i := 0; i < 3; i++ {
+		client.noteOnce("Note: no 'Submitted' list on Makai School, leaving cards that belong there in place")
+	}
+	client.noteOnce("Note: no 'Done' list on Makai School, leaving cards that belong there in place")
+	if len(client.noted) != 2 {
+		t.Errorf("noted = %v, want each note once", client.noted)
+	}
+}
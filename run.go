@@ -0,0 +1,412 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// runSummaryCardPrefix starts the title of the status card kept on the ops list
+const runSummaryCardPrefix = "Automation Status"
+
+// JobResult records how one job in a scheduled run went
+type JobResult struct {
+	Name     string
+	Started  time.Time
+	Duration time.Duration
+	Created  int
+	Updated  int
+	Deleted  int
+	Err      error
+}
+
+// RunSummary collects the results of a scheduled run
+type RunSummary struct {
+	Started  time.Time
+	Finished time.Time
+	Jobs     []JobResult
+}
+
+// Failed returns the number of jobs that returned an error
+func (s RunSummary) Failed() int {
+	failed := 0
+	for _, job := range s.Jobs {
+		if job.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Title is the status card title, flagging failures so they show on the board
+func (s RunSummary) Title() string {
+	if failed := s.Failed(); failed > 0 {
+		return fmt.Sprintf("%s - ❌ %d of %d job(s) failed", runSummaryCardPrefix, failed, len(s.Jobs))
+	}
+	return fmt.Sprintf("%s - ✅ all %d job(s) OK", runSummaryCardPrefix, len(s.Jobs))
+}
+
+// Format renders the summary as the status card description
+func (s RunSummary) Format() string {
+	var desc strings.Builder
+
+	desc.WriteString(fmt.Sprintf("**Last run**: %s\n", s.Started.Format("Monday, January 2, 2006 at 3:04 PM MST")))
+	desc.WriteString(fmt.Sprintf("**Duration**: %s\n\n", s.Finished.Sub(s.Started).Round(time.Second)))
+
+	desc.WriteString("| Job | Result | Duration | Created | Updated | Deleted |\n")
+	desc.WriteString("|---|---|---|---|---|---|\n")
+	for _, job := range s.Jobs {
+		result := "✅ OK"
+		if job.Err != nil {
+			result = "❌ Failed"
+		}
+		desc.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %d | %d |\n",
+			job.Name, result, job.Duration.Round(time.Second), job.Created, job.Updated, job.Deleted))
+	}
+
+	if s.Failed() > 0 {
+		desc.WriteString("\n**Errors**\n")
+		for _, job := range s.Jobs {
+			if job.Err != nil {
+				desc.WriteString(fmt.Sprintf("- %s: %v\n", job.Name, job.Err))
+			}
+		}
+	}
+
+	return desc.String()
+}
+
+// runJob times a job and counts the Trello writes it made
+func (c *TrelloClient) runJob(name string, job func() error) JobResult {
+	before := make(map[string]int)
+	for method, count := range c.writes {
+		before[method] = count
+	}
+
+	fmt.Printf("\n=== %s ===\n", name)
+	start := time.Now()
+	err := job()
+
+	result := JobResult{
+		Name:     name,
+		Started:  start,
+		Duration: time.Since(start),
+		Created:  c.writes["POST"] - before["POST"],
+		Updated:  c.writes["PUT"] - before["PUT"],
+		Deleted:  c.writes["DELETE"] - before["DELETE"],
+		Err:      err,
+	}
+	if err != nil {
+		fmt.Printf("Warning: %s failed: %v\n", name, err)
+	}
+	return result
+}
+
+// JobOptions adjust a job for one pipeline step. Empty fields keep the
+// --run defaults.
+type JobOptions struct {
+	Board  string `json:"board,omitempty"`  // instead of Makai School
+	List   string `json:"list,omitempty"`   // daily-reset's Daily or canvas-messages' Weekly
+	To     string `json:"to,omitempty"`     // sync-canvas and sync-moodle end date, YYYY-MM-DD
+	DryRun bool   `json:"dryRun,omitempty"` // preview sync-moodle, sync-sheet, plugins, and grade-email
+}
+
+func (o JobOptions) board() string {
+	if o.Board != "" {
+		return o.Board
+	}
+	return "Makai School"
+}
+
+func (o JobOptions) list(fallback string) string {
+	if o.List != "" {
+		return o.List
+	}
+	return fallback
+}
+
+// scheduledJob returns the function for a --run job name
+func (c *TrelloClient) scheduledJob(name, jiraTasksDir string, opts JobOptions) (func() error, error) {
+	switch name {
+	case "refresh":
+		return c.CacheData, nil
+	case "snapshot":
+		return c.TakeSnapshot, nil
+	case "daily-reset":
+		return func() error { return c.ResetDailyTasks(opts.board(), opts.list("Daily")) }, nil
+	case "sync-mirrors":
+		return c.SyncMirrors, nil
+	case "check-links":
+		return func() error { return c.CheckCardLinks(NewLinkChecker()) }, nil
+	case "update-parts":
+		return func() error { return c.UpdatePartProgress(opts.board()) }, nil
+	case "create-weekly":
+		return c.CreateWeeklyCards, nil
+	case "rebucket":
+		return func() error { return c.RebucketCards(opts.board()) }, nil
+	case "week-review":
+		return func() error { return c.CreateWeekInReview(opts.board(), "Daily", "Weekly", optionalGPAEstimate()) }, nil
+	case "grade-email":
+		return func() error {
+			canvasClient, err := canvasClientFromEnv()
+			if err != nil {
+				return err
+			}
+			return c.SendGradeEmail(canvasClient, opts.board(), "Daily", opts.DryRun)
+		}, nil
+	case "check-attendance":
+		return func() error { return c.CheckAttendance(opts.board(), os.Getenv("ATTENDANCE_FOLLOWUP") == "true") }, nil
+	case "canvas-messages":
+		return func() error {
+			canvasClient, err := canvasClientFromEnv()
+			if err != nil {
+				return err
+			}
+			return c.SyncCanvasMessages(canvasClient, opts.board(), opts.list("Weekly"), os.Getenv("CANVAS_MARK_MESSAGES_READ") == "true")
+		}, nil
+	case "sync-jira":
+		return func() error { return c.SyncJiraTasks(jiraTasksDir) }, nil
+	case "sync-canvas":
+		return func() error {
+			canvasClients, err := canvasClientsFromEnv()
+			if err != nil {
+				return err
+			}
+			end, err := syncEndDate(opts.To, "to", "CANVAS_SYNC_TO", time.Now())
+			if err != nil {
+				return err
+			}
+			return c.SyncAllCanvas(canvasClients, pastDueSince(time.Now(), includePastDueDays()), end)
+		}, nil
+	case "sync-outlook":
+		return func() error {
+			graph, err := graphClientFromEnv()
+			if err != nil {
+				return err
+			}
+			return c.SyncOutlookItems(graph)
+		}, nil
+	case "sync-asana":
+		return func() error {
+			asana, err := asanaClientFromEnv()
+			if err != nil {
+				return err
+			}
+			return c.SyncAsanaTasks(asana, splitList(os.Getenv("ASANA_PROJECTS")))
+		}, nil
+	case "sync-gitlab":
+		return func() error {
+			gitlab, err := gitlabClientFromEnv()
+			if err != nil {
+				return err
+			}
+			return c.SyncGitLab(gitlab)
+		}, nil
+	case "sync-linear":
+		return func() error {
+			linear, err := linearClientFromEnv()
+			if err != nil {
+				return err
+			}
+			return c.SyncLinearIssues(linear)
+		}, nil
+	case "sync-oncall":
+		return func() error {
+			providers, err := onCallProvidersFromEnv()
+			if err != nil {
+				return err
+			}
+			return c.SyncOnCall(providers, onCallDays())
+		}, nil
+	case "sync-sheet":
+		return func() error {
+			source := os.Getenv("SHEET_SOURCE")
+			if source == "" {
+				return fmt.Errorf("SHEET_SOURCE must be set")
+			}
+			return c.SyncSheet(source, opts.DryRun)
+		}, nil
+	case "sync-moodle":
+		return func() error {
+			moodleClient, err := moodleClientFromEnv()
+			if err != nil {
+				return err
+			}
+			end, err := syncEndDate(opts.To, "to", "MOODLE_SYNC_TO", time.Now())
+			if err != nil {
+				return err
+			}
+			return c.SyncMoodleAssignments(moodleClient, pastDueSince(time.Now(), includePastDueDays()), end, opts.DryRun, "")
+		}, nil
+	}
+
+	if board, ok := strings.CutPrefix(name, "sundown:"); ok {
+		return func() error { return c.CreateDailySundownNotification(board) }, nil
+	}
+	if source, ok := strings.CutPrefix(name, "sheet:"); ok {
+		return func() error { return c.SyncSheet(source, opts.DryRun) }, nil
+	}
+	if plugin, ok := strings.CutPrefix(name, "plugin:"); ok {
+		return func() error {
+			return c.SyncPlugins([]string{plugin}, time.Now().AddDate(0, 0, syncHorizonDays()), opts.DryRun)
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown job '%s' (want refresh, snapshot, daily-reset, create-weekly, rebucket, week-review, grade-email, update-parts, check-links, sync-mirrors, sync-jira, sync-canvas, canvas-messages, check-attendance, sync-moodle, sync-sheet, sync-outlook, sync-asana, sync-gitlab, sync-linear, sync-oncall, sundown:<board>, sheet:<source>, plugin:<name>, or run-all)", name)
+}
+
+// runAllPreset is the job name that stands for the RUN_ALL job list
+const runAllPreset = "run-all"
+
+// expandJobPresets replaces run-all with the jobs listed in RUN_ALL
+func expandJobPresets(jobNames []string) ([]string, error) {
+	var expanded []string
+	for _, name := range jobNames {
+		if name != runAllPreset {
+			expanded = append(expanded, name)
+			continue
+		}
+		preset := splitList(os.Getenv("RUN_ALL"))
+		if len(preset) == 0 {
+			return nil, fmt.Errorf("%s needs RUN_ALL set to a comma-separated job list, e.g. refresh,sync-canvas,daily-reset", runAllPreset)
+		}
+		for _, job := range preset {
+			if job == runAllPreset {
+				return nil, fmt.Errorf("RUN_ALL can't include %s", runAllPreset)
+			}
+		}
+		expanded = append(expanded, preset...)
+	}
+	return expanded, nil
+}
+
+// combinedJobOrder is the order commands run in when several are given at
+// once: the cache first, then the syncs that bring in work, upkeep, the
+// daily reset, and the notifications last
+var combinedJobOrder = []string{
+	"refresh", "snapshot",
+	"sync-jira", "sync-canvas", "sync-moodle", "sync-sheet", "sync-plugins", "sync-outlook",
+	"sync-asana", "sync-gitlab", "sync-linear", "sync-oncall",
+	"canvas-messages", "check-attendance", "sync-mirrors", "update-parts", "check-links",
+	"rebucket", "daily-reset", "create-weekly", "week-review", "grade-email", "sundown-notify",
+}
+
+// combinedJobs returns the --run jobs for the command flags that are set, in
+// combinedJobOrder. value reads a flag's value, for the flags that take one.
+func combinedJobs(set map[string]bool, value func(name string) string) []string {
+	var jobs []string
+	for _, name := range combinedJobOrder {
+		if !set[name] {
+			continue
+		}
+		switch name {
+		case "sundown-notify":
+			jobs = append(jobs, "sundown:"+value(name))
+		case "sync-sheet":
+			jobs = append(jobs, "sheet:"+value(name))
+		case "sync-plugins":
+			for _, plugin := range splitList(value(name)) {
+				jobs = append(jobs, "plugin:"+plugin)
+			}
+		default:
+			jobs = append(jobs, name)
+		}
+	}
+	return jobs
+}
+
+// RunScheduledJobs runs each job in order, continuing past failures, and
+// returns the summary
+func (c *TrelloClient) RunScheduledJobs(jobNames []string, jiraTasksDir string) (RunSummary, error) {
+	jobNames, err := expandJobPresets(jobNames)
+	if err != nil {
+		return RunSummary{}, err
+	}
+
+	// Resolve every job up front so a typo fails before anything runs
+	var jobs []func() error
+	for _, name := range jobNames {
+		job, err := c.scheduledJob(name, jiraTasksDir, JobOptions{})
+		if err != nil {
+			return RunSummary{}, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	summary := RunSummary{Started: time.Now()}
+	for i, job := range jobs {
+		if shuttingDown() {
+			fmt.Printf("Shutting down; skipping %s\n", strings.Join(jobNames[i:], ", "))
+			break
+		}
+		summary.Jobs = append(summary.Jobs, c.runJob(jobNames[i], job))
+	}
+	summary.Finished = time.Now()
+
+	return summary, nil
+}
+
+// PostRunSummary creates or updates the status card on an ops list
+func (c *TrelloClient) PostRunSummary(boardName, listName string, summary RunSummary) error {
+	listID, err := c.FindListByName(boardName, listName)
+	if err != nil {
+		return err
+	}
+
+	cards, err := c.GetCardsInList(listID)
+	if err != nil {
+		return fmt.Errorf("failed to get cards in %s list: %w", listName, err)
+	}
+
+	title := summary.Title()
+	desc := summary.Format()
+
+	for _, card := range cards {
+		if strings.HasPrefix(card.Name, runSummaryCardPrefix) {
+			return c.UpdateCardFields(card.ID, CardPatch{Name: &title, Desc: &desc})
+		}
+	}
+
+	card, err := c.CreateCard(listID, title, desc, "")
+	if err != nil {
+		return fmt.Errorf("failed to create status card: %w", err)
+	}
+	return c.UpdateCardPosition(card.ID, "top")
+}
+
+// optionalGPAEstimate estimates GPA when Canvas is configured, returning nil
+// (with a warning on failure) so callers can carry on without it
+func optionalGPAEstimate() *GPAEstimate {
+	canvasClient, err := canvasClientFromEnv()
+	if err != nil {
+		return nil
+	}
+
+	estimate, err := canvasClient.EstimateGPA()
+	if err != nil {
+		fmt.Printf("Warning: failed to estimate GPA: %v\n", err)
+		return nil
+	}
+	return estimate
+}
+
+// canvasClientFromEnv builds a Canvas client from CANVAS_API_TOKEN and CANVAS_BASE_URL
+func canvasClientFromEnv() (*CanvasClient, error) {
+	canvasToken := os.Getenv("CANVAS_API_TOKEN")
+	canvasURL := os.Getenv("CANVAS_BASE_URL")
+	if canvasToken == "" || canvasURL == "" {
+		return nil, fmt.Errorf("CANVAS_API_TOKEN and CANVAS_BASE_URL must be set")
+	}
+	return NewCanvasClient(canvasToken, canvasURL), nil
+}
+
+// moodleClientFromEnv builds a Moodle client from MOODLE_WSTOKEN and MOODLE_BASE_URL
+func moodleClientFromEnv() (*MoodleClient, error) {
+	moodleToken := os.Getenv("MOODLE_WSTOKEN")
+	moodleURL := os.Getenv("MOODLE_BASE_URL")
+	if moodleToken == "" || moodleURL == "" {
+		return nil, fmt.Errorf("MOODLE_WSTOKEN and MOODLE_BASE_URL must be set")
+	}
+	return NewMoodleClient(moodleURL, moodleToken), nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunJobCountsWrites(t *testing.T) {
+	client := &TrelloClient{writes: map[string]int{"POST": 2}}
+
+	result := client.runJob("sync-canvas", func() error {
+		client.writes["POST"] += 3
+		client.writes["PUT"] += 4
+		client.writes["DELETE"]++
+		return errors.New("canvas unavailable")
+	})
+
+	if result.Created != 3 || result.Updated != 4 || result.Deleted != 1 {
+		t.Errorf("counts = %d/%d/%d, want 3/4/1", result.Created, result.Updated, result.Deleted)
+	}
+	if result.Err == nil {
+		t.Error("expected the job error to be recorded")
+	}
+}
+
+func TestRunSummaryFormat(t *testing.T) {
+	start := time.Date(2025, 10, 6, 23, 0, 0, 0, time.UTC)
+	summary := RunSummary{
+		Started:  start,
+		Finished: start.Add(95 * time.Second),
+		Jobs: []JobResult{
+			{Name: "refresh", Duration: 5 * time.Second},
+			{Name: "sync-canvas", Duration: 90 * time.Second, Created: 2, Updated: 7, Err: errors.New("401 Unauthorized")},
+		},
+	}
+
+	if summary.Failed() != 1 {
+		t.Errorf("Failed() = %d, want 1", summary.Failed())
+	}
+	if title := summary.Title(); !strings.HasPrefix(title, runSummaryCardPrefix) || !strings.Contains(title, "1 of 2") {
+		t.Errorf("Title() = %q", title)
+	}
+
+	desc := summary.Format()
+	for _, want := range []string{"**Duration**: 1m35s", "| sync-canvas | ❌ Failed | 1m30s | 2 | 7 | 0 |", "- sync-canvas: 401 Unauthorized"} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("Format() missing %q in:\n%s", want, desc)
+		}
+	}
+}
+
+func TestRunScheduledJobsRejectsUnknownJob(t *testing.T) {
+	client := &TrelloClient{}
+	if _, err := client.RunScheduledJobs([]string{"refresh", "sync-cavnas"}, ""); err == nil {
+		t.Error("expected an error for an unknown job name")
+	}
+}
+
+func TestExpandJobPresets(t *testing.T) {
+	t.Setenv("RUN_ALL", "refresh, sync-canvas,daily-reset")
+	got, err := expandJobPresets([]string{"snapshot", "run-all"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != "snapshot,refresh,sync-canvas,daily-reset" {
+		t.Errorf("expandJobPresets() = %v", got)
+	}
+
+	t.Setenv("RUN_ALL", "refresh,run-all")
+	if _, err := expandJobPresets([]string{"run-all"}); err == nil {
+		t.Error("expected an error for a RUN_ALL that includes itself")
+	}
+	t.Setenv("RUN_ALL", "")
+	if _, err := expandJobPresets([]string{"run-all"}); err == nil {
+		t.Error("expected an error when RUN_ALL isn't set")
+	}
+}
+
+func TestCombinedJobs(t *testing.T) {
+	values := map[string]string{"sundown-notify": "Makai School", "sync-plugins": "khan,duolingo", "sync-sheet": "grades.csv"}
+	value := func(name string) string { return values[name] }
+
+	tests := []struct {
+		name string
+		set  []string
+		want string
+	}{
+		{"flag order doesn't matter", []string{"sundown-notify", "daily-reset", "sync-canvas"}, "sync-canvas,daily-reset,sundown:Makai School"},
+		{"plugins expand", []string{"sync-plugins", "refresh"}, "refresh,plugin:khan,plugin:duolingo"},
+		{"sheet keeps its source", []string{"sync-sheet", "sync-canvas"}, "sync-canvas,sheet:grades.csv"},
+		{"options are ignored", []string{"board", "rebucket"}, "rebucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := make(map[string]bool)
+			for _, name := range tt.set {
+				set[name] = true
+			}
+			if got := strings.Join(combinedJobs(set, value), ","); got != tt.want {
+				t.Errorf("combinedJobs() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
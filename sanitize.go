@@ -0,0 +1,81 @@
+package main
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Scripts and styles are dropped along with everything inside them
+	scriptTagRegex = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(script|style)\s*>`)
+	// Embedded media become a link to their source
+	mediaTagRegex = regexp.MustCompile(`(?is)<(iframe|video|audio|embed|object)\b([^>]*)>(?:.*?</(?:iframe|video|audio|object)\s*>)?`)
+	imageTagRegex = regexp.MustCompile(`(?is)<img\b([^>]*)>`)
+	linkTagRegex  = regexp.MustCompile(`(?is)<a\b([^>]*)>(.*?)</a\s*>`)
+	listItemRegex = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	itemEndRegex  = regexp.MustCompile(`(?i)</li\s*>`)
+	srcAttrRegex  = regexp.MustCompile(`(?is)\b(?:src|data|href)\s*=\s*["']([^"']+)["']`)
+	altAttrRegex  = regexp.MustCompile(`(?is)\b(?:alt|title)\s*=\s*["']([^"']*)["']`)
+	blankRunRegex = regexp.MustCompile(`\n{3,}`)
+	// Real tags only, so Markdown autolinks like <https://...> survive
+	htmlTagRegex = regexp.MustCompile(`(?i)</?[a-z][a-z0-9]*(?:\s[^>]*)?/?>`)
+)
+
+// sanitizeDescription turns an LMS description into text that reads well in
+// Trello. HTML loses its tags, with paragraphs kept as line breaks, list
+// items as "- " bullets, links
+// kept as Markdown links, and embedded media (videos, iframes) replaced with
+// a link to the source; scripts and styles go entirely. Entities such as
+// &nbsp;, &amp; and &eacute; are decoded whether or not there's HTML.
+func sanitizeDescription(s string) string {
+	if htmlTagRegex.MatchString(s) {
+		s = scriptTagRegex.ReplaceAllString(s, "")
+		s = mediaTagRegex.ReplaceAllStringFunc(s, func(tag string) string {
+			return mediaLink(tag, "Embedded media")
+		})
+		s = imageTagRegex.ReplaceAllStringFunc(s, func(tag string) string {
+			return mediaLink(tag, "Image")
+		})
+		s = linkTagRegex.ReplaceAllStringFunc(s, func(tag string) string {
+			match := linkTagRegex.FindStringSubmatch(tag)
+			text := strings.Join(strings.Fields(htmlTagRegex.ReplaceAllString(match[2], "")), " ")
+			src := srcAttrRegex.FindStringSubmatch(match[1])
+			if src == nil {
+				return text
+			}
+			if text == "" {
+				text = src[1]
+			}
+			return "[" + text + "](" + src[1] + ")"
+		})
+		s = itemEndRegex.ReplaceAllString(s, "")
+		s = listItemRegex.ReplaceAllString(s, "\n- ")
+		s = blockTagRegex.ReplaceAllString(s, "\n")
+		s = htmlTagRegex.ReplaceAllString(s, "")
+	}
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	s = blankRunRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.Trim(s, "\n")
+}
+
+// mediaLink is the Markdown link that stands in for an embedded element, or
+// nothing when it has no source to link to
+func mediaLink(tag, fallback string) string {
+	src := srcAttrRegex.FindStringSubmatch(tag)
+	if src == nil {
+		return ""
+	}
+	label := fallback
+	if alt := altAttrRegex.FindStringSubmatch(tag); alt != nil && strings.TrimSpace(alt[1]) != "" {
+		label = strings.TrimSpace(alt[1])
+	}
+	return "\n[" + label + "](" + src[1] + ")\n"
+}
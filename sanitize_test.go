@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestSanitizeDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "Read chapter 4\n  - indented note", "Read chapter 4\n  - indented note"},
+		{"entities", "Tom &amp; Jerry&nbsp;&eacute;t&eacute; &#x4E2D;&#25991;", "Tom & Jerry été 中文"},
+		{"paragraphs", "<p>First&nbsp;part</p><p>Second</p>", "First part\n\nSecond"},
+		{"list", "<ul><li>One</li><li>Two</li></ul>", "- One\n- Two"},
+		{"link", `<p>See <a href="https://x.test/a?b=1&amp;c=2">the <b>rubric</b></a></p>`, "See [the rubric](https://x.test/a?b=1&c=2)"},
+		{"script", "<p>Hi</p><script>alert('x')</script><style>p{color:red}</style>", "Hi"},
+		{"iframe", `<p>Watch:</p><iframe src="https://www.youtube.com/embed/abc" title="Lecture 3"></iframe>`, "Watch:\n\n[Lecture 3](https://www.youtube.com/embed/abc)"},
+		{"video without source", "<video controls></video>Notes", "Notes"},
+		{"image", `<img src="https://x.test/cell.png" alt="Cell diagram">`, "[Cell diagram](https://x.test/cell.png)"},
+		{"autolink kept", "Due soon, see <https://x.test/a>", "Due soon, see <https://x.test/a>"},
+		{"blank runs", "One\n\n\n\nTwo   \n", "One\n\nTwo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeDescription(tt.in); got != tt.want {
+				t.Errorf("sanitizeDescription() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
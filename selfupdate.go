@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// releasesURL is the GitHub API endpoint for the newest published release
+const releasesURL = "https://api.github.com/repos/five-star-reveiws/trello-daily-reset/releases/latest"
+
+// GitHubRelease is the subset of a GitHub release used by --self-update
+type GitHubRelease struct {
+	TagName string `json:"tag_name"`
+	Assets  []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// releaseAssetName is the binary name published for a platform,
+// e.g. trello-client_linux_amd64 or trello-client_windows_amd64.exe
+func releaseAssetName(goos, goarch string) string {
+	name := fmt.Sprintf("trello-client_%s_%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// checksumsAssetName is the release asset listing each binary's SHA-256,
+// in sha256sum's format
+const checksumsAssetName = "SHA256SUMS"
+
+// findReleaseAsset returns the download URL for a platform's binary
+func findReleaseAsset(release *GitHubRelease, goos, goarch string) (string, error) {
+	want := releaseAssetName(goos, goarch)
+	if url := release.assetURL(want); url != "" {
+		return url, nil
+	}
+	return "", fmt.Errorf("release %s has no %s binary", release.TagName, want)
+}
+
+// assetURL returns the download URL for a named asset, or ""
+func (r *GitHubRelease) assetURL(name string) string {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset.BrowserDownloadURL
+		}
+	}
+	return ""
+}
+
+// expectedChecksum finds a file's SHA-256 in sha256sum output
+// ("<hash>  <name>", or "<hash> *<name>" for binary mode)
+func expectedChecksum(sums, name string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no checksum for %s", checksumsAssetName, name)
+}
+
+// download fetches a URL, failing on any status but 200
+func download(httpClient *http.Client, url string) (*http.Response, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download failed with status %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// SelfUpdate replaces the running binary with the latest GitHub release
+func SelfUpdate() error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved
+	}
+
+	return selfUpdate(releasesURL, exePath)
+}
+
+// selfUpdate checks apiURL for a newer release and swaps it in at exePath
+func selfUpdate(apiURL, exePath string) error {
+	httpClient := &http.Client{Timeout: 60 * time.Second}
+
+	resp, err := httpClient.Get(apiURL)
+	if err != nil {
+		return fmt.Errorf("failed to check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("release check failed with status %s: %s", resp.Status, string(body))
+	}
+
+	var release GitHubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return fmt.Errorf("failed to decode release: %w", err)
+	}
+
+	if !isNewerVersion(version, release.TagName) {
+		fmt.Printf("Already up to date (%s)\n", version)
+		return nil
+	}
+
+	downloadURL, err := findReleaseAsset(&release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+
+	// Never install a binary that can't be checked against the release
+	sumsURL := release.assetURL(checksumsAssetName)
+	if sumsURL == "" {
+		return fmt.Errorf("release %s has no %s, refusing to update without a checksum", release.TagName, checksumsAssetName)
+	}
+	sumsResp, err := download(httpClient, sumsURL)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", checksumsAssetName, err)
+	}
+	sums, err := io.ReadAll(sumsResp.Body)
+	sumsResp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", checksumsAssetName, err)
+	}
+	want, err := expectedChecksum(string(sums), releaseAssetName(runtime.GOOS, runtime.GOARCH))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updating %s -> %s...\n", version, release.TagName)
+
+	binResp, err := download(httpClient, downloadURL)
+	if err != nil {
+		return fmt.Errorf("failed to download update: %w", err)
+	}
+	defer binResp.Body.Close()
+
+	// Write next to the binary so the final rename stays on one filesystem
+	tmp, err := os.CreateTemp(filepath.Dir(exePath), ".trello-client-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), binResp.Body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write update: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write update: %w", err)
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != want {
+		return fmt.Errorf("downloaded binary's SHA-256 %s doesn't match %s (%s), refusing to update", got, checksumsAssetName, want)
+	}
+	if err := os.Chmod(tmpPath, 0755); err != nil {
+		return fmt.Errorf("failed to make update executable: %w", err)
+	}
+
+	// Windows can't overwrite a running executable, but it can rename it
+	oldPath := exePath + ".old"
+	os.Remove(oldPath)
+	if err := os.Rename(exePath, oldPath); err != nil {
+		return fmt.Errorf("failed to move current binary aside: %w", err)
+	}
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		os.Rename(oldPath, exePath)
+		return fmt.Errorf("failed to install update: %w", err)
+	}
+	if runtime.GOOS != "windows" {
+		os.Remove(oldPath)
+	}
+
+	fmt.Printf("%s Updated to %s\n", iconSuccess, release.TagName)
+	return nil
+}
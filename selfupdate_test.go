@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestExpectedChecksum(t *testing.T) {
+	sums := "aaa111  trello-client_linux_amd64\nBBB222 *trello-client_windows_amd64.exe\n"
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"trello-client_linux_amd64", "aaa111", false},
+		{"trello-client_windows_amd64.exe", "bbb222", false},
+		{"trello-client_darwin_arm64", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expectedChecksum(sums, tt.name)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("expectedChecksum() = %q, %v; want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelfUpdate(t *testing.T) {
+	assetName := releaseAssetName(runtime.GOOS, runtime.GOARCH)
+	sum := sha256.Sum256([]byte("new binary"))
+	goodSums := hex.EncodeToString(sum[:]) + "  " + assetName + "\n"
+	badSum := sha256.Sum256([]byte("tampered"))
+	badSums := hex.EncodeToString(badSum[:]) + "  " + assetName + "\n"
+
+	tests := []struct {
+		name    string
+		sums    string // "" publishes no SHA256SUMS asset
+		wantErr string
+		want    string
+	}{
+		{"matching checksum", goodSums, "", "new binary"},
+		{"no checksums", "", "refusing to update without a checksum", "old binary"},
+		{"checksum mismatch", badSums, "doesn't match", "old binary"},
+		{"binary not listed", "abc  trello-client_plan9_386\n", "has no checksum", "old binary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/latest":
+					assets := fmt.Sprintf(`{"name": %q, "browser_download_url": %q}`, assetName, server.URL+"/download")
+					if tt.sums != "" {
+						assets += fmt.Sprintf(`, {"name": %q, "browser_download_url": %q}`, checksumsAssetName, server.URL+"/sums")
+					}
+					fmt.Fprintf(w, `{"tag_name": "v9.9.9", "assets": [%s]}`, assets)
+				case "/download":
+					w.Write([]byte("new binary"))
+				case "/sums":
+					w.Write([]byte(tt.sums))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			exePath := filepath.Join(t.TempDir(), "trello-client")
+			if err := os.WriteFile(exePath, []byte("old binary"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			err := selfUpdate(server.URL+"/latest", exePath)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("selfUpdate() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("selfUpdate() error = %v, want %q", err, tt.wantErr)
+			}
+
+			data, err := os.ReadFile(exePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("binary contents = %q, want %q", data, tt.want)
+			}
+			leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(exePath), ".trello-client-update-*"))
+			if len(leftovers) != 0 {
+				t.Errorf("temp files left behind: %v", leftovers)
+			}
+		})
+	}
+}
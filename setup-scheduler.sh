@@ -17,7 +17,7 @@ echo "Loading launch agent..."
 launchctl load "$PLIST_DEST"
 
 echo "✅ Scheduler set up successfully!"
-echo "The daily reset will run every day at 8:00 PM"
+echo "The daily reset will run every day at 8:00 PM, and at login if a run was missed"
 echo ""
 echo "To check status: launchctl list | grep com.makai.trello"
 echo "To stop: launchctl unload $PLIST_DEST"
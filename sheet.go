@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// sheetSourceName labels spreadsheet cards in their metadata and comments
+const sheetSourceName = "Sheet"
+
+// googleSheetRegex pulls the spreadsheet ID out of a Google Sheets link
+var googleSheetRegex = regexp.MustCompile(`docs\.google\.com/spreadsheets/d/([a-zA-Z0-9_-]+)`)
+
+// sheetDueLayouts are the due date formats accepted in a spreadsheet, tried in order
+var sheetDueLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"1/2/2006 15:04",
+	"1/2/2006 3:04 PM",
+	"1/2/2006",
+}
+
+// sheetColumns maps accepted header names to item fields
+var sheetColumns = map[string]string{
+	"id":          "id",
+	"title":       "title",
+	"assignment":  "title",
+	"course":      "course",
+	"subject":     "course",
+	"due":         "due",
+	"due date":    "due",
+	"notes":       "notes",
+	"description": "notes",
+	"url":         "url",
+	"link":        "url",
+}
+
+// sheetCSVURL turns a Google Sheets link into its CSV export URL, keeping the
+// tab (gid) when the link names one. Other URLs are returned unchanged.
+func sheetCSVURL(source string) string {
+	match := googleSheetRegex.FindStringSubmatch(source)
+	if match == nil {
+		return source
+	}
+
+	exportURL := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/export?format=csv", match[1])
+	if u, err := url.Parse(source); err == nil {
+		gid := u.Query().Get("gid")
+		if fragment, ok := strings.CutPrefix(u.Fragment, "gid="); ok && gid == "" {
+			gid = fragment
+		}
+		if gid != "" {
+			exportURL += "&gid=" + url.QueryEscape(gid)
+		}
+	}
+	return exportURL
+}
+
+// readSheet loads CSV from a local file or a URL (including Google Sheets
+// shared as "anyone with the link")
+func readSheet(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(expandHome(source))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spreadsheet: %w", err)
+		}
+		return data, nil
+	}
+
+	resp, err := http.Get(sheetCSVURL(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download spreadsheet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("spreadsheet download failed with status %d (is the sheet shared by link?)", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spreadsheet: %w", err)
+	}
+	return data, nil
+}
+
+// parseSheetDue reads a due date cell; dates without a time are due at
+// 11:59 PM in loc
+func parseSheetDue(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range sheetDueLayouts {
+		due, err := time.ParseInLocation(layout, value, loc)
+		if err != nil {
+			continue
+		}
+		if layout == "2006-01-02" || layout == "1/2/2006" {
+			due = due.Add(23*time.Hour + 59*time.Minute)
+		}
+		return due, nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized due date '%s' (use YYYY-MM-DD or M/D/YYYY)", value)
+}
+
+// sheetRowID is a stable ID for rows without an id column, so re-sorting the
+// sheet doesn't duplicate cards
+func sheetRowID(course, title string) string {
+	sum := sha1.Sum([]byte(strings.ToLower(strings.TrimSpace(course)) + "\x00" + strings.ToLower(strings.TrimSpace(title))))
+	return hex.EncodeToString(sum[:6])
+}
+
+// parseSheet reads assignments from CSV with a header row. Only a title
+// column is required; blank rows are skipped.
+func parseSheet(data []byte, loc *time.Location) ([]PluginItem, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spreadsheet CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, header := range rows[0] {
+		if field, ok := sheetColumns[strings.ToLower(strings.TrimSpace(header))]; ok {
+			if _, taken := columns[field]; !taken {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("spreadsheet needs a 'title' column")
+	}
+
+	cell := func(row []string, field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var items []PluginItem
+	seen := make(map[string]int)
+	for n, row := range rows[1:] {
+		item := PluginItem{
+			ID:          cell(row, "id"),
+			Title:       cell(row, "title"),
+			Course:      cell(row, "course"),
+			Description: cell(row, "notes"),
+			URL:         cell(row, "url"),
+		}
+		if item.Title == "" {
+			continue
+		}
+		if item.ID == "" {
+			item.ID = sheetRowID(item.Course, item.Title)
+		}
+		if first, dup := seen[item.ID]; dup {
+			return nil, fmt.Errorf("row %d repeats row %d (give them different titles or an id column)", n+2, first)
+		}
+		seen[item.ID] = n + 2
+
+		if dueCell := cell(row, "due"); dueCell != "" {
+			due, err := parseSheetDue(dueCell, loc)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", n+2, err)
+			}
+			item.Due = due.Format(time.RFC3339)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// SyncSheet syncs assignments from a CSV file or Google Sheet to Trello,
+// using SHEET_BOARD and SHEET_LIST when set
+func (c *TrelloClient) SyncSheet(source string, dryRun bool) error {
+	fmt.Println("Starting spreadsheet sync...")
+
+	data, err := readSheet(source)
+	if err != nil {
+		return err
+	}
+
+	items, err := parseSheet(data, time.Local)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Found %d assignment(s) in spreadsheet\n", len(items))
+
+	return c.syncSourceItems(sheetSourceName, os.Getenv("SHEET_BOARD"), os.Getenv("SHEET_LIST"), items, dryRun)
+}
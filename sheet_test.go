@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSheetCSVURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"edit link with tab", "https://docs.google.com/spreadsheets/d/abc_123/edit#gid=42", "https://docs.google.com/spreadsheets/d/abc_123/export?format=csv&gid=42"},
+		{"link without tab", "https://docs.google.com/spreadsheets/d/abc_123/edit?usp=sharing", "https://docs.google.com/spreadsheets/d/abc_123/export?format=csv"},
+		{"other url", "https://example.com/tutor.csv", "https://example.com/tutor.csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sheetCSVURL(tt.source); got != tt.expected {
+				t.Errorf("sheetCSVURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSheet(t *testing.T) {
+	loc := time.FixedZone("HST", -10*60*60)
+	csv := "\xef\xbb\xbfSubject,Assignment,Due Date,Notes\n" +
+		"Math,Fractions worksheet,2025-10-10,Pages 4-6\n" +
+		",,,\n" +
+		"Science,Lab report,10/12/2025 3:00 PM,\n" +
+		"Art,Sketchbook,,\n"
+
+	items, err := parseSheet([]byte(csv), loc)
+	if err != nil {
+		t.Fatalf("parseSheet() error = %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items (blank row skipped), got %d", len(items))
+	}
+
+	if items[0].Course != "Math" || items[0].Description != "Pages 4-6" {
+		t.Errorf("unexpected first item: %+v", items[0])
+	}
+	if items[0].Due != "2025-10-10T23:59:00-10:00" {
+		t.Errorf("date-only due = %q, want end of day", items[0].Due)
+	}
+	if items[1].Due != "2025-10-12T15:00:00-10:00" {
+		t.Errorf("due with time = %q", items[1].Due)
+	}
+	if items[2].Due != "" {
+		t.Errorf("blank due should stay empty, got %q", items[2].Due)
+	}
+	if items[0].ID != sheetRowID("math", "Fractions Worksheet ") {
+		t.Error("row IDs should ignore case and surrounding spaces")
+	}
+}
+
+func TestParseSheetErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{"no title column", "course,due\nMath,2025-10-10\n"},
+		{"bad date", "title,due\nWorksheet,next Friday\n"},
+		{"duplicate row", "title,course\nWorksheet,Math\nWorksheet,Math\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseSheet([]byte(tt.csv), time.UTC); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
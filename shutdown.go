@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// syncCursorFile records how far interrupted syncs got, by source
+const syncCursorFile = "sync_cursor.json"
+
+// syncCursorMaxAge is how long an interrupted sync can be resumed. After
+// that the LMS data has likely moved on, so the sync starts over.
+const syncCursorMaxAge = 12 * time.Hour
+
+// errSyncInterrupted is returned by a sync that stopped early for a shutdown
+var errSyncInterrupted = errors.New("sync interrupted by shutdown; the next run resumes where it left off")
+
+var (
+	stopRequested  atomic.Bool
+	shutdownSignal sync.Once
+)
+
+// handleShutdownSignals makes SIGTERM and Ctrl+C stop syncs and scheduled
+// runs between cards instead of mid-request. A second signal exits at once.
+func handleShutdownSignals() {
+	shutdownSignal.Do(watchShutdownSignals)
+}
+
+func watchShutdownSignals() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	go func() {
+		sig := <-signals
+		fmt.Printf("\nReceived %v: finishing the current card, then stopping (send again to quit now)\n", sig)
+		stopRequested.Store(true)
+		<-signals
+		os.Exit(1)
+	}()
+}
+
+// shuttingDown reports whether a shutdown signal has arrived
+func shuttingDown() bool {
+	return stopRequested.Load()
+}
+
+// SyncCursor is the items an interrupted sync already handled
+type SyncCursor struct {
+	Done    []string  `json:"done"`
+	SavedAt time.Time `json:"savedAt"`
+}
+
+func loadSyncCursors(path string) (map[string]SyncCursor, error) {
+	cursors := make(map[string]SyncCursor)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cursors, nil
+		}
+		return nil, fmt.Errorf("failed to read sync cursor: %w", err)
+	}
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sync cursor: %w", err)
+	}
+	return cursors, nil
+}
+
+func saveSyncCursors(path string, cursors map[string]SyncCursor) error {
+	if len(cursors) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove sync cursor: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync cursor: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync cursor: %w", err)
+	}
+	return nil
+}
+
+// syncProgress tracks one sync's items so a shutdown can save a cursor and
+// the next run can skip what was already done. A nil syncProgress (for dry
+// runs) still stops on shutdown but never saves or resumes.
+type syncProgress struct {
+	path   string
+	source string
+	done   map[string]bool
+	order  []string
+}
+
+// resumeSync starts tracking a sync, picking up a recent cursor left by an
+// interrupted run of the same source
+func resumeSync(path, source string, now time.Time) *syncProgress {
+	progress := &syncProgress{path: path, source: source, done: make(map[string]bool)}
+	cursors, err := loadSyncCursors(path)
+	if err != nil {
+		fmt.Printf("Warning: starting %s sync over: %v\n", source, err)
+		return progress
+	}
+	cursor, ok := cursors[source]
+	if !ok || now.Sub(cursor.SavedAt) > syncCursorMaxAge {
+		return progress
+	}
+	for _, key := range cursor.Done {
+		progress.done[key] = true
+		progress.order = append(progress.order, key)
+	}
+	fmt.Printf("Resuming interrupted %s sync: skipping %s already done\n", source, plural(len(cursor.Done), "item"))
+	return progress
+}
+
+// Step is called before handling each item. It reports whether the item was
+// done before an interruption, or returns errSyncInterrupted (after saving
+// the cursor) once a shutdown has been requested.
+func (p *syncProgress) Step(key string) (bool, error) {
+	if p == nil {
+		if shuttingDown() {
+			return false, errSyncInterrupted
+		}
+		return false, nil
+	}
+	if p.done[key] {
+		return true, nil
+	}
+	if shuttingDown() {
+		if err := p.save(time.Now()); err != nil {
+			return false, fmt.Errorf("%w (and the cursor couldn't be saved: %v)", errSyncInterrupted, err)
+		}
+		return false, errSyncInterrupted
+	}
+	// Items run to completion once started, so this one counts as done
+	p.done[key] = true
+	p.order = append(p.order, key)
+	return false, nil
+}
+
+// Finish clears the cursor once every item has been handled
+func (p *syncProgress) Finish() {
+	if p == nil {
+		return
+	}
+	cursors, err := loadSyncCursors(p.path)
+	if err != nil {
+		fmt.Printf("Warning: failed to clear %s sync cursor: %v\n", p.source, err)
+		return
+	}
+	if _, ok := cursors[p.source]; !ok {
+		return
+	}
+	delete(cursors, p.source)
+	if err := saveSyncCursors(p.path, cursors); err != nil {
+		fmt.Printf("Warning: failed to clear %s sync cursor: %v\n", p.source, err)
+	}
+}
+
+func (p *syncProgress) save(now time.Time) error {
+	cursors, err := loadSyncCursors(p.path)
+	if err != nil {
+		return err
+	}
+	cursors[p.source] = SyncCursor{Done: p.order, SavedAt: now}
+	return saveSyncCursors(p.path, cursors)
+}
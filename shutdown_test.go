@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSyncProgressResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), syncCursorFile)
+	now := time.Now()
+	defer stopRequested.Store(false)
+
+	// First run: two items, then a shutdown before the third
+	progress := resumeSync(path, "canvas", now)
+	for _, key := range []string{"1", "2"} {
+		if done, err := progress.Step(key); done || err != nil {
+			t.Fatalf("Step(%s) = %v, %v", key, done, err)
+		}
+	}
+	stopRequested.Store(true)
+	if _, err := progress.Step("3"); !errors.Is(err, errSyncInterrupted) {
+		t.Fatalf("Step after shutdown = %v, want errSyncInterrupted", err)
+	}
+	stopRequested.Store(false)
+
+	// Another source's cursor is kept separately
+	other := resumeSync(path, "moodle", now)
+	if done, _ := other.Step("1"); done {
+		t.Error("moodle resumed canvas's cursor")
+	}
+
+	// Next run skips what was done and finishes the rest
+	progress = resumeSync(path, "canvas", now.Add(time.Hour))
+	tests := []struct {
+		key  string
+		done bool
+	}{{"1", true}, {"2", true}, {"3", false}}
+	for _, tt := range tests {
+		if done, err := progress.Step(tt.key); done != tt.done || err != nil {
+			t.Errorf("resumed Step(%s) = %v, %v, want %v", tt.key, done, err, tt.done)
+		}
+	}
+	progress.Finish()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("cursor file still exists after Finish: %v", err)
+	}
+}
+
+func TestSyncProgressStaleCursor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), syncCursorFile)
+	saved := time.Now().Add(-syncCursorMaxAge - time.Hour)
+	if err := saveSyncCursors(path, map[string]SyncCursor{"moodle": {Done: []string{"1"}, SavedAt: saved}}); err != nil {
+		t.Fatal(err)
+	}
+
+	progress := resumeSync(path, "moodle", time.Now())
+	if done, err := progress.Step("1"); done || err != nil {
+		t.Errorf("Step(1) with a stale cursor = %v, %v, want a fresh start", done, err)
+	}
+}
+
+func TestNilSyncProgressStopsOnShutdown(t *testing.T) {
+	defer stopRequested.Store(false)
+	var progress *syncProgress
+	if done, err := progress.Step("1"); done || err != nil {
+		t.Errorf("Step() = %v, %v", done, err)
+	}
+	stopRequested.Store(true)
+	if _, err := progress.Step("2"); !errors.Is(err, errSyncInterrupted) {
+		t.Errorf("Step() after shutdown = %v, want errSyncInterrupted", err)
+	}
+	progress.Finish()
+}
@@ -0,0 +1,69 @@
+package main
+
+import "fmt"
+
+// BoardSnapshot holds one board's lists and cards for the length of a sync
+// run, so the board is only re-fetched after mutations that need fresh data.
+type BoardSnapshot struct {
+	client  *TrelloClient
+	BoardID string
+	Lists   []List
+	cards   []Card
+	stale   bool
+}
+
+// NewBoardSnapshot fetches a board's lists and cards once
+func NewBoardSnapshot(client *TrelloClient, boardID string) (*BoardSnapshot, error) {
+	lists, err := client.GetBoardLists(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board lists: %w", err)
+	}
+
+	snapshot := &BoardSnapshot{client: client, BoardID: boardID, Lists: lists}
+	if err := snapshot.Refresh(); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// Refresh re-fetches the board's cards
+func (s *BoardSnapshot) Refresh() error {
+	cards, err := s.client.GetBoardCardsByID(s.BoardID)
+	if err != nil {
+		return fmt.Errorf("failed to get board cards: %w", err)
+	}
+	s.cards = cards
+	s.stale = false
+	return nil
+}
+
+// Invalidate marks the cards as out of date after a mutation; the next
+// call to Cards will re-fetch them
+func (s *BoardSnapshot) Invalidate() {
+	s.stale = true
+}
+
+// Cards returns the board's cards, re-fetching only if invalidated
+func (s *BoardSnapshot) Cards() ([]Card, error) {
+	if s.stale {
+		if err := s.Refresh(); err != nil {
+			return nil, err
+		}
+	}
+	return s.cards, nil
+}
+
+// Add records a card created during the run without re-fetching the board
+func (s *BoardSnapshot) Add(card Card) {
+	s.cards = append(s.cards, card)
+}
+
+// ListNames maps list IDs to list names
+func (s *BoardSnapshot) ListNames() map[string]string {
+	names := make(map[string]string)
+	for _, list := range s.Lists {
+		names[list.ID] = list.Name
+	}
+	return names
+}
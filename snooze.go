@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// snoozeFile records every card snoozed with --snooze
+const snoozeFile = "snoozes.json"
+
+// snoozeLabel marks snoozed cards on the board
+var snoozeLabel = LabelSpec{Name: "snoozed", Color: "purple", CreateIfMissing: true}
+
+// Snooze is a card whose due date was pushed back on purpose. OriginalDue
+// is the due date before the first snooze; it is zero for undated cards.
+type Snooze struct {
+	CardID      string    `json:"cardId"`
+	CardName    string    `json:"cardName"`
+	OriginalDue time.Time `json:"originalDue"`
+	Until       time.Time `json:"until"`
+	SnoozedAt   time.Time `json:"snoozedAt"`
+}
+
+// SnoozeLog is the local record of snoozed cards, one entry per card
+type SnoozeLog struct {
+	Snoozes []Snooze `json:"snoozes"`
+}
+
+// LoadSnoozes reads the snooze log, returning an empty log if there isn't one yet
+func LoadSnoozes(path string) (*SnoozeLog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &SnoozeLog{}, nil
+		}
+		return nil, fmt.Errorf("failed to read snooze log: %w", err)
+	}
+
+	var snoozes SnoozeLog
+	if err := json.Unmarshal(data, &snoozes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snooze log: %w", err)
+	}
+	return &snoozes, nil
+}
+
+// Save writes the log through a temp file so a crash can't truncate it
+func (l *SnoozeLog) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snooze log: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snooze log: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Find returns a card's snooze, if it has one
+func (l *SnoozeLog) Find(cardID string) *Snooze {
+	for i := range l.Snoozes {
+		if l.Snoozes[i].CardID == cardID {
+			return &l.Snoozes[i]
+		}
+	}
+	return nil
+}
+
+// Remove drops a card's snooze
+func (l *SnoozeLog) Remove(cardID string) {
+	kept := l.Snoozes[:0]
+	for _, snooze := range l.Snoozes {
+		if snooze.CardID != cardID {
+			kept = append(kept, snooze)
+		}
+	}
+	l.Snoozes = kept
+}
+
+// Between returns snoozes whose original due date falls in [start, end)
+func (l *SnoozeLog) Between(start, end time.Time) []Snooze {
+	var snoozes []Snooze
+	for _, snooze := range l.Snoozes {
+		if !snooze.OriginalDue.Before(start) && snooze.OriginalDue.Before(end) {
+			snoozes = append(snoozes, snooze)
+		}
+	}
+	return snoozes
+}
+
+// parseSnoozeDuration reads durations like "3d", "1w", or "12h"
+func parseSnoozeDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	var d time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(s, "d") || strings.HasSuffix(s, "w"):
+		var n int
+		n, err = strconv.Atoi(s[:len(s)-1])
+		d = time.Duration(n) * 24 * time.Hour
+		if strings.HasSuffix(s, "w") {
+			d *= 7
+		}
+	default:
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid snooze length '%s' (use e.g. 3d, 1w, or 12h)", s)
+	}
+	return d, nil
+}
+
+// snoozeUntil pushes a due date back by d. Cards that are already overdue,
+// or have no due date, are snoozed until d from now.
+func snoozeUntil(due *time.Time, d time.Duration, now time.Time) time.Time {
+	if due == nil || due.Before(now) {
+		return now.Add(d)
+	}
+	return due.Add(d)
+}
+
+// SnoozeCard pushes a card's due date back, labels it "snoozed", and
+// records the snooze so reports and LMS syncs can tell it apart from an
+// overdue card
+func (c *TrelloClient) SnoozeCard(query, length string) error {
+	if query == "" || length == "" {
+		return fmt.Errorf("usage: --snooze <card> <length>, e.g. --snooze \"Essay draft\" 3d")
+	}
+
+	d, err := parseSnoozeDuration(length)
+	if err != nil {
+		return err
+	}
+
+	card, err := c.findCardByQuery(query)
+	if err != nil {
+		return err
+	}
+
+	snoozes, err := LoadSnoozes(snoozeFile)
+	if err != nil {
+		return err
+	}
+
+	until := snoozeUntil(card.Due, d, time.Now())
+	due := until.UTC().Format(trelloDueLayout)
+	if err := c.UpdateCardFields(card.ID, CardPatch{Due: &due}); err != nil {
+		return fmt.Errorf("failed to snooze card: %w", err)
+	}
+
+	// Snoozing again keeps the original due date from the first snooze
+	snooze := snoozes.Find(card.ID)
+	if snooze == nil {
+		snoozes.Snoozes = append(snoozes.Snoozes, Snooze{CardID: card.ID, CardName: card.Name})
+		snooze = &snoozes.Snoozes[len(snoozes.Snoozes)-1]
+		if card.Due != nil {
+			snooze.OriginalDue = *card.Due
+		}
+		if err := c.AddLabelToCardWithOptions(card.ID, snoozeLabel); err != nil {
+			fmt.Printf("Warning: failed to label %s as snoozed: %v\n", card.Name, err)
+		}
+	}
+	snooze.Until = until
+	snooze.SnoozedAt = time.Now()
+
+	if err := snoozes.Save(snoozeFile); err != nil {
+		return err
+	}
+
+	loc := displayLocation()
+	comment := fmt.Sprintf("😴 Snoozed until %s", friendlyTime(until, loc))
+	if !snooze.OriginalDue.IsZero() {
+		comment += fmt.Sprintf(" (originally due %s)", friendlyTime(snooze.OriginalDue, loc))
+	}
+	if err := c.UpsertComment(card.ID, "snooze", comment); err != nil {
+		fmt.Printf("Warning: failed to comment on snoozed card %s: %v\n", card.Name, err)
+	}
+
+	fmt.Printf("%s Snoozed %s until %s\n", iconSuccess, card.Name, friendlyTime(until, loc))
+	return nil
+}
+
+// snoozedDue returns the snoozed due date while the source due date is
+// still the one the card was snoozed from (within Trello's rounding)
+func snoozedDue(snooze *Snooze, sourceDue string) (string, bool) {
+	parsed, err := time.Parse(trelloDueLayout, sourceDue)
+	if err != nil {
+		return "", false
+	}
+	if diff := parsed.Sub(snooze.OriginalDue); diff >= time.Minute || diff <= -time.Minute {
+		return "", false
+	}
+	return snooze.Until.UTC().Format(trelloDueLayout), true
+}
+
+// preserveSnooze returns the due date an LMS sync should set on a card.
+// A snoozed card keeps its snoozed date while the source still has the due
+// date it was snoozed from. If the teacher has since moved the due date,
+// the snooze is cleared and the new date wins.
+func (c *TrelloClient) preserveSnooze(card *Card, sourceDue string) string {
+	snoozes, err := LoadSnoozes(snoozeFile)
+	if err != nil {
+		fmt.Printf("Warning: can't check snoozes for %s: %v\n", card.Name, err)
+		return sourceDue
+	}
+
+	snooze := snoozes.Find(card.ID)
+	if snooze == nil || sourceDue == "" {
+		return sourceDue
+	}
+	if due, kept := snoozedDue(snooze, sourceDue); kept {
+		return due
+	}
+
+	fmt.Printf("  Teacher moved the due date of snoozed card %s; clearing the snooze\n", card.Name)
+	// Report the teacher's move against the date they originally set
+	if !snooze.OriginalDue.IsZero() {
+		original := snooze.OriginalDue
+		card.Due = &original
+	}
+	snoozes.Remove(card.ID)
+	if err := snoozes.Save(snoozeFile); err != nil {
+		fmt.Printf("Warning: failed to clear snooze for %s: %v\n", card.Name, err)
+	}
+	if labels, err := c.GetBoardLabels(card.IDBoard); err != nil {
+		fmt.Printf("Warning: failed to remove snoozed label from %s: %v\n", card.Name, err)
+	} else if label := findLabel(labels, snoozeLabel); label != nil {
+		if err := c.RemoveLabelFromCard(card.ID, label.ID); err != nil {
+			fmt.Printf("Warning: failed to remove snoozed label from %s: %v\n", card.Name, err)
+		}
+	}
+	return sourceDue
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSnoozeDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"3d", 72 * time.Hour, false},
+		{"1w", 7 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{" 2D ", 48 * time.Hour, false},
+		{"0d", 0, true},
+		{"-1d", 0, true},
+		{"soon", 0, true},
+		{"d", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseSnoozeDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSnoozeDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSnoozeDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnoozeUntil(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	future := now.Add(24 * time.Hour)
+	past := now.Add(-48 * time.Hour)
+
+	if got := snoozeUntil(&future, 72*time.Hour, now); !got.Equal(future.Add(72 * time.Hour)) {
+		t.Errorf("snoozeUntil(future) = %v, want due + 3d", got)
+	}
+	if got := snoozeUntil(&past, 72*time.Hour, now); !got.Equal(now.Add(72 * time.Hour)) {
+		t.Errorf("snoozeUntil(overdue) = %v, want now + 3d", got)
+	}
+	if got := snoozeUntil(nil, time.Hour, now); !got.Equal(now.Add(time.Hour)) {
+		t.Errorf("snoozeUntil(nil) = %v, want now + 1h", got)
+	}
+}
+
+func TestSnoozedDue(t *testing.T) {
+	snooze := &Snooze{
+		OriginalDue: time.Date(2025, 10, 6, 5, 59, 0, 0, time.UTC),
+		Until:       time.Date(2025, 10, 9, 5, 59, 0, 0, time.UTC),
+	}
+
+	due, kept := snoozedDue(snooze, "2025-10-06T05:59:00.000Z")
+	if !kept || due != "2025-10-09T05:59:00.000Z" {
+		t.Errorf("unchanged source: snoozedDue() = %q, %v, want the snoozed date", due, kept)
+	}
+
+	if _, kept := snoozedDue(snooze, "2025-10-08T05:59:00.000Z"); kept {
+		t.Errorf("teacher moved the due date, snooze should not be kept")
+	}
+}
+
+func TestSnoozeLogRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snoozes.json")
+	start := time.Date(2025, 10, 5, 0, 0, 0, 0, time.UTC)
+
+	snoozes, err := LoadSnoozes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snoozes.Snoozes = []Snooze{
+		{CardID: "a", CardName: "Essay", OriginalDue: start.Add(24 * time.Hour)},
+		{CardID: "b", CardName: "Lab", OriginalDue: start.AddDate(0, 0, 8)},
+	}
+	if err := snoozes.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSnoozes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	week := loaded.Between(start, start.AddDate(0, 0, 7))
+	if len(week) != 1 || week[0].CardName != "Essay" {
+		t.Errorf("Between() = %+v, want only Essay", week)
+	}
+
+	loaded.Remove("a")
+	if loaded.Find("a") != nil || loaded.Find("b") == nil {
+		t.Errorf("Remove() left %+v", loaded.Snoozes)
+	}
+}
+
+func TestTodayAgendaMarksSnoozed(t *testing.T) {
+	now := time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+	due := now.Add(2 * time.Hour)
+	agenda := TodayAgenda{
+		Date:    now,
+		DueSoon: []Card{{ID: "a", Name: "Essay", Due: &due}, {ID: "b", Name: "Lab", Due: &due}},
+		Snoozed: map[string]bool{"a": true},
+	}
+
+	out := agenda.Format()
+	if !strings.Contains(out, "- Essay (😴 snoozed, due Mon 2:00 PM)") || !strings.Contains(out, "- Lab (due Mon 2:00 PM)") {
+		t.Errorf("Format() = %s", out)
+	}
+}
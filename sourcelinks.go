@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// sourceURLLine is a synced card's link back to the LMS, e.g. "Canvas URL:
+// [Open in Canvas](https://...)". SOURCE_LINKS=raw keeps the bare URL.
+// Either way the line starts "<source> URL: " for the link checker.
+func sourceURLLine(source, link string) string {
+	if link == "" || rawSourceLinks() {
+		return fmt.Sprintf("%s URL: %s", source, link)
+	}
+	return fmt.Sprintf("%s URL: [Open in %s](%s)", source, source, link)
+}
+
+// rawSourceLinks reads SOURCE_LINKS: named (the default) or raw
+func rawSourceLinks() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("SOURCE_LINKS")), "raw")
+}
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestSourceURLLine(t *testing.T) {
+	link := "https://school.instructure.com/courses/1/assignments/5"
+	tests := []struct {
+		name  string
+		links string
+		url   string
+		want  string
+	}{
+		{"named by default", "", link, "Canvas URL: [Open in Canvas](" + link + ")"},
+		{"raw", "raw", link, "Canvas URL: " + link},
+		{"no link", "", "", "Canvas URL: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SOURCE_LINKS", tt.links)
+			got := sourceURLLine("Canvas", tt.url)
+			if got != tt.want {
+				t.Errorf("sourceURLLine() = %q, want %q", got, tt.want)
+			}
+			if tt.url != "" {
+				if links := sourceLinks("Essay\n\n---\n" + got); len(links) != 1 || links[0] != tt.url {
+					t.Errorf("sourceLinks() = %v, want the link back", links)
+				}
+			}
+		})
+	}
+}
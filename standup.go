@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultBlockedLabels is used when STANDUP_BLOCKED_LABELS isn't set
+const defaultBlockedLabels = "Blocked"
+
+// StandupMove is a card that changed lists since the last stand-up
+type StandupMove struct {
+	CardName string
+	From     string
+	To       string
+	Done     bool // moved to a done list or checked off
+}
+
+// Standup is the daily stand-up for a work board
+type Standup struct {
+	Date      time.Time
+	Since     time.Time
+	Yesterday []StandupMove
+	Today     []Card
+	Blockers  []Card
+}
+
+// standupSince is the start of the last workday before day, so Monday's
+// stand-up covers Friday
+func standupSince(day time.Time) time.Time {
+	since := day.AddDate(0, 0, -1)
+	for since.Weekday() == time.Saturday || since.Weekday() == time.Sunday {
+		since = since.AddDate(0, 0, -1)
+	}
+	return since
+}
+
+// blockedLabelNames reads STANDUP_BLOCKED_LABELS, the comma-separated labels
+// that mark a card as blocked
+func blockedLabelNames() []string {
+	value := os.Getenv("STANDUP_BLOCKED_LABELS")
+	if value == "" {
+		value = defaultBlockedLabels
+	}
+	return splitList(value)
+}
+
+// isBlocked reports whether a card carries one of the blocked labels
+func isBlocked(card Card, blockedLabels []string) bool {
+	for _, label := range card.Labels {
+		for _, name := range blockedLabels {
+			if normalizeString(label.Name) == normalizeString(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isActiveList reports whether a list holds work in progress
+func (c *TrelloClient) isActiveList(listName string) bool {
+	status := c.mapListNameToStatus(listName)
+	return status == statusInProgress || status == statusInReview
+}
+
+// buildStandup sorts the board's activity since the last workday into what
+// moved or finished, and its open cards into what's in progress and blocked
+func (c *TrelloClient) buildStandup(cards []Card, actions []BoardAction, listNames map[string]string, doneLists map[string]bool, blockedLabels []string, day time.Time) Standup {
+	standup := Standup{Date: day, Since: standupSince(day)}
+
+	// Trello lists activity newest first; walk it oldest first and keep each
+	// card's latest move, so a card dragged across several lists shows once
+	// with where it started and ended up
+	ordered := append([]BoardAction(nil), actions...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Date.Before(ordered[j].Date) })
+	latest := make(map[string]int)
+	for _, action := range ordered {
+		if action.Date.Before(standup.Since) || !action.Date.Before(day) {
+			continue
+		}
+		card := action.Data.Card
+		old := action.Data.Old
+		move := StandupMove{CardName: card.Name, To: listNames[card.IDList]}
+		switch {
+		case old.IDList != nil && *old.IDList != card.IDList:
+			move.From = listNames[*old.IDList]
+			move.Done = doneLists[card.IDList]
+		case old.DueComplete != nil && card.DueComplete:
+			move.Done = true
+		default:
+			continue
+		}
+
+		if i, seen := latest[card.ID]; seen {
+			if earlier := standup.Yesterday[i].From; earlier != "" {
+				move.From = earlier
+			}
+			move.Done = move.Done || standup.Yesterday[i].Done
+			standup.Yesterday[i] = move
+			continue
+		}
+		latest[card.ID] = len(standup.Yesterday)
+		standup.Yesterday = append(standup.Yesterday, move)
+	}
+
+	for _, card := range cards {
+		if card.Closed || doneLists[card.IDList] {
+			continue
+		}
+		if isBlocked(card, blockedLabels) {
+			standup.Blockers = append(standup.Blockers, card)
+		} else if c.isActiveList(listNames[card.IDList]) {
+			standup.Today = append(standup.Today, card)
+		}
+	}
+	return standup
+}
+
+// Format renders the stand-up as Markdown
+func (s Standup) Format() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "**Stand-up - %s**\n\n", s.Date.Format("Monday, January 2"))
+
+	fmt.Fprintf(&out, "**Yesterday** (since %s)\n", s.Since.Format("Mon Jan 2"))
+	if len(s.Yesterday) == 0 {
+		out.WriteString("- Nothing moved\n")
+	}
+	for _, move := range s.Yesterday {
+		switch {
+		case move.Done:
+			fmt.Fprintf(&out, "- ✅ Finished %s\n", move.CardName)
+		case move.From != "":
+			fmt.Fprintf(&out, "- Moved %s from %s to %s\n", move.CardName, move.From, move.To)
+		default:
+			fmt.Fprintf(&out, "- Moved %s to %s\n", move.CardName, move.To)
+		}
+	}
+
+	out.WriteString("\n**Today**\n")
+	if len(s.Today) == 0 {
+		out.WriteString("- Nothing in progress\n")
+	}
+	for _, card := range s.Today {
+		fmt.Fprintf(&out, "- %s\n", card.Name)
+	}
+
+	out.WriteString("\n**Blockers**\n")
+	if len(s.Blockers) == 0 {
+		out.WriteString("- None\n")
+	}
+	for _, card := range s.Blockers {
+		fmt.Fprintf(&out, "- 🚧 %s\n", card.Name)
+	}
+	return out.String()
+}
+
+// SlackText renders the stand-up in Slack's mrkdwn, which bolds with single asterisks
+func (s Standup) SlackText() string {
+	return strings.ReplaceAll(s.Format(), "**", "*")
+}
+
+// postStandupSlack posts the stand-up to a Slack incoming webhook
+func postStandupSlack(webhook string, s Standup) error {
+	payload, err := json.Marshal(map[string]string{"text": s.SlackText()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack payload: %w", err)
+	}
+	resp, err := http.Post(webhook, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// GetStandup builds today's stand-up for a work board
+func (c *TrelloClient) GetStandup(boardName string) (*Standup, error) {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cache: %w", err)
+	}
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := NewBoardSnapshot(c, board.ID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := snapshot.Cards()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	actions, err := c.GetBoardActions(board.ID, "updateCard", standupSince(day))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board activity: %w", err)
+	}
+
+	standup := c.buildStandup(cards, actions, snapshot.ListNames(), doneListIDs(snapshot.Lists), blockedLabelNames(), day)
+	return &standup, nil
+}
+
+// PostStandup sends the stand-up to STANDUP_SLACK_WEBHOOK_URL
+func PostStandup(standup *Standup) error {
+	webhook := os.Getenv("STANDUP_SLACK_WEBHOOK_URL")
+	if webhook == "" {
+		return fmt.Errorf("set STANDUP_SLACK_WEBHOOK_URL in .env to post the stand-up to Slack")
+	}
+	return postStandupSlack(webhook, *standup)
+}
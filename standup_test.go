@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStandupSince(t *testing.T) {
+	tests := []struct {
+		name string
+		day  time.Time
+		want time.Time
+	}{
+		{"midweek", time.Date(2025, 10, 8, 0, 0, 0, 0, time.UTC), time.Date(2025, 10, 7, 0, 0, 0, 0, time.UTC)},
+		{"monday covers friday", time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC), time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := standupSince(tt.day); !got.Equal(tt.want) {
+				t.Errorf("standupSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildStandup(t *testing.T) {
+	t.Setenv("STANDUP_BLOCKED_LABELS", "")
+	day := time.Date(2025, 10, 8, 0, 0, 0, 0, time.UTC)
+	listNames := map[string]string{"todo": "To Do", "doing": "In Progress", "review": "Code Review", "done": "Done"}
+	doneLists := map[string]bool{"done": true}
+
+	move := func(id, name, from, to string, at time.Time) BoardAction {
+		var action BoardAction
+		action.Date = at
+		action.Data.Card.ID = id
+		action.Data.Card.Name = name
+		action.Data.Card.IDList = to
+		action.Data.Old.IDList = &from
+		return action
+	}
+	yesterday := day.Add(-12 * time.Hour)
+	actions := []BoardAction{ // newest first, like Trello
+		move("1", "AK-1: Router", "doing", "review", yesterday.Add(time.Hour)),
+		move("1", "AK-1: Router", "todo", "doing", yesterday),
+		move("2", "AK-2: Docs", "review", "done", yesterday),
+		move("3", "AK-3: Old", "todo", "doing", day.AddDate(0, 0, -3)), // before yesterday
+	}
+	renamed := move("4", "AK-4: Renamed", "", "todo", yesterday)
+	renamed.Data.Old.IDList = nil
+	actions = append(actions, renamed)
+
+	cards := []Card{
+		{ID: "1", Name: "AK-1: Router", IDList: "review"},
+		{ID: "2", Name: "AK-2: Docs", IDList: "done"},
+		{ID: "3", Name: "AK-3: Old", IDList: "doing"},
+		{ID: "5", Name: "AK-5: Waiting on IT", IDList: "doing", Labels: []Label{{Name: "blocked"}}},
+		{ID: "6", Name: "AK-6: Later", IDList: "todo"},
+	}
+
+	client := &TrelloClient{}
+	standup := client.buildStandup(cards, actions, listNames, doneLists, blockedLabelNames(), day)
+
+	if len(standup.Yesterday) != 2 {
+		t.Fatalf("Yesterday = %+v, want AK-1 and AK-2", standup.Yesterday)
+	}
+	if got := standup.Yesterday[0]; got.From != "To Do" || got.To != "Code Review" || got.Done {
+		t.Errorf("AK-1 move = %+v, want To Do to Code Review", got)
+	}
+	if !standup.Yesterday[1].Done {
+		t.Errorf("AK-2 move = %+v, want it finished", standup.Yesterday[1])
+	}
+	if len(standup.Today) != 2 || standup.Today[0].ID != "1" || standup.Today[1].ID != "3" {
+		t.Errorf("Today = %+v, want AK-1 and AK-3", standup.Today)
+	}
+	if len(standup.Blockers) != 1 || standup.Blockers[0].ID != "5" {
+		t.Errorf("Blockers = %+v, want AK-5", standup.Blockers)
+	}
+
+	text := standup.Format()
+	for _, want := range []string{"Moved AK-1: Router from To Do to Code Review", "✅ Finished AK-2: Docs", "🚧 AK-5: Waiting on IT"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Format() missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestPostStandupSlack(t *testing.T) {
+	var text string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		text = payload["text"]
+	}))
+	defer server.Close()
+
+	standup := Standup{Date: time.Date(2025, 10, 8, 0, 0, 0, 0, time.UTC), Since: time.Date(2025, 10, 7, 0, 0, 0, 0, time.UTC)}
+	if err := postStandupSlack(server.URL, standup); err != nil {
+		t.Fatalf("postStandupSlack() error = %v", err)
+	}
+	if !strings.HasPrefix(text, "*Stand-up - Wednesday, October 8*") || strings.Contains(text, "**") {
+		t.Errorf("posted %q, want Slack bold", text)
+	}
+}
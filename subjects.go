@@ -26,9 +26,9 @@ type SubjectsConfig struct {
 }
 
 func LoadSubjectsConfig() (*SubjectsConfig, error) {
-	data, err := os.ReadFile("subjects.json")
+	data, err := os.ReadFile(findConfigFile("subjects.json"))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read subjects.json: %w", err)
+		return nil, fmt.Errorf("failed to read subjects.json (run --init to create one): %w", err)
 	}
 
 	var config SubjectsConfig
@@ -3,9 +3,6 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"os"
 	"time"
 )
@@ -33,6 +30,7 @@ type SunriseSunsetResult struct {
 // SunsetCache represents the local cache structure
 type SunsetCache struct {
 	Location    SunsetLocation          `json:"location"`
+	Timezone    string                 `json:"timezone"`
 	CachedUntil time.Time              `json:"cached_until"`
 	Data        map[string]string      `json:"data"` // date -> sunset time
 }
@@ -44,22 +42,82 @@ type SunsetLocation struct {
 
 const (
 	sunsetCacheFile = "sunset_cache.json"
+	sunsetCacheDays = 30
 	oremLat         = 40.2969
 	oremLng         = -111.6946
 )
 
-// GetSundownTime gets the sunset time for today using hybrid caching approach
-func GetSundownTime(lat, lng float64) (string, error) {
-	today := time.Now().Format("2006-01-02")
+// GetSundownTime gets the sunset time for a day using hybrid caching approach
+func GetSundownTime(lat, lng float64, day time.Time) (string, error) {
+	today := day.Format("2006-01-02")
 
 	// 1. Check local cache first
 	if cachedTime := checkSunsetCache(today, lat, lng); cachedTime != "" {
 		return cachedTime, nil
 	}
 
-	// 2. Cache miss - fetch next 30 days and cache
+	// 2. Cache miss - ask each configured provider in turn for the next 30 days
 	fmt.Println("Cache miss - fetching sunset data for next 30 days...")
-	return fetchAndCacheSunsetData(lat, lng, today)
+	start, err := time.Parse("2006-01-02", today)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse start date: %w", err)
+	}
+
+	var lastErr error
+	for _, provider := range configuredSunTimeProviders() {
+		sunsets, loc, err := provider.FetchSunsets(lat, lng, start, sunsetCacheDays)
+		if err != nil {
+			fmt.Printf("Warning: sunset provider %s failed: %v\n", provider.Name(), err)
+			lastErr = err
+			continue
+		}
+
+		formatted := make(map[string]string)
+		for date, sunset := range sunsets {
+			formatted[date] = sunset.In(loc).Format("3:04 PM MST")
+		}
+
+		// Offline results are cheap to recompute, so leave the cache for the APIs
+		if provider.Name() != offlineProviderName {
+			if err := writeSunsetCache(lat, lng, loc, start, formatted); err != nil {
+				fmt.Printf("Warning: failed to cache sunset data: %v\n", err)
+			}
+		}
+
+		if todaySunset, ok := formatted[today]; ok {
+			return todaySunset, nil
+		}
+		lastErr = fmt.Errorf("no sunset data found for today (%s) from %s", today, provider.Name())
+	}
+
+	return "", fmt.Errorf("all sunset providers failed: %w", lastErr)
+}
+
+// writeSunsetCache saves formatted sunset times for the window starting at start
+func writeSunsetCache(lat, lng float64, loc *time.Location, start time.Time, data map[string]string) error {
+	end := start.AddDate(0, 0, sunsetCacheDays-1)
+
+	cache := SunsetCache{
+		Location: SunsetLocation{
+			Latitude:  lat,
+			Longitude: lng,
+		},
+		Timezone:    loc.String(),
+		CachedUntil: end.AddDate(0, 0, 1), // Valid until day after end date
+		Data:        data,
+	}
+
+	cacheData, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache: %w", err)
+	}
+
+	if err := os.WriteFile(sunsetCacheFile, cacheData, 0644); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	fmt.Printf("%s Cached sunset data for %d days (until %s)\n", iconSuccess, sunsetCacheDays, end.Format("2006-01-02"))
+	return nil
 }
 
 // checkSunsetCache checks if we have valid cached data for today
@@ -92,122 +150,46 @@ func checkSunsetCache(dateStr string, lat, lng float64) string {
 	return "" // No data for today
 }
 
-// fetchAndCacheSunsetData fetches 30 days of sunset data and caches it
-func fetchAndCacheSunsetData(lat, lng float64, startDate string) (string, error) {
-	// Parse start date
-	start, err := time.Parse("2006-01-02", startDate)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse start date: %w", err)
-	}
-
-	// Calculate end date (30 days from start)
-	end := start.AddDate(0, 0, 29)
-
-	// Build API URL for batch request
-	apiURL := "https://api.sunrisesunset.io/json"
-	u, err := url.Parse(apiURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse API URL: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("lat", fmt.Sprintf("%.6f", lat))
-	q.Set("lng", fmt.Sprintf("%.6f", lng))
-	q.Set("date_start", start.Format("2006-01-02"))
-	q.Set("date_end", end.Format("2006-01-02"))
-	q.Set("time_format", "24")
-	u.RawQuery = q.Encode()
-
-	// Make API request
-	resp, err := http.Get(u.String())
-	if err != nil {
-		return "", fmt.Errorf("failed to make API request: %w", err)
+// resultLocation returns the timezone the API reported for a result.
+// Falls back to the numeric UTC offset, then Mountain Time.
+func resultLocation(result SunriseSunsetResult) *time.Location {
+	if result.Timezone != "" {
+		loc, err := time.LoadLocation(result.Timezone)
+		if err == nil {
+			return loc
+		}
+		fmt.Printf("Warning: failed to load timezone '%s': %v\n", result.Timezone, err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	// utc_offset is reported in minutes
+	if result.UTCOffset != 0 {
+		return time.FixedZone(fmt.Sprintf("UTC%+d", result.UTCOffset/60), result.UTCOffset*60)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	mountainTZ, err := time.LoadLocation("America/Denver")
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var apiResponse SunriseSunsetIOResponse
-	if err := json.Unmarshal(body, &apiResponse); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	// Process results and build cache
-	cache := SunsetCache{
-		Location: SunsetLocation{
-			Latitude:  lat,
-			Longitude: lng,
-		},
-		CachedUntil: end.AddDate(0, 0, 1), // Valid until day after end date
-		Data:        make(map[string]string),
+		fmt.Printf("Warning: failed to load Mountain timezone: %v\n", err)
+		return time.UTC
 	}
+	return mountainTZ
+}
 
-	var todaySunset string
-
-	for _, result := range apiResponse.Results {
-		// Parse sunset time and convert to local time (API returns HH:MM:SS format)
-		sunsetTime, err := time.Parse("15:04:05", result.Sunset)
-		if err != nil {
-			fmt.Printf("Warning: failed to parse sunset time '%s': %v\n", result.Sunset, err)
-			continue // Skip invalid times
-		}
-
-		// Create full date-time for proper timezone conversion
-		resultDate, err := time.Parse("2006-01-02", result.Date)
-		if err != nil {
-			continue
-		}
-
-		// Load Mountain Time zone
-		mountainTZ, err := time.LoadLocation("America/Denver")
-		if err != nil {
-			fmt.Printf("Warning: failed to load Mountain timezone: %v\n", err)
-			mountainTZ = time.UTC // fallback to UTC
-		}
-
-		// The API returns times already adjusted for the location's timezone
-		// So we create the time directly in Mountain Time
-		fullSunset := time.Date(resultDate.Year(), resultDate.Month(), resultDate.Day(),
-			sunsetTime.Hour(), sunsetTime.Minute(), sunsetTime.Second(), 0, mountainTZ)
-
-		formattedTime := fullSunset.Format("3:04 PM MST")
-
-		// Store in cache
-		cache.Data[result.Date] = formattedTime
-
-		// Keep track of today's sunset
-		if result.Date == startDate {
-			todaySunset = formattedTime
+// cachedSunsetTimezone returns the timezone stored with the sunset cache,
+// falling back to Mountain Time
+func cachedSunsetTimezone() *time.Location {
+	if data, err := os.ReadFile(sunsetCacheFile); err == nil {
+		var cache SunsetCache
+		if err := json.Unmarshal(data, &cache); err == nil && cache.Timezone != "" {
+			if loc, err := time.LoadLocation(cache.Timezone); err == nil {
+				return loc
+			}
 		}
 	}
 
-	// Save cache to file
-	cacheData, err := json.MarshalIndent(cache, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal cache: %w", err)
-	}
-
-	if err := os.WriteFile(sunsetCacheFile, cacheData, 0644); err != nil {
-		return "", fmt.Errorf("failed to write cache file: %w", err)
-	}
-
-	fmt.Printf("✅ Cached sunset data for 30 days (until %s)\n", end.Format("2006-01-02"))
-
-	if todaySunset == "" {
-		return "", fmt.Errorf("no sunset data found for today (%s)", startDate)
-	}
-
-	return todaySunset, nil
+	return resultLocation(SunriseSunsetResult{})
 }
 
-// GetTodaySundownTime gets sundown time for today using Orem, Utah coordinates
-func GetTodaySundownTime() (string, error) {
-	return GetSundownTime(oremLat, oremLng)
+// GetTodaySundownTime gets sundown time for a day using Orem, Utah coordinates
+func GetTodaySundownTime(day time.Time) (string, error) {
+	return GetSundownTime(oremLat, oremLng, day)
 }
\ No newline at end of file
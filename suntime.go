@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// SunTimeProvider is a source of sunset times.
+// FetchSunsets returns sunset instants keyed by local date (YYYY-MM-DD)
+// along with the timezone they should be displayed in.
+type SunTimeProvider interface {
+	Name() string
+	FetchSunsets(lat, lng float64, start time.Time, days int) (map[string]time.Time, *time.Location, error)
+}
+
+const (
+	sunriseSunsetIOProviderName  = "sunrisesunset.io"
+	sunriseSunsetOrgProviderName = "sunrise-sunset.org"
+	offlineProviderName          = "offline"
+)
+
+var defaultSunTimeProviders = []string{
+	sunriseSunsetIOProviderName,
+	sunriseSunsetOrgProviderName,
+	offlineProviderName,
+}
+
+// configuredSunTimeProviders returns providers in failover order.
+// Set SUNDOWN_PROVIDERS to a comma-separated list to change the order or drop sources.
+func configuredSunTimeProviders() []SunTimeProvider {
+	names := defaultSunTimeProviders
+	if raw := os.Getenv("SUNDOWN_PROVIDERS"); raw != "" {
+		names = strings.Split(raw, ",")
+	}
+
+	var providers []SunTimeProvider
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case sunriseSunsetIOProviderName:
+			providers = append(providers, sunriseSunsetIOProvider{})
+		case sunriseSunsetOrgProviderName:
+			providers = append(providers, sunriseSunsetOrgProvider{})
+		case offlineProviderName:
+			providers = append(providers, offlineSunProvider{})
+		default:
+			fmt.Printf("Warning: unknown sunset provider '%s'\n", name)
+		}
+	}
+
+	// Never end up with nothing to try
+	if len(providers) == 0 {
+		providers = append(providers, offlineSunProvider{})
+	}
+
+	return providers
+}
+
+// sunriseSunsetIOProvider uses api.sunrisesunset.io, which supports date ranges
+type sunriseSunsetIOProvider struct{}
+
+func (sunriseSunsetIOProvider) Name() string { return sunriseSunsetIOProviderName }
+
+func (sunriseSunsetIOProvider) FetchSunsets(lat, lng float64, start time.Time, days int) (map[string]time.Time, *time.Location, error) {
+	end := start.AddDate(0, 0, days-1)
+
+	// Build API URL for batch request
+	u, err := url.Parse("https://api.sunrisesunset.io/json")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse API URL: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("lat", fmt.Sprintf("%.6f", lat))
+	q.Set("lng", fmt.Sprintf("%.6f", lng))
+	q.Set("date_start", start.Format("2006-01-02"))
+	q.Set("date_end", end.Format("2006-01-02"))
+	q.Set("time_format", "24")
+	u.RawQuery = q.Encode()
+
+	body, err := fetchSunAPI(u.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResponse SunriseSunsetIOResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	sunsets := make(map[string]time.Time)
+	loc := resultLocation(SunriseSunsetResult{})
+
+	for _, result := range apiResponse.Results {
+		// API returns HH:MM:SS already adjusted for the location's timezone
+		sunsetTime, err := time.Parse("15:04:05", result.Sunset)
+		if err != nil {
+			fmt.Printf("Warning: failed to parse sunset time '%s': %v\n", result.Sunset, err)
+			continue // Skip invalid times
+		}
+
+		resultDate, err := time.Parse("2006-01-02", result.Date)
+		if err != nil {
+			continue
+		}
+
+		// Build the time in the location's zone rather than the machine's
+		loc = resultLocation(result)
+		sunsets[result.Date] = time.Date(resultDate.Year(), resultDate.Month(), resultDate.Day(),
+			sunsetTime.Hour(), sunsetTime.Minute(), sunsetTime.Second(), 0, loc)
+	}
+
+	if len(sunsets) == 0 {
+		return nil, nil, fmt.Errorf("no sunset data returned")
+	}
+
+	return sunsets, loc, nil
+}
+
+// sunriseSunsetOrgProvider uses api.sunrise-sunset.org, one request per day
+type sunriseSunsetOrgProvider struct{}
+
+func (sunriseSunsetOrgProvider) Name() string { return sunriseSunsetOrgProviderName }
+
+func (sunriseSunsetOrgProvider) FetchSunsets(lat, lng float64, start time.Time, days int) (map[string]time.Time, *time.Location, error) {
+	loc := cachedSunsetTimezone()
+	sunsets := make(map[string]time.Time)
+
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i).Format("2006-01-02")
+
+		u, err := url.Parse("https://api.sunrise-sunset.org/json")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse API URL: %w", err)
+		}
+
+		q := u.Query()
+		q.Set("lat", fmt.Sprintf("%.6f", lat))
+		q.Set("lng", fmt.Sprintf("%.6f", lng))
+		q.Set("date", date)
+		q.Set("formatted", "0") // ISO 8601 in UTC
+		u.RawQuery = q.Encode()
+
+		body, err := fetchSunAPI(u.String())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var apiResponse struct {
+			Results struct {
+				Sunset string `json:"sunset"`
+			} `json:"results"`
+			Status string `json:"status"`
+		}
+		if err := json.Unmarshal(body, &apiResponse); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if apiResponse.Status != "OK" {
+			return nil, nil, fmt.Errorf("API returned status %s", apiResponse.Status)
+		}
+
+		sunset, err := time.Parse(time.RFC3339, apiResponse.Results.Sunset)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse sunset time '%s': %w", apiResponse.Results.Sunset, err)
+		}
+		sunsets[date] = sunset.In(loc)
+	}
+
+	return sunsets, loc, nil
+}
+
+// offlineSunProvider calculates sunsets locally and never needs the network
+type offlineSunProvider struct{}
+
+func (offlineSunProvider) Name() string { return offlineProviderName }
+
+func (offlineSunProvider) FetchSunsets(lat, lng float64, start time.Time, days int) (map[string]time.Time, *time.Location, error) {
+	loc := cachedSunsetTimezone()
+	sunsets := make(map[string]time.Time)
+
+	for i := 0; i < days; i++ {
+		// Evaluate at local noon so the date matches the location's calendar day
+		day := start.AddDate(0, 0, i)
+		noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, loc)
+
+		_, sunset, err := calculateSunTimes(noon, lat, lng)
+		if err != nil {
+			return nil, nil, err
+		}
+		sunsets[noon.Format("2006-01-02")] = sunset.In(loc)
+	}
+
+	return sunsets, loc, nil
+}
+
+// fetchSunAPI performs a GET against a sun-times API and returns the body
+func fetchSunAPI(apiURL string) ([]byte, error) {
+	resp, err := http.Get(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return body, nil
+}
+
+// calculateSunTimes returns sunrise and sunset (in UTC) for the given date
+// using the NOAA general solar position equations. Accurate to about a minute.
+func calculateSunTimes(date time.Time, lat, lng float64) (time.Time, time.Time, error) {
+	// Fractional year in radians, evaluated at local noon
+	dayOfYear := float64(date.YearDay())
+	gamma := 2 * math.Pi / 365 * (dayOfYear - 1)
+
+	// Equation of time (minutes) and solar declination (radians)
+	eqTime := 229.18 * (0.000075 + 0.001868*math.Cos(gamma) - 0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) - 0.040849*math.Sin(2*gamma))
+	decl := 0.006918 - 0.399912*math.Cos(gamma) + 0.070257*math.Sin(gamma) -
+		0.006758*math.Cos(2*gamma) + 0.000907*math.Sin(2*gamma) -
+		0.002697*math.Cos(3*gamma) + 0.00148*math.Sin(3*gamma)
+
+	// Hour angle for the sun's upper limb at the horizon, with refraction (90.833°)
+	latRad := lat * math.Pi / 180
+	zenith := 90.833 * math.Pi / 180
+	cosHA := math.Cos(zenith)/(math.Cos(latRad)*math.Cos(decl)) - math.Tan(latRad)*math.Tan(decl)
+	if cosHA < -1 || cosHA > 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("no sunrise/sunset at latitude %.4f on %s", lat, date.Format("2006-01-02"))
+	}
+	haDeg := math.Acos(cosHA) * 180 / math.Pi
+
+	// Minutes after UTC midnight
+	sunriseMinutes := 720 - 4*(lng+haDeg) - eqTime
+	sunsetMinutes := 720 - 4*(lng-haDeg) - eqTime
+
+	midnightUTC := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	sunrise := midnightUTC.Add(time.Duration(sunriseMinutes * float64(time.Minute)))
+	sunset := midnightUTC.Add(time.Duration(sunsetMinutes * float64(time.Minute)))
+
+	return sunrise, sunset, nil
+}
+
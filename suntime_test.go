@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateSunTimes(t *testing.T) {
+	mdt := time.FixedZone("MDT", -6*60*60)
+
+	tests := []struct {
+		name   string
+		date   time.Time
+		sunset string
+	}{
+		// Expected values from sunset_cache.json (sunrisesunset.io) for Orem, UT
+		{"orem september", time.Date(2025, 9, 16, 12, 0, 0, 0, mdt), "19:35"},
+		{"orem october", time.Date(2025, 10, 15, 12, 0, 0, 0, mdt), "18:48"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sunrise, sunset, err := calculateSunTimes(test.date, oremLat, oremLng)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !sunrise.Before(sunset) {
+				t.Errorf("sunrise %s should be before sunset %s", sunrise, sunset)
+			}
+
+			want, _ := time.ParseInLocation("2006-01-02 15:04", test.date.Format("2006-01-02")+" "+test.sunset, mdt)
+			if diff := sunset.Sub(want); diff < -3*time.Minute || diff > 3*time.Minute {
+				t.Errorf("sunset = %s, want within 3 minutes of %s", sunset.In(mdt).Format("15:04"), test.sunset)
+			}
+		})
+	}
+}
+
+func TestCalculateSunTimesPolarNight(t *testing.T) {
+	date := time.Date(2025, 12, 21, 12, 0, 0, 0, time.UTC)
+	if _, _, err := calculateSunTimes(date, 80, 0); err == nil {
+		t.Errorf("expected error for polar night")
+	}
+}
+
+func TestResultLocation(t *testing.T) {
+	loc := resultLocation(SunriseSunsetResult{UTCOffset: -360})
+	_, offset := time.Date(2025, 9, 16, 12, 0, 0, 0, loc).Zone()
+	if offset != -6*60*60 {
+		t.Errorf("offset = %d, want %d", offset, -6*60*60)
+	}
+}
+
+func TestConfiguredSunTimeProviders(t *testing.T) {
+	tests := []struct {
+		env      string
+		expected []string
+	}{
+		{"", []string{"sunrisesunset.io", "sunrise-sunset.org", "offline"}},
+		{"offline, sunrisesunset.io", []string{"offline", "sunrisesunset.io"}},
+		{"bogus", []string{"offline"}},
+	}
+
+	for _, test := range tests {
+		t.Setenv("SUNDOWN_PROVIDERS", test.env)
+
+		providers := configuredSunTimeProviders()
+		if len(providers) != len(test.expected) {
+			t.Fatalf("SUNDOWN_PROVIDERS=%q: got %d providers, want %d", test.env, len(providers), len(test.expected))
+		}
+		for i, name := range test.expected {
+			if providers[i].Name() != name {
+				t.Errorf("SUNDOWN_PROVIDERS=%q: provider %d = %s, want %s", test.env, i, providers[i].Name(), name)
+			}
+		}
+	}
+}
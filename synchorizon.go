@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// defaultSyncHorizonDays is how far ahead the LMS and plugin syncs look
+// when nothing else is set
+const defaultSyncHorizonDays = 90
+
+// syncHorizonDays reads SYNC_HORIZON_DAYS, the look-ahead shared by Canvas,
+// Moodle, and plugin syncs
+func syncHorizonDays() int {
+	value := os.Getenv("SYNC_HORIZON_DAYS")
+	if value == "" {
+		return defaultSyncHorizonDays
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 {
+		fmt.Printf("Warning: invalid SYNC_HORIZON_DAYS '%s' (want a positive number of days), using %d\n", value, defaultSyncHorizonDays)
+		return defaultSyncHorizonDays
+	}
+	return days
+}
+
+// syncEndDate is the last due date a sync includes: the date from the
+// command line, then the provider's own env setting (e.g. CANVAS_SYNC_TO),
+// then SYNC_HORIZON_DAYS from now. Dates are YYYY-MM-DD.
+func syncEndDate(flagValue, flagName, envName string, now time.Time) (time.Time, error) {
+	value, source := flagValue, "--"+flagName
+	if value == "" {
+		value, source = os.Getenv(envName), envName
+	}
+	if value == "" {
+		return now.AddDate(0, 0, syncHorizonDays()), nil
+	}
+
+	end, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date '%s' (want YYYY-MM-DD)", source, value)
+	}
+	return end, nil
+}
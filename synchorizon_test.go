@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncEndDate(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		flag    string
+		env     string
+		horizon string
+		want    time.Time
+		wantErr bool
+	}{
+		{"default horizon", "", "", "", now.AddDate(0, 0, defaultSyncHorizonDays), false},
+		{"shared horizon", "", "", "30", now.AddDate(0, 0, 30), false},
+		{"invalid horizon", "", "", "soon", now.AddDate(0, 0, defaultSyncHorizonDays), false},
+		{"env date", "", "2026-12-19", "30", time.Date(2026, 12, 19, 0, 0, 0, 0, time.UTC), false},
+		{"flag wins", "2026-11-01", "2026-12-19", "", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), false},
+		{"bad flag", "11/01/2026", "", "", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CANVAS_SYNC_TO", tt.env)
+			t.Setenv("SYNC_HORIZON_DAYS", tt.horizon)
+			got, err := syncEndDate(tt.flag, "canvas-to", "CANVAS_SYNC_TO", now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("syncEndDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("syncEndDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// syncStatsFile holds one line of counts per completed LMS sync
+const syncStatsFile = "sync_stats.jsonl"
+
+// stormFactor is how many times a source's usual number of updates a run
+// must make to be flagged, e.g. when a teacher moves every due date at once
+const stormFactor = 3
+
+// SyncStats counts what one sync run did with the items it looked at. Items
+// neither created, updated, nor failed were skipped.
+type SyncStats struct {
+	Source    string    `json:"source"`
+	Started   time.Time `json:"started"`
+	Seconds   float64   `json:"seconds"`
+	Evaluated int       `json:"evaluated"`
+	Created   int       `json:"created"`
+	Updated   int       `json:"updated"`
+	Failed    int       `json:"failed"`
+}
+
+// newSyncStats starts counting a sync run. Dry runs leave their stats nil,
+// which counts nothing and saves nothing.
+func newSyncStats(source string) *SyncStats {
+	return &SyncStats{Source: source, Started: time.Now()}
+}
+
+// Skipped is the number of items the run left alone
+func (s *SyncStats) Skipped() int {
+	return max(s.Evaluated-s.Created-s.Updated-s.Failed, 0)
+}
+
+// Evaluate counts an item the sync looked at
+func (s *SyncStats) Evaluate() {
+	if s != nil {
+		s.Evaluated++
+	}
+}
+
+// Create counts a new card
+func (s *SyncStats) Create() {
+	if s != nil {
+		s.Created++
+	}
+}
+
+// Fail counts an item whose card couldn't be written
+func (s *SyncStats) Fail() {
+	if s != nil {
+		s.Failed++
+	}
+}
+
+// Existing counts an item with a card already: failed if a write to it
+// failed, updated if anything on it changed, otherwise skipped
+func (s *SyncStats) Existing(changed, failed bool) {
+	switch {
+	case s == nil:
+	case failed:
+		s.Failed++
+	case changed:
+		s.Updated++
+	}
+}
+
+// Save records the finished run in the stats history. Stats that can't be
+// saved are only a warning; they must never fail the sync.
+func (s *SyncStats) Save(path string) {
+	if s == nil {
+		return
+	}
+	s.Seconds = time.Since(s.Started).Round(time.Millisecond).Seconds()
+	data, err := json.Marshal(s)
+	if err != nil {
+		fmt.Printf("Warning: not recording sync stats: %v\n", err)
+		return
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Printf("Warning: not recording sync stats: %v\n", err)
+		return
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		fmt.Printf("Warning: not recording sync stats: %v\n", err)
+	}
+}
+
+// LoadSyncStats reads the stats history, oldest first
+func LoadSyncStats(path string) ([]SyncStats, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open sync stats: %w", err)
+	}
+	defer file.Close()
+
+	var stats []SyncStats
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var run SyncStats
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal sync stats: %w", err)
+		}
+		stats = append(stats, run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sync stats: %w", err)
+	}
+	return stats, nil
+}
+
+// lastRunsBySource groups the stats by source, keeping each source's last
+// n runs, with sources in the order they first appear
+func lastRunsBySource(stats []SyncStats, n int) ([]string, map[string][]SyncStats) {
+	var sources []string
+	bySource := make(map[string][]SyncStats)
+	for _, run := range stats {
+		if bySource[run.Source] == nil {
+			sources = append(sources, run.Source)
+		}
+		bySource[run.Source] = append(bySource[run.Source], run)
+	}
+	for source, runs := range bySource {
+		if len(runs) > n {
+			bySource[source] = runs[len(runs)-n:]
+		}
+	}
+	return sources, bySource
+}
+
+// updateStorm reports whether the last run updated far more cards than the
+// runs before it did on average
+func updateStorm(runs []SyncStats) bool {
+	if len(runs) < 2 {
+		return false
+	}
+	last := runs[len(runs)-1]
+	total := 0
+	for _, run := range runs[:len(runs)-1] {
+		total += run.Updated
+	}
+	average := float64(total) / float64(len(runs)-1)
+	return last.Updated >= 5 && float64(last.Updated) > stormFactor*max(average, 1)
+}
+
+// writeSyncStats prints each source's last n runs with averages, flagging
+// update storms
+func writeSyncStats(out io.Writer, stats []SyncStats, n int, loc *time.Location) {
+	if len(stats) == 0 {
+		fmt.Fprintln(out, "No sync stats yet; they're recorded after each Canvas, Moodle, and plugin sync.")
+		return
+	}
+	sources, bySource := lastRunsBySource(stats, n)
+	for i, source := range sources {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		runs := bySource[source]
+		fmt.Fprintf(out, "%s (last %s)\n", source, plural(len(runs), "run"))
+		fmt.Fprintf(out, "  %-17s %8s %9s %7s %7s %7s %6s\n", "Started", "Duration", "Evaluated", "Created", "Updated", "Skipped", "Failed")
+
+		var total SyncStats
+		var seconds float64
+		for _, run := range runs {
+			fmt.Fprintf(out, "  %-17s %7.1fs %9d %7d %7d %7d %6d\n", run.Started.In(loc).Format("Jan 2 3:04 PM"),
+				run.Seconds, run.Evaluated, run.Created, run.Updated, run.Skipped(), run.Failed)
+			seconds += run.Seconds
+			total.Evaluated += run.Evaluated
+			total.Created += run.Created
+			total.Updated += run.Updated
+			total.Failed += run.Failed
+		}
+		count := float64(len(runs))
+		fmt.Fprintf(out, "  %-17s %7.1fs %9.1f %7.1f %7.1f %7.1f %6.1f\n", "Average", seconds/count,
+			float64(total.Evaluated)/count, float64(total.Created)/count, float64(total.Updated)/count,
+			float64(total.Skipped())/count, float64(total.Failed)/count)
+
+		if updateStorm(runs) {
+			fmt.Fprintf(out, "  ⚠️ The last run updated %s, well above usual. A teacher may have moved many due dates at once.\n",
+				plural(runs[len(runs)-1].Updated, "card"))
+		}
+	}
+}
+
+// ShowSyncStats prints the trends over the last n runs of each sync
+func ShowSyncStats(n int) error {
+	if n < 1 {
+		return fmt.Errorf("--sync-stats-runs must be at least 1, got %d", n)
+	}
+	stats, err := LoadSyncStats(syncStatsFile)
+	if err != nil {
+		return err
+	}
+	var out strings.Builder
+	writeSyncStats(&out, stats, n, displayLocation())
+	fmt.Print(consoleText(out.String()))
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyncStatsCounts(t *testing.T) {
+	stats := newSyncStats("canvas")
+	for i := 0; i < 6; i++ {
+		stats.Evaluate()
+	}
+	stats.Create()
+	stats.Existing(true, false)
+	stats.Existing(true, true)
+	stats.Existing(false, false)
+	stats.Fail()
+	if stats.Created != 1 || stats.Updated != 1 || stats.Failed != 2 || stats.Skipped() != 2 {
+		t.Errorf("stats = %+v, skipped %d", stats, stats.Skipped())
+	}
+
+	// Dry runs have no stats, and counting them is a no-op
+	var none *SyncStats
+	none.Evaluate()
+	none.Existing(true, false)
+	none.Save(filepath.Join(t.TempDir(), syncStatsFile))
+}
+
+func TestSyncStatsSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), syncStatsFile)
+	if stats, err := LoadSyncStats(path); err != nil || stats != nil {
+		t.Fatalf("LoadSyncStats() with no file = %v, %v", stats, err)
+	}
+
+	for _, source := range []string{"canvas", "moodle"} {
+		stats := newSyncStats(source)
+		stats.Evaluate()
+		stats.Create()
+		stats.Save(path)
+	}
+	stats, err := LoadSyncStats(path)
+	if err != nil {
+		t.Fatalf("LoadSyncStats() error = %v", err)
+	}
+	if len(stats) != 2 || stats[1].Source != "moodle" || stats[1].Created != 1 {
+		t.Errorf("LoadSyncStats() = %+v", stats)
+	}
+}
+
+func TestUpdateStorm(t *testing.T) {
+	runs := func(updated ...int) []SyncStats {
+		var stats []SyncStats
+		for _, n := range updated {
+			stats = append(stats, SyncStats{Updated: n})
+		}
+		return stats
+	}
+	tests := []struct {
+		name string
+		runs []SyncStats
+		want bool
+	}{
+		{"one run", runs(40), false},
+		{"steady", runs(3, 4, 2, 3), false},
+		{"storm", runs(3, 4, 2, 40), true},
+		{"small bump", runs(0, 0, 0, 3), false},
+		{"storm after quiet runs", runs(0, 0, 0, 12), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := updateStorm(tt.runs); got != tt.want {
+				t.Errorf("updateStorm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteSyncStats(t *testing.T) {
+	start := time.Date(2025, 10, 1, 15, 0, 0, 0, time.UTC)
+	var stats []SyncStats
+	for day, updated := range []int{2, 3, 2, 30} {
+		stats = append(stats, SyncStats{Source: "canvas", Started: start.AddDate(0, 0, day), Seconds: 12, Evaluated: 40, Updated: updated})
+	}
+	stats = append(stats, SyncStats{Source: "moodle", Started: start, Seconds: 3, Evaluated: 5, Created: 1})
+
+	var out strings.Builder
+	writeSyncStats(&out, stats, 3, time.UTC)
+	text := out.String()
+	for _, want := range []string{"canvas (last 3 runs)", "Oct 4 3:00 PM", "moodle (last 1 run)", "updated 30 cards"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output missing %q:\n%s", want, text)
+		}
+	}
+	// Three canvas runs and one moodle run
+	if n := strings.Count(text, "Oct "); n != 4 {
+		t.Errorf("output shows %d runs, want 4:\n%s", n, text)
+	}
+}
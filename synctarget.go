@@ -0,0 +1,25 @@
+package main
+
+// SyncTarget points syncs at another board or list than the ones they
+// normally use, from --target-board and --target-list. Empty fields keep
+// each sync's own default.
+type SyncTarget struct {
+	Board string
+	List  string
+}
+
+// board is the target board, or fallback when none was given
+func (t SyncTarget) board(fallback string) string {
+	if t.Board != "" {
+		return t.Board
+	}
+	return fallback
+}
+
+// list is the target list, or fallback when none was given
+func (t SyncTarget) list(fallback string) string {
+	if t.List != "" {
+		return t.List
+	}
+	return fallback
+}
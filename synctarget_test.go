@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestSyncTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    SyncTarget
+		wantBoard string
+		wantList  string
+	}{
+		{"no target keeps the defaults", SyncTarget{}, "Makai School", "Weekly"},
+		{"board only", SyncTarget{Board: "Test School"}, "Test School", "Weekly"},
+		{"board and list", SyncTarget{Board: "Test School", List: "Inbox"}, "Test School", "Inbox"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.target.board("Makai School"); got != tt.wantBoard {
+				t.Errorf("board() = %q, want %q", got, tt.wantBoard)
+			}
+			if got := tt.target.list("Weekly"); got != tt.wantList {
+				t.Errorf("list() = %q, want %q", got, tt.wantList)
+			}
+		})
+	}
+
+	// An empty fallback stays empty, so syncs that pick their first list still do
+	if got := (SyncTarget{}).list(""); got != "" {
+		t.Errorf("list(\"\") = %q, want \"\"", got)
+	}
+}
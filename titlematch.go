@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// defaultTitleMatchThreshold is how alike a title must be for a sync to
+// take over an unlinked card instead of creating a new one
+const defaultTitleMatchThreshold = 0.85
+
+// titleMatchThreshold reads TITLE_MATCH_THRESHOLD (0-1), where 0 turns
+// title matching off
+func titleMatchThreshold() float64 {
+	value := os.Getenv("TITLE_MATCH_THRESHOLD")
+	if value == "" {
+		return defaultTitleMatchThreshold
+	}
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil || threshold < 0 || threshold > 1 {
+		fmt.Printf("Warning: invalid TITLE_MATCH_THRESHOLD '%s' (want 0 to 1), using %g\n", value, defaultTitleMatchThreshold)
+		return defaultTitleMatchThreshold
+	}
+	return threshold
+}
+
+// matchCardByTitle finds the unlinked card whose title best matches a
+// synced item's, for cards made before the sync knew about the item. The
+// card's title must name the course and score at least threshold, and a
+// tie for the best score matches nothing.
+func matchCardByTitle(cards []Card, title, course string, threshold float64, skip map[string]bool) (*Card, float64) {
+	if threshold <= 0 {
+		return nil, 0
+	}
+	courseWords := titleWords(course)
+
+	var best *Card
+	bestScore, tied := 0.0, false
+	for i, card := range cards {
+		if card.Closed || skip[card.ID] || syncedItemKey(card.Description) != "" {
+			continue
+		}
+		words := titleWords(card.Name)
+		namesCourse := true
+		for word := range courseWords {
+			namesCourse = namesCourse && words[word]
+		}
+		if !namesCourse {
+			continue
+		}
+
+		score := titleSimilarity(card.Name, title)
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = &cards[i], score, false
+		case score == bestScore:
+			tied = true
+		}
+	}
+	if best == nil || tied || bestScore < threshold {
+		return nil, 0
+	}
+	return best, bestScore
+}
+
+// TitleMatch records a card a sync adopted by title
+type TitleMatch struct {
+	CardName string
+	Title    string
+	Score    float64
+}
+
+// titleMatcher is a sync's fallback for items with no card by ID. Each
+// card is matched once per sync.
+type titleMatcher struct {
+	threshold float64
+	matched   map[string]bool
+	Matches   []TitleMatch
+}
+
+func newTitleMatcher() *titleMatcher {
+	return &titleMatcher{threshold: titleMatchThreshold(), matched: make(map[string]bool)}
+}
+
+// Find returns the unlinked card matching the item's title and course, or nil
+func (m *titleMatcher) Find(cards []Card, title, course string) *Card {
+	card, score := matchCardByTitle(cards, title, course, m.threshold, m.matched)
+	if card == nil {
+		return nil
+	}
+	m.matched[card.ID] = true
+	m.Matches = append(m.Matches, TitleMatch{CardName: card.Name, Title: title, Score: score})
+	fmt.Printf("Matched existing card %s to %s by title (%.0f%%)\n", card.Name, title, score*100)
+	return card
+}
+
+// Report lists the cards adopted by title, so wrong matches can be undone
+func (m *titleMatcher) Report() {
+	if len(m.Matches) == 0 {
+		return
+	}
+	fmt.Printf("Matched %s by title instead of ID (TITLE_MATCH_THRESHOLD is %g):\n", plural(len(m.Matches), "card"), m.threshold)
+	for _, match := range m.Matches {
+		fmt.Printf("  %s -> %s (%.0f%%)\n", match.CardName, match.Title, match.Score*100)
+	}
+}
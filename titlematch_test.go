@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func TestMatchCardByTitle(t *testing.T) {
+	cards := []Card{
+		{ID: "1", Name: "Biology - Lab Report #3"},
+		{ID: "2", Name: "Lab Report"},
+		{ID: "3", Name: "Biology - Quiz", Description: "Notes\n\n---\nCanvas Assignment ID: 5"},
+		{ID: "4", Name: "History - Essay"},
+		{ID: "5", Name: "History - essay"},
+		{ID: "6", Name: "Biology - Lab report 3", Closed: true},
+	}
+
+	tests := []struct {
+		name      string
+		title     string
+		course    string
+		threshold float64
+		skip      map[string]bool
+		want      string
+	}{
+		{"close title", "Biology - Lab Report 3", "Biology", 0.85, nil, "1"},
+		{"already matched", "Biology - Lab Report 3", "Biology", 0.85, map[string]bool{"1": true}, ""},
+		{"course missing from the card", "Chemistry - Lab Report", "Chemistry", 0.5, nil, ""},
+		{"linked cards are skipped", "Biology - Quiz", "Biology", 0.85, nil, ""},
+		{"tie", "History - Essay", "History", 0.85, nil, ""},
+		{"below threshold", "Biology - Lab Report 3 Draft", "Biology", 0.95, nil, ""},
+		{"off", "Biology - Lab Report 3", "Biology", 0, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, _ := matchCardByTitle(cards, tt.title, tt.course, tt.threshold, tt.skip)
+			got := ""
+			if card != nil {
+				got = card.ID
+			}
+			if got != tt.want {
+				t.Errorf("matchCardByTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTitleMatcherMatchesOnce(t *testing.T) {
+	cards := []Card{{ID: "1", Name: "Biology - Lab Report"}}
+	matcher := &titleMatcher{threshold: 0.85, matched: make(map[string]bool)}
+
+	if card := matcher.Find(cards, "Biology - Lab Report", "Biology"); card == nil {
+		t.Fatal("no match")
+	}
+	if card := matcher.Find(cards, "Biology - Lab Report", "Biology"); card != nil {
+		t.Error("matched the same card twice")
+	}
+	if len(matcher.Matches) != 1 || matcher.Matches[0].Score != 1 {
+		t.Errorf("Matches = %+v", matcher.Matches)
+	}
+}
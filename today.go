@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TodayAgenda is the student's ordered view of what needs attention today
+type TodayAgenda struct {
+	Date          time.Time
+	Dailies       []Card
+	DueSoon       []Card
+	Redos         []Card
+	TestsThisWeek []Card
+	Snoozed       map[string]bool // IDs of cards still snoozed with --snooze
+}
+
+// testCardRegex matches test, quiz, or exam as a whole word, plural or
+// numbered ("Quizzes", "Quiz4"), so "Latest" or "Contest" don't count
+var testCardRegex = regexp.MustCompile(`(?i)\b(test|quiz|exam)(s|zes)?\d*\b`)
+
+// isTestCard reports whether a card looks like a test, quiz, or exam
+func isTestCard(card Card) bool {
+	return testCardRegex.MatchString(card.Name)
+}
+
+// buildTodayAgenda groups board cards into the sections shown by --today
+func buildTodayAgenda(cards []Card, dailyListID string, now time.Time) TodayAgenda {
+	agenda := TodayAgenda{Date: now}
+
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	endOfTomorrow := startOfToday.AddDate(0, 0, 2)
+
+	// Week runs through Sunday night
+	daysUntilSunday := (7 - int(now.Weekday())) % 7
+	endOfWeek := startOfToday.AddDate(0, 0, daysUntilSunday+1)
+
+	for _, card := range cards {
+		if card.Closed || card.DueComplete {
+			continue
+		}
+
+		if card.IDList == dailyListID {
+			agenda.Dailies = append(agenda.Dailies, card)
+			continue
+		}
+
+		if strings.HasPrefix(card.Name, "REDO - ") {
+			agenda.Redos = append(agenda.Redos, card)
+			continue
+		}
+
+		if card.Due == nil {
+			continue
+		}
+		due := card.Due.In(now.Location())
+
+		if isTestCard(card) && due.Before(endOfWeek) && !due.Before(startOfToday) {
+			agenda.TestsThisWeek = append(agenda.TestsThisWeek, card)
+			continue
+		}
+
+		if due.Before(endOfTomorrow) {
+			agenda.DueSoon = append(agenda.DueSoon, card)
+		}
+	}
+
+	// Tests stay in date order; the work lists go most urgent first
+	sortCards(agenda.DueSoon, now)
+	sortCards(agenda.Redos, now)
+	sortCardsByDue(agenda.TestsThisWeek)
+
+	return agenda
+}
+
+// sortCardsByDue orders cards earliest due first, undated cards last
+func sortCardsByDue(cards []Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		if cards[i].Due == nil {
+			return false
+		}
+		if cards[j].Due == nil {
+			return true
+		}
+		return cards[i].Due.Before(*cards[j].Due)
+	})
+}
+
+// Format renders the agenda as plain text (also valid Trello markdown)
+func (a TodayAgenda) Format() string {
+	var out strings.Builder
+
+	out.WriteString(fmt.Sprintf("📅 Today - %s\n", a.Date.Format("Monday, January 2")))
+
+	writeSection := func(title string, cards []Card) {
+		out.WriteString(fmt.Sprintf("\n%s (%d)\n", title, len(cards)))
+		if len(cards) == 0 {
+			out.WriteString("- Nothing here 🎉\n")
+			return
+		}
+		for _, card := range cards {
+			if card.Due != nil && a.Snoozed[card.ID] {
+				due := card.Due.In(a.Date.Location())
+				out.WriteString(fmt.Sprintf("- %s (😴 snoozed, due %s)\n", card.Name, due.Format("Mon 3:04 PM")))
+			} else if card.Due != nil {
+				due := card.Due.In(a.Date.Location())
+				out.WriteString(fmt.Sprintf("- %s (due %s)\n", card.Name, due.Format("Mon 3:04 PM")))
+			} else {
+				out.WriteString(fmt.Sprintf("- %s\n", card.Name))
+			}
+		}
+	}
+
+	writeSection("Dailies", a.Dailies)
+	writeSection("Due Today/Tomorrow", a.DueSoon)
+	writeSection("REDOs", a.Redos)
+	writeSection("Tests This Week", a.TestsThisWeek)
+
+	return out.String()
+}
+
+// GetTodayAgenda builds the agenda for a board entirely from the local cache
+func (c *TrelloClient) GetTodayAgenda(boardName, dailyListName string) (*TodayAgenda, error) {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cache.Cards) == 0 {
+		return nil, fmt.Errorf("no cards in cache; run --refresh first")
+	}
+
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyList, err := findListByName(cache.Lists, board.ID, dailyListName)
+	if err != nil {
+		return nil, fmt.Errorf("%s in board '%s'", err.Error(), board.Name)
+	}
+
+	var boardCards []Card
+	for _, card := range cache.Cards {
+		if card.IDBoard == board.ID {
+			boardCards = append(boardCards, card)
+		}
+	}
+
+	agenda := buildTodayAgenda(inferDoneCards(boardCards, doneListIDs(cache.Lists)), dailyList.ID, time.Now())
+	if snoozes, err := LoadSnoozes(snoozeFile); err != nil {
+		fmt.Printf("Warning: skipping snoozed cards: %v\n", err)
+	} else {
+		agenda.Snoozed = make(map[string]bool)
+		for _, snooze := range snoozes.Snoozes {
+			// Once the snooze runs out the card is plain overdue again
+			if snooze.Until.After(agenda.Date) {
+				agenda.Snoozed[snooze.CardID] = true
+			}
+		}
+	}
+	return &agenda, nil
+}
+
+// PostTodayAgenda creates the agenda as a card at the top of a list
+func (c *TrelloClient) PostTodayAgenda(boardName, listName string, agenda *TodayAgenda) error {
+	listID, err := c.FindListByName(boardName, listName)
+	if err != nil {
+		return err
+	}
+
+	cardTitle := fmt.Sprintf("Today - %s", agenda.Date.Format("Monday, January 2, 2006"))
+	card, err := c.CreateCard(listID, cardTitle, agenda.Format(), "")
+	if err != nil {
+		return fmt.Errorf("failed to create agenda card: %w", err)
+	}
+
+	return c.UpdateCardPosition(card.ID, "top")
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestBuildTodayAgenda(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	cards := []Card{
+		{ID: "1", Name: "Make bed", IDList: "daily"},
+		{ID: "2", Name: "Math - Homework 3", IDList: "weekly", Due: timePtr(now.Add(5 * time.Hour))},
+		{ID: "3", Name: "REDO - English - Essay", IDList: "weekly", Due: timePtr(now.AddDate(0, 0, 6))},
+		{ID: "4", Name: "Biology - Unit Test", IDList: "weekly", Due: timePtr(now.AddDate(0, 0, 3))},
+		{ID: "5", Name: "History - Reading", IDList: "weekly", Due: timePtr(now.AddDate(0, 0, 5))},
+		{ID: "6", Name: "Math - Done already", IDList: "weekly", Due: timePtr(now), DueComplete: true},
+		{ID: "7", Name: "Chemistry - Quiz 2", IDList: "weekly", Due: timePtr(now.AddDate(0, 0, 10))},
+	}
+
+	agenda := buildTodayAgenda(cards, "daily", now)
+
+	tests := []struct {
+		name     string
+		got      []Card
+		expected []string
+	}{
+		{"dailies", agenda.Dailies, []string{"1"}},
+		{"due soon", agenda.DueSoon, []string{"2"}},
+		{"redos", agenda.Redos, []string{"3"}},
+		{"tests this week", agenda.TestsThisWeek, []string{"4"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if len(test.got) != len(test.expected) {
+				t.Fatalf("got %d cards, want %d", len(test.got), len(test.expected))
+			}
+			for i, id := range test.expected {
+				if test.got[i].ID != id {
+					t.Errorf("card %d = %s, want %s", i, test.got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestIsTestCard(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"Biology - Unit Test", true},
+		{"Math - QUIZ 4", true},
+		{"History - Final Exam", true},
+		{"English - Essay", false},
+		{"Science - Unit 3 Tests", true},
+		{"Spanish - Vocab Quizzes", true},
+		{"Math - Quiz4", true},
+		{"Chemistry - Pre-test", true},
+		{"English - Latest reading", false},
+		{"Art - Contest entry", false},
+		{"Math - Example problems", false},
+		{"History - Examine the sources", false},
+	}
+
+	for _, test := range tests {
+		if result := isTestCard(Card{Name: test.name}); result != test.expected {
+			t.Errorf("isTestCard(%q) = %v, want %v", test.name, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// writeFlags are the commands that change Trello. Everything else only reads.
+var writeFlags = []string{
+	"daily-reset", "create-weekly", "week-review", "sundown-notify", "today-post",
+	"sync-canvas", "sync-moodle", "sync-jira", "sync-sheet", "sync-plugins",
+	"sync-outlook", "sync-asana", "sync-gitlab", "sync-linear", "sync-oncall",
+	"sync-mirrors", "track", "split", "update-parts", "snooze", "delete-all",
+	"hygiene-fix", "check-links", "run", "catch-up", "triage",
+	"rebucket", "canvas-messages", "check-attendance", "season-rollover",
+	"relink", "clone-board", "reconcile", "purge-card-metadata",
+}
+
+// dryRunFlags turn a write command into a read-only preview
+var dryRunFlags = map[string]string{
+	"sync-moodle":  "sync-moodle-dry-run",
+	"sync-sheet":   "sync-sheet-dry-run",
+	"sync-plugins": "plugin-dry-run",
+	"reconcile":    "reconcile-dry-run",
+}
+
+// TokenPermission is one grant on a Trello token
+type TokenPermission struct {
+	IDModel   string `json:"idModel"`
+	ModelType string `json:"modelType"`
+	Read      bool   `json:"read"`
+	Write     bool   `json:"write"`
+}
+
+// TokenInfo is what Trello reports about the token in use
+type TokenInfo struct {
+	Permissions []TokenPermission `json:"permissions"`
+	DateExpires *time.Time        `json:"dateExpires"`
+}
+
+// CanWrite reports whether the token may change anything
+func (t TokenInfo) CanWrite() bool {
+	for _, permission := range t.Permissions {
+		if permission.Write {
+			return true
+		}
+	}
+	return false
+}
+
+// writeCommand returns the first command among the set flags that changes
+// Trello, or "" when the run only reads
+func writeCommand(set map[string]bool) string {
+	for _, name := range writeFlags {
+		if set[name] && !set[dryRunFlags[name]] {
+			return name
+		}
+	}
+	return ""
+}
+
+// GetTokenInfo asks Trello what the current token is allowed to do
+func (c *TrelloClient) GetTokenInfo() (*TokenInfo, error) {
+	body, err := c.makeRequest(fmt.Sprintf("/tokens/%s?fields=permissions,dateExpires", c.APIToken))
+	if err != nil {
+		return nil, err
+	}
+
+	var info TokenInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token info: %w", err)
+	}
+	return &info, nil
+}
+
+// RequireWriteAccess fails up front when the token is read-only, so a
+// command that changes Trello stops with one clear error instead of failing
+// on every write. If the check itself fails the command goes ahead.
+func (c *TrelloClient) RequireWriteAccess() error {
+	info, err := c.GetTokenInfo()
+	if err != nil {
+		fmt.Printf("Warning: couldn't check the Trello token's permissions: %v\n", err)
+		return nil
+	}
+	if !info.CanWrite() {
+		return fmt.Errorf("the Trello token is read-only. Read commands like --today, --week-view, --hygiene, and --status still work; for this one, run --authorize-trello (read,write by default) or generate a token with write access")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		set  []string
+		want string
+	}{
+		{"read command", []string{"today", "board"}, ""},
+		{"write command", []string{"no-emoji", "daily-reset"}, "daily-reset"},
+		{"dry run", []string{"sync-sheet", "sync-sheet-dry-run"}, ""},
+		{"today with post", []string{"today", "today-post"}, "today-post"},
+		{"hygiene report", []string{"hygiene"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := make(map[string]bool)
+			for _, name := range tt.set {
+				set[name] = true
+			}
+			if got := writeCommand(set); got != tt.want {
+				t.Errorf("writeCommand(%v) = %q, want %q", tt.set, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireWriteAccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   string
+		status  int
+		wantErr bool
+	}{
+		{"read-write", `{"permissions":[{"idModel":"*","modelType":"Board","read":true,"write":true}]}`, http.StatusOK, false},
+		{"read-only", `{"permissions":[{"idModel":"*","modelType":"Board","read":true,"write":false},{"idModel":"*","modelType":"Organization","read":true,"write":false}]}`, http.StatusOK, true},
+		{"check fails", `invalid token`, http.StatusUnauthorized, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/tokens/tok" {
+					t.Errorf("unexpected request %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.reply))
+			}))
+			defer server.Close()
+
+			client := &TrelloClient{APIToken: "tok", BaseURL: server.URL}
+			if err := client.RequireWriteAccess(); (err != nil) != tt.wantErr {
+				t.Errorf("RequireWriteAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
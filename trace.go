@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultTraceFile is where --trace logs requests unless TRACE_FILE is set
+const defaultTraceFile = "http_trace.jsonl"
+
+// secretParams are query parameters whose values never go in the trace
+var secretParams = []string{
+	"key", "token", "wstoken", "access_token", "refresh_token", "api_key", "apikey",
+	"client_secret", "password", "code", "secret",
+}
+
+// traceFilePath reads TRACE_FILE, defaulting to http_trace.jsonl
+func traceFilePath() string {
+	if path := os.Getenv("TRACE_FILE"); path != "" {
+		return path
+	}
+	return defaultTraceFile
+}
+
+// secretPathHosts put the secret in the URL path (Slack and Discord
+// webhooks), so only their host goes in the trace
+var secretPathHosts = []string{"hooks.slack.com", "discord.com", "discordapp.com"}
+
+// secretEnvSuffixes mark the environment variables holding credentials
+var secretEnvSuffixes = []string{"_TOKEN", "_KEY", "_SECRET", "_PASSWORD"}
+
+// configuredSecrets returns the credentials set in the environment
+func configuredSecrets() map[string]bool {
+	secrets := make(map[string]bool)
+	for _, env := range os.Environ() {
+		name, value, ok := strings.Cut(env, "=")
+		if !ok || len(value) < 8 {
+			continue
+		}
+		for _, suffix := range secretEnvSuffixes {
+			if strings.HasSuffix(name, suffix) {
+				secrets[value] = true
+			}
+		}
+	}
+	return secrets
+}
+
+// redactPath blanks path segments that are credentials: a configured
+// secret, or the token in Trello's /tokens/<token> lookups
+func redactPath(path string) string {
+	secrets := configuredSecrets()
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if secrets[segment] || (i > 0 && segments[i-1] == "tokens") {
+			segments[i] = "REDACTED"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// redactURL blanks credentials in a URL's path, query string, and user info
+// so it can be written to the trace
+func redactURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User("REDACTED")
+	}
+	for _, host := range secretPathHosts {
+		if strings.EqualFold(redacted.Hostname(), host) {
+			redacted.Path, redacted.RawPath = "/REDACTED", ""
+		}
+	}
+	redacted.Path, redacted.RawPath = redactPath(redacted.Path), ""
+	q := redacted.Query()
+	for name := range q {
+		for _, secret := range secretParams {
+			if strings.EqualFold(name, secret) {
+				q.Set(name, "REDACTED")
+			}
+		}
+	}
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
+
+// TraceEntry is one outbound request in the trace file
+type TraceEntry struct {
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	URL       string    `json:"url"`
+	Status    int       `json:"status,omitempty"`
+	LatencyMS int64     `json:"latencyMs"`
+	Retry     int       `json:"retry"` // earlier failed attempts at the same request
+	Error     string    `json:"error,omitempty"`
+}
+
+// tracingTransport is an http.RoundTripper that writes every request to the
+// trace. A request repeating one that just failed counts as a retry.
+type tracingTransport struct {
+	next   http.RoundTripper
+	out    io.Writer
+	mu     sync.Mutex
+	failed map[string]int // failed attempts in a row by method and URL
+}
+
+func newTracingTransport(next http.RoundTripper, out io.Writer) *tracingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &tracingTransport{next: next, out: out, failed: make(map[string]int)}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	entry := TraceEntry{
+		Time:      started.UTC(),
+		Method:    req.Method,
+		URL:       redactURL(req.URL),
+		LatencyMS: time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	attempt := entry.Method + " " + entry.URL
+	entry.Retry = t.failed[attempt]
+	if err != nil || resp.StatusCode >= 400 {
+		t.failed[attempt]++
+	} else {
+		delete(t.failed, attempt)
+	}
+
+	if line, marshalErr := json.Marshal(entry); marshalErr == nil {
+		if _, writeErr := t.out.Write(append(line, '\n')); writeErr != nil {
+			fmt.Printf("Warning: failed to write trace: %v\n", writeErr)
+		}
+	}
+	return resp, err
+}
+
+// startTrace sends every outbound HTTP request through the tracer, appending
+// to the trace file. Clients without their own transport use
+// http.DefaultTransport, so wrapping it covers Trello, the LMSes, and the rest.
+func startTrace(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	http.DefaultTransport = newTracingTransport(http.DefaultTransport, file)
+	return file, nil
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// timeLogFile holds every work session logged with --track
+const timeLogFile = "time_tracking.json"
+
+// TimeSession is one stretch of work on a card; End is nil while it's running
+type TimeSession struct {
+	CardID    string     `json:"cardId"`
+	CardName  string     `json:"cardName"`
+	Start     time.Time  `json:"start"`
+	End       *time.Time `json:"end,omitempty"`
+	JiraIssue string     `json:"jiraIssue,omitempty"` // set when the session should be logged to JIRA
+	Worklog   string     `json:"worklog,omitempty"`   // the JIRA worklog ID once it's logged
+}
+
+// TimeLog is the local record of work sessions
+type TimeLog struct {
+	Sessions []TimeSession `json:"sessions"`
+}
+
+// TrackedTime is the time spent on one card in a period
+type TrackedTime struct {
+	CardName string
+	Duration time.Duration
+}
+
+// LoadTimeLog reads the time log, returning an empty log if there isn't one yet
+func LoadTimeLog(path string) (*TimeLog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &TimeLog{}, nil
+		}
+		return nil, fmt.Errorf("failed to read time log: %w", err)
+	}
+
+	var timeLog TimeLog
+	if err := json.Unmarshal(data, &timeLog); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal time log: %w", err)
+	}
+	return &timeLog, nil
+}
+
+// Save writes the log through a temp file so a crash can't truncate it
+func (l *TimeLog) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal time log: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write time log: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Active returns the running session, if any
+func (l *TimeLog) Active() *TimeSession {
+	for i := range l.Sessions {
+		if l.Sessions[i].End == nil {
+			return &l.Sessions[i]
+		}
+	}
+	return nil
+}
+
+// CardTotal returns the total time logged on a card and the number of
+// finished sessions
+func (l *TimeLog) CardTotal(cardID string) (time.Duration, int) {
+	var total time.Duration
+	sessions := 0
+	for _, session := range l.Sessions {
+		if session.CardID == cardID && session.End != nil {
+			total += session.End.Sub(session.Start)
+			sessions++
+		}
+	}
+	return total, sessions
+}
+
+// Between returns the time spent per card within [start, end), longest
+// first. Sessions that straddle the window only count the overlap.
+func (l *TimeLog) Between(start, end time.Time) []TrackedTime {
+	totals := make(map[string]time.Duration)
+	names := make(map[string]string)
+	for _, session := range l.Sessions {
+		if session.End == nil {
+			continue
+		}
+		from, to := session.Start, *session.End
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if !to.After(from) {
+			continue
+		}
+		totals[session.CardID] += to.Sub(from)
+		names[session.CardID] = session.CardName
+	}
+
+	var tracked []TrackedTime
+	for id, total := range totals {
+		tracked = append(tracked, TrackedTime{CardName: names[id], Duration: total})
+	}
+	sort.Slice(tracked, func(i, j int) bool {
+		if tracked[i].Duration != tracked[j].Duration {
+			return tracked[i].Duration > tracked[j].Duration
+		}
+		return tracked[i].CardName < tracked[j].CardName
+	})
+	return tracked
+}
+
+// formatTrackedDuration renders a duration like "1h 25m", rounded to the minute
+func formatTrackedDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
+
+// cardShortLinkRegex pulls the short link out of a Trello card URL
+var cardShortLinkRegex = regexp.MustCompile(`trello\.com/c/([A-Za-z0-9]+)`)
+
+// matchCards finds cards by ID, exact name, or name fragment
+func matchCards(cards []Card, query string) []Card {
+	queryNorm := normalizeString(query)
+
+	var exact, partial []Card
+	for _, card := range cards {
+		switch {
+		case card.Closed:
+			continue
+		case card.ID == query || normalizeString(card.Name) == queryNorm:
+			exact = append(exact, card)
+		case strings.Contains(normalizeString(card.Name), queryNorm):
+			partial = append(partial, card)
+		}
+	}
+
+	if len(exact) > 0 {
+		return exact
+	}
+	return partial
+}
+
+// findCardByQuery resolves a card URL, ID, or name. Names are matched
+// against the cached cards of every board, so run --refresh after adding cards.
+func (c *TrelloClient) findCardByQuery(query string) (*Card, error) {
+	if match := cardShortLinkRegex.FindStringSubmatch(query); match != nil {
+		body, err := c.makeRequest("/cards/" + match[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get card: %w", err)
+		}
+		var card Card
+		if err := json.Unmarshal(body, &card); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal card: %w", err)
+		}
+		return &card, nil
+	}
+
+	cache, err := c.LoadCache()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cache: %w", err)
+	}
+	if len(cache.Cards) == 0 {
+		return nil, fmt.Errorf("no cards in cache; run --refresh first")
+	}
+
+	matches := matchCards(cache.Cards, query)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no card matching '%s' (run --refresh if it's new)", query)
+	case 1:
+		return &matches[0], nil
+	}
+
+	var names []string
+	for i, card := range matches {
+		if i == 5 {
+			names = append(names, "...")
+			break
+		}
+		names = append(names, card.Name)
+	}
+	return nil, fmt.Errorf("'%s' matches %d cards: %s", query, len(matches), strings.Join(names, "; "))
+}
+
+// postTrackedTotal keeps a single comment on the card with its total time
+func (c *TrelloClient) postTrackedTotal(timeLog *TimeLog, cardID string) error {
+	total, sessions := timeLog.CardTotal(cardID)
+	comment := fmt.Sprintf("⏱ Time tracked: %s over %d session(s)", formatTrackedDuration(total), sessions)
+	return c.UpsertComment(cardID, "time-tracked", comment)
+}
+
+// StartTracking starts a work session on a card. A session running on
+// another card is stopped first.
+func (c *TrelloClient) StartTracking(query string) error {
+	if query == "" {
+		return fmt.Errorf("no card given (usage: --track start <card>)")
+	}
+
+	card, err := c.findCardByQuery(query)
+	if err != nil {
+		return err
+	}
+
+	timeLog, err := LoadTimeLog(timeLogFile)
+	if err != nil {
+		return err
+	}
+
+	if active := timeLog.Active(); active != nil {
+		if active.CardID == card.ID {
+			fmt.Printf("Already tracking %s (started %s)\n", card.Name, active.Start.Local().Format("3:04 PM"))
+			return nil
+		}
+		if err := c.stopSession(timeLog, active); err != nil {
+			return err
+		}
+	}
+
+	session := TimeSession{CardID: card.ID, CardName: card.Name, Start: time.Now()}
+	if jiraClientFromEnv() != nil {
+		session.JiraIssue = jiraIssueKey(*card)
+	}
+	timeLog.Sessions = append(timeLog.Sessions, session)
+	if err := timeLog.Save(timeLogFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Started tracking %s\n", iconSuccess, card.Name)
+	return nil
+}
+
+// StopTracking ends the running session. query is optional; when given it
+// must match the card being tracked.
+func (c *TrelloClient) StopTracking(query string) error {
+	timeLog, err := LoadTimeLog(timeLogFile)
+	if err != nil {
+		return err
+	}
+
+	active := timeLog.Active()
+	if active == nil {
+		return fmt.Errorf("no session is being tracked")
+	}
+
+	if query != "" {
+		card, err := c.findCardByQuery(query)
+		if err != nil {
+			return err
+		}
+		if card.ID != active.CardID {
+			return fmt.Errorf("tracking %s, not %s", active.CardName, card.Name)
+		}
+	}
+
+	return c.stopSession(timeLog, active)
+}
+
+// stopSession ends a session, saves the timeLog, and updates the card's total
+func (c *TrelloClient) stopSession(timeLog *TimeLog, session *TimeSession) error {
+	now := time.Now()
+	session.End = &now
+	if err := timeLog.Save(timeLogFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Stopped tracking %s after %s\n", iconSuccess, session.CardName, formatTrackedDuration(now.Sub(session.Start)))
+
+	// The session is already saved locally, so a failed comment isn't fatal
+	if err := c.postTrackedTotal(timeLog, session.CardID); err != nil {
+		fmt.Printf("Warning: failed to post tracked time on %s: %v\n", session.CardName, err)
+	}
+
+	// Worklogs that fail stay pending for --track push
+	if jira := jiraClientFromEnv(); jira != nil && session.JiraIssue != "" {
+		if pushed, _ := pushWorklogs(jira, timeLog); pushed > 0 {
+			if err := timeLog.Save(timeLogFile); err != nil {
+				fmt.Printf("Warning: failed to record the JIRA worklog: %v\n", err)
+			}
+		}
+	}
+	return nil
+}
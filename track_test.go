@@ -0,0 +1,115 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeLogTotals(t *testing.T) {
+	at := func(day, hour, minute int) *time.Time {
+		ts := time.Date(2025, 10, day, hour, minute, 0, 0, time.UTC)
+		return &ts
+	}
+	timeLog := TimeLog{Sessions: []TimeSession{
+		{CardID: "a", CardName: "Essay", Start: *at(5, 23, 0), End: at(6, 1, 0)}, // straddles the week start
+		{CardID: "a", CardName: "Essay", Start: *at(7, 9, 0), End: at(7, 9, 45)},
+		{CardID: "b", CardName: "MAC-42", Start: *at(8, 10, 0), End: at(8, 13, 0)},
+		{CardID: "b", CardName: "MAC-42", Start: *at(9, 10, 0)}, // still running
+	}}
+
+	total, sessions := timeLog.CardTotal("a")
+	if total != 2*time.Hour+45*time.Minute || sessions != 2 {
+		t.Errorf("CardTotal(a) = %v over %d, want 2h45m over 2", total, sessions)
+	}
+
+	got := timeLog.Between(*at(6, 0, 0), *at(13, 0, 0))
+	want := []TrackedTime{{"MAC-42", 3 * time.Hour}, {"Essay", time.Hour + 45*time.Minute}}
+	if len(got) != len(want) {
+		t.Fatalf("Between() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Between()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if active := timeLog.Active(); active == nil || active.CardID != "b" {
+		t.Errorf("Active() = %+v, want the running MAC-42 session", active)
+	}
+}
+
+func TestTimeLogSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), timeLogFile)
+
+	empty, err := LoadTimeLog(path)
+	if err != nil || len(empty.Sessions) != 0 {
+		t.Fatalf("LoadTimeLog(missing) = %+v, %v; want an empty log", empty, err)
+	}
+
+	start := time.Date(2025, 10, 7, 9, 0, 0, 0, time.UTC)
+	timeLog := &TimeLog{Sessions: []TimeSession{{CardID: "a", CardName: "Essay", Start: start}}}
+	if err := timeLog.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadTimeLog(path)
+	if err != nil {
+		t.Fatalf("LoadTimeLog() error = %v", err)
+	}
+	if len(loaded.Sessions) != 1 || !loaded.Sessions[0].Start.Equal(start) || loaded.Sessions[0].End != nil {
+		t.Errorf("LoadTimeLog() = %+v, want the saved running session", loaded)
+	}
+}
+
+func TestFormatTrackedDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0m"},
+		{25*time.Minute + 40*time.Second, "26m"},
+		{2*time.Hour + 5*time.Minute, "2h 5m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatTrackedDuration(tt.d); got != tt.want {
+				t.Errorf("formatTrackedDuration(%v) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchCards(t *testing.T) {
+	cards := []Card{
+		{ID: "1", Name: "Essay draft"},
+		{ID: "2", Name: "Essay draft - final"},
+		{ID: "3", Name: "MAC-42: Fix login"},
+		{ID: "4", Name: "Old essay", Closed: true},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"essay draft", []string{"1"}},
+		{"essay", []string{"1", "2"}},
+		{"mac-42", []string{"3"}},
+		{"3", []string{"3"}},
+		{"old essay", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var got []string
+			for _, card := range matchCards(cards, tt.query) {
+				got = append(got, card.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("matchCards(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
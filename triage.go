@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// triageRejectedFile records synced items rejected in --triage, so the next
+// sync doesn't bring them back
+const triageRejectedFile = "triage_rejected.json"
+
+// inboxListName reads INBOX_LIST, the list new synced cards land in for
+// --triage; empty sends them straight to their usual list
+func inboxListName() string {
+	return strings.TrimSpace(os.Getenv("INBOX_LIST"))
+}
+
+// syncedItemKey identifies the LMS or plugin item a card was synced from by
+// its metadata ID lines, e.g. "Canvas Assignment ID: 42"
+func syncedItemKey(desc string) string {
+	i := strings.LastIndex(desc, "\n---\n")
+	if i < 0 {
+		return ""
+	}
+
+	var ids []string
+	for _, line := range strings.Split(desc[i+len("\n---\n"):], "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, " ID: ") || strings.HasPrefix(line, canvasInstitutionPrefix) {
+			ids = append(ids, line)
+		}
+	}
+	return strings.Join(ids, "|")
+}
+
+// LoadTriageRejected reads the rejected item keys, returning an empty set if
+// nothing has been rejected yet
+func LoadTriageRejected(path string) (map[string]bool, error) {
+	rejected := make(map[string]bool)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return rejected, nil
+		}
+		return nil, fmt.Errorf("failed to read rejected items: %w", err)
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rejected items: %w", err)
+	}
+	for _, key := range keys {
+		rejected[key] = true
+	}
+	return rejected, nil
+}
+
+// saveTriageRejected writes the rejected item keys, sorted so the file diffs cleanly
+func saveTriageRejected(path string, rejected map[string]bool) error {
+	keys := make([]string, 0, len(rejected))
+	for key := range rejected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rejected items: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write rejected items: %w", err)
+	}
+	return nil
+}
+
+// NewCardPlacement is where a sync puts the cards it creates
+type NewCardPlacement struct {
+	ListID   string
+	horizons map[string]string // horizon list IDs by name, when routing by due date
+	rejected map[string]bool
+}
+
+// newCardPlacement sends new synced cards to the INBOX_LIST list when it's
+// set and on the board, then to the horizon list for their due date when
+// the board has them, otherwise to defaultListID
+func (c *TrelloClient) newCardPlacement(boardName, defaultListID string) NewCardPlacement {
+	placement := NewCardPlacement{ListID: defaultListID}
+	inbox := false
+	if name := inboxListName(); name != "" {
+		if listID, err := c.FindListByName(boardName, name); err != nil {
+			fmt.Printf("Note: no '%s' list on %s, new cards go to their usual list\n", name, boardName)
+		} else {
+			placement.ListID = listID
+			inbox = true
+		}
+	}
+	if !inbox {
+		placement.horizons = c.horizonListIDs(boardName)
+	}
+
+	rejected, err := LoadTriageRejected(triageRejectedFile)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	placement.rejected = rejected
+	return placement
+}
+
+// ListFor returns the list for a new card with a Trello due date (or "")
+func (p NewCardPlacement) ListFor(cardTitle, due string) string {
+	if p.horizons == nil {
+		return p.ListID
+	}
+
+	var dueAt *time.Time
+	if parsed, err := time.Parse(time.RFC3339, due); err == nil {
+		dueAt = &parsed
+	}
+	if name := horizonListForCard(cardTitle, dueAt, time.Now().In(displayLocation())); name != "" {
+		return p.horizons[name]
+	}
+	return p.ListID
+}
+
+// Rejected reports whether the item a new card would be synced from was
+// rejected in --triage, noting that it was left out
+func (p NewCardPlacement) Rejected(cardTitle, desc string) bool {
+	key := syncedItemKey(desc)
+	if key == "" || !p.rejected[key] {
+		return false
+	}
+	fmt.Printf("Skipping %s (rejected in triage)\n", cardTitle)
+	return true
+}
+
+// What --triage does with an inbox card
+const (
+	triageSkip   = "skip"
+	triageAccept = "accept"
+	triageMove   = "move"
+	triageReject = "reject"
+)
+
+// TriageDecision is the answer given for one inbox card
+type TriageDecision struct {
+	Card   Card
+	Action string
+	List   string // for triageMove
+}
+
+// askTriage reads one answer, asking again until it makes sense. ok is
+// false at end of input or on quit.
+func askTriage(reader *bufio.Reader, out io.Writer) (action, list string, all, ok bool) {
+	for {
+		fmt.Fprint(out, "  [a]ccept, [A]ccept all, [l]ist <name>, [r]eject, [s]kip, [q]uit: ")
+		answer, err := reader.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer == "" && err != nil {
+			return "", "", false, false
+		}
+
+		command, arg, _ := strings.Cut(answer, " ")
+		switch command {
+		case "a", "accept":
+			return triageAccept, "", false, true
+		case "A", "all":
+			return triageAccept, "", true, true
+		case "l", "list":
+			if arg = strings.TrimSpace(arg); arg != "" {
+				return triageMove, arg, false, true
+			}
+			fmt.Fprintln(out, "  Which list? e.g. 'l Later'")
+		case "r", "reject":
+			return triageReject, "", false, true
+		case "s", "skip", "":
+			return triageSkip, "", false, true
+		case "q", "quit":
+			return "", "", false, false
+		default:
+			fmt.Fprintf(out, "  Unknown answer '%s'\n", answer)
+		}
+	}
+}
+
+// chooseTriage asks about each inbox card on in. After "accept all" the
+// remaining cards are accepted without asking.
+func chooseTriage(cards []Card, in io.Reader, out io.Writer) []TriageDecision {
+	reader := bufio.NewReader(in)
+	var decisions []TriageDecision
+	acceptAll := false
+	for i, card := range cards {
+		if acceptAll {
+			decisions = append(decisions, TriageDecision{Card: card, Action: triageAccept})
+			continue
+		}
+
+		fmt.Fprintf(out, "[%d/%d] %s", i+1, len(cards), card.Name)
+		if card.Due != nil {
+			fmt.Fprintf(out, " (due %s)", friendlyTime(*card.Due, displayLocation()))
+		}
+		fmt.Fprintln(out)
+
+		action, list, all, ok := askTriage(reader, out)
+		if !ok {
+			break
+		}
+		acceptAll = all
+		decisions = append(decisions, TriageDecision{Card: card, Action: action, List: list})
+	}
+	return decisions
+}
+
+// TriageInbox walks through the cards in the INBOX_LIST list, moving
+// accepted ones to acceptListName (or their horizon list, on boards that
+// route by due date) and archiving rejected ones
+func (c *TrelloClient) TriageInbox(boardName, acceptListName string, in io.Reader, out io.Writer) error {
+	inboxName := inboxListName()
+	if inboxName == "" {
+		return fmt.Errorf("INBOX_LIST must be set to triage")
+	}
+	inboxID, err := c.FindListByName(boardName, inboxName)
+	if err != nil {
+		return err
+	}
+
+	cards, err := c.GetCardsInList(inboxID)
+	if err != nil {
+		return fmt.Errorf("failed to get cards in %s list: %w", inboxName, err)
+	}
+	cards = withoutNoAuto(cards)
+	if len(cards) == 0 {
+		fmt.Fprintf(out, "%s Nothing to triage in '%s'\n", iconSuccess, inboxName)
+		return nil
+	}
+	sortCardsByDue(cards)
+
+	decisions := chooseTriage(cards, in, out)
+
+	rejected, err := LoadTriageRejected(triageRejectedFile)
+	if err != nil {
+		return err
+	}
+	horizons := c.horizonListIDs(boardName)
+	now := time.Now().In(displayLocation())
+	listIDs := make(map[string]string)
+	counts := make(map[string]int)
+	for _, decision := range decisions {
+		card := decision.Card
+		switch decision.Action {
+		case triageAccept, triageMove:
+			listName := acceptListName
+			if decision.Action == triageMove {
+				listName = decision.List
+			} else if horizon := horizonListForCard(card.Name, card.Due, now); horizons != nil && horizon != "" {
+				listName = horizon
+			}
+			listID, ok := listIDs[listName]
+			if !ok {
+				if listID, err = c.FindListByName(boardName, listName); err != nil {
+					fmt.Fprintf(out, "Warning: leaving %s in %s: %v\n", card.Name, inboxName, err)
+					continue
+				}
+				listIDs[listName] = listID
+			}
+			if err := c.MoveCardToList(card.ID, listID); err != nil {
+				fmt.Fprintf(out, "Warning: failed to move %s to %s: %v\n", card.Name, listName, err)
+				continue
+			}
+		case triageReject:
+			if err := c.UpdateCardFields(card.ID, CardPatch{Closed: boolPtr(true)}); err != nil {
+				fmt.Fprintf(out, "Warning: failed to archive %s: %v\n", card.Name, err)
+				continue
+			}
+			if key := syncedItemKey(card.Description); key != "" {
+				rejected[key] = true
+			}
+		}
+		counts[decision.Action]++
+	}
+
+	if counts[triageReject] > 0 {
+		if err := saveTriageRejected(triageRejectedFile, rejected); err != nil {
+			return err
+		}
+	}
+	for listName, listID := range listIDs {
+		if err := c.SortListCards(listID); err != nil {
+			fmt.Fprintf(out, "Warning: failed to sort %s: %v\n", listName, err)
+		}
+	}
+
+	fmt.Fprintf(out, "%s Accepted %d, moved %d, rejected %d, left %d in '%s'\n", iconSuccess,
+		counts[triageAccept], counts[triageMove], counts[triageReject], len(cards)-counts[triageAccept]-counts[triageMove]-counts[triageReject], inboxName)
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncedItemKey(t *testing.T) {
+	tests := []struct {
+		name string
+		desc string
+		want string
+	}{
+		{"canvas", "Read chapter 3\n\n---\nCanvas Assignment ID: 42\nCourse: English 10\nCanvas URL: https://x", "Canvas Assignment ID: 42"},
+		{"canvas institution", "\n\n---\nCanvas Assignment ID: 42\nCourse: ENGL 1010 (UVU)\nCanvas Institution: UVU", "Canvas Assignment ID: 42|Canvas Institution: UVU"},
+		{"moodle", "\n\n---\nMoodle quiz ID: 7\nCourse: Biology", "Moodle quiz ID: 7"},
+		{"plugin", "\n\n---\nkhan Item ID: abc\nCourse: Math", "khan Item ID: abc"},
+		{"ID lines above the metadata don't count", "Project ID: 9\n\n---\nCanvas Assignment ID: 42", "Canvas Assignment ID: 42"},
+		{"no metadata", "Just a card", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := syncedItemKey(tt.desc); got != tt.want {
+				t.Errorf("syncedItemKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChooseTriage(t *testing.T) {
+	cards := []Card{{ID: "1", Name: "One"}, {ID: "2", Name: "Two"}, {ID: "3", Name: "Three"}}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string // card ID:action[:list]
+	}{
+		{"one of each", "a\nl Later\nr\n", []string{"1:accept", "2:move:Later", "3:reject"}},
+		{"accept all", "s\nA\n", []string{"1:skip", "2:accept", "3:accept"}},
+		{"quit", "a\nq\n", []string{"1:accept"}},
+		{"end of input", "r\n", []string{"1:reject"}},
+		{"asks again after a bad answer", "x\nl\nl Done\n\n\n", []string{"1:move:Done", "2:skip", "3:skip"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, d := range chooseTriage(cards, strings.NewReader(tt.input), io.Discard) {
+				entry := d.Card.ID + ":" + d.Action
+				if d.List != "" {
+					entry += ":" + d.List
+				}
+				got = append(got, entry)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("chooseTriage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriageRejectedRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), triageRejectedFile)
+
+	rejected, err := LoadTriageRejected(path)
+	if err != nil || len(rejected) != 0 {
+		t.Fatalf("LoadTriageRejected() on a missing file = %v, %v", rejected, err)
+	}
+
+	rejected["Canvas Assignment ID: 42"] = true
+	if err := saveTriageRejected(path, rejected); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadTriageRejected(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	placement := NewCardPlacement{rejected: loaded}
+	if !placement.Rejected("Essay", "\n\n---\nCanvas Assignment ID: 42") {
+		t.Error("rejected item would be created again")
+	}
+	if placement.Rejected("Quiz", "\n\n---\nCanvas Assignment ID: 43") {
+		t.Error("other item was treated as rejected")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Set at build time with:
+//
+//	go build -ldflags "-X main.version=v1.2.3 -X main.buildDate=2025-10-01"
+var (
+	version   = "dev"
+	buildDate = ""
+)
+
+// versionString describes this build, filling in VCS details recorded by
+// the Go toolchain when ldflags weren't used
+func versionString() string {
+	commit := ""
+	modified := false
+	date := buildDate
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				commit = setting.Value
+			case "vcs.time":
+				if date == "" {
+					date = setting.Value
+				}
+			case "vcs.modified":
+				modified = setting.Value == "true"
+			}
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("trello-client %s", version))
+	if commit != "" {
+		if len(commit) > 12 {
+			commit = commit[:12]
+		}
+		out.WriteString(fmt.Sprintf(" (commit %s", commit))
+		if modified {
+			out.WriteString(", modified")
+		}
+		out.WriteString(")")
+	}
+	if date != "" {
+		out.WriteString(fmt.Sprintf(" built %s", date))
+	}
+	out.WriteString(fmt.Sprintf(" %s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH))
+
+	return out.String()
+}
+
+// parseVersion splits "v1.2.3" into its numeric parts
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	// Ignore pre-release/build suffixes like 1.2.3-rc1
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+
+	var parts []int
+	for _, field := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
+
+// isNewerVersion reports whether latest is newer than current. Development
+// builds (anything that isn't a version number) are always out of date.
+func isNewerVersion(current, latest string) bool {
+	latestParts, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	currentParts, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+
+	for i := 0; i < len(latestParts) || i < len(currentParts); i++ {
+		var l, c int
+		if i < len(latestParts) {
+			l = latestParts[i]
+		}
+		if i < len(currentParts) {
+			c = currentParts[i]
+		}
+		if l != c {
+			return l > c
+		}
+	}
+	return false
+}
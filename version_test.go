@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsNewerVersion(t *testing.T) {
+	tests := []struct {
+		current  string
+		latest   string
+		expected bool
+	}{
+		{"v1.2.3", "v1.2.4", true},
+		{"v1.2.3", "v1.10.0", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v1.3.0", "v1.2.9", false},
+		{"1.2", "v1.2.1", true},
+		{"v1.2.3", "v1.2.4-rc1", true},
+		{"dev", "v0.1.0", true},
+		{"v1.0.0", "nightly", false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s_to_%s", test.current, test.latest), func(t *testing.T) {
+			if result := isNewerVersion(test.current, test.latest); result != test.expected {
+				t.Errorf("isNewerVersion(%q, %q) = %v, want %v", test.current, test.latest, result, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// defaultWatchItems are the lists the parent account watches when
+// WATCH_ITEMS isn't set
+var defaultWatchItems = []string{"Makai School/Daily", "Makai School/Weekly"}
+
+// watchTarget is a list or card the parent account should watch
+type watchTarget struct {
+	Kind string // "list" or "card"
+	ID   string
+	Name string
+}
+
+// watchedItem is a list or card with its subscription state for the
+// member whose token made the request
+type watchedItem struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	ShortURL   string `json:"shortUrl"`
+	IDBoard    string `json:"idBoard"`
+	Subscribed bool   `json:"subscribed"`
+}
+
+// watchItemsFromEnv reads WATCH_ITEMS: comma-separated "Board/List" names
+// and Trello card URLs
+func watchItemsFromEnv() []string {
+	if items := splitList(os.Getenv("WATCH_ITEMS")); len(items) > 0 {
+		return items
+	}
+	return defaultWatchItems
+}
+
+// NewParentClient returns a client acting as the parent account. Trello
+// subscriptions belong to whoever's token makes the request, so watching
+// needs the parent's own token in PARENT_TRELLO_TOKEN.
+func NewParentClient(c *TrelloClient) (*TrelloClient, error) {
+	token := os.Getenv("PARENT_TRELLO_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("set PARENT_TRELLO_TOKEN to the parent account's Trello token (watches belong to whoever's token makes the request)")
+	}
+	return &TrelloClient{APIKey: c.APIKey, APIToken: token, BaseURL: c.BaseURL, Filter: c.Filter}, nil
+}
+
+// resolveWatchTargets turns WATCH_ITEMS entries into list and card IDs
+// using the cache. Entries that can't be found are warnings.
+func (c *TrelloClient) resolveWatchTargets(items []string) []watchTarget {
+	var targets []watchTarget
+	for _, item := range items {
+		if match := cardShortLinkRegex.FindStringSubmatch(item); match != nil {
+			targets = append(targets, watchTarget{Kind: "card", ID: match[1], Name: item})
+			continue
+		}
+
+		boardName, listName, ok := strings.Cut(item, "/")
+		if !ok {
+			fmt.Printf("Warning: skipping watch item '%s' (want \"Board/List\" or a card URL)\n", item)
+			continue
+		}
+		listID, err := c.FindListByName(strings.TrimSpace(boardName), strings.TrimSpace(listName))
+		if err != nil {
+			fmt.Printf("Warning: skipping watch item '%s': %v\n", item, err)
+			continue
+		}
+		targets = append(targets, watchTarget{Kind: "list", ID: listID, Name: item})
+	}
+	return targets
+}
+
+// getWatchState fetches a list or card with its subscription state
+func (c *TrelloClient) getWatchState(target watchTarget) (*watchedItem, error) {
+	body, err := c.makeRequest(fmt.Sprintf("/%ss/%s?fields=name,shortUrl,idBoard,subscribed", target.Kind, target.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	var item watchedItem
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", target.Kind, err)
+	}
+	return &item, nil
+}
+
+// subscribe watches a list or card as the member whose token is in use
+func (c *TrelloClient) subscribe(target watchTarget) error {
+	fields := url.Values{}
+	var endpoint string
+	if target.Kind == "list" {
+		endpoint = fmt.Sprintf("/lists/%s/subscribed", target.ID)
+		fields.Set("value", "true")
+	} else {
+		endpoint = fmt.Sprintf("/cards/%s", target.ID)
+		fields.Set("subscribed", "true")
+	}
+
+	if _, err := c.sendForm("PUT", endpoint, fields); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", target.Kind, err)
+	}
+	return nil
+}
+
+// SetupWatches makes the parent account watch each item in items, then
+// prints every list and card the parent watches on those boards. c resolves
+// names from the cache; parent makes the requests.
+func (c *TrelloClient) SetupWatches(parent *TrelloClient, items []string) error {
+	targets := c.resolveWatchTargets(items)
+	if len(targets) == 0 {
+		return fmt.Errorf("nothing to watch (check WATCH_ITEMS)")
+	}
+
+	boardIDs := make(map[string]bool)
+	for _, target := range targets {
+		state, err := parent.getWatchState(target)
+		if err != nil {
+			fmt.Printf("Warning: can't check %s '%s' as the parent account: %v\n", target.Kind, target.Name, err)
+			continue
+		}
+		if state.Subscribed {
+			fmt.Printf("Already watching %s '%s'\n", target.Kind, state.Name)
+		} else if err := parent.subscribe(target); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+			continue
+		} else {
+			fmt.Printf("%s Now watching %s '%s'\n", iconSuccess, target.Kind, state.Name)
+		}
+
+		boardIDs[state.IDBoard] = true
+	}
+
+	return c.printWatched(parent, boardIDs)
+}
+
+// printWatched reports the lists and cards the parent watches on each board
+func (c *TrelloClient) printWatched(parent *TrelloClient, boardIDs map[string]bool) error {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+
+	fmt.Println("\nCurrently watched by the parent account:")
+	for _, board := range cache.Boards {
+		if !boardIDs[board.ID] {
+			continue
+		}
+
+		var watched []string
+		for _, kind := range []string{"list", "card"} {
+			body, err := parent.makeRequest(fmt.Sprintf("/boards/%s/%ss?fields=name,shortUrl,subscribed", board.ID, kind))
+			if err != nil {
+				return fmt.Errorf("failed to get watched %ss: %w", kind, err)
+			}
+			var items []watchedItem
+			if err := json.Unmarshal(body, &items); err != nil {
+				return fmt.Errorf("failed to unmarshal %ss: %w", kind, err)
+			}
+			for _, item := range items {
+				if !item.Subscribed {
+					continue
+				}
+				line := fmt.Sprintf("%s: %s", kind, item.Name)
+				if item.ShortURL != "" {
+					line += " (" + item.ShortURL + ")"
+				}
+				watched = append(watched, line)
+			}
+		}
+
+		fmt.Printf("%s:\n", board.Name)
+		if len(watched) == 0 {
+			fmt.Println("  (nothing)")
+		}
+		for _, line := range watched {
+			fmt.Printf("  - %s\n", line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWatchItemsFromEnv(t *testing.T) {
+	t.Setenv("WATCH_ITEMS", "")
+	if got := watchItemsFromEnv(); strings.Join(got, ",") != "Makai School/Daily,Makai School/Weekly" {
+		t.Errorf("default watchItemsFromEnv() = %v", got)
+	}
+
+	t.Setenv("WATCH_ITEMS", "Mac/Today, https://trello.com/c/AbC123/4-essay")
+	got := watchItemsFromEnv()
+	if len(got) != 2 || got[1] != "https://trello.com/c/AbC123/4-essay" {
+		t.Errorf("watchItemsFromEnv() = %v", got)
+	}
+
+	targets := (&TrelloClient{}).resolveWatchTargets(got[1:])
+	if len(targets) != 1 || targets[0].Kind != "card" || targets[0].ID != "AbC123" {
+		t.Errorf("resolveWatchTargets() = %+v, want card AbC123", targets)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.PostForm.Encode())
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	parent := &TrelloClient{BaseURL: server.URL}
+	if err := parent.subscribe(watchTarget{Kind: "list", ID: "l1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := parent.subscribe(watchTarget{Kind: "card", ID: "c1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"PUT /lists/l1/subscribed value=true", "PUT /cards/c1 subscribed=true"}
+	if strings.Join(requests, "|") != strings.Join(want, "|") {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
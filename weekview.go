@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// weekViewColumnWidth is how many characters each day's column shows
+const weekViewColumnWidth = 16
+
+// ansiReset ends a colored span
+const ansiReset = "\033[0m"
+
+// labelColors maps Trello label colors to terminal colors
+var labelColors = map[string]string{
+	"green":  "\033[32m",
+	"yellow": "\033[33m",
+	"orange": "\033[38;5;208m",
+	"red":    "\033[31m",
+	"purple": "\033[35m",
+	"blue":   "\033[34m",
+	"sky":    "\033[36m",
+	"lime":   "\033[92m",
+	"pink":   "\033[95m",
+	"black":  "\033[90m",
+}
+
+// listPalette colors cards without a colored label by list, in board order
+var listPalette = []string{"\033[36m", "\033[33m", "\033[32m", "\033[35m", "\033[34m", "\033[91m"}
+
+// WeekView is seven days of due cards, starting today
+type WeekView struct {
+	Start time.Time
+	Days  [7][]Card
+}
+
+// buildWeekView groups open cards by the day they are due, for the seven
+// days starting at start. Each day's cards are in due order.
+func buildWeekView(cards []Card, start time.Time) WeekView {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	view := WeekView{Start: start}
+
+	for _, card := range cards {
+		if card.Closed || card.Due == nil || card.Due.Before(start) {
+			continue
+		}
+		for day := range view.Days {
+			if card.Due.Before(start.AddDate(0, 0, day+1)) {
+				view.Days[day] = append(view.Days[day], card)
+				break
+			}
+		}
+	}
+
+	for i := range view.Days {
+		sortCardsByDue(view.Days[i])
+	}
+	return view
+}
+
+// cardColor picks a card's color: its first colored label, otherwise its list's
+func cardColor(card Card, listColors map[string]string) string {
+	for _, label := range card.Labels {
+		if color, ok := labelColors[label.Color]; ok {
+			return color
+		}
+	}
+	return listColors[card.IDList]
+}
+
+// fitCell trims or pads text to exactly width characters
+func fitCell(text string, width int) string {
+	if utf8.RuneCountInString(text) > width {
+		runes := []rune(text)
+		return string(runes[:width-1]) + "…"
+	}
+	return text + strings.Repeat(" ", width-utf8.RuneCountInString(text))
+}
+
+// Format renders the week as a grid. listColors maps list IDs to terminal
+// colors; pass nil for plain text.
+func (v WeekView) Format(listColors map[string]string) string {
+	width := weekViewColumnWidth
+	border := "+" + strings.Repeat(strings.Repeat("-", width+2)+"+", 7) + "\n"
+
+	var out strings.Builder
+	out.WriteString(border)
+	out.WriteString("|")
+	for i := range v.Days {
+		day := v.Start.AddDate(0, 0, i)
+		header := day.Format("Mon 1/2")
+		if i == 0 {
+			header += " (today)"
+		}
+		out.WriteString(" " + fitCell(header, width) + " |")
+	}
+	out.WriteString("\n" + border)
+
+	rows := 1
+	for _, cards := range v.Days {
+		if len(cards) > rows {
+			rows = len(cards)
+		}
+	}
+	for row := 0; row < rows; row++ {
+		out.WriteString("|")
+		for _, cards := range v.Days {
+			if row >= len(cards) {
+				out.WriteString(" " + strings.Repeat(" ", width) + " |")
+				continue
+			}
+			card := cards[row]
+			// Emoji are double width in most terminals and would break the grid
+			name := stripEmoji(card.Name)
+			if card.DueComplete {
+				name = "✓ " + name
+			}
+			cell := fitCell(name, width)
+			if color := cardColor(card, listColors); listColors != nil && color != "" {
+				cell = color + cell + ansiReset
+			}
+			out.WriteString(" " + cell + " |")
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString(border)
+	return out.String()
+}
+
+// colorEnabled reports whether output should use ANSI colors: not in
+// --no-emoji mode, not with NO_COLOR set, and only on a terminal
+func colorEnabled() bool {
+	if plainOutput || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// PrintWeekView prints the next seven days of due cards on a board from the
+// cache, colored by label or list, with a legend of list colors
+func (c *TrelloClient) PrintWeekView(boardName string) error {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return err
+	}
+	if len(cache.Cards) == 0 {
+		return fmt.Errorf("no cards in cache; run --refresh first")
+	}
+
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return err
+	}
+
+	var listColors map[string]string
+	var legend []string
+	useColor := colorEnabled()
+	if useColor {
+		listColors = make(map[string]string)
+	}
+	for _, list := range cache.Lists {
+		if list.BoardID != board.ID {
+			continue
+		}
+		color := listPalette[len(legend)%len(listPalette)]
+		legend = append(legend, list.Name)
+		if useColor {
+			listColors[list.ID] = color
+			legend[len(legend)-1] = color + list.Name + ansiReset
+		}
+	}
+
+	var boardCards []Card
+	for _, card := range cache.Cards {
+		if card.IDBoard == board.ID {
+			boardCards = append(boardCards, card)
+		}
+	}
+
+	view := buildWeekView(inferDoneCards(boardCards, doneListIDs(cache.Lists)), time.Now())
+	fmt.Printf("%s - week of %s\n", board.Name, view.Start.Format("January 2"))
+	fmt.Print(view.Format(listColors))
+	if useColor {
+		fmt.Printf("Lists: %s (colored labels take priority)\n", strings.Join(legend, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildWeekView(t *testing.T) {
+	now := time.Date(2025, 10, 6, 15, 0, 0, 0, time.UTC)
+	at := func(days, hour int) *time.Time {
+		due := time.Date(2025, 10, 6+days, hour, 0, 0, 0, time.UTC)
+		return &due
+	}
+	cards := []Card{
+		{ID: "late", Name: "Essay", Due: at(0, 23)},
+		{ID: "early", Name: "Quiz", Due: at(0, 8)},
+		{ID: "past", Name: "Overdue", Due: at(-1, 12)},
+		{ID: "sun", Name: "Project", Due: at(6, 23)},
+		{ID: "next", Name: "Next week", Due: at(7, 0)},
+		{ID: "closed", Name: "Archived", Due: at(2, 12), Closed: true},
+		{ID: "undated", Name: "Someday"},
+	}
+
+	view := buildWeekView(cards, now)
+	if !view.Start.Equal(time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Start = %v, want start of today", view.Start)
+	}
+
+	tests := []struct {
+		day  int
+		want []string
+	}{
+		{0, []string{"early", "late"}},
+		{2, nil},
+		{6, []string{"sun"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, card := range view.Days[tt.day] {
+			got = append(got, card.ID)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("Days[%d] = %v, want %v", tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestWeekViewFormat(t *testing.T) {
+	due := time.Date(2025, 10, 7, 12, 0, 0, 0, time.UTC)
+	view := buildWeekView([]Card{
+		{Name: "📚 A very long assignment name", IDList: "w", Due: &due},
+		{Name: "Flagged", IDList: "w", Due: &due, DueComplete: true, Labels: []Label{{Color: "red"}}},
+	}, time.Date(2025, 10, 6, 9, 0, 0, 0, time.UTC))
+
+	plain := view.Format(nil)
+	lines := strings.Split(strings.TrimRight(plain, "\n"), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("plain grid has %d lines, want 6:\n%s", len(lines), plain)
+	}
+	for _, line := range lines {
+		if got := len([]rune(line)); got != len([]rune(lines[0])) {
+			t.Errorf("line %q is %d wide, want %d", line, got, len([]rune(lines[0])))
+		}
+	}
+	if !strings.Contains(plain, "Mon 10/6 (today)") || !strings.Contains(plain, "A very long ass…") || !strings.Contains(plain, "✓ Flagged") {
+		t.Errorf("unexpected grid:\n%s", plain)
+	}
+	if strings.Contains(plain, "\033[") {
+		t.Errorf("plain grid should have no color codes")
+	}
+
+	colored := view.Format(map[string]string{"w": "\033[36m"})
+	if !strings.Contains(colored, "\033[31m✓ Flagged") || !strings.Contains(colored, "\033[36mA very long") {
+		t.Errorf("label color should win over list color:\n%q", colored)
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WorkItem is an email, task, issue, or merge request headed for a work board
+type WorkItem struct {
+	Source string // labels the card metadata, e.g. "Outlook" or "Asana"
+	ID     string
+	Title  string
+	Due    *time.Time
+	URL    string
+	Notes  string
+	List   string // list the card belongs in; empty leaves it where it is
+}
+
+// containsFold reports whether values holds s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// workItemIDPattern is the metadata line that ties a card to a work item
+func workItemIDPattern(source, id string) string {
+	return fmt.Sprintf("%s ID: %s\n", source, id)
+}
+
+// formatWorkItemDescription builds a card description with dedupe metadata
+func formatWorkItemDescription(item WorkItem) string {
+	return fmt.Sprintf("%s\n\n---\n%sLink: %s", strings.TrimSpace(item.Notes), workItemIDPattern(item.Source, item.ID), item.URL)
+}
+
+// findCardByWorkItem finds the card synced from a work item
+func findCardByWorkItem(cards []Card, source, id string) *Card {
+	pattern := workItemIDPattern(source, id)
+	for i, card := range cards {
+		if strings.Contains(card.Description, pattern) {
+			return &cards[i]
+		}
+	}
+	return nil
+}
+
+// workItemCardID reads a work item ID back out of a card's metadata
+func workItemCardID(card Card, source string) string {
+	_, after, found := strings.Cut(card.Description, "---\n"+source+" ID: ")
+	if !found {
+		return ""
+	}
+	id, _, _ := strings.Cut(after, "\n")
+	return id
+}
+
+// mirrorWorkItems creates or updates a card for each open work item on a
+// board. New cards go to the item's list, else defaultList, else the board's
+// first list. Cards from sources whose items are no longer open move to the
+// Done list, if the board has one.
+func (c *TrelloClient) mirrorWorkItems(boardName, defaultList string, items []WorkItem, sources []string) error {
+	cache, err := c.LoadCache()
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+	board, err := findBoardByName(cache.Boards, boardName)
+	if err != nil {
+		return err
+	}
+
+	var defaultListID string
+	if defaultList != "" {
+		list, err := findListByName(cache.Lists, board.ID, defaultList)
+		if err != nil {
+			return fmt.Errorf("%s in board '%s'", err.Error(), board.Name)
+		}
+		defaultListID = list.ID
+	} else {
+		for _, list := range cache.Lists {
+			if list.BoardID == board.ID {
+				defaultListID = list.ID
+				fmt.Printf("Using list '%s' for new cards\n", list.Name)
+				break
+			}
+		}
+	}
+	if defaultListID == "" {
+		return fmt.Errorf("no lists found on %s board", boardName)
+	}
+
+	// listIDFor resolves an item's list, reporting whether it named one that exists
+	listIDFor := func(item WorkItem) (string, bool) {
+		if item.List == "" {
+			return defaultListID, false
+		}
+		list, err := findListByName(cache.Lists, board.ID, item.List)
+		if err != nil {
+			return defaultListID, false
+		}
+		return list.ID, true
+	}
+
+	cards, err := c.GetBoardCardsByID(board.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get Trello cards: %w", err)
+	}
+
+	createdCards, updatedCards := 0, 0
+	open := make(map[string]bool)
+	for _, item := range items {
+		open[item.Source+"\x00"+item.ID] = true
+
+		var dueDate string
+		if item.Due != nil {
+			dueDate = item.Due.UTC().Format(trelloDueLayout)
+		}
+		description := formatWorkItemDescription(item)
+		listID, placed := listIDFor(item)
+
+		existing := findCardByWorkItem(cards, item.Source, item.ID)
+		if skipNoAuto(existing) {
+			continue
+		}
+		if existing == nil {
+			fmt.Printf("Creating card for %s item: %s\n", item.Source, item.Title)
+			newCard, err := c.CreateCard(listID, item.Title, description, dueDate)
+			if err != nil {
+				fmt.Printf("Warning: failed to create card %s: %v\n", item.Title, err)
+				continue
+			}
+			createdCards++
+			if item.URL != "" {
+				if err := c.EnsureLinkAttachment(newCard.ID, item.Source, item.URL); err != nil {
+					fmt.Printf("Warning: failed to attach %s link to %s: %v\n", item.Source, item.Title, err)
+				}
+			}
+			continue
+		}
+
+		title := item.Title
+		patch := CardPatch{}
+		if existing.Name != title {
+			patch.Name = &title
+		}
+		if existing.Description != description {
+			patch.Desc = &description
+		}
+		if _, _, moved := dueDateMoved(existing, dueDate); moved || (existing.Due == nil) != (dueDate == "") {
+			patch.Due = &dueDate
+		}
+		if placed && existing.IDList != listID {
+			patch.IDList = &listID
+		}
+		if patch == (CardPatch{}) {
+			continue
+		}
+
+		fmt.Printf("Updating card: %s\n", item.Title)
+		if err := c.UpdateCardFields(existing.ID, patch); err != nil {
+			fmt.Printf("Warning: failed to update card %s: %v\n", item.Title, err)
+			continue
+		}
+		updatedCards++
+	}
+
+	if doneListID, err := c.FindListByName(boardName, "Done"); err == nil {
+		for _, card := range cards {
+			for _, source := range sources {
+				id := workItemCardID(card, source)
+				if id == "" || open[source+"\x00"+id] || card.IDList == doneListID || skipNoAuto(&card) {
+					continue
+				}
+				fmt.Printf("Moving %s to Done (closed in %s)\n", card.Name, source)
+				if err := c.MoveCardToList(card.ID, doneListID); err != nil {
+					fmt.Printf("Warning: failed to move %s to Done: %v\n", card.Name, err)
+				}
+			}
+		}
+	}
+
+	fmt.Printf("\n%s sync completed!\n", strings.Join(sources, "/"))
+	fmt.Printf("Created: %d cards\n", createdCards)
+	fmt.Printf("Updated: %d cards\n", updatedCards)
+
+	return nil
+}
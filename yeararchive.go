@@ -0,0 +1,189 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// gradeReportEntry is the --grade-report output saved in a year archive
+const gradeReportEntry = "grade_report.txt"
+
+// yearArchiveName is the zip --archive-year writes, e.g.
+// school_year_2025-2026_2026-06-12.zip
+func yearArchiveName(label string, now time.Time) string {
+	label = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '/' || r == '\\' {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(label))
+	return fmt.Sprintf("school_year_%s_%s.zip", label, now.Format("2006-01-02"))
+}
+
+// archiveEntryName is a file's path inside the archive: relative to the
+// working directory, or just its name when it lives elsewhere
+func archiveEntryName(path string) string {
+	rel, err := filepath.Rel(".", path)
+	if err != nil || filepath.IsAbs(rel) || strings.HasPrefix(rel, "..") {
+		return filepath.Base(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// buildArchiveManifest lists what an archive holds, by kind
+func buildArchiveManifest(label string, items []PurgeItem, gradeReport bool, now time.Time) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "School year %s, archived %s\n\n", label, now.Format("2006-01-02 15:04"))
+	for _, item := range items {
+		fmt.Fprintf(&out, "%s (%s)\n", archiveEntryName(item.Path), item.Kind)
+	}
+	if gradeReport {
+		fmt.Fprintf(&out, "%s (report)\n", gradeReportEntry)
+	}
+	return out.String()
+}
+
+// addArchiveFile copies a file into the archive
+func addArchiveFile(archive *zip.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = archiveEntryName(path)
+	header.Method = zip.Deflate
+
+	entry, err := archive.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, file)
+	return err
+}
+
+// writeYearArchive zips the files with a manifest and the grade report, if
+// there is one. The zip only appears once it's complete.
+func writeYearArchive(path, label string, items []PurgeItem, gradeReport string, now time.Time) error {
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	archive := zip.NewWriter(file)
+
+	write := func() error {
+		manifest, err := archive.Create("MANIFEST.txt")
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(manifest, buildArchiveManifest(label, items, gradeReport != "", now)); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := addArchiveFile(archive, item.Path); err != nil {
+				return fmt.Errorf("%s: %w", item.Path, err)
+			}
+		}
+		if gradeReport != "" {
+			report, err := archive.Create(gradeReportEntry)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(report, gradeReport); err != nil {
+				return err
+			}
+		}
+		return archive.Close()
+	}
+
+	if err := write(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// ArchiveYear bundles the school year's exports, grade history, board
+// snapshots, reports, logs, and card backups into one dated zip, then
+// deletes them, along with the caches, so the new year starts fresh. A
+// final board snapshot and, when Canvas is set up, a grade report are
+// added first. Nothing is deleted unless the zip was written.
+func (c *TrelloClient) ArchiveYear(label, backupDir string) error {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return fmt.Errorf("archiving needs the school year's name, e.g. 2025-2026")
+	}
+	now := time.Now()
+	path := yearArchiveName(label, now)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists; move it away or pick another name", path)
+	}
+
+	if err := c.TakeSnapshot(); err != nil {
+		fmt.Printf("Warning: archiving without a final board snapshot: %v\n", err)
+	}
+
+	gradeReport := ""
+	if canvasClient, err := canvasClientFromEnv(); err == nil {
+		if estimate, err := canvasClient.EstimateGPA(); err != nil {
+			fmt.Printf("Warning: archiving without a grade report: %v\n", err)
+		} else {
+			gradeReport = estimate.Format()
+		}
+	}
+
+	extra := backupFiles(backupDir)
+	if trace := traceFilePath(); trace != defaultTraceFile {
+		extra = append(extra, PurgeItem{Kind: "audit log", Path: trace})
+	}
+	items := findStudentData(".", extra)
+
+	// Caches are rebuilt by the next sync, so they're reset but not kept
+	var archived []PurgeItem
+	for _, item := range items {
+		if item.Kind != "cache" {
+			archived = append(archived, item)
+		}
+	}
+	if len(archived) == 0 && gradeReport == "" {
+		fmt.Println("Nothing to archive")
+		return nil
+	}
+
+	fmt.Printf("Archiving %s to %s...\n", plural(len(archived), "file"), path)
+	if err := writeYearArchive(path, label, archived, gradeReport, now); err != nil {
+		return err
+	}
+
+	deleted := 0
+	for _, item := range items {
+		if err := os.Remove(item.Path); err != nil {
+			fmt.Printf("Warning: failed to delete %s: %v\n", item.Path, err)
+			continue
+		}
+		deleted++
+	}
+
+	fmt.Printf("%s Archived %s to %s and reset %s for the new year\n", iconSuccess, label, path, plural(deleted, "file"))
+	fmt.Println("Run --refresh to rebuild the cache before the next sync")
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestYearArchiveName(t *testing.T) {
+	now := time.Date(2026, 6, 12, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{"2025-2026", "school_year_2025-2026_2026-06-12.zip"},
+		{" 2025/26 ", "school_year_2025-26_2026-06-12.zip"},
+		{"Grade 9", "school_year_Grade-9_2026-06-12.zip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			if got := yearArchiveName(tt.label, now); got != tt.want {
+				t.Errorf("yearArchiveName(%q) = %q, want %q", tt.label, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArchiveEntryName(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{gradeHistoryFile, gradeHistoryFile},
+		{filepath.Join("backups", "list_1.json"), "backups/list_1.json"},
+		{filepath.Join(string(filepath.Separator), "var", "log", "trace.jsonl"), "trace.jsonl"},
+		{filepath.Join("..", "elsewhere", "trace.jsonl"), "trace.jsonl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := archiveEntryName(tt.path); got != tt.want {
+				t.Errorf("archiveEntryName(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteYearArchive(t *testing.T) {
+	dir := t.TempDir()
+	history := filepath.Join(dir, gradeHistoryFile)
+	if err := os.WriteFile(history, []byte(`{"date":"2026-06-01"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "year.zip")
+	now := time.Date(2026, 6, 12, 15, 0, 0, 0, time.UTC)
+	items := []PurgeItem{{"grade history", history}}
+	if err := writeYearArchive(path, "2025-2026", items, "GPA: 3.9\n", now); err != nil {
+		t.Fatalf("writeYearArchive() error = %v", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	entries := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(reader)
+		reader.Close()
+		entries[file.Name] = string(data)
+	}
+
+	if entries[gradeHistoryFile] != `{"date":"2026-06-01"}`+"\n" {
+		t.Errorf("%s = %q", gradeHistoryFile, entries[gradeHistoryFile])
+	}
+	if entries[gradeReportEntry] != "GPA: 3.9\n" {
+		t.Errorf("%s = %q", gradeReportEntry, entries[gradeReportEntry])
+	}
+	manifest := entries["MANIFEST.txt"]
+	if !strings.HasPrefix(manifest, "School year 2025-2026, archived 2026-06-12 15:00\n") || !strings.Contains(manifest, "grade_report.txt (report)\n") {
+		t.Errorf("MANIFEST.txt = %q", manifest)
+	}
+}